@@ -9,6 +9,7 @@ package keystore
 import (
 	"encoding/json"
 	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -43,6 +44,22 @@ func NewPlainAccountManager(dir string) (*AccountManager, error) {
 	return accountManager, nil
 }
 
+// NewEncryptedAccountManager creates a new account manager based on encrypted keystore, where the new accounts are
+// encrypted with the passphrase
+func NewEncryptedAccountManager(
+	dir string,
+	passphrase string,
+	opts ...EncryptedKeyStoreOption,
+) (*AccountManager, error) {
+	ksDir, _ := filepath.Abs(dir)
+	ks, err := NewEncryptedKeyStore(ksDir, passphrase, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a new encrypted keystore")
+	}
+	accountManager := &AccountManager{keystore: ks}
+	return accountManager, nil
+}
+
 // NewMemAccountManager creates a new account manager based on in-memory keystore
 func NewMemAccountManager() *AccountManager {
 	accountManager := &AccountManager{
@@ -56,6 +73,25 @@ func (m *AccountManager) Contains(rawAddr string) (bool, error) {
 	return m.keystore.Has(rawAddr)
 }
 
+// Unlock unlocks the given account for the timeout, a zero timeout keeps it unlocked until Lock() is called. The
+// accounts of a keystore which is not encrypted are always unlocked
+func (m *AccountManager) Unlock(rawAddr string, passphrase string, timeout time.Duration) error {
+	ks, ok := m.keystore.(*EncryptedKeyStore)
+	if !ok {
+		return nil
+	}
+	return ks.Unlock(rawAddr, passphrase, timeout)
+}
+
+// Lock locks the given account
+func (m *AccountManager) Lock(rawAddr string) error {
+	ks, ok := m.keystore.(*EncryptedKeyStore)
+	if !ok {
+		return nil
+	}
+	return ks.Lock(rawAddr)
+}
+
 // Remove removes the given account if exists
 func (m *AccountManager) Remove(rawAddr string) error {
 	return m.keystore.Remove(rawAddr)
@@ -0,0 +1,622 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+const (
+	// EncryptedKeyVersion is the version of the encrypted key file format
+	EncryptedKeyVersion = 1
+
+	kdfScrypt    = "scrypt"
+	kdfPBKDF2    = "pbkdf2"
+	prfSHA256    = "hmac-sha256"
+	cipherAESGCM = "aes-256-gcm"
+	dkLen        = 32
+	saltLen      = 32
+
+	// StandardScryptN is the N parameter of scrypt, which takes about 1 second and 256MB memory to derive a key
+	StandardScryptN = 1 << 18
+	// StandardScryptP is the P parameter of scrypt
+	StandardScryptP = 1
+	scryptR         = 8
+	// StandardPBKDF2Iterations is the iteration count of PBKDF2
+	StandardPBKDF2Iterations = 1 << 18
+)
+
+var (
+	// ErrLocked indicates the key needs to be unlocked before being used
+	ErrLocked = errors.New("key is locked")
+	// ErrDecrypt indicates the key cannot be decrypted with the passphrase
+	ErrDecrypt = errors.New("failed to decrypt key with the passphrase")
+	// ErrKeyFormat indicates the key file is not in the supported format
+	ErrKeyFormat = errors.New("unsupported key file format")
+
+	// rename renames a file, which is replaced in tests to fail the replacement of a key file
+	rename = os.Rename
+)
+
+type (
+	// encryptedKey is the json format of an encrypted key file. The private key is sealed with AES-GCM, using the key
+	// derived from the passphrase and the raw address as the additional data, so that a key file cannot be renamed to
+	// another account
+	encryptedKey struct {
+		Version    int        `json:"version"`
+		RawAddress string     `json:"rawAddress"`
+		PublicKey  string     `json:"publicKey"`
+		Crypto     cryptoJSON `json:"crypto"`
+	}
+
+	cryptoJSON struct {
+		KDF        string    `json:"kdf"`
+		KDFParams  kdfParams `json:"kdfParams"`
+		Cipher     string    `json:"cipher"`
+		Nonce      string    `json:"nonce"`
+		CipherText string    `json:"cipherText"`
+	}
+
+	kdfParams struct {
+		Salt  string `json:"salt"`
+		DKLen int    `json:"dkLen"`
+		// scrypt parameters
+		N int `json:"n,omitempty"`
+		R int `json:"r,omitempty"`
+		P int `json:"p,omitempty"`
+		// PBKDF2 parameters
+		C   int    `json:"c,omitempty"`
+		PRF string `json:"prf,omitempty"`
+	}
+
+	unlockedKey struct {
+		addr  *iotxaddress.Address
+		timer *time.Timer
+	}
+)
+
+// EncryptedKeyStore is a filesystem keystore which implements KeyStore interface. The private keys are encrypted at
+// rest with the key derived from a passphrase, and have to be unlocked before Get() returns them
+type EncryptedKeyStore struct {
+	directory  string
+	passphrase string
+	kdf        kdfParams
+	mutex      sync.Mutex
+	unlocked   map[string]*unlockedKey
+}
+
+// EncryptedKeyStoreOption sets the parameters of the encrypted keystore
+type EncryptedKeyStoreOption func(*EncryptedKeyStore) error
+
+// ScryptOption derives the keys with scrypt, which is the default
+func ScryptOption(n int, p int) EncryptedKeyStoreOption {
+	return func(ks *EncryptedKeyStore) error {
+		if n <= 1 || n&(n-1) != 0 || p <= 0 {
+			return errors.Errorf("invalid scrypt parameters n = %d, p = %d", n, p)
+		}
+		ks.kdf = kdfParams{DKLen: dkLen, N: n, R: scryptR, P: p}
+		return nil
+	}
+}
+
+// PBKDF2Option derives the keys with PBKDF2 using HMAC-SHA256
+func PBKDF2Option(iterations int) EncryptedKeyStoreOption {
+	return func(ks *EncryptedKeyStore) error {
+		if iterations <= 0 {
+			return errors.Errorf("invalid PBKDF2 iteration count %d", iterations)
+		}
+		ks.kdf = kdfParams{DKLen: dkLen, C: iterations, PRF: prfSHA256}
+		return nil
+	}
+}
+
+// NewEncryptedKeyStore returns a new instance of encrypted keystore. The passphrase is used to encrypt the keys stored
+// by Store(), while the passphrase to unlock a key is given to Unlock()
+func NewEncryptedKeyStore(dir string, passphrase string, opts ...EncryptedKeyStoreOption) (*EncryptedKeyStore, error) {
+	if passphrase == "" {
+		return nil, errors.Wrap(ErrKey, "passphrase must not be empty")
+	}
+	ks := &EncryptedKeyStore{
+		directory:  dir,
+		passphrase: passphrase,
+		kdf:        kdfParams{DKLen: dkLen, N: StandardScryptN, R: scryptR, P: StandardScryptP},
+		unlocked:   make(map[string]*unlockedKey),
+	}
+	for _, opt := range opts {
+		if err := opt(ks); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := os.Stat(dir); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "failed to get the status of directory %s", dir)
+		}
+		if err := os.Mkdir(dir, 0700); err != nil {
+			return nil, errors.Wrapf(err, "failed to make directory %s", dir)
+		}
+	}
+	return ks, nil
+}
+
+// Has returns whether the raw address already exists in keystore filesystem
+func (ks *EncryptedKeyStore) Has(rawAddr string) (bool, error) {
+	if err := validateAddress(rawAddr); err != nil {
+		return false, err
+	}
+	filePath := filepath.Join(ks.directory, rawAddr)
+	if _, err := os.Stat(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get the status of file %s", filePath)
+	}
+	return true, nil
+}
+
+// Get returns the unlocked iotxaddress given raw address
+func (ks *EncryptedKeyStore) Get(rawAddr string) (*iotxaddress.Address, error) {
+	exist, err := ks.Has(rawAddr)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.Wrapf(ErrNotExist, "raw address = %s", rawAddr)
+	}
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	key, ok := ks.unlocked[rawAddr]
+	if !ok {
+		return nil, errors.Wrapf(ErrLocked, "raw address = %s", rawAddr)
+	}
+	// a copy is returned, since the cached key is wiped when it's locked
+	addr := *key.addr
+	return &addr, nil
+}
+
+// Store encrypts the iotxaddress with the passphrase of the keystore and stores it in keystore filesystem
+func (ks *EncryptedKeyStore) Store(rawAddr string, address *iotxaddress.Address) error {
+	exist, err := ks.Has(rawAddr)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return errors.Wrapf(ErrExist, "raw address = %s", rawAddr)
+	}
+	return ks.write(rawAddr, address)
+}
+
+// Remove locks and removes the iotxaddress from keystore filesystem given raw address
+func (ks *EncryptedKeyStore) Remove(rawAddr string) error {
+	if err := validateAddress(rawAddr); err != nil {
+		return err
+	}
+	ks.lock(rawAddr)
+	filePath := filepath.Join(ks.directory, rawAddr)
+	err := os.Remove(filePath)
+	if os.IsNotExist(err) {
+		return errors.Wrapf(ErrNotExist, "raw address = %s", rawAddr)
+	}
+	return err
+}
+
+// All returns a list of raw addresses currently stored in keystore filesystem
+func (ks *EncryptedKeyStore) All() ([]string, error) {
+	return listKeyFiles(ks.directory)
+}
+
+// Unlock decrypts the key of the raw address with the passphrase, so that Get() returns it until timeout. A zero
+// timeout keeps the key unlocked until Lock() is called. Unlocking an unlocked key resets its timeout
+func (ks *EncryptedKeyStore) Unlock(rawAddr string, passphrase string, timeout time.Duration) error {
+	if err := validateAddress(rawAddr); err != nil {
+		return err
+	}
+	filePath := filepath.Join(ks.directory, rawAddr)
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Wrapf(ErrNotExist, "raw address = %s", rawAddr)
+		}
+		return errors.Wrapf(err, "failed to read file %s", filePath)
+	}
+	addr, err := decryptKey(data, rawAddr, passphrase)
+	if err != nil {
+		return err
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	if key, ok := ks.unlocked[rawAddr]; ok && key.timer != nil {
+		key.timer.Stop()
+	}
+	key := &unlockedKey{addr: addr}
+	if timeout > 0 {
+		key.timer = time.AfterFunc(timeout, func() {
+			ks.mutex.Lock()
+			defer ks.mutex.Unlock()
+			// the key may have been unlocked again with another timeout
+			if ks.unlocked[rawAddr] == key {
+				delete(ks.unlocked, rawAddr)
+				zeroKey(key.addr)
+			}
+		})
+	}
+	ks.unlocked[rawAddr] = key
+	return nil
+}
+
+// Lock drops the decrypted key of the raw address from memory
+func (ks *EncryptedKeyStore) Lock(rawAddr string) error {
+	if err := validateAddress(rawAddr); err != nil {
+		return err
+	}
+	ks.lock(rawAddr)
+	return nil
+}
+
+// ImportPlainKeys encrypts the plain key files in dir into the keystore, and wipes the plain files. The dir could be
+// the directory of the keystore itself, where the plain files are encrypted in place. The encrypted files in dir are
+// skipped. The raw addresses of the imported keys are returned
+func (ks *EncryptedKeyStore) ImportPlainKeys(dir string) ([]string, error) {
+	rawAddrs, err := listKeyFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the absolute path of %s", dir)
+	}
+	absKsDir, err := filepath.Abs(ks.directory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the absolute path of %s", ks.directory)
+	}
+	inPlace := absDir == absKsDir
+
+	imported := make([]string, 0, len(rawAddrs))
+	for _, rawAddr := range rawAddrs {
+		filePath := filepath.Join(dir, rawAddr)
+		data, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return imported, errors.Wrapf(err, "failed to read file %s", filePath)
+		}
+		if isEncryptedKey(data) {
+			continue
+		}
+		key := &Key{}
+		if err := json.Unmarshal(data, key); err != nil {
+			return imported, errors.Wrapf(err, "failed to decode plain key file %s", filePath)
+		}
+		addr, err := keyToAddr(key)
+		if err != nil {
+			return imported, err
+		}
+		if addr.RawAddress != rawAddr {
+			return imported, errors.Wrapf(ErrKey, "key file %s stores the key of %s", filePath, addr.RawAddress)
+		}
+		if !inPlace {
+			exist, err := ks.Has(rawAddr)
+			if err != nil {
+				return imported, err
+			}
+			if exist {
+				return imported, errors.Wrapf(ErrExist, "raw address = %s", rawAddr)
+			}
+		}
+		tmpPath, err := ks.writeTemp(rawAddr, addr)
+		if err != nil {
+			return imported, err
+		}
+		if inPlace {
+			// the encrypted key replaces the plain file in one step, and the replaced plain file is wiped through
+			// the descriptor opened before. If the replacement fails, the plain file is left intact
+			plainFile, err := os.OpenFile(filePath, os.O_WRONLY, 0)
+			if err != nil {
+				os.Remove(tmpPath)
+				return imported, errors.Wrapf(err, "failed to open file %s", filePath)
+			}
+			if err := rename(tmpPath, filePath); err != nil {
+				plainFile.Close()
+				os.Remove(tmpPath)
+				return imported, errors.Wrapf(err, "failed to rename temporary key file %s to %s", tmpPath, filePath)
+			}
+			err = wipe(plainFile, len(data))
+			plainFile.Close()
+			if err != nil {
+				return imported, errors.Wrapf(err, "failed to wipe plain key file %s", filePath)
+			}
+		} else {
+			// the plain file is wiped only after the encrypted key is committed
+			if err := ks.commitTemp(tmpPath, rawAddr); err != nil {
+				return imported, err
+			}
+			if err := wipeFile(filePath, len(data)); err != nil {
+				return imported, err
+			}
+			if err := os.Remove(filePath); err != nil {
+				return imported, errors.Wrapf(err, "failed to remove plain key file %s", filePath)
+			}
+		}
+		imported = append(imported, rawAddr)
+	}
+	return imported, nil
+}
+
+//======================================
+// private functions
+//======================================
+
+func (ks *EncryptedKeyStore) lock(rawAddr string) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	key, ok := ks.unlocked[rawAddr]
+	if !ok {
+		return
+	}
+	if key.timer != nil {
+		key.timer.Stop()
+	}
+	delete(ks.unlocked, rawAddr)
+	zeroKey(key.addr)
+}
+
+// write encrypts the key and writes it into the key file through a temporary file, so that the key file is either the
+// original one or the complete new one
+func (ks *EncryptedKeyStore) write(rawAddr string, address *iotxaddress.Address) error {
+	tmpPath, err := ks.writeTemp(rawAddr, address)
+	if err != nil {
+		return err
+	}
+	return ks.commitTemp(tmpPath, rawAddr)
+}
+
+// writeTemp encrypts the key and writes it into a temporary file, whose path is returned
+func (ks *EncryptedKeyStore) writeTemp(rawAddr string, address *iotxaddress.Address) (string, error) {
+	if address == nil {
+		return "", errors.Wrap(ErrKey, "address must not be nil")
+	}
+	data, err := encryptKey(address, rawAddr, ks.passphrase, ks.kdf)
+	if err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile(ks.directory, "."+rawAddr+".tmp")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create temporary key file")
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "failed to write temporary key file")
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "failed to sync temporary key file")
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "failed to close temporary key file")
+	}
+	return f.Name(), nil
+}
+
+// commitTemp renames the temporary file to the key file of the raw address
+func (ks *EncryptedKeyStore) commitTemp(tmpPath string, rawAddr string) error {
+	filePath := filepath.Join(ks.directory, rawAddr)
+	if err := rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return errors.Wrapf(err, "failed to rename temporary key file to %s", filePath)
+	}
+	return nil
+}
+
+func encryptKey(address *iotxaddress.Address, rawAddr string, passphrase string, params kdfParams) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+	params.Salt = hex.EncodeToString(salt)
+	derivedKey, err := deriveKey(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	cipherText := aead.Seal(nil, nonce, address.PrivateKey[:], []byte(rawAddr))
+	key := &encryptedKey{
+		Version:    EncryptedKeyVersion,
+		RawAddress: rawAddr,
+		PublicKey:  keypair.EncodePublicKey(address.PublicKey),
+		Crypto: cryptoJSON{
+			KDF:        kdfName(params),
+			KDFParams:  params,
+			Cipher:     cipherAESGCM,
+			Nonce:      hex.EncodeToString(nonce),
+			CipherText: hex.EncodeToString(cipherText),
+		},
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal encrypted key")
+	}
+	return data, nil
+}
+
+func decryptKey(data []byte, rawAddr string, passphrase string) (*iotxaddress.Address, error) {
+	key := &encryptedKey{}
+	if err := json.Unmarshal(data, key); err != nil {
+		return nil, errors.Wrap(err, "failed to decode encrypted key file")
+	}
+	if key.Version == 0 {
+		return nil, errors.Wrapf(ErrKeyFormat, "key of %s is not encrypted, import it first", rawAddr)
+	}
+	if key.Version != EncryptedKeyVersion {
+		return nil, errors.Wrapf(ErrKeyFormat, "key file version = %d", key.Version)
+	}
+	if key.RawAddress != rawAddr {
+		return nil, errors.Wrapf(ErrKey, "key file of %s stores the key of %s", rawAddr, key.RawAddress)
+	}
+	if key.Crypto.Cipher != cipherAESGCM {
+		return nil, errors.Wrapf(ErrKeyFormat, "cipher = %s", key.Crypto.Cipher)
+	}
+	if key.Crypto.KDF != kdfName(key.Crypto.KDFParams) {
+		return nil, errors.Wrapf(ErrKeyFormat, "kdf = %s", key.Crypto.KDF)
+	}
+	derivedKey, err := deriveKey(passphrase, key.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(key.Crypto.Nonce)
+	if err != nil || len(nonce) != aead.NonceSize() {
+		return nil, errors.Wrap(ErrKeyFormat, "invalid nonce")
+	}
+	cipherText, err := hex.DecodeString(key.Crypto.CipherText)
+	if err != nil {
+		return nil, errors.Wrap(ErrKeyFormat, "invalid cipher text")
+	}
+	plainText, err := aead.Open(nil, nonce, cipherText, []byte(rawAddr))
+	if err != nil {
+		return nil, errors.Wrapf(ErrDecrypt, "raw address = %s", rawAddr)
+	}
+	privateKey, err := keypair.BytesToPrivateKey(plainText)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode private key")
+	}
+	// the public key in the file isn't authenticated by the cipher, so it's derived from the decrypted private key, which
+	// must be the key of the raw address
+	publicKey, err := crypto.EC283.NewPubKey(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive public key")
+	}
+	pkHash, err := iotxaddress.AddressToPKHash(rawAddr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid raw address %s", rawAddr)
+	}
+	if pkHash != keypair.HashPubKey(publicKey) {
+		return nil, errors.Wrapf(ErrKey, "key file of %s stores the private key of another address", rawAddr)
+	}
+	return &iotxaddress.Address{PublicKey: publicKey, PrivateKey: privateKey, RawAddress: rawAddr}, nil
+}
+
+func kdfName(params kdfParams) string {
+	if params.C > 0 {
+		return kdfPBKDF2
+	}
+	return kdfScrypt
+}
+
+func deriveKey(passphrase string, params kdfParams) ([]byte, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, errors.Wrap(ErrKeyFormat, "invalid salt")
+	}
+	if params.DKLen != dkLen {
+		return nil, errors.Wrapf(ErrKeyFormat, "derived key length = %d", params.DKLen)
+	}
+	if params.C > 0 {
+		if params.PRF != prfSHA256 {
+			return nil, errors.Wrapf(ErrKeyFormat, "prf = %s", params.PRF)
+		}
+		return pbkdf2.Key([]byte(passphrase), salt, params.C, params.DKLen, sha256.New), nil
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive key with scrypt")
+	}
+	return derivedKey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AES cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCM")
+	}
+	return aead, nil
+}
+
+func isEncryptedKey(data []byte) bool {
+	var header struct {
+		Version int `json:"version"`
+	}
+	return json.Unmarshal(data, &header) == nil && header.Version > 0
+}
+
+// listKeyFiles returns the raw addresses of the key files in dir
+func listKeyFiles(dir string) ([]string, error) {
+	fd, err := os.Open(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open directory %s", dir)
+	}
+	defer fd.Close()
+	names, err := fd.Readdirnames(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read directory names")
+	}
+	rawAddrs := make([]string, 0, len(names))
+	for _, name := range names {
+		// skip the temporary files
+		if _, err := iotxaddress.GetPubkeyHash(name); err != nil {
+			continue
+		}
+		rawAddrs = append(rawAddrs, name)
+	}
+	return rawAddrs, nil
+}
+
+// wipeFile overwrites the file with zeros before it's removed
+func wipeFile(filePath string, size int) error {
+	f, err := os.OpenFile(filePath, os.O_WRONLY, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file %s", filePath)
+	}
+	defer f.Close()
+	return errors.Wrapf(wipe(f, size), "failed to wipe file %s", filePath)
+}
+
+// wipe overwrites the file opened for writing with zeros
+func wipe(f *os.File, size int) error {
+	if _, err := f.Write(make([]byte, size)); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+func zeroKey(addr *iotxaddress.Address) {
+	for i := range addr.PrivateKey {
+		addr.PrivateKey[i] = 0
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestEncryptedKeyStore(t *testing.T) {
+	require := require.New(t)
+
+	ksDir := filepath.Join(os.TempDir(), "encryptedkeystore")
+	os.RemoveAll(ksDir)
+	defer func() {
+		require.NoError(os.RemoveAll(ksDir))
+	}()
+
+	_, err := NewEncryptedKeyStore(ksDir, "")
+	require.Error(err)
+	_, err = NewEncryptedKeyStore(ksDir, "pass", ScryptOption(3, 1))
+	require.Error(err)
+
+	// light parameters for testing
+	ks, err := NewEncryptedKeyStore(ksDir, "pass", ScryptOption(1<<4, 1))
+	require.NoError(err)
+	addr1 := testaddress.ConstructAddress(1, pubKey1, priKey1)
+	require.Error(ks.Store("123", addr1))
+	require.NoError(ks.Store(rawAddr1, addr1))
+	require.Equal(ErrExist, errors.Cause(ks.Store(rawAddr1, addr1)))
+
+	// the private key is not stored in plain text
+	data, err := ioutil.ReadFile(filepath.Join(ksDir, rawAddr1))
+	require.NoError(err)
+	require.False(strings.Contains(string(data), priKey1))
+	require.True(strings.Contains(string(data), `"kdf":"scrypt"`))
+
+	exist, err := ks.Has(rawAddr1)
+	require.NoError(err)
+	require.True(exist)
+	_, err = ks.Get(rawAddr2)
+	require.Equal(ErrNotExist, errors.Cause(err))
+	_, err = ks.Get(rawAddr1)
+	require.Equal(ErrLocked, errors.Cause(err))
+
+	// Test Unlock and Lock
+	require.Equal(ErrDecrypt, errors.Cause(ks.Unlock(rawAddr1, "wrong", 0)))
+	require.Equal(ErrNotExist, errors.Cause(ks.Unlock(rawAddr2, "pass", 0)))
+	require.NoError(ks.Unlock(rawAddr1, "pass", 0))
+	val, err := ks.Get(rawAddr1)
+	require.NoError(err)
+	require.Equal(addr1, val)
+	require.NoError(ks.Lock(rawAddr1))
+	_, err = ks.Get(rawAddr1)
+	require.Equal(ErrLocked, errors.Cause(err))
+	// the returned key is not wiped by Lock()
+	require.Equal(addr1, val)
+
+	// Test unlock timeout
+	require.NoError(ks.Unlock(rawAddr1, "pass", 50*time.Millisecond))
+	_, err = ks.Get(rawAddr1)
+	require.NoError(err)
+	time.Sleep(200 * time.Millisecond)
+	_, err = ks.Get(rawAddr1)
+	require.Equal(ErrLocked, errors.Cause(err))
+	// unlocking again without timeout cancels the timeout
+	require.NoError(ks.Unlock(rawAddr1, "pass", 50*time.Millisecond))
+	require.NoError(ks.Unlock(rawAddr1, "pass", 0))
+	time.Sleep(200 * time.Millisecond)
+	_, err = ks.Get(rawAddr1)
+	require.NoError(err)
+
+	// the public key in the file isn't trusted, it's derived from the private key
+	require.True(strings.Contains(string(data), pubKey1))
+	tampered := strings.Replace(string(data), pubKey1, pubKey2, 1)
+	require.NoError(ioutil.WriteFile(filepath.Join(ksDir, rawAddr1), []byte(tampered), 0600))
+	require.NoError(ks.Lock(rawAddr1))
+	require.NoError(ks.Unlock(rawAddr1, "pass", 0))
+	val, err = ks.Get(rawAddr1)
+	require.NoError(err)
+	require.Equal(addr1, val)
+
+	// a key file renamed to another account cannot be decrypted
+	addr2 := testaddress.ConstructAddress(1, pubKey2, priKey2)
+	require.NoError(ioutil.WriteFile(filepath.Join(ksDir, rawAddr2), data, 0600))
+	require.Error(ks.Unlock(rawAddr2, "pass", 0))
+	require.NoError(ks.Remove(rawAddr2))
+
+	// PBKDF2 keys can be unlocked by a keystore using scrypt
+	ks2, err := NewEncryptedKeyStore(ksDir, "pass2", PBKDF2Option(16))
+	require.NoError(err)
+	require.NoError(ks2.Store(rawAddr2, addr2))
+	data, err = ioutil.ReadFile(filepath.Join(ksDir, rawAddr2))
+	require.NoError(err)
+	require.True(strings.Contains(string(data), `"kdf":"pbkdf2"`))
+	require.NoError(ks.Unlock(rawAddr2, "pass2", 0))
+	val, err = ks.Get(rawAddr2)
+	require.NoError(err)
+	require.Equal(addr2, val)
+
+	addrs, err := ks.All()
+	require.NoError(err)
+	require.ElementsMatch([]string{rawAddr1, rawAddr2}, addrs)
+
+	// Test Remove
+	require.NoError(ks.Remove(rawAddr1))
+	_, err = ks.Get(rawAddr1)
+	require.Equal(ErrNotExist, errors.Cause(err))
+	require.Equal(ErrNotExist, errors.Cause(ks.Remove(rawAddr1)))
+}
+
+func TestEncryptedKeyStore_ImportPlainKeys(t *testing.T) {
+	require := require.New(t)
+
+	plainDir := filepath.Join(os.TempDir(), "plainkeystore")
+	ksDir := filepath.Join(os.TempDir(), "encryptedkeystore")
+	os.RemoveAll(plainDir)
+	os.RemoveAll(ksDir)
+	defer func() {
+		require.NoError(os.RemoveAll(plainDir))
+		require.NoError(os.RemoveAll(ksDir))
+	}()
+
+	plain, err := NewPlainKeyStore(plainDir)
+	require.NoError(err)
+	addr1 := testaddress.ConstructAddress(1, pubKey1, priKey1)
+	addr2 := testaddress.ConstructAddress(1, pubKey2, priKey2)
+	require.NoError(plain.Store(rawAddr1, addr1))
+
+	// import from another directory
+	ks, err := NewEncryptedKeyStore(ksDir, "pass", PBKDF2Option(16))
+	require.NoError(err)
+	imported, err := ks.ImportPlainKeys(plainDir)
+	require.NoError(err)
+	require.Equal([]string{rawAddr1}, imported)
+	exist, err := plain.Has(rawAddr1)
+	require.NoError(err)
+	require.False(exist)
+	require.NoError(ks.Unlock(rawAddr1, "pass", 0))
+	val, err := ks.Get(rawAddr1)
+	require.NoError(err)
+	require.Equal(addr1, val)
+
+	// migrate in place, the encrypted keys are skipped
+	require.NoError(plain.Store(rawAddr2, addr2))
+	ks2, err := NewEncryptedKeyStore(plainDir, "pass2", PBKDF2Option(16))
+	require.NoError(err)
+	require.Equal(ErrKeyFormat, errors.Cause(ks2.Unlock(rawAddr2, "pass2", 0)))
+	// the plain key is left intact if it fails to be replaced by the encrypted one
+	rename = func(string, string) error { return errors.New("failed to rename") }
+	_, err = ks2.ImportPlainKeys(plainDir)
+	rename = os.Rename
+	require.Error(err)
+	addrs, err := ks2.All()
+	require.NoError(err)
+	require.Equal([]string{rawAddr2}, addrs)
+	val, err = plain.Get(rawAddr2)
+	require.NoError(err)
+	require.Equal(addr2, val)
+	imported, err = ks2.ImportPlainKeys(plainDir)
+	require.NoError(err)
+	require.Equal([]string{rawAddr2}, imported)
+	imported, err = ks2.ImportPlainKeys(plainDir)
+	require.NoError(err)
+	require.Empty(imported)
+	addrs, err = ks2.All()
+	require.NoError(err)
+	require.Equal([]string{rawAddr2}, addrs)
+	require.NoError(ks2.Unlock(rawAddr2, "pass2", 0))
+	val, err = ks2.Get(rawAddr2)
+	require.NoError(err)
+	require.Equal(addr2, val)
+}
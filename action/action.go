@@ -173,6 +173,56 @@ func Verify(act Action) error {
 	)
 }
 
+// LoadAction converts an action proto into the action of its type
+func LoadAction(actPb *iproto.ActionPb) (Action, error) {
+	var act Action
+	if actPb.GetTransfer() != nil {
+		act = &Transfer{}
+	} else if actPb.GetVote() != nil {
+		act = &Vote{}
+	} else if actPb.GetExecution() != nil {
+		act = &Execution{}
+	} else if actPb.GetPutBlock() != nil {
+		act = &PutBlock{}
+	} else if actPb.GetStartSubChain() != nil {
+		act = &StartSubChain{}
+	} else if actPb.GetStopSubChain() != nil {
+		act = &StopSubChain{}
+	} else if actPb.GetCreateDeposit() != nil {
+		act = &CreateDeposit{}
+	} else if actPb.GetSettleDeposit() != nil {
+		act = &SettleDeposit{}
+	} else if actPb.GetCreatePlumChain() != nil {
+		act = &CreatePlumChain{}
+	} else if actPb.GetTerminatePlumChain() != nil {
+		act = &TerminatePlumChain{}
+	} else if actPb.GetPlumPutBlock() != nil {
+		act = &PlumPutBlock{}
+	} else if actPb.GetPlumCreateDeposit() != nil {
+		act = &PlumCreateDeposit{}
+	} else if actPb.GetPlumStartExit() != nil {
+		act = &PlumStartExit{}
+	} else if actPb.GetPlumChallengeExit() != nil {
+		act = &PlumChallengeExit{}
+	} else if actPb.GetPlumResponseChallengeExit() != nil {
+		act = &PlumResponseChallengeExit{}
+	} else if actPb.GetPlumFinalizeExit() != nil {
+		act = &PlumFinalizeExit{}
+	} else if actPb.GetPlumSettleDeposit() != nil {
+		act = &PlumSettleDeposit{}
+	} else if actPb.GetPlumTransfer() != nil {
+		act = &PlumTransfer{}
+	} else if actPb.GetEvidence() != nil {
+		act = &Evidence{}
+	} else {
+		return nil, errors.New("no appliable action to handle in action proto")
+	}
+	if err := act.LoadProto(actPb); err != nil {
+		return nil, err
+	}
+	return act, nil
+}
+
 // ClassifyActions classfies actions
 func ClassifyActions(actions []Action) ([]*Transfer, []*Vote, []*Execution) {
 	transfers := make([]*Transfer, 0)
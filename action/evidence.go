@@ -0,0 +1,130 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+// EvidenceIntrinsicGas represents the intrinsic gas for the evidence action
+const EvidenceIntrinsicGas = uint64(10000)
+
+// EvidenceType is the type of the equivocation proven by an evidence
+type EvidenceType uint32
+
+const (
+	// DoubleEndorsement is the equivocation of endorsing two blocks with the same height, round and topic
+	DoubleEndorsement EvidenceType = iota
+	// DoubleProposal is the equivocation of proposing two blocks in the same round
+	DoubleProposal
+)
+
+// Evidence represents the action to report a delegate signing conflicting consensus messages. It carries the two
+// serialized messages in conflict, which are EndorsePb for DoubleEndorsement and ProposePb for DoubleProposal
+type Evidence struct {
+	AbstractAction
+	evidenceType EvidenceType
+	first        []byte
+	second       []byte
+}
+
+// NewEvidence instantiates an evidence action struct
+func NewEvidence(
+	nonce uint64,
+	reporter string,
+	evidenceType EvidenceType,
+	first []byte,
+	second []byte,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *Evidence {
+	return &Evidence{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  reporter,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		evidenceType: evidenceType,
+		first:        first,
+		second:       second,
+	}
+}
+
+// Reporter returns the address of the reporter. It's the wrapper of Action.SrcAddr
+func (e *Evidence) Reporter() string { return e.SrcAddr() }
+
+// EvidenceType returns the type of the equivocation
+func (e *Evidence) EvidenceType() EvidenceType { return e.evidenceType }
+
+// First returns the first serialized message in conflict
+func (e *Evidence) First() []byte { return e.first }
+
+// Second returns the second serialized message in conflict
+func (e *Evidence) Second() []byte { return e.second }
+
+// ByteStream returns a raw byte stream of the evidence action
+func (e *Evidence) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(e).String())
+	stream = append(stream, e.BasicActionByteStream()...)
+	stream = append(stream, byteutil.Uint32ToBytes(uint32(e.evidenceType))...)
+	stream = append(stream, e.first...)
+	return append(stream, e.second...)
+}
+
+// Proto converts Evidence to protobuf's ActionPb
+func (e *Evidence) Proto() *iproto.ActionPb {
+	act := e.abstractActionProto()
+	act.Action = &iproto.ActionPb_Evidence{
+		Evidence: &iproto.EvidencePb{
+			Type:   uint32(e.evidenceType),
+			First:  e.first,
+			Second: e.second,
+		},
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to Evidence
+func (e *Evidence) LoadProto(pbAct *iproto.ActionPb) error {
+	if e == nil {
+		return errors.New("nil action to load proto")
+	}
+	*e = Evidence{}
+	pbEvidence := pbAct.GetEvidence()
+	if pbEvidence == nil {
+		return errors.New("empty Evidence action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, "")
+	if err != nil {
+		return err
+	}
+	e.AbstractAction = act
+	e.evidenceType = EvidenceType(pbEvidence.Type)
+	e.first = pbEvidence.First
+	e.second = pbEvidence.Second
+	return nil
+}
+
+// Hash returns the hash of the evidence action
+func (e *Evidence) Hash() hash.Hash32B { return blake2b.Sum256(e.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the evidence action
+func (e *Evidence) IntrinsicGas() (uint64, error) { return EvidenceIntrinsicGas, nil }
+
+// Cost returns the total cost of the evidence action
+func (e *Evidence) Cost() (*big.Int, error) { return intrinsicGasFee(e) }
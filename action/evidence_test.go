@@ -0,0 +1,43 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestEvidence(t *testing.T) {
+	t.Parallel()
+
+	reporter := testaddress.Addrinfo["producer"]
+	e := NewEvidence(1, reporter.RawAddress, DoubleProposal, []byte("first"), []byte("second"), 10, big.NewInt(2))
+	require.NoError(t, Sign(e, reporter.PrivateKey))
+
+	var e2 Evidence
+	require.NoError(t, e2.LoadProto(e.Proto()))
+	assert.Equal(t, reporter.RawAddress, e2.Reporter())
+	assert.Equal(t, DoubleProposal, e2.EvidenceType())
+	assert.Equal(t, []byte("first"), e2.First())
+	assert.Equal(t, []byte("second"), e2.Second())
+	assert.Equal(t, e.Hash(), e2.Hash())
+	assert.NoError(t, Verify(&e2))
+
+	cost, err := e2.Cost()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(int64(2*EvidenceIntrinsicGas)), cost)
+
+	// the messages in conflict are covered by the hash
+	e3 := NewEvidence(1, reporter.RawAddress, DoubleProposal, []byte("first"), []byte("third"), 10, big.NewInt(2))
+	assert.NotEqual(t, e.Hash(), e3.Hash())
+	assert.Error(t, e2.LoadProto(NewPlumSettleDeposit(1, 3, reporter.RawAddress, 10, big.NewInt(2)).Proto()))
+}
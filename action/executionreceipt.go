@@ -84,8 +84,8 @@ func (log *Log) ConvertToLogPb() *iproto.LogPb {
 	l := &iproto.LogPb{}
 	l.Address = log.Address
 	l.Topics = [][]byte{}
-	for _, topic := range log.Topics {
-		l.Topics = append(l.Topics, topic[:])
+	for i := range log.Topics {
+		l.Topics = append(l.Topics, log.Topics[i][:])
 	}
 	l.Data = log.Data
 	l.BlockNumber = log.BlockNumber
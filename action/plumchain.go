@@ -0,0 +1,432 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+const (
+	// CreatePlumChainIntrinsicGas represents the intrinsic gas for the create plum chain action
+	CreatePlumChainIntrinsicGas = uint64(10000)
+	// TerminatePlumChainIntrinsicGas represents the intrinsic gas for the terminate plum chain action
+	TerminatePlumChainIntrinsicGas = uint64(10000)
+	// PlumPutBlockIntrinsicGas represents the intrinsic gas for the plum put block action
+	PlumPutBlockIntrinsicGas = uint64(1000)
+	// PlumCreateDepositIntrinsicGas represents the intrinsic gas for the plum create deposit action
+	PlumCreateDepositIntrinsicGas = uint64(10000)
+)
+
+// CreatePlumChain represents the action to create a plum chain, which is a plasma chain whose operator is the sender
+type CreatePlumChain struct {
+	AbstractAction
+}
+
+// NewCreatePlumChain instantiates a plum chain creation action struct
+func NewCreatePlumChain(
+	nonce uint64,
+	ownerAddr string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *CreatePlumChain {
+	return &CreatePlumChain{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  ownerAddr,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+	}
+}
+
+// OwnerAddress returns the address of the plum chain owner. It's the wrapper of Action.SrcAddr
+func (c *CreatePlumChain) OwnerAddress() string { return c.SrcAddr() }
+
+// ByteStream returns a raw byte stream of the create plum chain action
+func (c *CreatePlumChain) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(c).String())
+	return append(stream, c.BasicActionByteStream()...)
+}
+
+// Proto converts CreatePlumChain to protobuf's ActionPb
+func (c *CreatePlumChain) Proto() *iproto.ActionPb {
+	act := c.abstractActionProto()
+	act.Action = &iproto.ActionPb_CreatePlumChain{CreatePlumChain: &iproto.CreatePlumChainPb{}}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to CreatePlumChain
+func (c *CreatePlumChain) LoadProto(pbAct *iproto.ActionPb) error {
+	if c == nil {
+		return errors.New("nil action to load proto")
+	}
+	*c = CreatePlumChain{}
+	if pbAct.GetCreatePlumChain() == nil {
+		return errors.New("empty CreatePlumChain action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, "")
+	if err != nil {
+		return err
+	}
+	c.AbstractAction = act
+	return nil
+}
+
+// Hash returns the hash of the create plum chain action
+func (c *CreatePlumChain) Hash() hash.Hash32B { return blake2b.Sum256(c.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the create plum chain action
+func (c *CreatePlumChain) IntrinsicGas() (uint64, error) { return CreatePlumChainIntrinsicGas, nil }
+
+// Cost returns the total cost of the create plum chain action
+func (c *CreatePlumChain) Cost() (*big.Int, error) { return intrinsicGasFee(c) }
+
+// TerminatePlumChain represents the action to terminate a plum chain. Once terminated, the plum chain doesn't accept
+// new blocks or deposits, but the coins on it can still exit
+type TerminatePlumChain struct {
+	AbstractAction
+}
+
+// NewTerminatePlumChain instantiates a plum chain termination action struct
+func NewTerminatePlumChain(
+	nonce uint64,
+	subChainAddress string,
+	ownerAddr string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *TerminatePlumChain {
+	return &TerminatePlumChain{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  ownerAddr,
+			dstAddr:  subChainAddress,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+	}
+}
+
+// SubChainAddress returns the address of the plum chain. It's the wrapper of Action.DstAddr
+func (t *TerminatePlumChain) SubChainAddress() string { return t.DstAddr() }
+
+// OwnerAddress returns the address of the plum chain owner. It's the wrapper of Action.SrcAddr
+func (t *TerminatePlumChain) OwnerAddress() string { return t.SrcAddr() }
+
+// ByteStream returns a raw byte stream of the terminate plum chain action
+func (t *TerminatePlumChain) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(t).String())
+	return append(stream, t.BasicActionByteStream()...)
+}
+
+// Proto converts TerminatePlumChain to protobuf's ActionPb
+func (t *TerminatePlumChain) Proto() *iproto.ActionPb {
+	act := t.abstractActionProto()
+	act.Action = &iproto.ActionPb_TerminatePlumChain{
+		TerminatePlumChain: &iproto.TerminatePlumChainPb{SubChainAddress: t.dstAddr},
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to TerminatePlumChain
+func (t *TerminatePlumChain) LoadProto(pbAct *iproto.ActionPb) error {
+	if t == nil {
+		return errors.New("nil action to load proto")
+	}
+	*t = TerminatePlumChain{}
+	pbTerminate := pbAct.GetTerminatePlumChain()
+	if pbTerminate == nil {
+		return errors.New("empty TerminatePlumChain action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, pbTerminate.SubChainAddress)
+	if err != nil {
+		return err
+	}
+	t.AbstractAction = act
+	return nil
+}
+
+// Hash returns the hash of the terminate plum chain action
+func (t *TerminatePlumChain) Hash() hash.Hash32B { return blake2b.Sum256(t.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the terminate plum chain action
+func (t *TerminatePlumChain) IntrinsicGas() (uint64, error) {
+	return TerminatePlumChainIntrinsicGas, nil
+}
+
+// Cost returns the total cost of the terminate plum chain action
+func (t *TerminatePlumChain) Cost() (*big.Int, error) { return intrinsicGasFee(t) }
+
+// PlumPutBlock represents the action of the plum chain owner to commit the merkle roots of a plum chain block
+type PlumPutBlock struct {
+	AbstractAction
+	height uint64
+	roots  map[string]hash.Hash32B
+}
+
+// NewPlumPutBlock instantiates a plum chain block commitment action struct
+func NewPlumPutBlock(
+	nonce uint64,
+	subChainAddress string,
+	producerAddress string,
+	height uint64,
+	roots map[string]hash.Hash32B,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumPutBlock {
+	return &PlumPutBlock{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  producerAddress,
+			dstAddr:  subChainAddress,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		height: height,
+		roots:  roots,
+	}
+}
+
+// SubChainAddress returns the address of the plum chain. It's the wrapper of Action.DstAddr
+func (pb *PlumPutBlock) SubChainAddress() string { return pb.DstAddr() }
+
+// ProducerAddress returns the address of the block producer. It's the wrapper of Action.SrcAddr
+func (pb *PlumPutBlock) ProducerAddress() string { return pb.SrcAddr() }
+
+// Height returns the height of the plum chain block
+func (pb *PlumPutBlock) Height() uint64 { return pb.height }
+
+// Roots returns the merkle roots of the plum chain block
+func (pb *PlumPutBlock) Roots() map[string]hash.Hash32B { return pb.roots }
+
+// ByteStream returns a raw byte stream of the plum put block action
+func (pb *PlumPutBlock) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(pb).String())
+	stream = append(stream, pb.BasicActionByteStream()...)
+	stream = append(stream, byteutil.Uint64ToBytes(pb.height)...)
+	keys := make([]string, 0, len(pb.roots))
+	for k := range pb.roots {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := pb.roots[k]
+		stream = append(stream, k...)
+		stream = append(stream, v[:]...)
+	}
+	return stream
+}
+
+// Proto converts PlumPutBlock to protobuf's ActionPb
+func (pb *PlumPutBlock) Proto() *iproto.ActionPb {
+	roots := make(map[string][]byte)
+	for k, v := range pb.roots {
+		roots[k] = make([]byte, len(v))
+		copy(roots[k], v[:])
+	}
+	act := pb.abstractActionProto()
+	act.Action = &iproto.ActionPb_PlumPutBlock{
+		PlumPutBlock: &iproto.PlumPutBlockPb{
+			SubChainAddress: pb.dstAddr,
+			Height:          pb.height,
+			Roots:           roots,
+		},
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to PlumPutBlock
+func (pb *PlumPutBlock) LoadProto(pbAct *iproto.ActionPb) error {
+	if pb == nil {
+		return errors.New("nil action to load proto")
+	}
+	*pb = PlumPutBlock{}
+	pbPutBlock := pbAct.GetPlumPutBlock()
+	if pbPutBlock == nil {
+		return errors.New("empty PlumPutBlock action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, pbPutBlock.SubChainAddress)
+	if err != nil {
+		return err
+	}
+	pb.AbstractAction = act
+	pb.height = pbPutBlock.Height
+	pb.roots = make(map[string]hash.Hash32B)
+	for k, v := range pbPutBlock.Roots {
+		pb.roots[k] = byteutil.BytesTo32B(v)
+	}
+	return nil
+}
+
+// Hash returns the hash of the plum put block action
+func (pb *PlumPutBlock) Hash() hash.Hash32B { return blake2b.Sum256(pb.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the plum put block action
+func (pb *PlumPutBlock) IntrinsicGas() (uint64, error) { return PlumPutBlockIntrinsicGas, nil }
+
+// Cost returns the total cost of the plum put block action
+func (pb *PlumPutBlock) Cost() (*big.Int, error) { return intrinsicGasFee(pb) }
+
+// PlumCreateDeposit represents the action to deposit the token from main-chain to a plum chain. The deposit becomes a
+// coin on the plum chain owned by the recipient, which must be a plum chain address
+type PlumCreateDeposit struct {
+	AbstractAction
+	amount    *big.Int
+	recipient string
+}
+
+// NewPlumCreateDeposit instantiates a deposit creation to plum chain action struct
+func NewPlumCreateDeposit(
+	nonce uint64,
+	subChainAddress string,
+	amount *big.Int,
+	sender string,
+	recipient string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumCreateDeposit {
+	return &PlumCreateDeposit{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  sender,
+			dstAddr:  subChainAddress,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		amount:    amount,
+		recipient: recipient,
+	}
+}
+
+// SubChainAddress returns the address of the plum chain. It's the wrapper of Action.DstAddr
+func (d *PlumCreateDeposit) SubChainAddress() string { return d.DstAddr() }
+
+// Amount returns the amount
+func (d *PlumCreateDeposit) Amount() *big.Int { return d.amount }
+
+// Sender returns the sender address. It's the wrapper of Action.SrcAddr
+func (d *PlumCreateDeposit) Sender() string { return d.SrcAddr() }
+
+// Recipient returns the recipient address on the plum chain
+func (d *PlumCreateDeposit) Recipient() string { return d.recipient }
+
+// ByteStream returns a raw byte stream of the plum create deposit action
+func (d *PlumCreateDeposit) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(d).String())
+	stream = append(stream, d.BasicActionByteStream()...)
+	if d.amount != nil && len(d.amount.Bytes()) > 0 {
+		stream = append(stream, d.amount.Bytes()...)
+	}
+	return append(stream, d.recipient...)
+}
+
+// Proto converts PlumCreateDeposit to protobuf's ActionPb
+func (d *PlumCreateDeposit) Proto() *iproto.ActionPb {
+	pbDeposit := &iproto.PlumCreateDepositPb{
+		SubChainAddress: d.dstAddr,
+		Recipient:       d.recipient,
+	}
+	if d.amount != nil && len(d.amount.Bytes()) > 0 {
+		pbDeposit.Amount = d.amount.Bytes()
+	}
+	act := d.abstractActionProto()
+	act.Action = &iproto.ActionPb_PlumCreateDeposit{PlumCreateDeposit: pbDeposit}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to PlumCreateDeposit
+func (d *PlumCreateDeposit) LoadProto(pbAct *iproto.ActionPb) error {
+	if d == nil {
+		return errors.New("nil action to load proto")
+	}
+	*d = PlumCreateDeposit{}
+	pbDeposit := pbAct.GetPlumCreateDeposit()
+	if pbDeposit == nil {
+		return errors.New("empty PlumCreateDeposit action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, pbDeposit.SubChainAddress)
+	if err != nil {
+		return err
+	}
+	d.AbstractAction = act
+	d.amount = big.NewInt(0).SetBytes(pbDeposit.Amount)
+	d.recipient = pbDeposit.Recipient
+	return nil
+}
+
+// Hash returns the hash of the plum create deposit action
+func (d *PlumCreateDeposit) Hash() hash.Hash32B { return blake2b.Sum256(d.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the plum create deposit action
+func (d *PlumCreateDeposit) IntrinsicGas() (uint64, error) { return PlumCreateDepositIntrinsicGas, nil }
+
+// Cost returns the total cost of the plum create deposit action
+func (d *PlumCreateDeposit) Cost() (*big.Int, error) {
+	fee, err := intrinsicGasFee(d)
+	if err != nil {
+		return nil, err
+	}
+	return fee.Add(fee, d.Amount()), nil
+}
+
+// abstractActionProto converts the common fields of the action to protobuf's ActionPb, leaving the payload to set
+func (act *AbstractAction) abstractActionProto() *iproto.ActionPb {
+	pbAct := &iproto.ActionPb{
+		Version:      act.version,
+		Sender:       act.srcAddr,
+		SenderPubKey: act.srcPubkey[:],
+		Nonce:        act.nonce,
+		GasLimit:     act.gasLimit,
+		Signature:    act.signature,
+	}
+	if act.gasPrice != nil && len(act.gasPrice.Bytes()) > 0 {
+		pbAct.GasPrice = act.gasPrice.Bytes()
+	}
+	return pbAct
+}
+
+// loadAbstractActionProto converts the common fields of protobuf's ActionPb to an abstract action
+func loadAbstractActionProto(pbAct *iproto.ActionPb, dstAddr string) (AbstractAction, error) {
+	srcPub, err := keypair.BytesToPublicKey(pbAct.SenderPubKey)
+	if err != nil {
+		return AbstractAction{}, err
+	}
+	ab := &Builder{}
+	act := ab.SetVersion(pbAct.Version).
+		SetNonce(pbAct.Nonce).
+		SetSourceAddress(pbAct.Sender).
+		SetSourcePublicKey(srcPub).
+		SetGasLimit(pbAct.GasLimit).
+		SetGasPriceByBytes(pbAct.GasPrice).
+		SetDestinationAddress(dstAddr).
+		Build()
+	act.SetSignature(pbAct.Signature)
+	return act, nil
+}
+
+// intrinsicGasFee returns the fee of the intrinsic gas of the action
+func intrinsicGasFee(act Action) (*big.Int, error) {
+	intrinsicGas, err := act.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get intrinsic gas for action %x", act.Hash())
+	}
+	return big.NewInt(0).Mul(act.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
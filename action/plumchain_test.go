@@ -0,0 +1,86 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestCreateAndTerminatePlumChain(t *testing.T) {
+	t.Parallel()
+
+	owner := testaddress.Addrinfo["producer"].RawAddress
+	subChain := testaddress.Addrinfo["alfa"].RawAddress
+
+	create := NewCreatePlumChain(1, owner, 10, big.NewInt(100))
+	var create2 CreatePlumChain
+	require.NoError(t, create2.LoadProto(create.Proto()))
+	assert.Equal(t, uint64(1), create2.Nonce())
+	assert.Equal(t, owner, create2.OwnerAddress())
+	assert.Equal(t, uint64(10), create2.GasLimit())
+	assert.Equal(t, big.NewInt(100), create2.GasPrice())
+	assert.Equal(t, create.Hash(), create2.Hash())
+
+	terminate := NewTerminatePlumChain(2, subChain, owner, 10, big.NewInt(100))
+	var terminate2 TerminatePlumChain
+	require.NoError(t, terminate2.LoadProto(terminate.Proto()))
+	assert.Equal(t, uint64(2), terminate2.Nonce())
+	assert.Equal(t, subChain, terminate2.SubChainAddress())
+	assert.Equal(t, owner, terminate2.OwnerAddress())
+	assert.Equal(t, terminate.Hash(), terminate2.Hash())
+
+	assert.Error(t, create2.LoadProto(terminate.Proto()))
+}
+
+func TestPlumPutBlock(t *testing.T) {
+	t.Parallel()
+
+	producer := testaddress.Addrinfo["producer"].RawAddress
+	subChain := testaddress.Addrinfo["alfa"].RawAddress
+	roots := map[string]hash.Hash32B{
+		"tx":    byteutil.BytesTo32B(hash.Hash256b([]byte("tx"))),
+		"state": byteutil.BytesTo32B(hash.Hash256b([]byte("state"))),
+	}
+
+	put := NewPlumPutBlock(1, subChain, producer, 100, roots, 10, big.NewInt(100))
+	var put2 PlumPutBlock
+	require.NoError(t, put2.LoadProto(put.Proto()))
+	assert.Equal(t, subChain, put2.SubChainAddress())
+	assert.Equal(t, producer, put2.ProducerAddress())
+	assert.Equal(t, uint64(100), put2.Height())
+	assert.Equal(t, roots, put2.Roots())
+	assert.Equal(t, put.Hash(), put2.Hash())
+}
+
+func TestPlumCreateDeposit(t *testing.T) {
+	t.Parallel()
+
+	sender := testaddress.Addrinfo["producer"].RawAddress
+	recipient := testaddress.Addrinfo["bravo"].RawAddress
+	subChain := testaddress.Addrinfo["alfa"].RawAddress
+
+	deposit := NewPlumCreateDeposit(1, subChain, big.NewInt(1000), sender, recipient, 10, big.NewInt(100))
+	var deposit2 PlumCreateDeposit
+	require.NoError(t, deposit2.LoadProto(deposit.Proto()))
+	assert.Equal(t, subChain, deposit2.SubChainAddress())
+	assert.Equal(t, big.NewInt(1000), deposit2.Amount())
+	assert.Equal(t, sender, deposit2.Sender())
+	assert.Equal(t, recipient, deposit2.Recipient())
+	assert.Equal(t, deposit.Hash(), deposit2.Hash())
+
+	cost, err := deposit2.Cost()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000+int64(PlumCreateDepositIntrinsicGas)*100), cost)
+}
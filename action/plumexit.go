@@ -0,0 +1,507 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+const (
+	// PlumStartExitIntrinsicGas represents the intrinsic gas for the plum start exit action
+	PlumStartExitIntrinsicGas = uint64(10000)
+	// PlumChallengeExitIntrinsicGas represents the intrinsic gas for the plum challenge exit action
+	PlumChallengeExitIntrinsicGas = uint64(10000)
+	// PlumResponseChallengeExitIntrinsicGas represents the intrinsic gas for the plum response challenge exit action
+	PlumResponseChallengeExitIntrinsicGas = uint64(10000)
+	// PlumFinalizeExitIntrinsicGas represents the intrinsic gas for the plum finalize exit action
+	PlumFinalizeExitIntrinsicGas = uint64(10000)
+)
+
+// PlumStartExit represents the action to start exiting a coin from a plum chain to main-chain. The sender proves the
+// ownership of the coin with the last transfer of the coin (exit transfer) and the transfer before it (previous
+// transfer), both of which are serialized PlumTransfer actions, and their merkle proofs in the plum chain blocks. The
+// depositor of a coin never transferred exits it by the coin ID without any transfer (deposit exit)
+type PlumStartExit struct {
+	AbstractAction
+	coinID                      uint64
+	previousTransfer            []byte
+	previousTransferBlockProof  []byte
+	previousTransferBlockHeight uint64
+	exitTransfer                []byte
+	exitTransferBlockProof      []byte
+	exitTransferBlockHeight     uint64
+}
+
+// NewPlumStartExit instantiates a plum start exit action struct
+func NewPlumStartExit(
+	nonce uint64,
+	subChainAddress string,
+	previousTransfer []byte,
+	previousTransferBlockProof []byte,
+	previousTransferBlockHeight uint64,
+	exitTransfer []byte,
+	exitTransferBlockProof []byte,
+	exitTransferBlockHeight uint64,
+	sender string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumStartExit {
+	return &PlumStartExit{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  sender,
+			dstAddr:  subChainAddress,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		previousTransfer:            previousTransfer,
+		previousTransferBlockProof:  previousTransferBlockProof,
+		previousTransferBlockHeight: previousTransferBlockHeight,
+		exitTransfer:                exitTransfer,
+		exitTransferBlockProof:      exitTransferBlockProof,
+		exitTransferBlockHeight:     exitTransferBlockHeight,
+	}
+}
+
+// NewPlumDepositExit instantiates a plum start exit action struct of the depositor exiting a coin by its ID
+func NewPlumDepositExit(
+	nonce uint64,
+	subChainAddress string,
+	coinID uint64,
+	sender string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumStartExit {
+	return &PlumStartExit{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  sender,
+			dstAddr:  subChainAddress,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		coinID: coinID,
+	}
+}
+
+// SubChainAddress returns the address of the plum chain. It's the wrapper of Action.DstAddr
+func (s *PlumStartExit) SubChainAddress() string { return s.DstAddr() }
+
+// CoinID returns the ID of the coin of a deposit exit. The coin of other exits is the one of the exit transfer
+func (s *PlumStartExit) CoinID() uint64 { return s.coinID }
+
+// PreviousTransfer returns the serialized transfer before the exit transfer
+func (s *PlumStartExit) PreviousTransfer() []byte { return s.previousTransfer }
+
+// PreviousTransferBlockProof returns the merkle proof of the previous transfer
+func (s *PlumStartExit) PreviousTransferBlockProof() []byte { return s.previousTransferBlockProof }
+
+// PreviousTransferBlockHeight returns the height of the plum chain block including the previous transfer
+func (s *PlumStartExit) PreviousTransferBlockHeight() uint64 { return s.previousTransferBlockHeight }
+
+// ExitTransfer returns the serialized transfer to the exit owner
+func (s *PlumStartExit) ExitTransfer() []byte { return s.exitTransfer }
+
+// ExitTransferBlockProof returns the merkle proof of the exit transfer
+func (s *PlumStartExit) ExitTransferBlockProof() []byte { return s.exitTransferBlockProof }
+
+// ExitTransferBlockHeight returns the height of the plum chain block including the exit transfer
+func (s *PlumStartExit) ExitTransferBlockHeight() uint64 { return s.exitTransferBlockHeight }
+
+// ByteStream returns a raw byte stream of the plum start exit action
+func (s *PlumStartExit) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(s).String())
+	stream = append(stream, s.BasicActionByteStream()...)
+	stream = append(stream, byteutil.Uint64ToBytes(s.coinID)...)
+	stream = append(stream, s.previousTransfer...)
+	stream = append(stream, s.previousTransferBlockProof...)
+	stream = append(stream, byteutil.Uint64ToBytes(s.previousTransferBlockHeight)...)
+	stream = append(stream, s.exitTransfer...)
+	stream = append(stream, s.exitTransferBlockProof...)
+	return append(stream, byteutil.Uint64ToBytes(s.exitTransferBlockHeight)...)
+}
+
+// Proto converts PlumStartExit to protobuf's ActionPb
+func (s *PlumStartExit) Proto() *iproto.ActionPb {
+	act := s.abstractActionProto()
+	act.Action = &iproto.ActionPb_PlumStartExit{
+		PlumStartExit: &iproto.PlumStartExitPb{
+			SubChainAddress:             s.dstAddr,
+			PreviousTransfer:            s.previousTransfer,
+			PreviousTransferBlockProof:  s.previousTransferBlockProof,
+			PreviousTransferBlockHeight: s.previousTransferBlockHeight,
+			ExitTransfer:                s.exitTransfer,
+			ExitTransferBlockProof:      s.exitTransferBlockProof,
+			ExitTransferBlockHeight:     s.exitTransferBlockHeight,
+			CoinID:                      s.coinID,
+		},
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to PlumStartExit
+func (s *PlumStartExit) LoadProto(pbAct *iproto.ActionPb) error {
+	if s == nil {
+		return errors.New("nil action to load proto")
+	}
+	*s = PlumStartExit{}
+	pbStartExit := pbAct.GetPlumStartExit()
+	if pbStartExit == nil {
+		return errors.New("empty PlumStartExit action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, pbStartExit.SubChainAddress)
+	if err != nil {
+		return err
+	}
+	s.AbstractAction = act
+	s.coinID = pbStartExit.CoinID
+	s.previousTransfer = pbStartExit.PreviousTransfer
+	s.previousTransferBlockProof = pbStartExit.PreviousTransferBlockProof
+	s.previousTransferBlockHeight = pbStartExit.PreviousTransferBlockHeight
+	s.exitTransfer = pbStartExit.ExitTransfer
+	s.exitTransferBlockProof = pbStartExit.ExitTransferBlockProof
+	s.exitTransferBlockHeight = pbStartExit.ExitTransferBlockHeight
+	return nil
+}
+
+// Hash returns the hash of the plum start exit action
+func (s *PlumStartExit) Hash() hash.Hash32B { return blake2b.Sum256(s.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the plum start exit action
+func (s *PlumStartExit) IntrinsicGas() (uint64, error) { return PlumStartExitIntrinsicGas, nil }
+
+// Cost returns the total cost of the plum start exit action
+func (s *PlumStartExit) Cost() (*big.Int, error) { return intrinsicGasFee(s) }
+
+// PlumChallengeExit represents the action to challenge an exit in progress with a transfer of the exiting coin
+type PlumChallengeExit struct {
+	AbstractAction
+	coinID                       uint64
+	challengeTransfer            []byte
+	challengeTransferBlockProof  []byte
+	challengeTransferBlockHeight uint64
+}
+
+// NewPlumChallengeExit instantiates a plum challenge exit action struct
+func NewPlumChallengeExit(
+	nonce uint64,
+	subChainAddress string,
+	coinID uint64,
+	challengeTransfer []byte,
+	challengeTransferBlockProof []byte,
+	challengeTransferBlockHeight uint64,
+	sender string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumChallengeExit {
+	return &PlumChallengeExit{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  sender,
+			dstAddr:  subChainAddress,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		coinID:                       coinID,
+		challengeTransfer:            challengeTransfer,
+		challengeTransferBlockProof:  challengeTransferBlockProof,
+		challengeTransferBlockHeight: challengeTransferBlockHeight,
+	}
+}
+
+// SubChainAddress returns the address of the plum chain. It's the wrapper of Action.DstAddr
+func (c *PlumChallengeExit) SubChainAddress() string { return c.DstAddr() }
+
+// CoinID returns the ID of the exiting coin
+func (c *PlumChallengeExit) CoinID() uint64 { return c.coinID }
+
+// ChallengeTransfer returns the serialized transfer to challenge the exit with
+func (c *PlumChallengeExit) ChallengeTransfer() []byte { return c.challengeTransfer }
+
+// ChallengeTransferBlockProof returns the merkle proof of the challenge transfer
+func (c *PlumChallengeExit) ChallengeTransferBlockProof() []byte {
+	return c.challengeTransferBlockProof
+}
+
+// ChallengeTransferBlockHeight returns the height of the plum chain block including the challenge transfer
+func (c *PlumChallengeExit) ChallengeTransferBlockHeight() uint64 {
+	return c.challengeTransferBlockHeight
+}
+
+// ByteStream returns a raw byte stream of the plum challenge exit action
+func (c *PlumChallengeExit) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(c).String())
+	stream = append(stream, c.BasicActionByteStream()...)
+	stream = append(stream, byteutil.Uint64ToBytes(c.coinID)...)
+	stream = append(stream, c.challengeTransfer...)
+	stream = append(stream, c.challengeTransferBlockProof...)
+	return append(stream, byteutil.Uint64ToBytes(c.challengeTransferBlockHeight)...)
+}
+
+// Proto converts PlumChallengeExit to protobuf's ActionPb
+func (c *PlumChallengeExit) Proto() *iproto.ActionPb {
+	act := c.abstractActionProto()
+	act.Action = &iproto.ActionPb_PlumChallengeExit{
+		PlumChallengeExit: &iproto.PlumChallengeExit{
+			SubChainAddress:              c.dstAddr,
+			CoinID:                       c.coinID,
+			ChallengeTransfer:            c.challengeTransfer,
+			ChallengeTransferBlockProof:  c.challengeTransferBlockProof,
+			ChallengeTransferBlockHeight: c.challengeTransferBlockHeight,
+		},
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to PlumChallengeExit
+func (c *PlumChallengeExit) LoadProto(pbAct *iproto.ActionPb) error {
+	if c == nil {
+		return errors.New("nil action to load proto")
+	}
+	*c = PlumChallengeExit{}
+	pbChallenge := pbAct.GetPlumChallengeExit()
+	if pbChallenge == nil {
+		return errors.New("empty PlumChallengeExit action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, pbChallenge.SubChainAddress)
+	if err != nil {
+		return err
+	}
+	c.AbstractAction = act
+	c.coinID = pbChallenge.CoinID
+	c.challengeTransfer = pbChallenge.ChallengeTransfer
+	c.challengeTransferBlockProof = pbChallenge.ChallengeTransferBlockProof
+	c.challengeTransferBlockHeight = pbChallenge.ChallengeTransferBlockHeight
+	return nil
+}
+
+// Hash returns the hash of the plum challenge exit action
+func (c *PlumChallengeExit) Hash() hash.Hash32B { return blake2b.Sum256(c.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the plum challenge exit action
+func (c *PlumChallengeExit) IntrinsicGas() (uint64, error) { return PlumChallengeExitIntrinsicGas, nil }
+
+// Cost returns the total cost of the plum challenge exit action
+func (c *PlumChallengeExit) Cost() (*big.Int, error) { return intrinsicGasFee(c) }
+
+// PlumResponseChallengeExit represents the action to respond to a challenge of an exit with a transfer which spends
+// the challenge transfer
+type PlumResponseChallengeExit struct {
+	AbstractAction
+	coinID                      uint64
+	challengeTransfer           []byte
+	responseTransfer            []byte
+	responseTransferBlockProof  []byte
+	responseTransferBlockHeight uint64
+}
+
+// NewPlumResponseChallengeExit instantiates a plum response challenge exit action struct
+func NewPlumResponseChallengeExit(
+	nonce uint64,
+	subChainAddress string,
+	coinID uint64,
+	challengeTransfer []byte,
+	responseTransfer []byte,
+	responseTransferBlockProof []byte,
+	responseTransferBlockHeight uint64,
+	sender string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumResponseChallengeExit {
+	return &PlumResponseChallengeExit{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  sender,
+			dstAddr:  subChainAddress,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		coinID:                      coinID,
+		challengeTransfer:           challengeTransfer,
+		responseTransfer:            responseTransfer,
+		responseTransferBlockProof:  responseTransferBlockProof,
+		responseTransferBlockHeight: responseTransferBlockHeight,
+	}
+}
+
+// SubChainAddress returns the address of the plum chain. It's the wrapper of Action.DstAddr
+func (r *PlumResponseChallengeExit) SubChainAddress() string { return r.DstAddr() }
+
+// CoinID returns the ID of the exiting coin
+func (r *PlumResponseChallengeExit) CoinID() uint64 { return r.coinID }
+
+// ChallengeTransfer returns the serialized transfer of the challenge to respond to
+func (r *PlumResponseChallengeExit) ChallengeTransfer() []byte { return r.challengeTransfer }
+
+// ResponseTransfer returns the serialized transfer which spends the challenge transfer
+func (r *PlumResponseChallengeExit) ResponseTransfer() []byte { return r.responseTransfer }
+
+// ResponseTransferBlockProof returns the merkle proof of the response transfer
+func (r *PlumResponseChallengeExit) ResponseTransferBlockProof() []byte {
+	return r.responseTransferBlockProof
+}
+
+// ResponseTransferBlockHeight returns the height of the plum chain block including the response transfer, which is
+// carried by the previousTransferBlockHeight field of the proto
+func (r *PlumResponseChallengeExit) ResponseTransferBlockHeight() uint64 {
+	return r.responseTransferBlockHeight
+}
+
+// ByteStream returns a raw byte stream of the plum response challenge exit action
+func (r *PlumResponseChallengeExit) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(r).String())
+	stream = append(stream, r.BasicActionByteStream()...)
+	stream = append(stream, byteutil.Uint64ToBytes(r.coinID)...)
+	stream = append(stream, r.challengeTransfer...)
+	stream = append(stream, r.responseTransfer...)
+	stream = append(stream, r.responseTransferBlockProof...)
+	return append(stream, byteutil.Uint64ToBytes(r.responseTransferBlockHeight)...)
+}
+
+// Proto converts PlumResponseChallengeExit to protobuf's ActionPb
+func (r *PlumResponseChallengeExit) Proto() *iproto.ActionPb {
+	act := r.abstractActionProto()
+	act.Action = &iproto.ActionPb_PlumResponseChallengeExit{
+		PlumResponseChallengeExit: &iproto.PlumResponseChallengeExit{
+			SubChainAddress:             r.dstAddr,
+			CoinID:                      r.coinID,
+			ChallengeTransfer:           r.challengeTransfer,
+			ResponseTransfer:            r.responseTransfer,
+			ResponseTransferBlockProof:  r.responseTransferBlockProof,
+			PreviousTransferBlockHeight: r.responseTransferBlockHeight,
+		},
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to PlumResponseChallengeExit
+func (r *PlumResponseChallengeExit) LoadProto(pbAct *iproto.ActionPb) error {
+	if r == nil {
+		return errors.New("nil action to load proto")
+	}
+	*r = PlumResponseChallengeExit{}
+	pbResponse := pbAct.GetPlumResponseChallengeExit()
+	if pbResponse == nil {
+		return errors.New("empty PlumResponseChallengeExit action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, pbResponse.SubChainAddress)
+	if err != nil {
+		return err
+	}
+	r.AbstractAction = act
+	r.coinID = pbResponse.CoinID
+	r.challengeTransfer = pbResponse.ChallengeTransfer
+	r.responseTransfer = pbResponse.ResponseTransfer
+	r.responseTransferBlockProof = pbResponse.ResponseTransferBlockProof
+	r.responseTransferBlockHeight = pbResponse.PreviousTransferBlockHeight
+	return nil
+}
+
+// Hash returns the hash of the plum response challenge exit action
+func (r *PlumResponseChallengeExit) Hash() hash.Hash32B { return blake2b.Sum256(r.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the plum response challenge exit action
+func (r *PlumResponseChallengeExit) IntrinsicGas() (uint64, error) {
+	return PlumResponseChallengeExitIntrinsicGas, nil
+}
+
+// Cost returns the total cost of the plum response challenge exit action
+func (r *PlumResponseChallengeExit) Cost() (*big.Int, error) { return intrinsicGasFee(r) }
+
+// PlumFinalizeExit represents the action to settle an exit whose challenge period is over on main-chain
+type PlumFinalizeExit struct {
+	AbstractAction
+	coinID uint64
+}
+
+// NewPlumFinalizeExit instantiates a plum finalize exit action struct
+func NewPlumFinalizeExit(
+	nonce uint64,
+	subChainAddress string,
+	coinID uint64,
+	sender string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumFinalizeExit {
+	return &PlumFinalizeExit{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  sender,
+			dstAddr:  subChainAddress,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		coinID: coinID,
+	}
+}
+
+// SubChainAddress returns the address of the plum chain. It's the wrapper of Action.DstAddr
+func (f *PlumFinalizeExit) SubChainAddress() string { return f.DstAddr() }
+
+// CoinID returns the ID of the exiting coin
+func (f *PlumFinalizeExit) CoinID() uint64 { return f.coinID }
+
+// ByteStream returns a raw byte stream of the plum finalize exit action
+func (f *PlumFinalizeExit) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(f).String())
+	stream = append(stream, f.BasicActionByteStream()...)
+	return append(stream, byteutil.Uint64ToBytes(f.coinID)...)
+}
+
+// Proto converts PlumFinalizeExit to protobuf's ActionPb
+func (f *PlumFinalizeExit) Proto() *iproto.ActionPb {
+	act := f.abstractActionProto()
+	act.Action = &iproto.ActionPb_PlumFinalizeExit{
+		PlumFinalizeExit: &iproto.PlumFinalizeExit{
+			SubChainAddress: f.dstAddr,
+			CoinID:          f.coinID,
+		},
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to PlumFinalizeExit
+func (f *PlumFinalizeExit) LoadProto(pbAct *iproto.ActionPb) error {
+	if f == nil {
+		return errors.New("nil action to load proto")
+	}
+	*f = PlumFinalizeExit{}
+	pbFinalize := pbAct.GetPlumFinalizeExit()
+	if pbFinalize == nil {
+		return errors.New("empty PlumFinalizeExit action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, pbFinalize.SubChainAddress)
+	if err != nil {
+		return err
+	}
+	f.AbstractAction = act
+	f.coinID = pbFinalize.CoinID
+	return nil
+}
+
+// Hash returns the hash of the plum finalize exit action
+func (f *PlumFinalizeExit) Hash() hash.Hash32B { return blake2b.Sum256(f.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the plum finalize exit action
+func (f *PlumFinalizeExit) IntrinsicGas() (uint64, error) { return PlumFinalizeExitIntrinsicGas, nil }
+
+// Cost returns the total cost of the plum finalize exit action
+func (f *PlumFinalizeExit) Cost() (*big.Int, error) { return intrinsicGasFee(f) }
@@ -0,0 +1,94 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestPlumExit(t *testing.T) {
+	t.Parallel()
+
+	sender := testaddress.Addrinfo["producer"].RawAddress
+	subChain := testaddress.Addrinfo["alfa"].RawAddress
+
+	start := NewPlumStartExit(
+		1,
+		subChain,
+		[]byte("previous"),
+		[]byte("previous proof"),
+		10,
+		[]byte("exit"),
+		[]byte("exit proof"),
+		20,
+		sender,
+		10,
+		big.NewInt(100),
+	)
+	var start2 PlumStartExit
+	require.NoError(t, start2.LoadProto(start.Proto()))
+	assert.Equal(t, subChain, start2.SubChainAddress())
+	assert.Equal(t, []byte("previous"), start2.PreviousTransfer())
+	assert.Equal(t, []byte("previous proof"), start2.PreviousTransferBlockProof())
+	assert.Equal(t, uint64(10), start2.PreviousTransferBlockHeight())
+	assert.Equal(t, []byte("exit"), start2.ExitTransfer())
+	assert.Equal(t, []byte("exit proof"), start2.ExitTransferBlockProof())
+	assert.Equal(t, uint64(20), start2.ExitTransferBlockHeight())
+	assert.Equal(t, start.Hash(), start2.Hash())
+
+	depositExit := NewPlumDepositExit(1, subChain, 3, sender, 10, big.NewInt(100))
+	var depositExit2 PlumStartExit
+	require.NoError(t, depositExit2.LoadProto(depositExit.Proto()))
+	assert.Equal(t, uint64(3), depositExit2.CoinID())
+	assert.Empty(t, depositExit2.ExitTransfer())
+	assert.Equal(t, depositExit.Hash(), depositExit2.Hash())
+	assert.NotEqual(t, NewPlumDepositExit(1, subChain, 4, sender, 10, big.NewInt(100)).Hash(), depositExit.Hash())
+
+	challenge := NewPlumChallengeExit(2, subChain, 3, []byte("challenge"), []byte("proof"), 15, sender, 10, big.NewInt(100))
+	var challenge2 PlumChallengeExit
+	require.NoError(t, challenge2.LoadProto(challenge.Proto()))
+	assert.Equal(t, subChain, challenge2.SubChainAddress())
+	assert.Equal(t, uint64(3), challenge2.CoinID())
+	assert.Equal(t, []byte("challenge"), challenge2.ChallengeTransfer())
+	assert.Equal(t, []byte("proof"), challenge2.ChallengeTransferBlockProof())
+	assert.Equal(t, uint64(15), challenge2.ChallengeTransferBlockHeight())
+	assert.Equal(t, challenge.Hash(), challenge2.Hash())
+
+	response := NewPlumResponseChallengeExit(
+		3,
+		subChain,
+		3,
+		[]byte("challenge"),
+		[]byte("response"),
+		[]byte("proof"),
+		16,
+		sender,
+		10,
+		big.NewInt(100),
+	)
+	var response2 PlumResponseChallengeExit
+	require.NoError(t, response2.LoadProto(response.Proto()))
+	assert.Equal(t, uint64(3), response2.CoinID())
+	assert.Equal(t, []byte("challenge"), response2.ChallengeTransfer())
+	assert.Equal(t, []byte("response"), response2.ResponseTransfer())
+	assert.Equal(t, []byte("proof"), response2.ResponseTransferBlockProof())
+	assert.Equal(t, uint64(16), response2.ResponseTransferBlockHeight())
+	assert.Equal(t, response.Hash(), response2.Hash())
+
+	finalize := NewPlumFinalizeExit(4, subChain, 3, sender, 10, big.NewInt(100))
+	var finalize2 PlumFinalizeExit
+	require.NoError(t, finalize2.LoadProto(finalize.Proto()))
+	assert.Equal(t, subChain, finalize2.SubChainAddress())
+	assert.Equal(t, uint64(3), finalize2.CoinID())
+	assert.Equal(t, finalize.Hash(), finalize2.Hash())
+}
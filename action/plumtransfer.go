@@ -0,0 +1,222 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+const (
+	// PlumSettleDepositIntrinsicGas represents the intrinsic gas for the plum settle deposit action
+	PlumSettleDepositIntrinsicGas = uint64(10000)
+	// PlumTransferIntrinsicGas represents the intrinsic gas for the plum transfer action
+	PlumTransferIntrinsicGas = uint64(10000)
+)
+
+// PlumSettleDeposit represents the action of the plum chain operator to bring a coin deposited on main-chain into the
+// plum chain
+type PlumSettleDeposit struct {
+	AbstractAction
+	coinID uint64
+}
+
+// NewPlumSettleDeposit instantiates a plum settle deposit action struct
+func NewPlumSettleDeposit(
+	nonce uint64,
+	coinID uint64,
+	sender string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumSettleDeposit {
+	return &PlumSettleDeposit{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  sender,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		coinID: coinID,
+	}
+}
+
+// CoinID returns the ID of the deposited coin
+func (sd *PlumSettleDeposit) CoinID() uint64 { return sd.coinID }
+
+// ByteStream returns a raw byte stream of the plum settle deposit action
+func (sd *PlumSettleDeposit) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(sd).String())
+	stream = append(stream, sd.BasicActionByteStream()...)
+	return append(stream, byteutil.Uint64ToBytes(sd.coinID)...)
+}
+
+// Proto converts PlumSettleDeposit to protobuf's ActionPb
+func (sd *PlumSettleDeposit) Proto() *iproto.ActionPb {
+	act := sd.abstractActionProto()
+	act.Action = &iproto.ActionPb_PlumSettleDeposit{
+		PlumSettleDeposit: &iproto.PlumSettleDepositPb{CoinID: sd.coinID},
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to PlumSettleDeposit
+func (sd *PlumSettleDeposit) LoadProto(pbAct *iproto.ActionPb) error {
+	if sd == nil {
+		return errors.New("nil action to load proto")
+	}
+	*sd = PlumSettleDeposit{}
+	pbSettle := pbAct.GetPlumSettleDeposit()
+	if pbSettle == nil {
+		return errors.New("empty PlumSettleDeposit action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, "")
+	if err != nil {
+		return err
+	}
+	sd.AbstractAction = act
+	sd.coinID = pbSettle.CoinID
+	return nil
+}
+
+// Hash returns the hash of the plum settle deposit action
+func (sd *PlumSettleDeposit) Hash() hash.Hash32B { return blake2b.Sum256(sd.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the plum settle deposit action
+func (sd *PlumSettleDeposit) IntrinsicGas() (uint64, error) {
+	return PlumSettleDepositIntrinsicGas, nil
+}
+
+// Cost returns the total cost of the plum settle deposit action
+func (sd *PlumSettleDeposit) Cost() (*big.Int, error) { return intrinsicGasFee(sd) }
+
+// PlumTransfer represents the action to transfer a coin from its owner to the recipient on a plum chain. The coin is
+// transferred as a whole, so the denomination is always the deposited amount
+type PlumTransfer struct {
+	AbstractAction
+	coinID       uint64
+	denomination *big.Int
+	owner        string
+}
+
+// NewPlumTransfer instantiates a plum transfer action struct
+func NewPlumTransfer(
+	nonce uint64,
+	coinID uint64,
+	denomination *big.Int,
+	owner string,
+	recipient string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *PlumTransfer {
+	return &PlumTransfer{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			srcAddr:  owner,
+			dstAddr:  recipient,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		coinID:       coinID,
+		denomination: denomination,
+		owner:        owner,
+	}
+}
+
+// DeserializePlumTransfer parses a serialized ActionPb into a plum transfer
+func DeserializePlumTransfer(data []byte) (*PlumTransfer, error) {
+	var pbAct iproto.ActionPb
+	if err := proto.Unmarshal(data, &pbAct); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal plum transfer")
+	}
+	var tsf PlumTransfer
+	if err := tsf.LoadProto(&pbAct); err != nil {
+		return nil, err
+	}
+	return &tsf, nil
+}
+
+// CoinID returns the ID of the transferred coin
+func (t *PlumTransfer) CoinID() uint64 { return t.coinID }
+
+// Denomination returns the denomination of the transferred coin
+func (t *PlumTransfer) Denomination() *big.Int { return t.denomination }
+
+// Owner returns the owner of the coin before the transfer
+func (t *PlumTransfer) Owner() string { return t.owner }
+
+// Recipient returns the owner of the coin after the transfer. It's the wrapper of Action.DstAddr
+func (t *PlumTransfer) Recipient() string { return t.DstAddr() }
+
+// Serialize returns the serialized ActionPb of the plum transfer, which is how it's carried by the exit actions
+func (t *PlumTransfer) Serialize() ([]byte, error) { return proto.Marshal(t.Proto()) }
+
+// ByteStream returns a raw byte stream of the plum transfer action
+func (t *PlumTransfer) ByteStream() []byte {
+	stream := []byte(reflect.TypeOf(t).String())
+	stream = append(stream, t.BasicActionByteStream()...)
+	stream = append(stream, byteutil.Uint64ToBytes(t.coinID)...)
+	if t.denomination != nil && len(t.denomination.Bytes()) > 0 {
+		stream = append(stream, t.denomination.Bytes()...)
+	}
+	return append(stream, t.owner...)
+}
+
+// Proto converts PlumTransfer to protobuf's ActionPb
+func (t *PlumTransfer) Proto() *iproto.ActionPb {
+	pbTransfer := &iproto.PlumTransferPb{
+		CoinID:    t.coinID,
+		Owner:     t.owner,
+		Recipient: t.dstAddr,
+	}
+	if t.denomination != nil && len(t.denomination.Bytes()) > 0 {
+		pbTransfer.Denomination = t.denomination.Bytes()
+	}
+	act := t.abstractActionProto()
+	act.Action = &iproto.ActionPb_PlumTransfer{PlumTransfer: pbTransfer}
+	return act
+}
+
+// LoadProto converts a protobuf's ActionPb to PlumTransfer
+func (t *PlumTransfer) LoadProto(pbAct *iproto.ActionPb) error {
+	if t == nil {
+		return errors.New("nil action to load proto")
+	}
+	*t = PlumTransfer{}
+	pbTransfer := pbAct.GetPlumTransfer()
+	if pbTransfer == nil {
+		return errors.New("empty PlumTransfer action proto to load")
+	}
+	act, err := loadAbstractActionProto(pbAct, pbTransfer.Recipient)
+	if err != nil {
+		return err
+	}
+	t.AbstractAction = act
+	t.coinID = pbTransfer.CoinID
+	t.denomination = big.NewInt(0).SetBytes(pbTransfer.Denomination)
+	t.owner = pbTransfer.Owner
+	return nil
+}
+
+// Hash returns the hash of the plum transfer action
+func (t *PlumTransfer) Hash() hash.Hash32B { return blake2b.Sum256(t.ByteStream()) }
+
+// IntrinsicGas returns the intrinsic gas of the plum transfer action
+func (t *PlumTransfer) IntrinsicGas() (uint64, error) { return PlumTransferIntrinsicGas, nil }
+
+// Cost returns the total cost of the plum transfer action
+func (t *PlumTransfer) Cost() (*big.Int, error) { return intrinsicGasFee(t) }
@@ -0,0 +1,53 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestPlumSettleDeposit(t *testing.T) {
+	t.Parallel()
+
+	sender := testaddress.Addrinfo["producer"].RawAddress
+	settle := NewPlumSettleDeposit(1, 3, sender, 10, big.NewInt(100))
+	var settle2 PlumSettleDeposit
+	require.NoError(t, settle2.LoadProto(settle.Proto()))
+	assert.Equal(t, uint64(3), settle2.CoinID())
+	assert.Equal(t, sender, settle2.SrcAddr())
+	assert.Equal(t, settle.Hash(), settle2.Hash())
+}
+
+func TestPlumTransfer(t *testing.T) {
+	t.Parallel()
+
+	owner := testaddress.Addrinfo["producer"]
+	recipient := testaddress.Addrinfo["alfa"].RawAddress
+
+	tsf := NewPlumTransfer(1, 3, big.NewInt(1000), owner.RawAddress, recipient, 10, big.NewInt(100))
+	require.NoError(t, Sign(tsf, owner.PrivateKey))
+	data, err := tsf.Serialize()
+	require.NoError(t, err)
+
+	tsf2, err := DeserializePlumTransfer(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), tsf2.CoinID())
+	assert.Equal(t, big.NewInt(1000), tsf2.Denomination())
+	assert.Equal(t, owner.RawAddress, tsf2.Owner())
+	assert.Equal(t, recipient, tsf2.Recipient())
+	assert.Equal(t, tsf.Hash(), tsf2.Hash())
+	assert.NoError(t, Verify(tsf2))
+
+	_, err = DeserializePlumTransfer([]byte("invalid"))
+	assert.Error(t, err)
+}
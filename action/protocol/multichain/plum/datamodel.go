@@ -0,0 +1,115 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package plum
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// CoinStatus is the status of a coin deposited into a plum chain
+type CoinStatus uint8
+
+const (
+	// CoinDeposited means that the coin is on the plum chain
+	CoinDeposited CoinStatus = iota
+	// CoinExiting means that the coin is in the challenge period of an exit
+	CoinExiting
+	// CoinExited means that the coin has been settled on main-chain
+	CoinExited
+)
+
+// PlumChain represents the state of a plum chain in the state factory
+type PlumChain struct {
+	OwnerAddress  string
+	CurrentHeight uint64
+	CoinCount     uint64
+	Terminated    bool
+}
+
+// Serialize serializes plum chain state into bytes
+func (pc *PlumChain) Serialize() ([]byte, error) { return state.GobBasedSerialize(pc) }
+
+// Deserialize deserializes bytes into plum chain state
+func (pc *PlumChain) Deserialize(data []byte) error { return state.GobBasedDeserialize(pc, data) }
+
+// MerkleRoot defines a merkle root of a plum chain block
+type MerkleRoot struct {
+	Name  string
+	Value hash.Hash32B
+}
+
+// Block represents the merkle roots of a plum chain block committed to the state factory
+type Block struct {
+	Height uint64
+	// Roots are sorted by name, so that the serialization is deterministic
+	Roots []MerkleRoot
+}
+
+// Serialize serializes plum chain block state into bytes
+func (b *Block) Serialize() ([]byte, error) { return state.GobBasedSerialize(b) }
+
+// Deserialize deserializes bytes into plum chain block state
+func (b *Block) Deserialize(data []byte) error { return state.GobBasedDeserialize(b, data) }
+
+// Root returns the merkle root of the name
+func (b *Block) Root(name string) (hash.Hash32B, bool) {
+	for _, root := range b.Roots {
+		if root.Name == name {
+			return root.Value, true
+		}
+	}
+	return hash.ZeroHash32B, false
+}
+
+// Coin represents the state of a coin deposited into a plum chain
+type Coin struct {
+	Amount *big.Int
+	// Owner is the plum chain address of the deposit recipient
+	Owner string
+	// DepositHeight is the height of the plum chain when the coin is deposited, so that all the transfers of the coin
+	// are in the blocks above it
+	DepositHeight uint64
+	Status        CoinStatus
+}
+
+// Serialize serializes coin state into bytes
+func (c *Coin) Serialize() ([]byte, error) { return state.GobBasedSerialize(c) }
+
+// Deserialize deserializes bytes into coin state
+func (c *Coin) Deserialize(data []byte) error { return state.GobBasedDeserialize(c, data) }
+
+// Challenge represents an open challenge of an exit with a transfer before the previous transfer of the exit. It has
+// to be responded with a transfer spending the challenge transfer, otherwise the exit cannot be finalized
+type Challenge struct {
+	TransferHash hash.Hash32B
+	Recipient    string
+	Height       uint64
+}
+
+// Exit represents the state of an exit of a coin
+type Exit struct {
+	// Exiter is the main-chain address to receive the coin
+	Exiter string
+	// Owner is the plum chain address owning the coin after the exit transfer
+	Owner      string
+	ExitHeight uint64
+	// PrevOwner is the plum chain address owning the coin after the previous transfer
+	PrevOwner  string
+	PrevHeight uint64
+	// StartHeight is the main-chain height when the exit starts
+	StartHeight uint64
+	Challenges  []Challenge
+}
+
+// Serialize serializes exit state into bytes
+func (e *Exit) Serialize() ([]byte, error) { return state.GobBasedSerialize(e) }
+
+// Deserialize deserializes bytes into exit state
+func (e *Exit) Deserialize(data []byte) error { return state.GobBasedDeserialize(e, data) }
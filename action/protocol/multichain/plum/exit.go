@@ -0,0 +1,324 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package plum
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+)
+
+func (p *Protocol) handleStartExit(start *action.PlumStartExit, sm protocol.StateManager) error {
+	coinID, coin, exit, err := p.validateStartExit(start, sm)
+	if err != nil {
+		return err
+	}
+	if _, err := increaseNonce(start, sm); err != nil {
+		return err
+	}
+	exit.StartHeight = sm.Height()
+	if err := putPlumChainItem(start.SubChainAddress(), "exit", coinID, exit, sm); err != nil {
+		return err
+	}
+	coin.Status = CoinExiting
+	return putPlumChainItem(start.SubChainAddress(), "coin", coinID, coin, sm)
+}
+
+func (p *Protocol) validateStartExit(start *action.PlumStartExit, sm protocol.StateManager) (uint64, *Coin, *Exit, error) {
+	if len(start.ExitTransfer()) == 0 {
+		return p.validateDepositExit(start, sm)
+	}
+	// The coin is identified by the exit transfer
+	exitTsf, err := action.DeserializePlumTransfer(start.ExitTransfer())
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	coinID := exitTsf.CoinID()
+	coin, err := p.coin(start.SubChainAddress(), coinID, sm)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if coin.Status != CoinDeposited {
+		return 0, nil, nil, fmt.Errorf("coin %d is not on plum chain %s", coinID, start.SubChainAddress())
+	}
+	if _, err := p.verifyTransfer(
+		start.SubChainAddress(),
+		coinID,
+		coin,
+		start.ExitTransfer(),
+		start.ExitTransferBlockProof(),
+		start.ExitTransferBlockHeight(),
+		sm,
+	); err != nil {
+		return 0, nil, nil, errors.Wrap(err, "invalid exit transfer")
+	}
+	if !sameOwner(exitTsf.Recipient(), start.SrcAddr()) {
+		return 0, nil, nil, fmt.Errorf("%s is not the recipient of the exit transfer", start.SrcAddr())
+	}
+	exit := &Exit{
+		Exiter:     start.SrcAddr(),
+		Owner:      exitTsf.Recipient(),
+		ExitHeight: start.ExitTransferBlockHeight(),
+		PrevOwner:  exitTsf.Owner(),
+	}
+	if len(start.PreviousTransfer()) == 0 {
+		// The exit transfer is the first transfer of the coin
+		if !sameOwner(exitTsf.Owner(), coin.Owner) {
+			return 0, nil, nil, fmt.Errorf("%s is not the depositor of coin %d", exitTsf.Owner(), coinID)
+		}
+		exit.PrevHeight = coin.DepositHeight
+		return coinID, coin, exit, nil
+	}
+	prevTsf, err := p.verifyTransfer(
+		start.SubChainAddress(),
+		coinID,
+		coin,
+		start.PreviousTransfer(),
+		start.PreviousTransferBlockProof(),
+		start.PreviousTransferBlockHeight(),
+		sm,
+	)
+	if err != nil {
+		return 0, nil, nil, errors.Wrap(err, "invalid previous transfer")
+	}
+	if !sameOwner(prevTsf.Recipient(), exitTsf.Owner()) {
+		return 0, nil, nil, errors.New("exit transfer doesn't spend the previous transfer")
+	}
+	if start.PreviousTransferBlockHeight() >= start.ExitTransferBlockHeight() {
+		return 0, nil, nil, errors.New("previous transfer is not before the exit transfer")
+	}
+	exit.PrevHeight = start.PreviousTransferBlockHeight()
+	return coinID, coin, exit, nil
+}
+
+// validateDepositExit validates the exit of a coin by its depositor, which needs no transfer. Any transfer of the coin
+// on plum chain is then spent by the depositor after the deposit, and cancels the exit
+func (p *Protocol) validateDepositExit(
+	start *action.PlumStartExit,
+	sm protocol.StateManager,
+) (uint64, *Coin, *Exit, error) {
+	if len(start.PreviousTransfer()) != 0 {
+		return 0, nil, nil, errors.New("exit transfer is missing")
+	}
+	coinID := start.CoinID()
+	coin, err := p.coin(start.SubChainAddress(), coinID, sm)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if coin.Status != CoinDeposited {
+		return 0, nil, nil, fmt.Errorf("coin %d is not on plum chain %s", coinID, start.SubChainAddress())
+	}
+	if !sameOwner(start.SrcAddr(), coin.Owner) {
+		return 0, nil, nil, fmt.Errorf("%s is not the depositor of coin %d", start.SrcAddr(), coinID)
+	}
+	return coinID, coin, &Exit{
+		Exiter:     start.SrcAddr(),
+		Owner:      coin.Owner,
+		ExitHeight: coin.DepositHeight,
+		PrevOwner:  coin.Owner,
+		PrevHeight: coin.DepositHeight,
+	}, nil
+}
+
+func (p *Protocol) handleChallengeExit(challenge *action.PlumChallengeExit, sm protocol.StateManager) error {
+	addr := challenge.SubChainAddress()
+	coin, exit, tsf, cancel, err := p.validateChallengeExit(challenge, sm)
+	if err != nil {
+		return err
+	}
+	if _, err := increaseNonce(challenge, sm); err != nil {
+		return err
+	}
+	if cancel {
+		return p.cancelExit(addr, challenge.CoinID(), coin, sm)
+	}
+	exit.Challenges = append(exit.Challenges, Challenge{
+		TransferHash: tsf.Hash(),
+		Recipient:    tsf.Recipient(),
+		Height:       challenge.ChallengeTransferBlockHeight(),
+	})
+	return putPlumChainItem(addr, "exit", challenge.CoinID(), exit, sm)
+}
+
+// validateChallengeExit returns whether the challenge cancels the exit, otherwise the challenge transfer has to be
+// recorded for the exiter to respond
+func (p *Protocol) validateChallengeExit(
+	challenge *action.PlumChallengeExit,
+	sm protocol.StateManager,
+) (*Coin, *Exit, *action.PlumTransfer, bool, error) {
+	addr := challenge.SubChainAddress()
+	coin, exit, err := p.exitingCoin(addr, challenge.CoinID(), sm)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	height := challenge.ChallengeTransferBlockHeight()
+	tsf, err := p.verifyTransfer(
+		addr,
+		challenge.CoinID(),
+		coin,
+		challenge.ChallengeTransfer(),
+		challenge.ChallengeTransferBlockProof(),
+		height,
+		sm,
+	)
+	if err != nil {
+		return nil, nil, nil, false, errors.Wrap(err, "invalid challenge transfer")
+	}
+	switch {
+	case height > exit.ExitHeight && sameOwner(tsf.Owner(), exit.Owner):
+		// The exiting coin has been spent after the exit transfer
+		return coin, exit, tsf, true, nil
+	case height > exit.PrevHeight && height < exit.ExitHeight && sameOwner(tsf.Owner(), exit.PrevOwner):
+		// The previous owner double spent the coin before the exit transfer
+		return coin, exit, tsf, true, nil
+	case height < exit.PrevHeight:
+		// The transfer history may be invalid, which has to be responded by the exiter
+		hash := tsf.Hash()
+		for _, c := range exit.Challenges {
+			if c.TransferHash == hash {
+				return nil, nil, nil, false, fmt.Errorf("transfer %x has already challenged the exit", hash)
+			}
+		}
+		return coin, exit, tsf, false, nil
+	}
+	return nil, nil, nil, false, errors.New("the transfer doesn't challenge the exit")
+}
+
+func (p *Protocol) handleResponseChallengeExit(
+	response *action.PlumResponseChallengeExit,
+	sm protocol.StateManager,
+) error {
+	exit, index, err := p.validateResponseChallengeExit(response, sm)
+	if err != nil {
+		return err
+	}
+	if _, err := increaseNonce(response, sm); err != nil {
+		return err
+	}
+	exit.Challenges = append(exit.Challenges[:index], exit.Challenges[index+1:]...)
+	return putPlumChainItem(response.SubChainAddress(), "exit", response.CoinID(), exit, sm)
+}
+
+// validateResponseChallengeExit returns the exit and the index of the challenge that the response resolves
+func (p *Protocol) validateResponseChallengeExit(
+	response *action.PlumResponseChallengeExit,
+	sm protocol.StateManager,
+) (*Exit, int, error) {
+	addr := response.SubChainAddress()
+	coin, exit, err := p.exitingCoin(addr, response.CoinID(), sm)
+	if err != nil {
+		return nil, 0, err
+	}
+	challengeTsf, err := action.DeserializePlumTransfer(response.ChallengeTransfer())
+	if err != nil {
+		return nil, 0, err
+	}
+	hash := challengeTsf.Hash()
+	index := -1
+	for i, c := range exit.Challenges {
+		if c.TransferHash == hash {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, 0, fmt.Errorf("transfer %x is not challenging the exit", hash)
+	}
+	challenge := exit.Challenges[index]
+	height := response.ResponseTransferBlockHeight()
+	tsf, err := p.verifyTransfer(
+		addr,
+		response.CoinID(),
+		coin,
+		response.ResponseTransfer(),
+		response.ResponseTransferBlockProof(),
+		height,
+		sm,
+	)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "invalid response transfer")
+	}
+	if !sameOwner(tsf.Owner(), challenge.Recipient) {
+		return nil, 0, errors.New("response transfer doesn't spend the challenge transfer")
+	}
+	if height <= challenge.Height || height > exit.PrevHeight {
+		return nil, 0, fmt.Errorf(
+			"response transfer at height %d is not between the challenge at %d and the exit at %d",
+			height,
+			challenge.Height,
+			exit.PrevHeight,
+		)
+	}
+	return exit, index, nil
+}
+
+func (p *Protocol) handleFinalizeExit(finalize *action.PlumFinalizeExit, sm protocol.StateManager) error {
+	coin, exit, err := p.validateFinalizeExit(finalize, sm.Height(), sm)
+	if err != nil {
+		return err
+	}
+	if _, err := increaseNonce(finalize, sm); err != nil {
+		return err
+	}
+	// Settle the coin to the exiter on main-chain
+	account, err := sm.LoadOrCreateAccountState(exit.Exiter, big.NewInt(0))
+	if err != nil {
+		return errors.Wrapf(err, "error when getting the account of address %s", exit.Exiter)
+	}
+	if err := account.AddBalance(coin.Amount); err != nil {
+		return err
+	}
+	if err := putAccount(exit.Exiter, account, sm); err != nil {
+		return err
+	}
+	coin.Status = CoinExited
+	return putPlumChainItem(finalize.SubChainAddress(), "coin", finalize.CoinID(), coin, sm)
+}
+
+func (p *Protocol) validateFinalizeExit(
+	finalize *action.PlumFinalizeExit,
+	height uint64,
+	sm protocol.StateManager,
+) (*Coin, *Exit, error) {
+	coin, exit, err := p.exitingCoin(finalize.SubChainAddress(), finalize.CoinID(), sm)
+	if err != nil {
+		return nil, nil, err
+	}
+	if height < exit.StartHeight+ExitChallengePeriod {
+		return nil, nil, fmt.Errorf("exit of coin %d is still in challenge period", finalize.CoinID())
+	}
+	if len(exit.Challenges) > 0 {
+		return nil, nil, fmt.Errorf("exit of coin %d has %d open challenges", finalize.CoinID(), len(exit.Challenges))
+	}
+	return coin, exit, nil
+}
+
+// exitingCoin returns the coin and its exit if the coin is in the challenge period
+func (p *Protocol) exitingCoin(addr string, coinID uint64, sm protocol.StateManager) (*Coin, *Exit, error) {
+	coin, err := p.coin(addr, coinID, sm)
+	if err != nil {
+		return nil, nil, err
+	}
+	if coin.Status != CoinExiting {
+		return nil, nil, fmt.Errorf("coin %d is not exiting", coinID)
+	}
+	exit, err := p.exit(addr, coinID, sm)
+	if err != nil {
+		return nil, nil, err
+	}
+	return coin, exit, nil
+}
+
+// cancelExit puts the coin back to plum chain
+func (p *Protocol) cancelExit(addr string, coinID uint64, coin *Coin, sm protocol.StateManager) error {
+	coin.Status = CoinDeposited
+	return putPlumChainItem(addr, "coin", coinID, coin, sm)
+}
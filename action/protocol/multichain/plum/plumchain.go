@@ -0,0 +1,258 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package plum
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// PlumChainAddress returns the address of the plum chain created by the owner with the nonce
+func (p *Protocol) PlumChainAddress(ownerAddr string, nonce uint64) (string, error) {
+	owner, err := addressPKHash(ownerAddr)
+	if err != nil {
+		return "", err
+	}
+	temp := make([]byte, 8)
+	enc.MachineEndian.PutUint64(temp, nonce)
+	addr := hash.Hash160b(append(append(owner[:], []byte(".plum.")...), temp...))
+	return address.New(p.rootChain.ChainID(), addr).IotxAddress(), nil
+}
+
+func (p *Protocol) handleCreatePlumChain(
+	create *action.CreatePlumChain,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	addr, err := p.validateCreatePlumChain(create, sm)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := increaseNonce(create, sm); err != nil {
+		return nil, err
+	}
+	key, err := plumChainKey(addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := sm.PutState(key, &PlumChain{OwnerAddress: create.OwnerAddress()}); err != nil {
+		return nil, err
+	}
+	gas, err := create.IntrinsicGas()
+	if err != nil {
+		return nil, err
+	}
+	return &action.Receipt{
+		Status:          0,
+		Hash:            create.Hash(),
+		GasConsumed:     gas,
+		ContractAddress: addr,
+	}, nil
+}
+
+// validateCreatePlumChain returns the address of the plum chain to create
+func (p *Protocol) validateCreatePlumChain(create *action.CreatePlumChain, sm protocol.StateManager) (string, error) {
+	addr, err := p.PlumChainAddress(create.OwnerAddress(), create.Nonce())
+	if err != nil {
+		return "", err
+	}
+	if _, err := p.plumChain(addr, sm); errors.Cause(err) != state.ErrStateNotExist {
+		return "", fmt.Errorf("plum chain %s already exists", addr)
+	}
+	return addr, nil
+}
+
+func (p *Protocol) handleTerminatePlumChain(terminate *action.TerminatePlumChain, sm protocol.StateManager) error {
+	plumChain, err := p.validateTerminatePlumChain(terminate, sm)
+	if err != nil {
+		return err
+	}
+	if _, err := increaseNonce(terminate, sm); err != nil {
+		return err
+	}
+	plumChain.Terminated = true
+	key, err := plumChainKey(terminate.SubChainAddress())
+	if err != nil {
+		return err
+	}
+	return sm.PutState(key, plumChain)
+}
+
+func (p *Protocol) validateTerminatePlumChain(
+	terminate *action.TerminatePlumChain,
+	sm protocol.StateManager,
+) (*PlumChain, error) {
+	return p.plumChainInOperation(terminate.SubChainAddress(), terminate.OwnerAddress(), sm)
+}
+
+func (p *Protocol) handlePutBlock(put *action.PlumPutBlock, sm protocol.StateManager) error {
+	plumChain, err := p.validatePutBlock(put, sm)
+	if err != nil {
+		return err
+	}
+	if _, err := increaseNonce(put, sm); err != nil {
+		return err
+	}
+	if err := putPlumChainItem(
+		put.SubChainAddress(),
+		"block",
+		put.Height(),
+		putBlockToBlock(put),
+		sm,
+	); err != nil {
+		return err
+	}
+	plumChain.CurrentHeight = put.Height()
+	key, err := plumChainKey(put.SubChainAddress())
+	if err != nil {
+		return err
+	}
+	return sm.PutState(key, plumChain)
+}
+
+func (p *Protocol) validatePutBlock(put *action.PlumPutBlock, sm protocol.StateManager) (*PlumChain, error) {
+	plumChain, err := p.plumChainInOperation(put.SubChainAddress(), put.ProducerAddress(), sm)
+	if err != nil {
+		return nil, err
+	}
+	// blocks are committed one by one
+	if put.Height() != plumChain.CurrentHeight+1 {
+		return nil, fmt.Errorf("expecting block %d instead of %d", plumChain.CurrentHeight+1, put.Height())
+	}
+	if _, ok := put.Roots()[TransferRootName]; !ok {
+		return nil, fmt.Errorf("%s root is missing", TransferRootName)
+	}
+	return plumChain, nil
+}
+
+func (p *Protocol) handleDeposit(deposit *action.PlumCreateDeposit, sm protocol.StateManager) (*action.Receipt, error) {
+	plumChain, err := p.validateDeposit(deposit, sm)
+	if err != nil {
+		return nil, err
+	}
+	// Subtract the balance from sender account
+	account, err := increaseNonce(deposit, sm)
+	if err != nil {
+		return nil, err
+	}
+	account.Balance = big.NewInt(0).Sub(account.Balance, deposit.Amount())
+	if err := putAccount(deposit.Sender(), account, sm); err != nil {
+		return nil, err
+	}
+
+	// Mint the coin on plum chain
+	coinID := plumChain.CoinCount
+	plumChain.CoinCount++
+	key, err := plumChainKey(deposit.SubChainAddress())
+	if err != nil {
+		return nil, err
+	}
+	if err := sm.PutState(key, plumChain); err != nil {
+		return nil, err
+	}
+	if err := putPlumChainItem(
+		deposit.SubChainAddress(),
+		"coin",
+		coinID,
+		&Coin{
+			Amount:        deposit.Amount(),
+			Owner:         deposit.Recipient(),
+			DepositHeight: plumChain.CurrentHeight,
+			Status:        CoinDeposited,
+		},
+		sm,
+	); err != nil {
+		return nil, err
+	}
+
+	gas, err := deposit.IntrinsicGas()
+	if err != nil {
+		return nil, err
+	}
+	return &action.Receipt{
+		ReturnValue:     byteutil.Uint64ToBytes(coinID),
+		Status:          0,
+		Hash:            deposit.Hash(),
+		GasConsumed:     gas,
+		ContractAddress: deposit.SubChainAddress(),
+	}, nil
+}
+
+func (p *Protocol) validateDeposit(deposit *action.PlumCreateDeposit, sm protocol.StateManager) (*PlumChain, error) {
+	if deposit.Amount().Sign() <= 0 {
+		return nil, errors.New("deposit amount must be positive")
+	}
+	if _, err := addressPKHash(deposit.Recipient()); err != nil {
+		return nil, err
+	}
+	plumChain, err := p.plumChain(deposit.SubChainAddress(), sm)
+	if err != nil {
+		return nil, err
+	}
+	if plumChain.Terminated {
+		return nil, fmt.Errorf("plum chain %s is terminated", deposit.SubChainAddress())
+	}
+	cost, err := deposit.Cost()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting deposit's cost")
+	}
+	var account *state.Account
+	if sm == nil {
+		account, err = p.sf.AccountState(deposit.Sender())
+	} else {
+		account, err = sm.CachedAccountState(deposit.Sender())
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when getting the account of address %s", deposit.Sender())
+	}
+	if account.Balance.Cmp(cost) < 0 {
+		return nil, fmt.Errorf("%s doesn't have at least required balance %d", deposit.Sender(), cost)
+	}
+	return plumChain, nil
+}
+
+// plumChainInOperation returns the plum chain if it's not terminated and owned by the owner
+func (p *Protocol) plumChainInOperation(addr string, owner string, sm protocol.StateManager) (*PlumChain, error) {
+	plumChain, err := p.plumChain(addr, sm)
+	if err != nil {
+		return nil, err
+	}
+	if plumChain.OwnerAddress != owner {
+		return nil, fmt.Errorf("%s is not the owner of plum chain %s", owner, addr)
+	}
+	if plumChain.Terminated {
+		return nil, fmt.Errorf("plum chain %s is terminated", addr)
+	}
+	return plumChain, nil
+}
+
+func putBlockToBlock(put *action.PlumPutBlock) *Block {
+	roots := put.Roots()
+	names := make([]string, 0, len(roots))
+	for name := range roots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	block := &Block{
+		Height: put.Height(),
+		Roots:  make([]MerkleRoot, 0, len(names)),
+	}
+	for _, name := range names {
+		block.Roots = append(block.Roots, MerkleRoot{Name: name, Value: roots[name]})
+	}
+	return block
+}
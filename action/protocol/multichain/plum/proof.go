@@ -0,0 +1,103 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package plum
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// EncodeBlockProof encodes the merkle proof of a transfer in a plum chain block, which is the index of the transfer
+// in the block and the number of the leaves of the merkle tree, followed by the sibling hashes from the leaf to the root
+func EncodeBlockProof(index uint64, size uint64, path []hash.Hash32B) []byte {
+	proof := make([]byte, 16, 16+len(path)*32)
+	enc.MachineEndian.PutUint64(proof, index)
+	enc.MachineEndian.PutUint64(proof[8:], size)
+	for _, h := range path {
+		proof = append(proof, h[:]...)
+	}
+	return proof
+}
+
+// DecodeBlockProof decodes the bytes produced by EncodeBlockProof
+func DecodeBlockProof(proof []byte) (uint64, uint64, []hash.Hash32B, error) {
+	if len(proof) < 16 || (len(proof)-16)%32 != 0 {
+		return 0, 0, nil, fmt.Errorf("invalid block proof length %d", len(proof))
+	}
+	index := enc.MachineEndian.Uint64(proof[:8])
+	size := enc.MachineEndian.Uint64(proof[8:16])
+	path := make([]hash.Hash32B, 0, (len(proof)-16)/32)
+	for i := 16; i < len(proof); i += 32 {
+		path = append(path, byteutil.BytesTo32B(proof[i:i+32]))
+	}
+	return index, size, path, nil
+}
+
+// verifyTransfer verifies that the serialized transfer is a signed transfer of the coin by its owner, and it's
+// included in the plum chain block of the height
+func (p *Protocol) verifyTransfer(
+	addr string,
+	coinID uint64,
+	coin *Coin,
+	transfer []byte,
+	proof []byte,
+	height uint64,
+	sm protocol.StateManager,
+) (*action.PlumTransfer, error) {
+	tsf, err := action.DeserializePlumTransfer(transfer)
+	if err != nil {
+		return nil, err
+	}
+	if tsf.CoinID() != coinID {
+		return nil, fmt.Errorf("transfer of coin %d instead of %d", tsf.CoinID(), coinID)
+	}
+	if tsf.Denomination().Cmp(coin.Amount) != 0 {
+		return nil, fmt.Errorf("transfer denomination %d doesn't match coin amount %d", tsf.Denomination(), coin.Amount)
+	}
+	if err := action.Verify(tsf); err != nil {
+		return nil, errors.Wrap(err, "failed to verify transfer signature")
+	}
+	owner, err := addressPKHash(tsf.Owner())
+	if err != nil {
+		return nil, err
+	}
+	if pkHash := keypair.HashPubKey(tsf.SrcPubkey()); !bytes.Equal(pkHash[:], owner[:]) {
+		return nil, fmt.Errorf("transfer is not signed by the owner %s", tsf.Owner())
+	}
+	if !sameOwner(tsf.SrcAddr(), tsf.Owner()) {
+		return nil, fmt.Errorf("transfer sender %s is not the owner %s", tsf.SrcAddr(), tsf.Owner())
+	}
+	if height <= coin.DepositHeight {
+		return nil, fmt.Errorf("transfer at height %d is before the deposit at %d", height, coin.DepositHeight)
+	}
+	block, err := p.block(addr, height, sm)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := block.Root(TransferRootName)
+	if !ok {
+		return nil, fmt.Errorf("%s root is missing in block %d", TransferRootName, height)
+	}
+	index, size, path, err := DecodeBlockProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	if !crypto.VerifyMerkleProof(root, tsf.Hash(), index, size, path) {
+		return nil, fmt.Errorf("transfer %x is not included in block %d", tsf.Hash(), height)
+	}
+	return tsf, nil
+}
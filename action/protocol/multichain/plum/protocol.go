@@ -0,0 +1,275 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package plum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+var (
+	// ExitChallengePeriod is the number of main-chain blocks an exit has to wait for challenges before it's finalized
+	ExitChallengePeriod = uint64(720)
+	// TransferRootName is the name of the merkle root in plum put block, against which the transfers are proved
+	TransferRootName = "tx"
+)
+
+// Protocol defines the protocol of handling plum chain actions on main-chain. A plum chain is a plasma chain, whose
+// owner commits the merkle roots of the blocks to main-chain. Tokens are deposited into a plum chain as coins, which
+// can only be transferred as a whole, and can exit back to main-chain by proving the latest transfers of the coin.
+// An exit can be challenged during the challenge period with a transfer, which either cancels the exit or has to be
+// responded with a later transfer
+type Protocol struct {
+	rootChain blockchain.Blockchain
+	sf        factory.Factory
+}
+
+// NewProtocol instantiates the protocol of plum chain
+func NewProtocol(rootChain blockchain.Blockchain) *Protocol {
+	return &Protocol{
+		rootChain: rootChain,
+		sf:        rootChain.GetFactory(),
+	}
+}
+
+// Handle handles how to mutate the state db given the plum chain action on main-chain
+func (p *Protocol) Handle(_ context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
+	switch act := act.(type) {
+	case *action.CreatePlumChain:
+		receipt, err := p.handleCreatePlumChain(act, sm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when handling create plum chain action")
+		}
+		return receipt, nil
+	case *action.TerminatePlumChain:
+		if err := p.handleTerminatePlumChain(act, sm); err != nil {
+			return nil, errors.Wrapf(err, "error when handling terminate plum chain action")
+		}
+	case *action.PlumPutBlock:
+		if err := p.handlePutBlock(act, sm); err != nil {
+			return nil, errors.Wrapf(err, "error when handling plum put block action")
+		}
+	case *action.PlumCreateDeposit:
+		receipt, err := p.handleDeposit(act, sm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when handling plum deposit creation action")
+		}
+		return receipt, nil
+	case *action.PlumStartExit:
+		if err := p.handleStartExit(act, sm); err != nil {
+			return nil, errors.Wrapf(err, "error when handling plum start exit action")
+		}
+	case *action.PlumChallengeExit:
+		if err := p.handleChallengeExit(act, sm); err != nil {
+			return nil, errors.Wrapf(err, "error when handling plum challenge exit action")
+		}
+	case *action.PlumResponseChallengeExit:
+		if err := p.handleResponseChallengeExit(act, sm); err != nil {
+			return nil, errors.Wrapf(err, "error when handling plum response challenge exit action")
+		}
+	case *action.PlumFinalizeExit:
+		if err := p.handleFinalizeExit(act, sm); err != nil {
+			return nil, errors.Wrapf(err, "error when handling plum finalize exit action")
+		}
+	}
+	// The action is not handled by this handler or no error
+	return nil, nil
+}
+
+// Validate validates the plum chain action on main-chain
+func (p *Protocol) Validate(_ context.Context, act action.Action) error {
+	switch act := act.(type) {
+	case *action.CreatePlumChain:
+		if _, err := p.validateCreatePlumChain(act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating create plum chain action")
+		}
+	case *action.TerminatePlumChain:
+		if _, err := p.validateTerminatePlumChain(act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating terminate plum chain action")
+		}
+	case *action.PlumPutBlock:
+		if _, err := p.validatePutBlock(act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating plum put block action")
+		}
+	case *action.PlumCreateDeposit:
+		if _, err := p.validateDeposit(act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating plum deposit creation action")
+		}
+	case *action.PlumStartExit:
+		if _, _, _, err := p.validateStartExit(act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating plum start exit action")
+		}
+	case *action.PlumChallengeExit:
+		if _, _, _, _, err := p.validateChallengeExit(act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating plum challenge exit action")
+		}
+	case *action.PlumResponseChallengeExit:
+		if _, _, err := p.validateResponseChallengeExit(act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating plum response challenge exit action")
+		}
+	case *action.PlumFinalizeExit:
+		if _, _, err := p.validateFinalizeExit(act, p.rootChain.TipHeight()+1, nil); err != nil {
+			return errors.Wrapf(err, "error when validating plum finalize exit action")
+		}
+	}
+	// The action is not validated by this handler or no error
+	return nil
+}
+
+// PlumChain returns the confirmed plum chain state
+func (p *Protocol) PlumChain(addr string) (*PlumChain, error) {
+	return p.plumChain(addr, nil)
+}
+
+// Coin returns the confirmed state of a coin on the plum chain
+func (p *Protocol) Coin(addr string, coinID uint64) (*Coin, error) {
+	return p.coin(addr, coinID, nil)
+}
+
+// Exit returns the confirmed state of the exit of a coin on the plum chain
+func (p *Protocol) Exit(addr string, coinID uint64) (*Exit, error) {
+	return p.exit(addr, coinID, nil)
+}
+
+func (p *Protocol) plumChain(addr string, sm protocol.StateManager) (*PlumChain, error) {
+	key, err := plumChainKey(addr)
+	if err != nil {
+		return nil, err
+	}
+	var plumChain PlumChain
+	if err := p.state(key, &plumChain, sm); err != nil {
+		return nil, errors.Wrapf(err, "error when loading state of plum chain %s", addr)
+	}
+	return &plumChain, nil
+}
+
+func (p *Protocol) coin(addr string, coinID uint64, sm protocol.StateManager) (*Coin, error) {
+	key, err := plumChainItemKey(addr, "coin", coinID)
+	if err != nil {
+		return nil, err
+	}
+	var coin Coin
+	if err := p.state(key, &coin, sm); err != nil {
+		return nil, errors.Wrapf(err, "error when loading state of coin %d on plum chain %s", coinID, addr)
+	}
+	return &coin, nil
+}
+
+func (p *Protocol) exit(addr string, coinID uint64, sm protocol.StateManager) (*Exit, error) {
+	key, err := plumChainItemKey(addr, "exit", coinID)
+	if err != nil {
+		return nil, err
+	}
+	var exit Exit
+	if err := p.state(key, &exit, sm); err != nil {
+		return nil, errors.Wrapf(err, "error when loading state of exit %d on plum chain %s", coinID, addr)
+	}
+	return &exit, nil
+}
+
+func (p *Protocol) block(addr string, height uint64, sm protocol.StateManager) (*Block, error) {
+	key, err := plumChainItemKey(addr, "block", height)
+	if err != nil {
+		return nil, err
+	}
+	var block Block
+	if err := p.state(key, &block, sm); err != nil {
+		return nil, errors.Wrapf(err, "error when loading state of block %d on plum chain %s", height, addr)
+	}
+	return &block, nil
+}
+
+func (p *Protocol) state(key hash.PKHash, s interface{}, sm protocol.StateManager) error {
+	if sm == nil {
+		return p.sf.State(key, s)
+	}
+	return sm.State(key, s)
+}
+
+func putPlumChainItem(addr string, item string, index uint64, s interface{}, sm protocol.StateManager) error {
+	key, err := plumChainItemKey(addr, item, index)
+	if err != nil {
+		return err
+	}
+	return sm.PutState(key, s)
+}
+
+// increaseNonce updates the nonce of the action sender
+func increaseNonce(act action.Action, sm protocol.StateManager) (*state.Account, error) {
+	account, err := sm.LoadOrCreateAccountState(act.SrcAddr(), big.NewInt(0))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when getting the account of address %s", act.SrcAddr())
+	}
+	// TODO: this is not right, but currently the actions in a block is not processed according to the nonce
+	if act.Nonce() > account.Nonce {
+		account.Nonce = act.Nonce()
+	}
+	if err := putAccount(act.SrcAddr(), account, sm); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func putAccount(addr string, account *state.Account, sm protocol.StateManager) error {
+	pkHash, err := addressPKHash(addr)
+	if err != nil {
+		return err
+	}
+	return sm.PutState(pkHash, account)
+}
+
+func plumChainKey(addr string) (hash.PKHash, error) {
+	return addressPKHash(addr)
+}
+
+// plumChainItemKey returns the key of the indexed item (block, coin or exit) of a plum chain
+func plumChainItemKey(addr string, item string, index uint64) (hash.PKHash, error) {
+	pkHash, err := addressPKHash(addr)
+	if err != nil {
+		return hash.ZeroPKHash, err
+	}
+	stream := append(pkHash[:], fmt.Sprintf(".%s.", item)...)
+	temp := make([]byte, 8)
+	enc.MachineEndian.PutUint64(temp, index)
+	stream = append(stream, temp...)
+	return byteutil.BytesTo20B(hash.Hash160b(stream)), nil
+}
+
+func addressPKHash(addr string) (hash.PKHash, error) {
+	iotxAddr, err := address.IotxAddressToAddress(addr)
+	if err != nil {
+		return hash.ZeroPKHash, errors.Wrapf(err, "cannot get the public key hash of address %s", addr)
+	}
+	return byteutil.BytesTo20B(iotxAddr.Payload()), nil
+}
+
+// sameOwner returns true if the two addresses, which may be on different chains, belong to the same key
+func sameOwner(addr1 string, addr2 string) bool {
+	pkHash1, err := addressPKHash(addr1)
+	if err != nil {
+		return false
+	}
+	pkHash2, err := addressPKHash(addr2)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(pkHash1[:], pkHash2[:])
+}
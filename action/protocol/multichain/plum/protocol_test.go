@@ -0,0 +1,507 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package plum
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+type plumBlock struct {
+	root   hash.Hash32B
+	proofs [][]byte
+}
+
+func newPlumBlock(t *testing.T, tsfs ...*action.PlumTransfer) *plumBlock {
+	// pad the block with unrelated hashes, so that the proofs are not empty and a single transfer is the last one of
+	// an odd number of leaves
+	leaves := []hash.Hash32B{hash.ZeroHash32B, {1}}
+	for _, tsf := range tsfs {
+		leaves = append(leaves, tsf.Hash())
+	}
+	mk := crypto.NewMerkleTree(leaves)
+	blk := &plumBlock{root: mk.HashTree()}
+	for i := range tsfs {
+		path, err := mk.Proof(uint64(i + 2))
+		require.NoError(t, err)
+		blk.proofs = append(blk.proofs, EncodeBlockProof(uint64(i+2), uint64(len(leaves)), path))
+	}
+	return blk
+}
+
+func newSignedPlumTransfer(
+	t *testing.T,
+	coinID uint64,
+	amount int64,
+	owner *iotxaddress.Address,
+	recipient *iotxaddress.Address,
+) (*action.PlumTransfer, []byte) {
+	tsf := action.NewPlumTransfer(0, coinID, big.NewInt(amount), owner.RawAddress, recipient.RawAddress, 0, big.NewInt(0))
+	require.NoError(t, action.Sign(tsf, owner.PrivateKey))
+	data, err := tsf.Serialize()
+	require.NoError(t, err)
+	return tsf, data
+}
+
+func TestPlumChainLifecycle(t *testing.T) {
+	cfg := config.Default
+	ctx := context.Background()
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.NoError(t, err)
+	require.NoError(t, sf.Start(ctx))
+	ctrl := gomock.NewController(t)
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	chain.EXPECT().GetFactory().Return(sf).AnyTimes()
+
+	defer func() {
+		require.NoError(t, sf.Stop(ctx))
+		ctrl.Finish()
+	}()
+
+	p := NewProtocol(chain)
+	sf.AddActionHandlers(p)
+
+	operator := testaddress.Addrinfo["producer"]
+	alfa := testaddress.Addrinfo["alfa"]
+	bravo := testaddress.Addrinfo["bravo"]
+	charlie := testaddress.Addrinfo["charlie"]
+
+	gasLimit := testutil.TestGasLimit
+	ctx = state.WithRunActionsCtx(ctx,
+		state.RunActionsCtx{
+			ProducerAddr:    operator.RawAddress,
+			GasLimit:        &gasLimit,
+			EnableGasCharge: testutil.EnableGasCharge,
+		})
+	runActions := func(height uint64, acts ...action.Action) map[hash.Hash32B]*action.Receipt {
+		ws, err := sf.NewWorkingSet()
+		require.NoError(t, err)
+		if height == 1 {
+			_, err = ws.LoadOrCreateAccountState(operator.RawAddress, big.NewInt(100000))
+			require.NoError(t, err)
+		}
+		_, receipts, err := ws.RunActions(ctx, height, acts)
+		require.NoError(t, err)
+		require.NoError(t, sf.Commit(ws))
+		return receipts
+	}
+	runActions(1)
+
+	// Create plum chain
+	create := action.NewCreatePlumChain(1, operator.RawAddress, testutil.TestGasLimit, big.NewInt(0))
+	receipts := runActions(2, create)
+	addr, err := p.PlumChainAddress(operator.RawAddress, 1)
+	require.NoError(t, err)
+	require.NotNil(t, receipts[create.Hash()])
+	assert.Equal(t, addr, receipts[create.Hash()].ContractAddress)
+	plumChain, err := p.PlumChain(addr)
+	require.NoError(t, err)
+	assert.Equal(t, operator.RawAddress, plumChain.OwnerAddress)
+	err = p.Validate(ctx, create)
+	assert.True(t, strings.Contains(err.Error(), "already exists"))
+
+	// Deposit coin 0 to alfa
+	deposit := action.NewPlumCreateDeposit(
+		2,
+		addr,
+		big.NewInt(1000),
+		operator.RawAddress,
+		alfa.RawAddress,
+		testutil.TestGasLimit,
+		big.NewInt(0),
+	)
+	receipts = runActions(3, deposit)
+	require.NotNil(t, receipts[deposit.Hash()])
+	coin, err := p.Coin(addr, 0)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), coin.Amount)
+	assert.Equal(t, alfa.RawAddress, coin.Owner)
+	assert.Equal(t, CoinDeposited, coin.Status)
+	account, err := sf.AccountState(operator.RawAddress)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(99000), account.Balance)
+	assert.Equal(t, uint64(2), account.Nonce)
+
+	// Coin 0 is transferred from alfa to bravo and then to charlie
+	tsf1, tsf1Bytes := newSignedPlumTransfer(t, 0, 1000, alfa, bravo)
+	tsf2, tsf2Bytes := newSignedPlumTransfer(t, 0, 1000, bravo, charlie)
+	blk1 := newPlumBlock(t, tsf1)
+	blk2 := newPlumBlock(t, tsf2)
+	put1 := action.NewPlumPutBlock(
+		3,
+		addr,
+		operator.RawAddress,
+		1,
+		map[string]hash.Hash32B{TransferRootName: blk1.root},
+		testutil.TestGasLimit,
+		big.NewInt(0),
+	)
+	put2 := action.NewPlumPutBlock(
+		4,
+		addr,
+		operator.RawAddress,
+		2,
+		map[string]hash.Hash32B{TransferRootName: blk2.root},
+		testutil.TestGasLimit,
+		big.NewInt(0),
+	)
+	runActions(4, put1, put2)
+	plumChain, err = p.PlumChain(addr)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), plumChain.CurrentHeight)
+	_, err = p.validatePutBlock(put2, nil)
+	assert.True(t, strings.Contains(err.Error(), "expecting block 3"))
+
+	// Charlie exits coin 0
+	start := action.NewPlumStartExit(
+		1,
+		addr,
+		tsf1Bytes,
+		blk1.proofs[0],
+		1,
+		tsf2Bytes,
+		blk2.proofs[0],
+		2,
+		charlie.RawAddress,
+		testutil.TestGasLimit,
+		big.NewInt(0),
+	)
+	_, _, _, err = p.validateStartExit(
+		action.NewPlumStartExit(
+			1,
+			addr,
+			tsf1Bytes,
+			blk1.proofs[0],
+			1,
+			tsf2Bytes,
+			EncodeBlockProof(0, 1, nil),
+			2,
+			charlie.RawAddress,
+			testutil.TestGasLimit,
+			big.NewInt(0),
+		),
+		nil,
+	)
+	assert.True(t, strings.Contains(err.Error(), "is not included in block 2"))
+	// The transfer is the last leaf of block 2, whose padded copy isn't a leaf to prove
+	index, size, path, err := DecodeBlockProof(blk2.proofs[0])
+	require.NoError(t, err)
+	require.Equal(t, size-1, index)
+	_, _, _, err = p.validateStartExit(
+		action.NewPlumStartExit(
+			1,
+			addr,
+			tsf1Bytes,
+			blk1.proofs[0],
+			1,
+			tsf2Bytes,
+			EncodeBlockProof(size, size, path),
+			2,
+			charlie.RawAddress,
+			testutil.TestGasLimit,
+			big.NewInt(0),
+		),
+		nil,
+	)
+	assert.True(t, strings.Contains(err.Error(), "is not included in block 2"))
+	runActions(5, start)
+	coin, err = p.Coin(addr, 0)
+	require.NoError(t, err)
+	assert.Equal(t, CoinExiting, coin.Status)
+	exit, err := p.Exit(addr, 0)
+	require.NoError(t, err)
+	assert.Equal(t, charlie.RawAddress, exit.Exiter)
+	assert.Equal(t, charlie.RawAddress, exit.Owner)
+	assert.Equal(t, bravo.RawAddress, exit.PrevOwner)
+	assert.Equal(t, uint64(2), exit.ExitHeight)
+	assert.Equal(t, uint64(1), exit.PrevHeight)
+	assert.Equal(t, uint64(5), exit.StartHeight)
+
+	finalize := action.NewPlumFinalizeExit(5, addr, 0, operator.RawAddress, testutil.TestGasLimit, big.NewInt(0))
+	_, _, err = p.validateFinalizeExit(finalize, 6, nil)
+	assert.True(t, strings.Contains(err.Error(), "still in challenge period"))
+
+	// Challenge with the transfer of the exit itself is invalid
+	challenge := action.NewPlumChallengeExit(
+		1,
+		addr,
+		0,
+		tsf2Bytes,
+		blk2.proofs[0],
+		2,
+		alfa.RawAddress,
+		testutil.TestGasLimit,
+		big.NewInt(0),
+	)
+	err = p.Validate(ctx, challenge)
+	assert.True(t, strings.Contains(err.Error(), "doesn't challenge the exit"))
+	ws, err := sf.NewWorkingSet()
+	require.NoError(t, err)
+	_, _, err = ws.RunActions(ctx, 6, []action.Action{challenge})
+	assert.True(t, strings.Contains(err.Error(), "doesn't challenge the exit"))
+	response := action.NewPlumResponseChallengeExit(
+		1,
+		addr,
+		0,
+		tsf1Bytes,
+		tsf2Bytes,
+		blk2.proofs[0],
+		2,
+		charlie.RawAddress,
+		testutil.TestGasLimit,
+		big.NewInt(0),
+	)
+	err = p.Validate(ctx, response)
+	assert.True(t, strings.Contains(err.Error(), "is not challenging the exit"))
+
+	_, _, err = p.validateFinalizeExit(finalize, 5+ExitChallengePeriod, nil)
+	require.NoError(t, err)
+	runActions(5+ExitChallengePeriod, finalize)
+	coin, err = p.Coin(addr, 0)
+	require.NoError(t, err)
+	assert.Equal(t, CoinExited, coin.Status)
+	account, err = sf.AccountState(charlie.RawAddress)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), account.Balance)
+}
+
+func TestPlumChallengeExit(t *testing.T) {
+	cfg := config.Default
+	ctx := context.Background()
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.NoError(t, err)
+	require.NoError(t, sf.Start(ctx))
+	ctrl := gomock.NewController(t)
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	chain.EXPECT().GetFactory().Return(sf).AnyTimes()
+
+	defer func() {
+		require.NoError(t, sf.Stop(ctx))
+		ctrl.Finish()
+	}()
+
+	p := NewProtocol(chain)
+	alfa := testaddress.Addrinfo["alfa"]
+	bravo := testaddress.Addrinfo["bravo"]
+	charlie := testaddress.Addrinfo["charlie"]
+	delta := testaddress.Addrinfo["delta"]
+	addr := testaddress.Addrinfo["echo"].RawAddress
+
+	// Coin 0 is transferred from alfa to bravo in block 1, bravo to charlie in block 2, and charlie to delta in block 4
+	tsf1, tsf1Bytes := newSignedPlumTransfer(t, 0, 1000, alfa, bravo)
+	tsf2, tsf2Bytes := newSignedPlumTransfer(t, 0, 1000, bravo, charlie)
+	tsf3, tsf3Bytes := newSignedPlumTransfer(t, 0, 1000, charlie, delta)
+	blk1 := newPlumBlock(t, tsf1)
+	blk2 := newPlumBlock(t, tsf2)
+	blk3 := newPlumBlock(t, tsf3)
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(t, err)
+	key, err := plumChainKey(addr)
+	require.NoError(t, err)
+	require.NoError(t, ws.PutState(key, &PlumChain{OwnerAddress: alfa.RawAddress, CurrentHeight: 4, CoinCount: 1}))
+	require.NoError(t, putPlumChainItem(
+		addr,
+		"coin",
+		0,
+		&Coin{Amount: big.NewInt(1000), Owner: alfa.RawAddress, Status: CoinExiting},
+		ws,
+	))
+	for height, blk := range map[uint64]*plumBlock{1: blk1, 2: blk2, 4: blk3} {
+		require.NoError(t, putPlumChainItem(
+			addr,
+			"block",
+			height,
+			&Block{Height: height, Roots: []MerkleRoot{{Name: TransferRootName, Value: blk.root}}},
+			ws,
+		))
+	}
+	require.NoError(t, putPlumChainItem(
+		addr,
+		"exit",
+		0,
+		&Exit{
+			Exiter:     delta.RawAddress,
+			Owner:      delta.RawAddress,
+			ExitHeight: 4,
+			PrevOwner:  charlie.RawAddress,
+			PrevHeight: 2,
+		},
+		ws,
+	))
+
+	// A transfer before the previous transfer has to be responded
+	challenge := action.NewPlumChallengeExit(1, addr, 0, tsf1Bytes, blk1.proofs[0], 1, alfa.RawAddress, 0, big.NewInt(0))
+	require.NoError(t, p.handleChallengeExit(challenge, ws))
+	exit, err := p.exit(addr, 0, ws)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(exit.Challenges))
+	assert.Equal(t, tsf1.Hash(), exit.Challenges[0].TransferHash)
+	assert.Equal(t, bravo.RawAddress, exit.Challenges[0].Recipient)
+	finalize := action.NewPlumFinalizeExit(1, addr, 0, delta.RawAddress, 0, big.NewInt(0))
+	_, _, err = p.validateFinalizeExit(finalize, ExitChallengePeriod, ws)
+	assert.True(t, strings.Contains(err.Error(), "open challenges"))
+	assert.Error(t, p.handleChallengeExit(challenge, ws))
+
+	// A response not spending the challenge transfer is rejected
+	response := action.NewPlumResponseChallengeExit(
+		1,
+		addr,
+		0,
+		tsf1Bytes,
+		tsf3Bytes,
+		blk3.proofs[0],
+		4,
+		delta.RawAddress,
+		0,
+		big.NewInt(0),
+	)
+	assert.Error(t, p.handleResponseChallengeExit(response, ws))
+	response = action.NewPlumResponseChallengeExit(
+		1,
+		addr,
+		0,
+		tsf1Bytes,
+		tsf2Bytes,
+		blk2.proofs[0],
+		2,
+		delta.RawAddress,
+		0,
+		big.NewInt(0),
+	)
+	require.NoError(t, p.handleResponseChallengeExit(response, ws))
+	exit, err = p.exit(addr, 0, ws)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(exit.Challenges))
+	_, _, err = p.validateFinalizeExit(finalize, ExitChallengePeriod, ws)
+	assert.NoError(t, err)
+
+	// A transfer of the exiting owner after the exit transfer cancels the exit
+	require.NoError(t, putPlumChainItem(
+		addr,
+		"exit",
+		0,
+		&Exit{
+			Exiter:     charlie.RawAddress,
+			Owner:      charlie.RawAddress,
+			ExitHeight: 2,
+			PrevOwner:  bravo.RawAddress,
+			PrevHeight: 1,
+		},
+		ws,
+	))
+	challenge = action.NewPlumChallengeExit(2, addr, 0, tsf3Bytes, blk3.proofs[0], 4, alfa.RawAddress, 0, big.NewInt(0))
+	require.NoError(t, p.handleChallengeExit(challenge, ws))
+	coin, err := p.coin(addr, 0, ws)
+	require.NoError(t, err)
+	assert.Equal(t, CoinDeposited, coin.Status)
+}
+
+func TestPlumDepositExit(t *testing.T) {
+	cfg := config.Default
+	ctx := context.Background()
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.NoError(t, err)
+	require.NoError(t, sf.Start(ctx))
+	ctrl := gomock.NewController(t)
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	chain.EXPECT().GetFactory().Return(sf).AnyTimes()
+
+	defer func() {
+		require.NoError(t, sf.Stop(ctx))
+		ctrl.Finish()
+	}()
+
+	p := NewProtocol(chain)
+	alfa := testaddress.Addrinfo["alfa"]
+	bravo := testaddress.Addrinfo["bravo"]
+	addr := testaddress.Addrinfo["echo"].RawAddress
+
+	// Coin 0 is deposited to alfa on height 1, and transferred from alfa to bravo in block 2 only later
+	tsf, tsfBytes := newSignedPlumTransfer(t, 0, 1000, alfa, bravo)
+	blk := newPlumBlock(t, tsf)
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(t, err)
+	key, err := plumChainKey(addr)
+	require.NoError(t, err)
+	require.NoError(t, ws.PutState(key, &PlumChain{OwnerAddress: bravo.RawAddress, CurrentHeight: 2, CoinCount: 1}))
+	require.NoError(t, putPlumChainItem(
+		addr,
+		"coin",
+		0,
+		&Coin{Amount: big.NewInt(1000), Owner: alfa.RawAddress, DepositHeight: 1, Status: CoinDeposited},
+		ws,
+	))
+	require.NoError(t, putPlumChainItem(
+		addr,
+		"block",
+		2,
+		&Block{Height: 2, Roots: []MerkleRoot{{Name: TransferRootName, Value: blk.root}}},
+		ws,
+	))
+
+	// Only the depositor exits the coin without a transfer
+	_, _, _, err = p.validateStartExit(action.NewPlumDepositExit(1, addr, 0, bravo.RawAddress, 0, big.NewInt(0)), ws)
+	assert.True(t, strings.Contains(err.Error(), "is not the depositor"))
+	_, _, _, err = p.validateStartExit(action.NewPlumDepositExit(1, addr, 1, alfa.RawAddress, 0, big.NewInt(0)), ws)
+	assert.Error(t, err)
+	start := action.NewPlumDepositExit(1, addr, 0, alfa.RawAddress, 0, big.NewInt(0))
+	require.NoError(t, p.handleStartExit(start, ws))
+	coin, err := p.coin(addr, 0, ws)
+	require.NoError(t, err)
+	assert.Equal(t, CoinExiting, coin.Status)
+	exit, err := p.exit(addr, 0, ws)
+	require.NoError(t, err)
+	assert.Equal(t, alfa.RawAddress, exit.Exiter)
+	assert.Equal(t, alfa.RawAddress, exit.Owner)
+	assert.Equal(t, uint64(1), exit.ExitHeight)
+	assert.Equal(t, uint64(1), exit.PrevHeight)
+	_, _, _, err = p.validateStartExit(start, ws)
+	assert.True(t, strings.Contains(err.Error(), "is not on plum chain"))
+
+	// The transfer of the depositor after the deposit cancels the exit
+	challenge := action.NewPlumChallengeExit(1, addr, 0, tsfBytes, blk.proofs[0], 2, bravo.RawAddress, 0, big.NewInt(0))
+	require.NoError(t, p.handleChallengeExit(challenge, ws))
+	coin, err = p.coin(addr, 0, ws)
+	require.NoError(t, err)
+	assert.Equal(t, CoinDeposited, coin.Status)
+}
+
+func TestBlockProof(t *testing.T) {
+	t.Parallel()
+
+	path := []hash.Hash32B{hash.ZeroHash32B, {1, 2, 3}}
+	index, size, path2, err := DecodeBlockProof(EncodeBlockProof(5, 6, path))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), index)
+	assert.Equal(t, uint64(6), size)
+	assert.Equal(t, path, path2)
+
+	_, _, _, err = DecodeBlockProof([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package vote
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// EquivocationsPrefix is the prefix of the key of the equivocations of a delegate
+const EquivocationsPrefix = "Equivocations."
+
+// equivocations are the heights on which a delegate has been proven equivocating. Each equivocation halves the votes
+// of the delegate as a candidate, which still applies when the votes are updated later
+type equivocations struct {
+	Heights []uint64
+}
+
+// handleEvidence penalizes the offender proven by an evidence. An offence already penalized is skipped, so that the
+// delegate isn't penalized again with another pair of its conflicting messages
+func (p *Protocol) handleEvidence(e *action.Evidence, sm protocol.StateManager) (*action.Receipt, error) {
+	offence, err := p.verifyEvidence(e)
+	if err != nil {
+		return nil, err
+	}
+	reporter, err := account.LoadOrCreateAccountState(sm, e.Reporter(), big.NewInt(0))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load or create the account of reporter %s", e.Reporter())
+	}
+	account.SetNonce(e, reporter)
+	if err := account.StoreState(sm, e.Reporter(), reporter); err != nil {
+		return nil, errors.Wrap(err, "failed to update pending account changes to trie")
+	}
+
+	pkHash, err := iotxaddress.AddressToPKHash(offence.Offender)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert address to public key hash")
+	}
+	record, err := p.getEquivocations(pkHash, sm)
+	if err != nil {
+		return nil, err
+	}
+	for _, height := range record.Heights {
+		if height == offence.Height {
+			return nil, nil
+		}
+	}
+	record.Heights = append(record.Heights, offence.Height)
+	if err := sm.PutState(p.equivocationsKey(pkHash), record); err != nil {
+		return nil, errors.Wrapf(err, "failed to put the equivocations of %s to trie", offence.Offender)
+	}
+
+	candidateMap, err := p.loadCandidateMap(sm)
+	if err != nil {
+		return nil, err
+	}
+	candidate, ok := candidateMap[pkHash]
+	if !ok {
+		return nil, nil
+	}
+	candidate.Votes = penalize(candidate.Votes, 1)
+	candidate.LastUpdateHeight = sm.Height()
+	if err := p.putCandidateMap(candidateMap, sm); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// validateEvidence checks that an evidence proves an equivocation of a delegate
+func (p *Protocol) validateEvidence(e *action.Evidence) error {
+	if _, err := p.verifyEvidence(e); err != nil {
+		return errors.Wrapf(err, "error when validating the evidence reported by %s", e.Reporter())
+	}
+	return nil
+}
+
+// verifyEvidence verifies that an evidence proves an equivocation, and the offender is a delegate of the height of the
+// equivocation
+func (p *Protocol) verifyEvidence(e *action.Evidence) (*evidence.Offence, error) {
+	offence, err := evidence.Verify(e.EvidenceType(), e.First(), e.Second())
+	if err != nil {
+		return nil, err
+	}
+	isDelegate, err := evidence.IsDelegate(p.delegates, offence.Offender, offence.Height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the delegates of height %d", offence.Height)
+	}
+	if !isDelegate {
+		return nil, errors.Wrapf(
+			evidence.ErrInvalidEvidence,
+			"%s is not a delegate of height %d",
+			offence.Offender,
+			offence.Height,
+		)
+	}
+	return offence, nil
+}
+
+func (p *Protocol) equivocationsKey(pkHash hash.PKHash) hash.PKHash {
+	k := []byte(EquivocationsPrefix)
+	k = append(k, pkHash[:]...)
+	return byteutil.BytesTo20B(hash.Hash160b(k))
+}
+
+// getEquivocations reads the equivocations of a delegate
+func (p *Protocol) getEquivocations(pkHash hash.PKHash, sm protocol.StateManager) (*equivocations, error) {
+	var record equivocations
+	err := sm.State(p.equivocationsKey(pkHash), &record)
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, errors.Wrapf(err, "failed to get the equivocations of %x from trie", pkHash)
+	}
+	return &record, nil
+}
+
+// penalize halves the votes once per equivocation
+func penalize(votes *big.Int, equivocations int) *big.Int {
+	if votes == nil {
+		return nil
+	}
+	return new(big.Int).Rsh(votes, uint(equivocations))
+}
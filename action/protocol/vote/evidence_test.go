@@ -0,0 +1,119 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package vote
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestProtocol_HandleEvidence(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.Default
+	cfg.Consensus.Scheme = config.RollDPoSScheme
+	cfg.Consensus.RollDPoS.NumDelegates = 1
+	ctx := context.Background()
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+
+	offender := testaddress.Addrinfo["alfa"]
+	mbc := mock_blockchain.NewMockBlockchain(ctrl)
+	mbc.EXPECT().CandidatesByHeight(uint64(1)).Return([]*state.Candidate{{Address: offender.RawAddress}}, nil).AnyTimes()
+	protocol := NewProtocol(mbc, cfg)
+
+	reporter := testaddress.Addrinfo["bravo"].RawAddress
+	voter := testaddress.Addrinfo["charlie"].RawAddress
+	pkHash, _ := iotxaddress.AddressToPKHash(offender.RawAddress)
+	for _, addr := range []string{offender.RawAddress, reporter, voter} {
+		_, err = account.LoadOrCreateAccountState(ws, addr, big.NewInt(100))
+		require.NoError(err)
+	}
+	vote, err := action.NewVote(1, offender.RawAddress, offender.RawAddress, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	_, err = protocol.Handle(ctx, vote, ws)
+	require.NoError(err)
+
+	endorseBy := func(endorser *iotxaddress.Address, blkHash hash.Hash32B) []byte {
+		vote := endorsement.NewConsensusVote(blkHash, 2, 0, endorsement.LOCK)
+		data, err := proto.Marshal(endorsement.NewEndorsement(vote, endorser).ToProtoMsg())
+		require.NoError(err)
+		return data
+	}
+	endorse := func(blkHash hash.Hash32B) []byte { return endorseBy(offender, blkHash) }
+	checkVotes := func(votes string) {
+		candidateMap, err := protocol.getCandidateMap(ws.Height(), ws)
+		require.NoError(err)
+		require.Equal(votes, candidateMap[pkHash].Votes.String())
+	}
+
+	// an evidence which doesn't prove an equivocation is rejected
+	invalid := action.NewEvidence(1, reporter, action.DoubleEndorsement, endorse(hash.Hash32B{1}),
+		endorse(hash.Hash32B{1}), uint64(100000), big.NewInt(0))
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(protocol.Validate(ctx, invalid)))
+	_, err = protocol.Handle(ctx, invalid, ws)
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(err))
+
+	// an equivocation of a non-delegate is rejected
+	charlie := testaddress.Addrinfo["charlie"]
+	nonDelegate := action.NewEvidence(1, reporter, action.DoubleEndorsement, endorseBy(charlie, hash.Hash32B{1}),
+		endorseBy(charlie, hash.Hash32B{2}), uint64(100000), big.NewInt(0))
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(protocol.Validate(ctx, nonDelegate)))
+	_, err = protocol.Handle(ctx, nonDelegate, ws)
+	require.Equal(evidence.ErrInvalidEvidence, errors.Cause(err))
+
+	// the offender's votes are halved
+	e1 := action.NewEvidence(1, reporter, action.DoubleEndorsement, endorse(hash.Hash32B{1}),
+		endorse(hash.Hash32B{2}), uint64(100000), big.NewInt(0))
+	require.NoError(protocol.Validate(ctx, e1))
+	_, err = protocol.Handle(ctx, e1, ws)
+	require.NoError(err)
+	checkVotes("50")
+	reporterState, err := account.LoadOrCreateAccountState(ws, reporter, big.NewInt(0))
+	require.NoError(err)
+	require.Equal(uint64(1), reporterState.Nonce)
+
+	// the same offence isn't penalized again
+	e2 := action.NewEvidence(2, reporter, action.DoubleEndorsement, endorse(hash.Hash32B{1}),
+		endorse(hash.Hash32B{3}), uint64(100000), big.NewInt(0))
+	_, err = protocol.Handle(ctx, e2, ws)
+	require.NoError(err)
+	checkVotes("50")
+
+	// the penalty still applies when the candidate gets more votes
+	vote2, err := action.NewVote(1, voter, offender.RawAddress, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	_, err = protocol.Handle(ctx, vote2, ws)
+	require.NoError(err)
+	checkVotes("100")
+}
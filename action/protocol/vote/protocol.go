@@ -17,6 +17,8 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
@@ -33,31 +35,28 @@ const (
 // Protocol defines the protocol of handling votes
 type Protocol struct {
 	bc               blockchain.Blockchain
+	delegates        evidence.DelegatesFunc
 	cachedCandidates map[hash.PKHash]*state.Candidate
 }
 
 // NewProtocol instantiates the protocol of vote
-func NewProtocol(bc blockchain.Blockchain) *Protocol { return &Protocol{bc: bc} }
+func NewProtocol(bc blockchain.Blockchain, cfg config.Config) *Protocol {
+	return &Protocol{bc: bc, delegates: evidence.NewDelegatesFunc(cfg, bc.CandidatesByHeight)}
+}
 
 // Handle handles a vote
 func (p *Protocol) Handle(_ context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
+	if evidence, ok := act.(*action.Evidence); ok {
+		return p.handleEvidence(evidence, sm)
+	}
 	vote, ok := act.(*action.Vote)
 	if !ok {
 		return nil, nil
 	}
-	// Get candidateList from trie and convert it to candidates map
-	candidateMap, err := p.getCandidateMap(sm.Height(), sm)
-	switch {
-	case errors.Cause(err) == state.ErrStateNotExist:
-		if sm.Height() == uint64(0) {
-			candidateMap = make(map[hash.PKHash]*state.Candidate)
-		} else if candidateMap, err = p.getCandidateMap(sm.Height()-1, sm); err != nil {
-			return nil, errors.Wrapf(err, "failed to get candidates on height %d from trie", sm.Height()-1)
-		}
-	case err != nil:
-		return nil, errors.Wrapf(err, "failed to get candidates on height %d from trie", sm.Height())
+	candidateMap, err := p.loadCandidateMap(sm)
+	if err != nil {
+		return nil, err
 	}
-
 	p.cachedCandidates = candidateMap
 
 	voteFrom, err := account.LoadOrCreateAccountState(sm, vote.Voter(), big.NewInt(0))
@@ -105,7 +104,9 @@ func (p *Protocol) Handle(_ context.Context, act action.Action, sm protocol.Stat
 		}
 		// Update candidate map
 		if oldVotee.IsCandidate {
-			p.updateCandidate(prevVotee, oldVotee.VotingWeight, sm.Height())
+			if err := p.updateCandidate(prevVotee, oldVotee.VotingWeight, sm); err != nil {
+				return nil, errors.Wrapf(err, "failed to update candidate %s", prevVotee)
+			}
 		}
 	}
 
@@ -123,19 +124,15 @@ func (p *Protocol) Handle(_ context.Context, act action.Action, sm protocol.Stat
 		}
 		// Update candidate map
 		if voteTo.IsCandidate {
-			p.updateCandidate(vote.Votee(), voteTo.VotingWeight, sm.Height())
+			if err := p.updateCandidate(vote.Votee(), voteTo.VotingWeight, sm); err != nil {
+				return nil, errors.Wrapf(err, "failed to update candidate %s", vote.Votee())
+			}
 		}
 	}
 
 	// Put updated candidate map to trie
-	candidateList, err := state.MapToCandidates(candidateMap)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert candidate map to candidate list")
-	}
-	sort.Sort(candidateList)
-	candidatesKey := p.constructKey(sm.Height())
-	if err := sm.PutState(candidatesKey, &candidateList); err != nil {
-		return nil, errors.Wrap(err, "failed to put updated candidates to trie")
+	if err := p.putCandidateMap(candidateMap, sm); err != nil {
+		return nil, err
 	}
 
 	// clear cached candidates
@@ -145,6 +142,9 @@ func (p *Protocol) Handle(_ context.Context, act action.Action, sm protocol.Stat
 
 // Validate validates a vote
 func (p *Protocol) Validate(_ context.Context, act action.Action) error {
+	if evidence, ok := act.(*action.Evidence); ok {
+		return p.validateEvidence(evidence)
+	}
 	vote, ok := act.(*action.Vote)
 	if !ok {
 		return nil
@@ -179,6 +179,37 @@ func (p *Protocol) constructKey(height uint64) hash.PKHash {
 	return byteutil.BytesTo20B(hash.Hash160b(k))
 }
 
+// loadCandidateMap reads the candidates of the current height, which start from the candidates of the last height
+func (p *Protocol) loadCandidateMap(sm protocol.StateManager) (map[hash.PKHash]*state.Candidate, error) {
+	// Get candidateList from trie and convert it to candidates map
+	candidateMap, err := p.getCandidateMap(sm.Height(), sm)
+	switch {
+	case errors.Cause(err) == state.ErrStateNotExist:
+		if sm.Height() == uint64(0) {
+			candidateMap = make(map[hash.PKHash]*state.Candidate)
+		} else if candidateMap, err = p.getCandidateMap(sm.Height()-1, sm); err != nil {
+			return nil, errors.Wrapf(err, "failed to get candidates on height %d from trie", sm.Height()-1)
+		}
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get candidates on height %d from trie", sm.Height())
+	}
+	return candidateMap, nil
+}
+
+// putCandidateMap writes the candidates of the current height
+func (p *Protocol) putCandidateMap(candidateMap map[hash.PKHash]*state.Candidate, sm protocol.StateManager) error {
+	candidateList, err := state.MapToCandidates(candidateMap)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert candidate map to candidate list")
+	}
+	sort.Sort(candidateList)
+	candidatesKey := p.constructKey(sm.Height())
+	if err := sm.PutState(candidatesKey, &candidateList); err != nil {
+		return errors.Wrap(err, "failed to put updated candidates to trie")
+	}
+	return nil
+}
+
 func (p *Protocol) getCandidateMap(height uint64, sm protocol.StateManager) (map[hash.PKHash]*state.Candidate, error) {
 	candidatesKey := p.constructKey(height)
 	var sc state.CandidateList
@@ -207,16 +238,20 @@ func (p *Protocol) addCandidate(vote *action.Vote, height uint64) error {
 func (p *Protocol) updateCandidate(
 	addr string,
 	totalWeight *big.Int,
-	blockHeight uint64,
+	sm protocol.StateManager,
 ) error {
 	pkHash, err := iotxaddress.AddressToPKHash(addr)
 	if err != nil {
 		return errors.Wrap(err, "failed to convert address to public key hash")
 	}
+	record, err := p.getEquivocations(pkHash, sm)
+	if err != nil {
+		return err
+	}
 	// Candidate was added when self-nomination, always exist in cachedCandidates
 	candidate := p.cachedCandidates[pkHash]
-	candidate.Votes = totalWeight
-	candidate.LastUpdateHeight = blockHeight
+	candidate.Votes = penalize(totalWeight, len(record.Heights))
+	candidate.LastUpdateHeight = sm.Height()
 
 	return nil
 }
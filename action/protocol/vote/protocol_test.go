@@ -44,7 +44,7 @@ func TestProtocol_Handle(t *testing.T) {
 	require.NoError(err)
 
 	mbc := mock_blockchain.NewMockBlockchain(ctrl)
-	protocol := NewProtocol(mbc)
+	protocol := NewProtocol(mbc, cfg)
 
 	// Create three accounts
 	addr1 := testaddress.Addrinfo["alfa"].RawAddress
@@ -141,7 +141,7 @@ func TestProtocol_Validate(t *testing.T) {
 		big.NewInt(0),
 	)
 	require.NoError(err)
-	protocol := NewProtocol(bc)
+	protocol := NewProtocol(bc, config.Default)
 
 	// Caes I: Oversized data
 	vote, err := action.NewVote(1, "src", "dst", uint64(100000), big.NewInt(0))
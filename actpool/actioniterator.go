@@ -51,10 +51,12 @@ type actionIterator struct {
 func NewActionIterator(accountActs map[string][]action.Action) ActionIterator {
 	heads := make(ActionByPrice, 0, len(accountActs))
 	for sender, accActs := range accountActs {
-		heads = append(heads, accActs[0])
-		if len(accActs) > 1 {
-			accountActs[sender] = accActs[1:]
+		if len(accActs) == 0 {
+			delete(accountActs, sender)
+			continue
 		}
+		heads = append(heads, accActs[0])
+		accountActs[sender] = accActs[1:]
 	}
 	heap.Init(&heads)
 	return &actionIterator{
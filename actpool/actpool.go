@@ -10,23 +10,29 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/routine"
 )
 
 // ActPool is the interface of actpool
 type ActPool interface {
+	lifecycle.StartStopper
 	// Reset resets actpool state
 	Reset()
-	// PickActs returns all currently accepted actions in actpool
+	// PickActs returns all currently accepted actions in actpool, the ones of higher gas prices first
 	PickActs() []action.Action
 	// Add adds an action into the pool after passing validation
 	Add(act action.Action) error
@@ -36,12 +42,18 @@ type ActPool interface {
 	GetUnconfirmedActs(addr string) []action.Action
 	// GetActionByHash returns the pending action in pool given action's hash
 	GetActionByHash(hash hash.Hash32B) (action.Action, error)
+	// GetDropReason returns why the action of the hash has been dropped from pool, if it has been dropped recently
+	GetDropReason(hash hash.Hash32B) (DropReason, bool)
 	// GetSize returns the act pool size
 	GetSize() uint64
 	// GetCapacity returns the act pool capacity
 	GetCapacity() uint64
 	// AddActionValidators add validators
 	AddActionValidators(...protocol.ActionValidator)
+	// AddSubscriber makes you listen to every action accepted into the pool
+	AddSubscriber(PendingActionSubscriber) error
+	// RemoveSubscriber makes you stop listening to the accepted actions
+	RemoveSubscriber(PendingActionSubscriber) error
 }
 
 // actPool implements ActPool interface
@@ -52,13 +64,45 @@ type actPool struct {
 	accountActs map[string]ActQueue
 	allActions  map[hash.Hash32B]action.Action
 	validators  []protocol.ActionValidator
+	journal     *journal
+	clk         clock.Clock
+	// addedAt is when each action in pool is accepted
+	addedAt map[hash.Hash32B]time.Time
+	// lastActive is when each account in pool has an action accepted or confirmed lately
+	lastActive map[string]time.Time
+	dropped    *dropLog
+	sweepTask  *routine.RecurringTask
+	// subscribers get notified of the accepted actions
+	subscribers []PendingActionSubscriber
+}
+
+// Option sets actpool construction parameter
+type Option func(*actPool) error
+
+// JournalOption keeps the accepted actions in the kvstore, which are replayed when the actpool starts
+func JournalOption(kvstore db.KVStore) Option {
+	return func(ap *actPool) error {
+		if kvstore == nil {
+			return errors.New("Try to attach a nil journal store")
+		}
+		ap.journal = newJournal(kvstore)
+		return nil
+	}
+}
+
+// ClockOption overrides the default clock
+func ClockOption(clk clock.Clock) Option {
+	return func(ap *actPool) error {
+		ap.clk = clk
+		return nil
+	}
 }
 
 // GenericValidator is the validator for generic action verification
 type GenericValidator struct{ bc blockchain.Blockchain }
 
 // NewActPool constructs a new actpool
-func NewActPool(bc blockchain.Blockchain, cfg config.ActPool) (ActPool, error) {
+func NewActPool(bc blockchain.Blockchain, cfg config.ActPool, opts ...Option) (ActPool, error) {
 	if bc == nil {
 		return nil, errors.New("Try to attach a nil blockchain")
 	}
@@ -67,10 +111,76 @@ func NewActPool(bc blockchain.Blockchain, cfg config.ActPool) (ActPool, error) {
 		bc:          bc,
 		accountActs: make(map[string]ActQueue),
 		allActions:  make(map[hash.Hash32B]action.Action),
+		clk:         clock.New(),
+		addedAt:     make(map[hash.Hash32B]time.Time),
+		lastActive:  make(map[string]time.Time),
+		dropped:     newDropLog(dropLogSize),
+	}
+	for _, opt := range opts {
+		if err := opt(ap); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.SweepInterval > 0 && (cfg.ActionTTL > 0 || cfg.AccountIdleTimeout > 0) {
+		ap.sweepTask = routine.NewRecurringTask(ap.sweep, cfg.SweepInterval, routine.WithClock(ap.clk))
 	}
 	return ap, nil
 }
 
+// Start replays the journaled actions, and starts sweeping the expired actions
+func (ap *actPool) Start(ctx context.Context) error {
+	if ap.journal != nil {
+		if err := ap.journal.Start(ctx); err != nil {
+			return errors.Wrap(err, "failed to start actpool journal")
+		}
+		if err := ap.replayJournal(); err != nil {
+			return err
+		}
+	}
+	if ap.sweepTask != nil {
+		return ap.sweepTask.Start(ctx)
+	}
+	return nil
+}
+
+// Stop stops sweeping the expired actions and the journal
+func (ap *actPool) Stop(ctx context.Context) error {
+	if ap.sweepTask != nil {
+		if err := ap.sweepTask.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	if ap.journal != nil {
+		return ap.journal.Stop(ctx)
+	}
+	return nil
+}
+
+// replayJournal replays the journaled actions through the validators against the current tip of the blockchain, which
+// must have been started. The actions that become invalid are dropped from the journal
+func (ap *actPool) replayJournal() error {
+	acts, err := ap.journal.load()
+	if err != nil {
+		return err
+	}
+	dropped := make([]action.Action, 0)
+	for _, act := range acts {
+		if err := ap.Add(act); err != nil {
+			actHash := act.Hash()
+			logger.Debug().Err(err).Hex("hash", actHash[:]).Msg("drop a journaled action")
+			dropped = append(dropped, act)
+		}
+	}
+	if err := ap.journal.delete(dropped); err != nil {
+		return errors.Wrap(err, "failed to delete the dropped actions from journal")
+	}
+	logger.Info().
+		Int("replayed", len(acts)-len(dropped)).
+		Int("dropped", len(dropped)).
+		Msg("replayed journaled actions into actpool")
+	return nil
+}
+
 // NewGenericValidator constructs a new genericValidator
 func NewGenericValidator(bc blockchain.Blockchain) *GenericValidator { return &GenericValidator{bc} }
 
@@ -79,6 +189,30 @@ func (ap *actPool) AddActionValidators(validators ...protocol.ActionValidator) {
 	ap.validators = append(ap.validators, validators...)
 }
 
+// AddSubscriber adds a subscriber notified of every action accepted into the pool
+func (ap *actPool) AddSubscriber(s PendingActionSubscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	if s == nil {
+		return errors.New("subscriber could not be nil")
+	}
+	ap.subscribers = append(ap.subscribers, s)
+	return nil
+}
+
+// RemoveSubscriber removes a subscriber
+func (ap *actPool) RemoveSubscriber(s PendingActionSubscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	for i, sub := range ap.subscribers {
+		if sub == s {
+			ap.subscribers = append(ap.subscribers[:i], ap.subscribers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("cannot find subscriber")
+}
+
 // Reset resets actpool state
 // Step I: remove all the actions in actpool that have already been committed to block
 // Step II: update pending balance of each account if it still exists in pool
@@ -115,33 +249,41 @@ func (ap *actPool) Reset() {
 	}
 }
 
-// PickActs returns all currently accepted transfers and votes for all accounts
+// PickActs returns all currently accepted transfers and votes for all accounts. The actions are picked in the order of
+// gas price across accounts, while the actions of an account are still picked in the order of nonce
 func (ap *actPool) PickActs() []action.Action {
 	ap.mutex.RLock()
 	defer ap.mutex.RUnlock()
 
+	accountActs := make(map[string][]action.Action, len(ap.accountActs))
+	for from, queue := range ap.accountActs {
+		accountActs[from] = queue.PendingActs()
+	}
 	numActs := uint64(0)
 	actions := make([]action.Action, 0)
-	for _, queue := range ap.accountActs {
-		for _, act := range queue.PendingActs() {
-			actions = append(actions, act)
-			numActs++
-			if ap.cfg.MaxNumActsToPick > 0 && numActs >= ap.cfg.MaxNumActsToPick {
-				logger.Debug().
-					Uint64("limit", ap.cfg.MaxNumActsToPick).
-					Msg("reach the max number of actions to pick")
-				return actions
-			}
+	ai := NewActionIterator(accountActs)
+	for act := ai.TopAction(); act != nil; act = ai.TopAction() {
+		actions = append(actions, act)
+		numActs++
+		if ap.cfg.MaxNumActsToPick > 0 && numActs >= ap.cfg.MaxNumActsToPick {
+			logger.Debug().
+				Uint64("limit", ap.cfg.MaxNumActsToPick).
+				Msg("reach the max number of actions to pick")
+			return actions
 		}
+		ai.LoadNextAction()
 	}
 	return actions
 }
 
+// Add adds an action into the pool. When the pool is full, the action takes the space of the lowest priced action at
+// the tail of another account, and is rejected if there's no such action to evict
 func (ap *actPool) Add(act action.Action) error {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
-	// Reject action if pool space is full
-	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
+	// Reject action if pool space is full and no action is cheaper to evict
+	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool && !ap.replaces(act) &&
+		ap.evictee(act, ap.numActs(act.SrcAddr())+1) == "" {
 		return errors.Wrap(action.ErrActPool, "insufficient space for action")
 	}
 	hash := act.Hash()
@@ -155,7 +297,17 @@ func (ap *actPool) Add(act action.Action) error {
 			return errors.Wrapf(err, "reject invalid action: %x", hash)
 		}
 	}
-	return ap.enqueueAction(act.SrcAddr(), act, hash, act.Nonce())
+	if err := ap.enqueueAction(act.SrcAddr(), act, hash, act.Nonce()); err != nil {
+		return err
+	}
+	ap.evict(act)
+	// the action may have been dropped from the queue as unpayable
+	if _, ok := ap.allActions[hash]; ok && ap.journal != nil {
+		if err := ap.journal.put(act); err != nil {
+			logger.Warn().Err(err).Msg("Failed to journal action")
+		}
+	}
+	return nil
 }
 
 // GetPendingNonce returns pending nonce in pool or confirmed nonce given an account address
@@ -194,6 +346,14 @@ func (ap *actPool) GetActionByHash(hash hash.Hash32B) (action.Action, error) {
 	return act, nil
 }
 
+// GetDropReason returns why the action of the hash has been dropped from pool, if it has been dropped recently
+func (ap *actPool) GetDropReason(hash hash.Hash32B) (DropReason, bool) {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	return ap.dropped.get(hash)
+}
+
 // GetSize returns the act pool size
 func (ap *actPool) GetSize() uint64 {
 	ap.mutex.RLock()
@@ -234,8 +394,15 @@ func (ap *actPool) enqueueAction(sender string, act action.Action, hash hash.Has
 		queue.SetPendingBalance(balance)
 	}
 	if queue.Overlaps(act) {
-		// Nonce already exists
-		return errors.Wrapf(action.ErrNonce, "duplicate nonce for action %x", hash)
+		// Nonce already exists, which is taken over only by a higher gas price
+		replaced, err := queue.Replace(act)
+		if err != nil {
+			return errors.Wrapf(err, "duplicate nonce for action %x", hash)
+		}
+		ap.removeActs([]action.Action{replaced}, DropReplaced)
+		ap.accept(sender, act, hash)
+		ap.updateAccount(sender)
+		return nil
 	}
 
 	if actNonce-queue.StartNonce() >= ap.cfg.MaxNumActsPerAcct {
@@ -259,7 +426,7 @@ func (ap *actPool) enqueueAction(sender string, act action.Action, hash hash.Has
 	if err := queue.Put(act); err != nil {
 		return errors.Wrapf(err, "cannot put action %x into ActQueue", hash)
 	}
-	ap.allActions[hash] = act
+	ap.accept(sender, act, hash)
 	// If the pending nonce equals this nonce, update queue
 	nonce := queue.PendingNonce()
 	if actNonce == nonce {
@@ -268,6 +435,61 @@ func (ap *actPool) enqueueAction(sender string, act action.Action, hash hash.Has
 	return nil
 }
 
+// replaces returns whether an action takes the nonce of an action in pool
+func (ap *actPool) replaces(act action.Action) bool {
+	queue, ok := ap.accountActs[act.SrcAddr()]
+	return ok && queue.Overlaps(act)
+}
+
+// numActs returns the number of actions of an account in pool
+func (ap *actPool) numActs(addr string) int {
+	if queue, ok := ap.accountActs[addr]; ok {
+		return queue.Len()
+	}
+	return 0
+}
+
+// evictee returns the account whose tail action gives way to the given action when the pool is full. It's the account
+// with the lowest priced tail among the others, which must be priced lower than the action, or priced the same while
+// the account holds more actions than the sender does with the action. Thus an account spamming the pool gives way to
+// the others
+func (ap *actPool) evictee(act action.Action, numSenderActs int) string {
+	sender := act.SrcAddr()
+	evictee := ""
+	var evicteeTail action.Action
+	for from, queue := range ap.accountActs {
+		tail := queue.Tail()
+		if from == sender || tail == nil {
+			continue
+		}
+		if cmp := tail.GasPrice().Cmp(act.GasPrice()); cmp > 0 || cmp == 0 && queue.Len() <= numSenderActs {
+			continue
+		}
+		if evicteeTail != nil {
+			cmp := tail.GasPrice().Cmp(evicteeTail.GasPrice())
+			if cmp > 0 || cmp == 0 && queue.Len() < ap.accountActs[evictee].Len() ||
+				cmp == 0 && queue.Len() == ap.accountActs[evictee].Len() && from > evictee {
+				continue
+			}
+		}
+		evictee, evicteeTail = from, tail
+	}
+	return evictee
+}
+
+// evict removes the tail actions of the evictees until the pool is back within its capacity
+func (ap *actPool) evict(act action.Action) {
+	for uint64(len(ap.allActions)) > ap.cfg.MaxNumActsPerPool {
+		evictee := ap.evictee(act, ap.numActs(act.SrcAddr()))
+		if evictee == "" {
+			return
+		}
+		tail := ap.accountActs[evictee].PopTail()
+		ap.removeActs([]action.Action{tail}, DropEvicted)
+		ap.updateAccount(evictee)
+	}
+}
+
 // removeConfirmedActs removes processed (committed to block) actions from pool
 func (ap *actPool) removeConfirmedActs() {
 	for from, queue := range ap.accountActs {
@@ -279,22 +501,67 @@ func (ap *actPool) removeConfirmedActs() {
 		pendingNonce := confirmedNonce + 1
 		// Remove all actions that are committed to new block
 		acts := queue.FilterNonce(pendingNonce)
-		ap.removeInvalidActs(acts)
+		ap.removeActs(acts, notDropped)
+		if len(acts) > 0 {
+			ap.lastActive[from] = ap.clk.Now()
+		}
 
 		// Delete the queue entry if it becomes empty
 		if queue.Empty() {
 			delete(ap.accountActs, from)
+			delete(ap.lastActive, from)
 		}
 	}
 }
 
 func (ap *actPool) removeInvalidActs(acts []action.Action) {
+	ap.removeActs(acts, DropInvalidated)
+}
+
+// accept puts an action queued for the sender into pool
+func (ap *actPool) accept(sender string, act action.Action, hash hash.Hash32B) {
+	now := ap.clk.Now()
+	ap.allActions[hash] = act
+	ap.addedAt[hash] = now
+	ap.lastActive[sender] = now
+	ap.emitToSubscribers(act)
+}
+
+func (ap *actPool) emitToSubscribers(act action.Action) {
+	for _, s := range ap.subscribers {
+		go func(s PendingActionSubscriber, act action.Action) {
+			if err := s.HandlePendingAction(act); err != nil {
+				logger.Error().Err(err).Msg("Failed to handle pending action")
+			}
+		}(s, act)
+	}
+}
+
+// removeActs removes the actions already out of their queues from pool, and records why they are dropped unless
+// they are confirmed
+func (ap *actPool) removeActs(acts []action.Action, reason DropReason) {
 	for _, act := range acts {
 		hash := act.Hash()
 		logger.Debug().
 			Hex("hash", hash[:]).
-			Msg("Removed invalidated action")
+			Str("reason", string(reason)).
+			Msg("Removed action")
 		delete(ap.allActions, hash)
+		delete(ap.addedAt, hash)
+		if reason != notDropped {
+			ap.dropped.put(hash, reason)
+		}
+	}
+	ap.unjournal(acts)
+}
+
+// unjournal deletes the actions leaving the pool from the journal
+func (ap *actPool) unjournal(acts []action.Action) {
+	if ap.journal == nil {
+		return
+	}
+	if err := ap.journal.delete(acts); err != nil {
+		logger.Warn().Err(err).Msg("Failed to delete actions from journal")
 	}
 }
 
@@ -308,6 +575,7 @@ func (ap *actPool) updateAccount(sender string) {
 	// Delete the queue entry if it becomes empty
 	if queue.Empty() {
 		delete(ap.accountActs, sender)
+		delete(ap.lastActive, sender)
 	}
 }
 
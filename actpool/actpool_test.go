@@ -10,6 +10,7 @@ import (
 	"context"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -23,6 +24,8 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/testaddress"
@@ -128,7 +131,7 @@ func TestActPool_AddActs(t *testing.T) {
 	require.NoError(err)
 	ap, ok := Ap.(*actPool)
 	require.True(ok)
-	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc),
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default),
 		execution.NewProtocol())
 	// Test actpool status after adding a sequence of Tsfs/votes: need to check confirmed nonce, pending nonce, and pending balance
 	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
@@ -271,7 +274,7 @@ func TestActPool_PickActs(t *testing.T) {
 		require.NoError(err)
 		ap, ok := Ap.(*actPool)
 		require.True(ok)
-		ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc))
+		ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default))
 
 		tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
 			[]byte{}, uint64(100000), big.NewInt(0))
@@ -347,6 +350,227 @@ func TestActPool_PickActs(t *testing.T) {
 	})
 }
 
+func TestActPool_PickActsByGasPrice(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, big.NewInt(100000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2.RawAddress, big.NewInt(100000))
+	require.NoError(err)
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol())
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr1, uint64(2), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(3))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, addr2, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(2))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.NoError(ap.Add(tsf3))
+	// The actions of an account are picked in the order of nonce, even if a later one is priced higher
+	require.Equal([]action.Action{tsf3, tsf1, tsf2}, ap.PickActs())
+}
+
+func TestActPool_ReplaceAct(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, big.NewInt(100000))
+	require.NoError(err)
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol())
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr1, uint64(2), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+
+	// Case I: The replacement isn't priced higher
+	replaceTsf, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(20),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	require.Equal(action.ErrNonce, errors.Cause(ap.Add(replaceTsf)))
+	// Case II: The replacement is priced higher
+	replaceTsf, err = testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(2))
+	require.NoError(err)
+	require.NoError(ap.Add(replaceTsf))
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.Equal(action.ErrHash, errors.Cause(err))
+	require.Equal([]action.Action{replaceTsf, tsf2}, ap.GetUnconfirmedActs(addr1.RawAddress))
+	reason, ok := ap.GetDropReason(tsf1.Hash())
+	require.True(ok)
+	require.Equal(DropReplaced, reason)
+	pBalance, _ := ap.getPendingBalance(addr1.RawAddress)
+	require.Equal(uint64(69980), pBalance.Uint64())
+	pNonce, _ := ap.getPendingNonce(addr1.RawAddress)
+	require.Equal(uint64(3), pNonce)
+	// Case III: The replacement is affordable, but the following actions become unpayable
+	replaceTsf, err = testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(70000),
+		[]byte{}, uint64(100000), big.NewInt(3))
+	require.NoError(err)
+	require.NoError(ap.Add(replaceTsf))
+	require.Equal([]action.Action{replaceTsf}, ap.GetUnconfirmedActs(addr1.RawAddress))
+	require.Equal(uint64(1), ap.GetSize())
+	reason, ok = ap.GetDropReason(tsf2.Hash())
+	require.True(ok)
+	require.Equal(DropInvalidated, reason)
+	pBalance, _ = ap.getPendingBalance(addr1.RawAddress)
+	require.Equal(uint64(0), pBalance.Uint64())
+	pNonce, _ = ap.getPendingNonce(addr1.RawAddress)
+	require.Equal(uint64(2), pNonce)
+}
+
+func TestActPool_EvictActs(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	for _, addr := range []string{addr1.RawAddress, addr2.RawAddress, addr3.RawAddress, addr4.RawAddress} {
+		_, err := bc.CreateState(addr, big.NewInt(100000))
+		require.NoError(err)
+	}
+	apConfig := getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 3
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol())
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr1, uint64(2), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, addr1, uint64(3), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.NoError(ap.Add(tsf3))
+
+	// Case I: An account holding more actions gives way to the same price
+	tsf4, err := testutil.SignedTransfer(addr2, addr2, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf4))
+	require.Equal(uint64(3), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf3.Hash())
+	require.Equal(action.ErrHash, errors.Cause(err))
+	reason, ok := ap.GetDropReason(tsf3.Hash())
+	require.True(ok)
+	require.Equal(DropEvicted, reason)
+	pNonce, _ := ap.getPendingNonce(addr1.RawAddress)
+	require.Equal(uint64(3), pNonce)
+	pBalance, _ := ap.getPendingBalance(addr1.RawAddress)
+	require.Equal(uint64(79980), pBalance.Uint64())
+	// Case II: No action gives way to the same price once the accounts hold as many actions
+	tsf5, err := testutil.SignedTransfer(addr2, addr2, uint64(2), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(tsf5)))
+	// Case III: The lowest priced tail gives way to a higher price
+	tsf6, err := testutil.SignedTransfer(addr3, addr3, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(2))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf6))
+	require.Equal(uint64(3), ap.GetSize())
+	require.Equal([]action.Action{tsf1}, ap.GetUnconfirmedActs(addr1.RawAddress))
+	require.Equal([]action.Action{tsf4}, ap.GetUnconfirmedActs(addr2.RawAddress))
+	// Case IV: No action gives way to a lower price
+	tsf7, err := testutil.SignedTransfer(addr4, addr4, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(tsf7)))
+	// Case V: A replacement takes no extra space
+	replaceTsf, err := testutil.SignedTransfer(addr2, addr2, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(3))
+	require.NoError(err)
+	require.NoError(ap.Add(replaceTsf))
+	require.Equal(uint64(3), ap.GetSize())
+	require.Equal([]action.Action{replaceTsf, tsf6, tsf1}, ap.PickActs())
+}
+
+func TestActPool_Journal(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	kvstore := db.NewMemKVStore()
+	bc := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(ctx))
+	_, err := bc.CreateState(addr1.RawAddress, big.NewInt(100))
+	require.NoError(err)
+	Ap, err := NewActPool(bc, getActPoolCfg(), JournalOption(kvstore))
+	require.NoError(err)
+	Ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol())
+	require.NoError(Ap.Start(ctx))
+	hashes := func(acts []action.Action) []hash.Hash32B {
+		hashes := make([]hash.Hash32B, 0, len(acts))
+		for _, act := range acts {
+			hashes = append(hashes, act.Hash())
+		}
+		return hashes
+	}
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, addr1, uint64(2), big.NewInt(20),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, addr1, uint64(3), big.NewInt(30),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(Ap.Add(tsf3))
+	require.NoError(Ap.Add(tsf2))
+	require.NoError(Ap.Add(tsf1))
+	require.NoError(Ap.Stop(ctx))
+	// A broken record is dropped
+	require.NoError(kvstore.Put(journalNS, []byte("broken"), []byte("broken")))
+
+	// The journaled actions are replayed in the order of nonce
+	Ap, err = NewActPool(bc, getActPoolCfg(), JournalOption(kvstore))
+	require.NoError(err)
+	Ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol())
+	require.NoError(Ap.Start(ctx))
+	require.Equal(hashes([]action.Action{tsf1, tsf2, tsf3}), hashes(Ap.GetUnconfirmedActs(addr1.RawAddress)))
+	pNonce, err := Ap.GetPendingNonce(addr1.RawAddress)
+	require.NoError(err)
+	require.Equal(uint64(4), pNonce)
+	require.NoError(Ap.Stop(ctx))
+
+	// The actions invalid against another tip are dropped from the journal
+	bc2 := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc2.Start(ctx))
+	_, err = bc2.CreateState(addr1.RawAddress, big.NewInt(15))
+	require.NoError(err)
+	Ap, err = NewActPool(bc2, getActPoolCfg(), JournalOption(kvstore))
+	require.NoError(err)
+	Ap.AddActionValidators(NewGenericValidator(bc2), account.NewProtocol())
+	require.NoError(Ap.Start(ctx))
+	require.Equal([]hash.Hash32B{tsf1.Hash()}, hashes(Ap.PickActs()))
+	acts, err := newJournal(kvstore).load()
+	require.NoError(err)
+	require.Equal([]hash.Hash32B{tsf1.Hash()}, hashes(acts))
+	require.NoError(Ap.Stop(ctx))
+}
+
 func TestActPool_removeConfirmedActs(t *testing.T) {
 	require := require.New(t)
 	bc := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
@@ -359,7 +583,7 @@ func TestActPool_removeConfirmedActs(t *testing.T) {
 	require.NoError(err)
 	ap, ok := Ap.(*actPool)
 	require.True(ok)
-	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc))
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default))
 
 	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
 		[]byte{}, uint64(100000), big.NewInt(0))
@@ -420,13 +644,13 @@ func TestActPool_Reset(t *testing.T) {
 	require.NoError(err)
 	ap1, ok := Ap1.(*actPool)
 	require.True(ok)
-	ap1.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc),
+	ap1.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default),
 		execution.NewProtocol())
 	Ap2, err := NewActPool(bc, apConfig)
 	require.NoError(err)
 	ap2, ok := Ap2.(*actPool)
 	require.True(ok)
-	ap2.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc),
+	ap2.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default),
 		execution.NewProtocol())
 
 	// Tsfs to be added to ap1
@@ -805,7 +1029,7 @@ func TestActPool_removeInvalidActs(t *testing.T) {
 	require.NoError(err)
 	ap, ok := Ap.(*actPool)
 	require.True(ok)
-	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc))
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default))
 
 	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
 		[]byte{}, uint64(100000), big.NewInt(0))
@@ -852,7 +1076,7 @@ func TestActPool_GetPendingNonce(t *testing.T) {
 	require.NoError(err)
 	ap, ok := Ap.(*actPool)
 	require.True(ok)
-	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc))
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default))
 
 	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
 		[]byte{}, uint64(100000), big.NewInt(0))
@@ -893,7 +1117,7 @@ func TestActPool_GetUnconfirmedActs(t *testing.T) {
 	require.NoError(err)
 	ap, ok := Ap.(*actPool)
 	require.True(ok)
-	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc))
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default))
 
 	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
 		[]byte{}, uint64(100000), big.NewInt(0))
@@ -979,7 +1203,7 @@ func TestActPool_GetSize(t *testing.T) {
 	require.NoError(err)
 	ap, ok := Ap.(*actPool)
 	require.True(ok)
-	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc))
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol(), vote.NewProtocol(bc, config.Default))
 	require.Zero(ap.GetSize())
 
 	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
@@ -1017,6 +1241,56 @@ func TestActPool_GetSize(t *testing.T) {
 	require.Equal(uint64(0), ap.GetSize())
 }
 
+func TestActPool_Subscriber(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1.RawAddress, big.NewInt(100))
+	require.NoError(err)
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol())
+
+	sub := &testSubscriber{acts: make(chan action.Action, 1)}
+	require.Error(ap.AddSubscriber(nil))
+	require.NoError(ap.AddSubscriber(sub))
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	select {
+	case act := <-sub.acts:
+		require.Equal(tsf1.Hash(), act.Hash())
+	case <-time.After(time.Second):
+		require.FailNow("subscriber isn't notified")
+	}
+	// a rejected action isn't notified
+	require.Error(ap.Add(tsf1))
+
+	require.NoError(ap.RemoveSubscriber(sub))
+	require.Error(ap.RemoveSubscriber(sub))
+	tsf2, err := testutil.SignedTransfer(addr1, addr1, uint64(2), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf2))
+	select {
+	case <-sub.acts:
+		require.FailNow("removed subscriber is notified")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+type testSubscriber struct {
+	acts chan action.Action
+}
+
+func (s *testSubscriber) HandlePendingAction(act action.Action) error {
+	s.acts <- act
+	return nil
+}
+
 // Helper function to return the correct pending nonce just in case of empty queue
 func (ap *actPool) getPendingNonce(addr string) (uint64, error) {
 	if queue, ok := ap.accountActs[addr]; ok {
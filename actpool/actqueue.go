@@ -42,6 +42,10 @@ func (h *noncePriorityQueue) Pop() interface{} {
 type ActQueue interface {
 	Overlaps(action.Action) bool
 	Put(action.Action) error
+	Replace(action.Action) (action.Action, error)
+	Tail() action.Action
+	PopTail() action.Action
+	Remove(uint64) action.Action
 	FilterNonce(uint64) []action.Action
 	SetStartNonce(uint64)
 	StartNonce() uint64
@@ -97,6 +101,72 @@ func (q *actQueue) Put(act action.Action) error {
 	return nil
 }
 
+// Replace replaces the action of the same nonce with one of a higher gas price, and returns the replaced action. If
+// the replaced action is pending, the pending nonce and balance are rolled back to its nonce, so that the queue is
+// updated again from there
+func (q *actQueue) Replace(act action.Action) (action.Action, error) {
+	nonce := act.Nonce()
+	old := q.items[nonce]
+	if old == nil {
+		return nil, errors.Wrapf(action.ErrNonce, "no action to replace on nonce %d", nonce)
+	}
+	if act.GasPrice().Cmp(old.GasPrice()) <= 0 {
+		return nil, errors.Wrapf(action.ErrNonce, "gas price %s doesn't exceed the replaced one", act.GasPrice())
+	}
+	balance := q.rolledBackBalance(nonce)
+	cost, err := act.Cost()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get cost of action on nonce %d", nonce)
+	}
+	if balance.Cmp(cost) < 0 {
+		return nil, errors.Wrapf(action.ErrBalance, "insufficient balance to replace action on nonce %d", nonce)
+	}
+	q.pendingBalance = balance
+	if nonce < q.pendingNonce {
+		q.pendingNonce = nonce
+	}
+	q.items[nonce] = act
+	return old, nil
+}
+
+// Tail returns the action with the highest nonce in the queue
+func (q *actQueue) Tail() action.Action {
+	if q.Len() == 0 {
+		return nil
+	}
+	return q.items[q.index[q.tailIndex()]]
+}
+
+// PopTail removes the action with the highest nonce from the queue, rolling back the pending nonce and balance if it's
+// pending
+func (q *actQueue) PopTail() action.Action {
+	if q.Len() == 0 {
+		return nil
+	}
+	return q.Remove(q.index[q.tailIndex()])
+}
+
+// Remove removes the action of a nonce from the queue. If it's pending, the pending nonce and balance are rolled back
+// to the nonce, and the queue should be updated again from there
+func (q *actQueue) Remove(nonce uint64) action.Action {
+	act := q.items[nonce]
+	if act == nil {
+		return nil
+	}
+	for i, n := range q.index {
+		if n == nonce {
+			heap.Remove(&q.index, i)
+			break
+		}
+	}
+	q.pendingBalance = q.rolledBackBalance(nonce)
+	if nonce < q.pendingNonce {
+		q.pendingNonce = nonce
+	}
+	delete(q.items, nonce)
+	return act
+}
+
 // FilterNonce removes all actions from the map with a nonce lower than the given threshold
 func (q *actQueue) FilterNonce(threshold uint64) []action.Action {
 	var removed []action.Action
@@ -226,6 +296,29 @@ func (q *actQueue) removeActs(idx int) []action.Action {
 	return removedFromQueue
 }
 
+// tailIndex returns the position of the highest nonce in the index
+func (q *actQueue) tailIndex() int {
+	idx := 0
+	for i, nonce := range q.index {
+		if nonce > q.index[idx] {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// rolledBackBalance returns the pending balance before the pending actions from the given nonce are paid
+func (q *actQueue) rolledBackBalance(nonce uint64) *big.Int {
+	balance := new(big.Int).Set(q.pendingBalance)
+	for ; nonce < q.pendingNonce; nonce++ {
+		if act := q.items[nonce]; act != nil {
+			cost, _ := act.Cost()
+			balance.Add(balance, cost)
+		}
+	}
+	return balance
+}
+
 // enoughBalance helps check whether queue's pending balance is sufficient for the given action
 func (q *actQueue) enoughBalance(act action.Action, updateBalance bool) bool {
 	cost, _ := act.Cost()
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -122,6 +123,73 @@ func TestActQueue_UpdateNonce(t *testing.T) {
 	require.Equal([]action.Action{tsf3, tsf4}, removed)
 }
 
+func TestActQueue_Replace(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue().(*actQueue)
+	q.pendingBalance = big.NewInt(100000)
+	tsf1, err := action.NewTransfer(uint64(1), big.NewInt(100), "1", "2", nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := action.NewTransfer(uint64(2), big.NewInt(200), "1", "2", nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(q.Put(tsf1))
+	require.NoError(q.Put(tsf2))
+	require.Equal(0, len(q.UpdateQueue(q.pendingNonce)))
+	require.Equal(uint64(3), q.pendingNonce)
+	require.Equal(big.NewInt(79700), q.pendingBalance)
+
+	// The replacement must be priced higher
+	replace1, err := action.NewTransfer(uint64(1), big.NewInt(300), "1", "2", nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	_, err = q.Replace(replace1)
+	require.Equal(action.ErrNonce, errors.Cause(err))
+	// The replacement must be affordable before the replaced action is paid
+	replace1, err = action.NewTransfer(uint64(1), big.NewInt(90000), "1", "2", nil, uint64(0), big.NewInt(2))
+	require.NoError(err)
+	_, err = q.Replace(replace1)
+	require.Equal(action.ErrBalance, errors.Cause(err))
+	replace1, err = action.NewTransfer(uint64(1), big.NewInt(300), "1", "2", nil, uint64(0), big.NewInt(2))
+	require.NoError(err)
+	replaced, err := q.Replace(replace1)
+	require.NoError(err)
+	require.Equal(tsf1, replaced)
+	require.Equal(uint64(1), q.pendingNonce)
+	require.Equal(big.NewInt(100000), q.pendingBalance)
+	require.Equal(0, len(q.UpdateQueue(q.pendingNonce)))
+	require.Equal(uint64(3), q.pendingNonce)
+	require.Equal(big.NewInt(69500), q.pendingBalance)
+	require.Equal([]action.Action{replace1, tsf2}, q.AllActs())
+}
+
+func TestActQueue_PopTail(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue().(*actQueue)
+	require.Nil(q.Tail())
+	require.Nil(q.PopTail())
+	q.pendingBalance = big.NewInt(1000)
+	tsf1, err := action.NewTransfer(uint64(1), big.NewInt(100), "1", "2", nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := action.NewTransfer(uint64(2), big.NewInt(200), "1", "2", nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := action.NewTransfer(uint64(4), big.NewInt(400), "1", "2", nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(q.Put(tsf4))
+	require.NoError(q.Put(tsf1))
+	require.NoError(q.Put(tsf2))
+	require.Equal(0, len(q.UpdateQueue(q.pendingNonce)))
+	require.Equal(uint64(3), q.pendingNonce)
+	require.Equal(big.NewInt(700), q.pendingBalance)
+
+	require.Equal(tsf4, q.Tail())
+	require.Equal(tsf4, q.PopTail())
+	require.Equal(uint64(3), q.pendingNonce)
+	require.Equal(big.NewInt(700), q.pendingBalance)
+	// Popping a pending action rolls back the pending nonce and balance
+	require.Equal(tsf2, q.PopTail())
+	require.Equal(uint64(2), q.pendingNonce)
+	require.Equal(big.NewInt(900), q.pendingBalance)
+	require.Equal([]action.Action{tsf1}, q.AllActs())
+}
+
 func TestActQueue_PendingActs(t *testing.T) {
 	require := require.New(t)
 	q := NewActQueue().(*actQueue)
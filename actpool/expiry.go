@@ -0,0 +1,112 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"math"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// dropLogSize is the number of the latest dropped actions whose reasons are kept
+const dropLogSize = 10000
+
+// DropReason is the reason an action is dropped from the pool
+type DropReason string
+
+const (
+	// DropExpired means the action stayed in the pool longer than the TTL
+	DropExpired DropReason = "expired"
+	// DropIdle means the account sending the action had no action accepted or confirmed for longer than the timeout
+	DropIdle DropReason = "idle"
+	// DropEvicted means the action gave way to a higher priced action when the pool was full
+	DropEvicted DropReason = "evicted"
+	// DropReplaced means the action was replaced by a higher priced action of the same nonce
+	DropReplaced DropReason = "replaced"
+	// DropInvalidated means the action became unpayable by the sender's pending balance
+	DropInvalidated DropReason = "invalidated"
+
+	// notDropped is for the actions removed from the pool since they are confirmed
+	notDropped DropReason = ""
+)
+
+// dropLog keeps the reasons of the latest dropped actions, the oldest of which is forgotten first
+type dropLog struct {
+	reasons map[hash.Hash32B]DropReason
+	order   []hash.Hash32B
+	next    int
+}
+
+func newDropLog(size int) *dropLog {
+	return &dropLog{
+		reasons: make(map[hash.Hash32B]DropReason, size),
+		order:   make([]hash.Hash32B, 0, size),
+	}
+}
+
+func (l *dropLog) put(h hash.Hash32B, reason DropReason) {
+	if _, ok := l.reasons[h]; ok {
+		l.reasons[h] = reason
+		return
+	}
+	if len(l.order) < cap(l.order) {
+		l.order = append(l.order, h)
+	} else {
+		delete(l.reasons, l.order[l.next])
+		l.order[l.next] = h
+		l.next = (l.next + 1) % len(l.order)
+	}
+	l.reasons[h] = reason
+}
+
+func (l *dropLog) get(h hash.Hash32B) (DropReason, bool) {
+	reason, ok := l.reasons[h]
+	return reason, ok
+}
+
+// sweep drops the actions staying in the pool longer than the TTL, and all the actions of the accounts idle for longer
+// than the timeout. Without them, the actions behind a nonce gap would stay in the pool until the gap is filled
+func (ap *actPool) sweep() {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	now := ap.clk.Now()
+	var numExpired, numIdle int
+	for from, queue := range ap.accountActs {
+		if ap.cfg.AccountIdleTimeout > 0 && now.Sub(ap.lastActive[from]) > ap.cfg.AccountIdleTimeout {
+			acts := queue.FilterNonce(math.MaxUint64)
+			ap.removeActs(acts, DropIdle)
+			numIdle += len(acts)
+			delete(ap.accountActs, from)
+			delete(ap.lastActive, from)
+			continue
+		}
+		if ap.cfg.ActionTTL == 0 {
+			continue
+		}
+		expired := make([]action.Action, 0)
+		for _, act := range queue.AllActs() {
+			if addedAt, ok := ap.addedAt[act.Hash()]; ok && now.Sub(addedAt) > ap.cfg.ActionTTL {
+				expired = append(expired, queue.Remove(act.Nonce()))
+			}
+		}
+		if len(expired) == 0 {
+			continue
+		}
+		ap.removeActs(expired, DropExpired)
+		numExpired += len(expired)
+		ap.updateAccount(from)
+	}
+	if numExpired > 0 || numIdle > 0 {
+		logger.Info().
+			Int("expired", numExpired).
+			Int("idle", numIdle).
+			Msg("swept expired actions from actpool")
+	}
+}
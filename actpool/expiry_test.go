@@ -0,0 +1,126 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestDropLog(t *testing.T) {
+	require := require.New(t)
+	l := newDropLog(2)
+	l.put(hash.Hash32B{1}, DropExpired)
+	l.put(hash.Hash32B{2}, DropEvicted)
+	l.put(hash.Hash32B{1}, DropIdle)
+	reason, ok := l.get(hash.Hash32B{1})
+	require.True(ok)
+	require.Equal(DropIdle, reason)
+	// the oldest dropped action is forgotten first
+	l.put(hash.Hash32B{3}, DropReplaced)
+	_, ok = l.get(hash.Hash32B{1})
+	require.False(ok)
+	l.put(hash.Hash32B{4}, DropInvalidated)
+	_, ok = l.get(hash.Hash32B{2})
+	require.False(ok)
+	reason, ok = l.get(hash.Hash32B{3})
+	require.True(ok)
+	require.Equal(DropReplaced, reason)
+	reason, ok = l.get(hash.Hash32B{4})
+	require.True(ok)
+	require.Equal(DropInvalidated, reason)
+}
+
+func TestActPool_Sweep(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	bc := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(ctx))
+	_, err := bc.CreateState(addr1.RawAddress, big.NewInt(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2.RawAddress, big.NewInt(100))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.ActionTTL = 10 * time.Minute
+	apConfig.AccountIdleTimeout = 30 * time.Minute
+	apConfig.SweepInterval = time.Minute
+	clk := clock.NewMock()
+	Ap, err := NewActPool(bc, apConfig, ClockOption(clk))
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionValidators(NewGenericValidator(bc), account.NewProtocol())
+	require.NoError(ap.Start(ctx))
+	defer func() {
+		require.NoError(ap.Stop(ctx))
+	}()
+
+	tsf1, err := testutil.SignedTransfer(addr1, addr1, uint64(1), big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, addr1, uint64(3), big.NewInt(30),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr1, addr1, uint64(4), big.NewInt(40),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf5, err := testutil.SignedTransfer(addr2, addr2, uint64(1), big.NewInt(50),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf3))
+	require.NoError(ap.Add(tsf5))
+	clk.Add(5 * time.Minute)
+	require.NoError(ap.Add(tsf4))
+
+	// Nothing has expired yet
+	clk.Add(5 * time.Minute)
+	ap.sweep()
+	require.Equal(uint64(4), ap.GetSize())
+
+	// The actions older than the TTL expire, no matter whether they are pending
+	clk.Add(time.Minute)
+	ap.sweep()
+	require.Equal([]action.Action{tsf4}, ap.GetUnconfirmedActs(addr1.RawAddress))
+	require.Equal(0, len(ap.GetUnconfirmedActs(addr2.RawAddress)))
+	for _, act := range []action.Action{tsf1, tsf3, tsf5} {
+		reason, ok := ap.GetDropReason(act.Hash())
+		require.True(ok)
+		require.Equal(DropExpired, reason)
+	}
+	_, ok = ap.GetDropReason(tsf4.Hash())
+	require.False(ok)
+	pNonce, _ := ap.getPendingNonce(addr1.RawAddress)
+	require.Equal(uint64(1), pNonce)
+	pBalance, _ := ap.getPendingBalance(addr1.RawAddress)
+	require.Equal(uint64(100), pBalance.Uint64())
+
+	// The account without any action accepted or confirmed lately times out
+	apConfig.ActionTTL = 0
+	ap.cfg = apConfig
+	clk.Add(24 * time.Minute)
+	ap.sweep()
+	require.Equal(uint64(1), ap.GetSize())
+	clk.Add(time.Minute)
+	ap.sweep()
+	require.Equal(uint64(0), ap.GetSize())
+	reason, ok := ap.GetDropReason(tsf4.Hash())
+	require.True(ok)
+	require.Equal(DropIdle, reason)
+}
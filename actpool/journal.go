@@ -0,0 +1,100 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+const journalNS = "actpoolJournal"
+
+// journal keeps the actions accepted by the actpool on disk, keyed by action hash, so that they survive the restart
+// of the node. The actions leaving the pool are deleted from the journal
+type journal struct {
+	kvstore db.KVStore
+}
+
+func newJournal(kvstore db.KVStore) *journal {
+	return &journal{kvstore: kvstore}
+}
+
+// Start starts the underlying KV store
+func (j *journal) Start(ctx context.Context) error { return j.kvstore.Start(ctx) }
+
+// Stop stops the underlying KV store
+func (j *journal) Stop(ctx context.Context) error { return j.kvstore.Stop(ctx) }
+
+// put records an accepted action
+func (j *journal) put(act action.Action) error {
+	data, err := proto.Marshal(act.Proto())
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal action %x", act.Hash())
+	}
+	hash := act.Hash()
+	if err := j.kvstore.Put(journalNS, hash[:], data); err != nil {
+		return errors.Wrapf(err, "failed to journal action %x", hash)
+	}
+	return nil
+}
+
+// delete deletes the actions leaving the pool
+func (j *journal) delete(acts []action.Action) error {
+	if len(acts) == 0 {
+		return nil
+	}
+	batch := db.NewBatch()
+	for _, act := range acts {
+		hash := act.Hash()
+		batch.Delete(journalNS, hash[:], "failed to delete action %x", hash)
+	}
+	return j.kvstore.Commit(batch)
+}
+
+// load reads the recorded actions in the order of nonce, so that the actions of an account are replayed in order. A
+// record that can't be decoded is deleted
+func (j *journal) load() ([]action.Action, error) {
+	iter, err := j.kvstore.Iterate(journalNS, db.Range{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the journal")
+	}
+	acts := make([]action.Action, 0)
+	batch := db.NewBatch()
+	for iter.Next() {
+		actPb := &iproto.ActionPb{}
+		if err := proto.Unmarshal(iter.Value(), actPb); err != nil {
+			logger.Warn().Err(err).Hex("hash", iter.Key()).Msg("Failed to unmarshal journaled action")
+			batch.Delete(journalNS, iter.Key(), "failed to delete action %x", iter.Key())
+			continue
+		}
+		act, err := action.LoadAction(actPb)
+		if err != nil {
+			logger.Warn().Err(err).Hex("hash", iter.Key()).Msg("Failed to load journaled action")
+			batch.Delete(journalNS, iter.Key(), "failed to delete action %x", iter.Key())
+			continue
+		}
+		acts = append(acts, act)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed to read the journal")
+	}
+	if batch.Size() > 0 {
+		if err := j.kvstore.Commit(batch); err != nil {
+			return nil, errors.Wrap(err, "failed to delete the broken records")
+		}
+	}
+	sort.SliceStable(acts, func(i, k int) bool { return acts[i].Nonce() < acts[k].Nonce() })
+	return acts, nil
+}
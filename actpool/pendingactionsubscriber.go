@@ -0,0 +1,16 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"github.com/iotexproject/iotex-core/action"
+)
+
+// PendingActionSubscriber is an interface which will get notified when an action is accepted into the pool
+type PendingActionSubscriber interface {
+	HandlePendingAction(action.Action) error
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+// ReorgHandler puts the orphaned actions back into the actpool when the chain switches to another branch, so that they
+// could be picked into the new branch
+type ReorgHandler struct {
+	ap ActPool
+}
+
+// NewReorgHandler creates a reorg handler of the actpool, which should be added to the chain as a subscriber
+func NewReorgHandler(ap ActPool) *ReorgHandler { return &ReorgHandler{ap} }
+
+// HandleBlock implements interface BlockCreationSubscriber. The actpool is reset by the block committers
+func (h *ReorgHandler) HandleBlock(*blockchain.Block) error { return nil }
+
+// HandleReorg implements interface ReorgSubscriber
+func (h *ReorgHandler) HandleReorg(reorg *blockchain.Reorg) error {
+	// the states of the orphaned actions' senders have changed
+	h.ap.Reset()
+	var added int
+	for _, act := range reorg.OrphanedActions() {
+		if err := h.ap.Add(act); err != nil {
+			// the action may be outdated by the new branch
+			actHash := act.Hash()
+			logger.Debug().Err(err).Hex("hash", actHash[:]).Msg("drop an orphaned action")
+			continue
+		}
+		added++
+	}
+	logger.Info().
+		Uint64("forkHeight", reorg.ForkHeight).
+		Int("added", added).
+		Msg("put orphaned actions back into actpool")
+	return nil
+}
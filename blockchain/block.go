@@ -52,6 +52,7 @@ type BlockHeader struct {
 	DKGID         []byte            // dkg ID of producer
 	DKGPubkey     []byte            // dkg public key of producer
 	DKGBlockSig   []byte            // dkg signature of producer
+	logsBloom     LogsBloom         // bloom of the contract addresses and topics of the logs
 }
 
 // Timestamp returns the timestamp in the block header
@@ -66,6 +67,24 @@ type BlockFooter struct {
 	commitTimestamp uint64
 }
 
+// NewBlockFooter creates a block footer with the endorsements committing the block
+func NewBlockFooter(endorsements *endorsement.Set, commitTimestamp uint64) *BlockFooter {
+	return &BlockFooter{
+		endorsements:    endorsements,
+		commitTimestamp: commitTimestamp,
+	}
+}
+
+// Endorsements returns the endorsements committing the block
+func (f *BlockFooter) Endorsements() *endorsement.Set {
+	return f.endorsements
+}
+
+// CommitTimestamp returns the timestamp when the block is committed
+func (f *BlockFooter) CommitTimestamp() uint64 {
+	return f.commitTimestamp
+}
+
 // Block defines the struct of block
 type Block struct {
 	Header          *BlockHeader
@@ -155,6 +174,11 @@ func (b *Block) StateRoot() hash.Hash32B {
 	return b.Header.stateRoot
 }
 
+// LogsBloom returns the bloom of the contract addresses and topics of the logs in this block
+func (b *Block) LogsBloom() LogsBloom {
+	return b.Header.logsBloom
+}
+
 // ByteStreamHeader returns a byte stream of the block header
 func (b *Block) ByteStreamHeader() []byte {
 	stream := make([]byte, 4)
@@ -172,6 +196,11 @@ func (b *Block) ByteStreamHeader() []byte {
 	stream = append(stream, b.Header.stateRoot[:]...)
 	stream = append(stream, b.Header.receiptRoot[:]...)
 	stream = append(stream, b.Header.Pubkey[:]...)
+	// The bloom is left out of the blocks without any log, so that the hashes of the blocks before it was introduced
+	// stay the same
+	if b.Header.logsBloom != ZeroLogsBloom {
+		stream = append(stream, b.Header.logsBloom[:]...)
+	}
 	return stream
 }
 
@@ -214,6 +243,9 @@ func (b *Block) ConvertToBlockHeaderPb() *iproto.BlockHeaderPb {
 	pbHeader.DkgID = b.Header.DKGID[:]
 	pbHeader.DkgPubkey = b.Header.DKGPubkey[:]
 	pbHeader.DkgSignature = b.Header.DKGBlockSig[:]
+	if b.Header.logsBloom != ZeroLogsBloom {
+		pbHeader.LogsBloom = b.Header.logsBloom[:]
+	}
 	return &pbHeader
 }
 
@@ -229,7 +261,29 @@ func (b *Block) ConvertToBlockPb() *iproto.BlockPb {
 	for _, act := range b.Actions {
 		actions = append(actions, act.Proto())
 	}
-	return &iproto.BlockPb{Header: b.ConvertToBlockHeaderPb(), Actions: actions}
+	return &iproto.BlockPb{Header: b.ConvertToBlockHeaderPb(), Actions: actions, Footer: b.convertToBlockFooterPb()}
+}
+
+func (b *Block) convertToBlockFooterPb() *iproto.BlockFooterPb {
+	if b.Footer == nil {
+		return nil
+	}
+	footerPb := &iproto.BlockFooterPb{CommitTimestamp: b.Footer.commitTimestamp}
+	if b.Footer.endorsements != nil {
+		footerPb.Endorsements = b.Footer.endorsements.ToProto()
+	}
+	return footerPb
+}
+
+// ConvertToBlockHeaderContainer converts the header and footer of Block to BlockHeaderContainer, which is what light
+// clients sync instead of the whole block
+func (b *Block) ConvertToBlockHeaderContainer() *iproto.BlockHeaderContainer {
+	return &iproto.BlockHeaderContainer{Header: b.ConvertToBlockHeaderPb(), Footer: b.convertToBlockFooterPb()}
+}
+
+// ConvertFromBlockHeaderContainer converts BlockHeaderContainer to a Block without actions
+func (b *Block) ConvertFromBlockHeaderContainer(container *iproto.BlockHeaderContainer) error {
+	return b.ConvertFromBlockPb(&iproto.BlockPb{Header: container.GetHeader(), Footer: container.GetFooter()})
 }
 
 // Serialize returns the serialized byte stream of the block
@@ -254,6 +308,7 @@ func (b *Block) ConvertFromBlockHeaderPb(pbBlock *iproto.BlockPb) {
 	b.Header.DKGID = pbBlock.GetHeader().GetDkgID()
 	b.Header.DKGPubkey = pbBlock.GetHeader().GetDkgPubkey()
 	b.Header.DKGBlockSig = pbBlock.GetHeader().GetDkgSignature()
+	copy(b.Header.logsBloom[:], pbBlock.GetHeader().GetLogsBloom())
 }
 
 // ConvertFromBlockPb converts BlockPb to Block
@@ -263,6 +318,16 @@ func (b *Block) ConvertFromBlockPb(pbBlock *iproto.BlockPb) error {
 	b.SecretProposals = []*action.SecretProposal{}
 	b.SecretWitness = nil
 	b.Actions = []action.Action{}
+	b.Footer = nil
+	if footerPb := pbBlock.GetFooter(); footerPb != nil {
+		b.Footer = &BlockFooter{commitTimestamp: footerPb.CommitTimestamp}
+		if setPb := footerPb.GetEndorsements(); setPb != nil {
+			b.Footer.endorsements = &endorsement.Set{}
+			if err := b.Footer.endorsements.FromProto(setPb); err != nil {
+				return err
+			}
+		}
+	}
 
 	for _, actPb := range pbBlock.Actions {
 		if secretProposalPb := actPb.GetSecretProposal(); secretProposalPb != nil {
@@ -277,54 +342,12 @@ func (b *Block) ConvertFromBlockPb(pbBlock *iproto.BlockPb) error {
 				return err
 			}
 			b.SecretWitness = secretWitness
-		} else if transferPb := actPb.GetTransfer(); transferPb != nil {
-			transfer := &action.Transfer{}
-			if err := transfer.LoadProto(actPb); err != nil {
+		} else {
+			act, err := action.LoadAction(actPb)
+			if err != nil {
 				return err
 			}
-			b.Actions = append(b.Actions, transfer)
-		} else if votePb := actPb.GetVote(); votePb != nil {
-			vote := &action.Vote{}
-			if err := vote.LoadProto(actPb); err != nil {
-				return err
-			}
-			b.Actions = append(b.Actions, vote)
-		} else if executionPb := actPb.GetExecution(); executionPb != nil {
-			execution := &action.Execution{}
-			if err := execution.LoadProto(actPb); err != nil {
-				return err
-			}
-			b.Actions = append(b.Actions, execution)
-		} else if startPb := actPb.GetStartSubChain(); startPb != nil {
-			start := &action.StartSubChain{}
-			if err := start.LoadProto(actPb); err != nil {
-				return err
-			}
-			b.Actions = append(b.Actions, start)
-		} else if stopPb := actPb.GetStopSubChain(); stopPb != nil {
-			stop := &action.StopSubChain{}
-			if err := stop.LoadProto(actPb); err != nil {
-				return err
-			}
-			b.Actions = append(b.Actions, stop)
-		} else if putPb := actPb.GetPutBlock(); putPb != nil {
-			put := &action.PutBlock{}
-			if err := put.LoadProto(actPb); err != nil {
-				return err
-			}
-			b.Actions = append(b.Actions, put)
-		} else if createDepositPb := actPb.GetCreateDeposit(); createDepositPb != nil {
-			createDeposit := &action.CreateDeposit{}
-			if err := createDeposit.LoadProto(actPb); err != nil {
-				return err
-			}
-			b.Actions = append(b.Actions, createDeposit)
-		} else if settleDepositPb := actPb.GetSettleDeposit(); settleDepositPb != nil {
-			settleDeposit := &action.SettleDeposit{}
-			if err := settleDeposit.LoadProto(actPb); err != nil {
-				return err
-			}
-			b.Actions = append(b.Actions, settleDeposit)
+			b.Actions = append(b.Actions, act)
 		}
 	}
 	return nil
@@ -350,16 +373,7 @@ func (b *Block) Deserialize(buf []byte) error {
 
 // CalculateTxRoot returns the Merkle root of all txs and actions in this block.
 func (b *Block) CalculateTxRoot() hash.Hash32B {
-	var h []hash.Hash32B
-	for _, sp := range b.SecretProposals {
-		h = append(h, sp.Hash())
-	}
-	if b.SecretWitness != nil {
-		h = append(h, b.SecretWitness.Hash())
-	}
-	for _, act := range b.Actions {
-		h = append(h, act.Hash())
-	}
+	h := b.txLeaves()
 	if len(h) == 0 {
 		return hash.ZeroHash32B
 	}
@@ -379,6 +393,14 @@ func (b *Block) VerifyStateRoot(root hash.Hash32B) error {
 	return nil
 }
 
+// VerifyLogsBloom verifies the logs bloom in header against the receipts of this block
+func (b *Block) VerifyLogsBloom() error {
+	if b.Header.logsBloom != NewLogsBloom(b.Receipts()) {
+		return errors.New("logs bloom does not match")
+	}
+	return nil
+}
+
 // SignBlock allows signer to sign the block b
 func (b *Block) SignBlock(signer *iotxaddress.Address) error {
 	if signer.PrivateKey == keypair.ZeroPrivateKey {
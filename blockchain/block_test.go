@@ -22,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
@@ -187,6 +188,10 @@ func TestConvertFromBlockPb(t *testing.T) {
 	}))
 
 	blk.Header.txRoot = blk.CalculateTxRoot()
+	endorsementSet := endorsement.NewSet(blk.HashBlock())
+	vote := endorsement.NewConsensusVote(blk.HashBlock(), blk.Height(), 0, endorsement.COMMIT)
+	require.NoError(t, endorsementSet.AddEndorsement(endorsement.NewEndorsement(vote, sender)))
+	blk.Footer = NewBlockFooter(endorsementSet, 123)
 
 	raw, err := blk.Serialize()
 	require.Nil(t, err)
@@ -194,6 +199,12 @@ func TestConvertFromBlockPb(t *testing.T) {
 	var newblk Block
 	err = newblk.Deserialize(raw)
 	require.Nil(t, err)
+	require.Equal(t, uint64(123), newblk.Footer.CommitTimestamp())
+	require.Equal(t, blk.HashBlock(), newblk.Footer.Endorsements().BlockHash())
+	require.Equal(t, 1, newblk.Footer.Endorsements().NumOfValidEndorsements(
+		map[endorsement.ConsensusVoteTopic]bool{endorsement.COMMIT: true},
+		[]string{sender.RawAddress},
+	))
 
 	blockBytes := blk.ByteStream()
 	require.True(t, len(blockBytes) > 0)
@@ -210,9 +221,45 @@ func TestConvertFromBlockPb(t *testing.T) {
 	require.Equal(t, blk.Header.stateRoot, blk.StateRoot())
 }
 
+func TestLogsBloomInHeader(t *testing.T) {
+	require := require.New(t)
+
+	producer := ta.Addrinfo["producer"]
+	blk := NewBlock(0, 1, hash.ZeroHash32B, testutil.TimestampNow(), producer.PublicKey, nil)
+	hashWithoutBloom := blk.HashBlock()
+	// the bloom of a block without any log is left out of the header
+	require.Nil(blk.ConvertToBlockHeaderPb().LogsBloom)
+	require.NoError(blk.VerifyLogsBloom())
+
+	receipt := &action.Receipt{Logs: []*action.Log{{Address: "contract", Topics: []hash.Hash32B{{1}}}}}
+	blk.Header.logsBloom = NewLogsBloom([]*action.Receipt{receipt})
+	require.NotEqual(hashWithoutBloom, blk.HashBlock())
+	require.NotNil(blk.ConvertToBlockHeaderPb().LogsBloom)
+	// the block doesn't have the receipt
+	require.Error(blk.VerifyLogsBloom())
+
+	raw, err := blk.Serialize()
+	require.NoError(err)
+	var newblk Block
+	require.NoError(newblk.Deserialize(raw))
+	require.Equal(blk.LogsBloom(), newblk.LogsBloom())
+	require.Equal(blk.HashBlock(), newblk.HashBlock())
+
+	// the logs bloom is verified from the activation height, and must be left out of the header below it
+	bc := &blockchain{config: config.Default}
+	bc.config.Chain.LogsBloomHeight = 2
+	require.Error(bc.verifyLogsBloom(blk))
+	bc.config.Chain.LogsBloomHeight = 1
+	require.Error(bc.verifyLogsBloom(blk))
+	blk.Header.logsBloom = ZeroLogsBloom
+	require.NoError(bc.verifyLogsBloom(blk))
+	bc.config.Chain.LogsBloomHeight = 2
+	require.NoError(bc.verifyLogsBloom(blk))
+}
+
 func TestWrongRootHash(t *testing.T) {
 	require := require.New(t)
-	val := validator{nil, ""}
+	val := validator{}
 	tsf1, err := action.NewTransfer(1, big.NewInt(20), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
 	require.NoError(err)
 	require.NoError(action.Sign(tsf1, ta.Addrinfo["producer"].PrivateKey))
@@ -231,7 +278,7 @@ func TestWrongRootHash(t *testing.T) {
 
 func TestSignBlock(t *testing.T) {
 	require := require.New(t)
-	val := validator{nil, ""}
+	val := validator{}
 	tsf1, err := action.NewTransfer(1, big.NewInt(20), ta.Addrinfo["producer"].RawAddress, ta.Addrinfo["alfa"].RawAddress, []byte{}, uint64(100000), big.NewInt(10))
 	require.NoError(err)
 	require.NoError(action.Sign(tsf1, ta.Addrinfo["producer"].PrivateKey))
@@ -256,7 +303,7 @@ func TestWrongNonce(t *testing.T) {
 	require.NoError(err)
 	require.NoError(sf.Start(context.Background()))
 	require.NoError(addCreatorToFactory(sf))
-	val := validator{sf, ""}
+	val := validator{sf: sf}
 
 	// correct nonce
 	coinbaseTsf := action.NewCoinBaseTransfer(1, Gen.BlockReward, ta.Addrinfo["producer"].RawAddress)
@@ -422,7 +469,7 @@ func TestWrongCoinbaseTsf(t *testing.T) {
 	require.NoError(err)
 	require.NoError(sf.Start(context.Background()))
 	require.NoError(addCreatorToFactory(sf))
-	val := validator{sf, ""}
+	val := validator{sf: sf}
 
 	// no coinbase tsf
 	coinbaseTsf := action.NewCoinBaseTransfer(1, Gen.BlockReward, ta.Addrinfo["producer"].RawAddress)
@@ -609,7 +656,7 @@ func TestValidateSecretBlock(t *testing.T) {
 	err = blk.SignBlock(ta.Addrinfo["producer"])
 	require.NoError(err)
 
-	val := validator{sf, delegates[1]}
+	val := validator{sf: sf, validatorAddr: delegates[1]}
 	require.NoError(val.Validate(blk, 2, hash, false))
 
 	// Falsify secret proposal
@@ -38,6 +38,8 @@ type Blockchain interface {
 
 	// Balance returns balance of an account
 	Balance(addr string) (*big.Int, error)
+	// BalanceByHeight returns balance of address at the given height
+	BalanceByHeight(addr string, height uint64) (*big.Int, error)
 	// Nonce returns the nonce if the account exists
 	Nonce(addr string) (uint64, error)
 	// CreateState adds a new account with initial balance to the factory
@@ -87,6 +89,9 @@ type Blockchain interface {
 	GetBlockHashByExecutionHash(h hash.Hash32B) (hash.Hash32B, error)
 	// GetReceiptByExecutionHash returns the receipt by execution hash
 	GetReceiptByExecutionHash(h hash.Hash32B) (*action.Receipt, error)
+	// GetLogs returns the logs matching the filter in the blocks from fromHeight to toHeight in order, at most limit of
+	// them if limit is positive
+	GetLogs(filter *LogFilter, fromHeight, toHeight, limit uint64) ([]*action.Log, error)
 	// GetActionsFromAddress returns actions from address
 	GetActionsFromAddress(address string) ([]hash.Hash32B, error)
 	// GetActionsToAddress returns actions to address
@@ -107,6 +112,8 @@ type Blockchain interface {
 	TipHeight() uint64
 	// StateByAddr returns account of a given address
 	StateByAddr(address string) (*state.Account, error)
+	// StateByAddrAndHeight returns account of a given address at the given height
+	StateByAddrAndHeight(address string, height uint64) (*state.Account, error)
 
 	// For block operations
 	// MintNewBlock creates a new block with given actions and dkg keys
@@ -121,7 +128,8 @@ type Blockchain interface {
 	// MintNewSecretBlock creates a new DKG secret block with given DKG secrets and witness
 	MintNewSecretBlock(secretProposals []*action.SecretProposal, secretWitness *action.SecretWitness,
 		producer *iotxaddress.Address) (*Block, error)
-	// CommitBlock validates and appends a block to the chain
+	// CommitBlock validates and appends a block to the chain. A block which doesn't extend the tip is validated against
+	// its parent and kept as a side block, and the chain switches to its branch once the branch becomes the longest
 	CommitBlock(blk *Block) error
 	// ValidateBlock validates a new block before adding it to the blockchain
 	ValidateBlock(blk *Block, containCoinbase bool) error
@@ -157,6 +165,7 @@ type blockchain struct {
 	clk           clock.Clock
 	blocklistener []BlockCreationSubscriber
 	timerFactory  *prometheustimer.TimerFactory
+	delegates     func(uint64) ([]string, error) // returns the delegates of the epoch of a height under RollDPoS
 
 	// used by account-based model
 	sf factory.Factory
@@ -215,10 +224,11 @@ func PrecreatedDaoOption(dao *blockDAO) Option {
 	}
 }
 
-// BoltDBDaoOption sets blockchain's dao with BoltDB from config.Chain.ChainDBPath
+// BoltDBDaoOption sets blockchain's dao with the on-disk DB (BoltDB unless config.DB.Engine says otherwise) from
+// config.Chain.ChainDBPath
 func BoltDBDaoOption() Option {
 	return func(bc *blockchain, cfg config.Config) error {
-		bc.dao = newBlockDAO(db.NewBoltDB(cfg.Chain.ChainDBPath, cfg.DB), cfg.Explorer.Enabled)
+		bc.dao = newBlockDAO(db.NewOnDiskDB(cfg.Chain.ChainDBPath, cfg.DB), cfg.Explorer.Enabled)
 
 		return nil
 	}
@@ -242,6 +252,18 @@ func ClockOption(clk clock.Clock) Option {
 	}
 }
 
+// DelegatesOption sets the function returning the delegates of the epoch of a height, which is built on the candidates
+// of the blockchain itself, e.g. evidence.NewDelegatesFunc. It's required to accept side blocks under RollDPoS
+func DelegatesOption(
+	newDelegates func(candidatesByHeight func(uint64) ([]*state.Candidate, error)) func(uint64) ([]string, error),
+) Option {
+	return func(bc *blockchain, conf config.Config) error {
+		bc.delegates = newDelegates(bc.CandidatesByHeight)
+
+		return nil
+	}
+}
+
 // NewBlockchain creates a new blockchain and DB instance
 func NewBlockchain(cfg config.Config, opts ...Option) Blockchain {
 	// create the Blockchain
@@ -278,7 +300,11 @@ func NewBlockchain(cfg config.Config, opts ...Option) Blockchain {
 		logger.Error().Err(err).Msg("Failed to get producer's address by public key")
 		return nil
 	}
-	chain.validator = &validator{sf: chain.sf, validatorAddr: address.IotxAddress()}
+	chain.validator = &validator{
+		sf:            chain.sf,
+		validatorAddr: address.IotxAddress(),
+		verifyFooter:  chain.verifyAggregateFooter,
+	}
 
 	if chain.dao != nil {
 		chain.lifecycle.Add(chain.dao)
@@ -332,6 +358,14 @@ func (bc *blockchain) Balance(addr string) (*big.Int, error) {
 	return bc.sf.Balance(addr)
 }
 
+// BalanceByHeight returns balance of address at the given height
+func (bc *blockchain) BalanceByHeight(addr string, height uint64) (*big.Int, error) {
+	if height > bc.TipHeight() {
+		return nil, errors.Wrapf(ErrInvalidTipHeight, "height %d is higher than the tip", height)
+	}
+	return bc.sf.BalanceByHeight(addr, height)
+}
+
 // Nonce returns the nonce if the account exists
 func (bc *blockchain) Nonce(addr string) (uint64, error) {
 	return bc.sf.Nonce(addr)
@@ -540,6 +574,52 @@ func (bc *blockchain) GetReceiptByExecutionHash(h hash.Hash32B) (*action.Receipt
 	return bc.dao.getReceiptByExecutionHash(h)
 }
 
+// GetLogs returns the logs matching the filter in the blocks from fromHeight to toHeight in order, at most limit of
+// them if limit is positive. The logs are looked up in the log index if it's written and the filter has any address
+// or topic, otherwise the blocks are scanned, skipping the ones whose logs bloom doesn't match the filter from the
+// height the logs bloom is activated
+func (bc *blockchain) GetLogs(filter *LogFilter, fromHeight, toHeight, limit uint64) ([]*action.Log, error) {
+	if fromHeight > toHeight {
+		return nil, errors.Errorf("from height %d is higher than to height %d", fromHeight, toHeight)
+	}
+	if tipHeight := bc.TipHeight(); toHeight > tipHeight {
+		toHeight = tipHeight
+	}
+	if bc.config.Explorer.Enabled && filter.isIndexable() {
+		return bc.dao.getLogs(filter, fromHeight, toHeight, limit)
+	}
+	var logs []*action.Log
+	for height := fromHeight; height <= toHeight; height++ {
+		blk, err := bc.GetBlockByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		bloom := blk.LogsBloom()
+		if height >= bc.config.Chain.LogsBloomHeight && !filter.MatchBloom(&bloom) {
+			continue
+		}
+		for _, act := range blk.Actions {
+			if _, ok := act.(*action.Execution); !ok {
+				continue
+			}
+			receipt, err := bc.dao.getReceiptByExecutionHash(act.Hash())
+			if err != nil {
+				return nil, err
+			}
+			for _, log := range receipt.Logs {
+				if !filter.Match(log) {
+					continue
+				}
+				logs = append(logs, log)
+				if limit > 0 && uint64(len(logs)) >= limit {
+					return logs, nil
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
 // GetActionsFromAddress returns actions from address
 func (bc *blockchain) GetActionsFromAddress(address string) ([]hash.Hash32B, error) {
 	if !bc.config.Explorer.Enabled {
@@ -645,6 +725,9 @@ func (bc *blockchain) MintNewBlock(
 		return nil, errors.Wrapf(err, "Failed to update state changes in new block %d", blk.Height())
 	}
 	blk.Header.stateRoot = root
+	if blk.Height() >= bc.config.Chain.LogsBloomHeight {
+		blk.Header.logsBloom = NewLogsBloom(blk.Receipts())
+	}
 	if err := blk.SignBlock(producer); err != nil {
 		return blk, err
 	}
@@ -695,7 +778,22 @@ func (bc *blockchain) CommitBlock(blk *Block) error {
 	defer bc.mu.Unlock()
 	defer bc.timerFactory.NewTimer("CommitBlock").End()
 
-	return bc.commitBlock(blk)
+	if isSideBlock(blk, bc.tipHash) {
+		return bc.commitSideBlock(blk)
+	}
+	if blk.workingSet == nil && bc.sf != nil {
+		// the block has not been validated against the tip, e.g., the tip moved onto its parent after it was received
+		if err := bc.validateBlock(blk, true); err != nil {
+			return err
+		}
+	}
+	if err := bc.commitBlock(blk); err != nil {
+		return err
+	}
+	if err := bc.pruneSideBlocks(); err != nil {
+		logger.Error().Err(err).Uint64("height", blk.Height()).Msg("Failed to prune side blocks")
+	}
+	return nil
 }
 
 // StateByAddr returns the account of an address
@@ -704,13 +802,28 @@ func (bc *blockchain) StateByAddr(address string) (*state.Account, error) {
 		s, err := bc.sf.AccountState(address)
 		if err != nil {
 			logger.Warn().Err(err).Str("Address", address)
-			return nil, errors.New("account does not exist")
+			return nil, errors.Wrap(err, "account does not exist")
 		}
 		return s, nil
 	}
 	return nil, errors.New("state factory is nil")
 }
 
+// StateByAddrAndHeight returns the account of an address at the given height
+func (bc *blockchain) StateByAddrAndHeight(address string, height uint64) (*state.Account, error) {
+	if bc.sf == nil {
+		return nil, errors.New("state factory is nil")
+	}
+	if height > bc.TipHeight() {
+		return nil, errors.Wrapf(ErrInvalidTipHeight, "height %d is higher than the tip", height)
+	}
+	s, err := bc.sf.AccountStateByHeight(address, height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the account of %s at height %d", address, height)
+	}
+	return s, nil
+}
+
 // SetValidator sets the current validator object
 func (bc *blockchain) SetValidator(val Validator) {
 	bc.mu.Lock()
@@ -778,7 +891,7 @@ func (bc *blockchain) ExecuteContractRead(ex *action.Execution) (*action.Receipt
 	exHash := ex.Hash()
 	receipt, ok := blk.receipts[exHash]
 	if !ok {
-		return nil, errors.Wrap(err, "failed to get receipt in ExecuteContractRead")
+		return nil, errors.Errorf("failed to get receipt of execution %x in ExecuteContractRead", exHash)
 	}
 	return receipt, nil
 }
@@ -937,6 +1050,53 @@ func (bc *blockchain) startExistingBlockchain(recoveryHeight uint64) error {
 	return nil
 }
 
+// verifyAggregateFooter verifies the aggregate endorsements in the footer of a block under RollDPoS, which have to
+// commit the block. A block without footer, e.g., a proposal, or with the plain endorsements passes
+func (bc *blockchain) verifyAggregateFooter(blk *Block) error {
+	if bc.config.Consensus.Scheme != config.RollDPoSScheme {
+		return nil
+	}
+	if blk.Footer == nil || blk.Footer.Endorsements() == nil || !blk.Footer.Endorsements().IsAggregate() {
+		return nil
+	}
+	if bc.delegates == nil {
+		return errors.Wrap(ErrInvalidBlock, "no delegates to verify the aggregate endorsements against")
+	}
+	delegates, err := bc.delegates(blk.Height())
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the delegates of block %d", blk.Height())
+	}
+	return bc.verifyCommit(blk, delegates)
+}
+
+// dkgPubkeys collects the DKG public keys the producers put into the headers of a block and the blocks of an epoch
+// below it. As the delegates generate new keys every epoch, the newer key of a producer overrides the older one
+func (bc *blockchain) dkgPubkeys(blk *Block) map[string][]byte {
+	rolldpos := bc.config.Consensus.RollDPoS
+	numSubEpochs := uint64(1)
+	if rolldpos.NumSubEpochs > 0 {
+		numSubEpochs = uint64(rolldpos.NumSubEpochs)
+	}
+	if rolldpos.EnableDKG {
+		numSubEpochs++
+	}
+	epochLen := uint64(rolldpos.NumDelegates) * numSubEpochs
+	pubkeys := make(map[string][]byte)
+	if len(blk.Header.DKGPubkey) > 0 {
+		pubkeys[blk.ProducerAddress()] = blk.Header.DKGPubkey
+	}
+	for h := blk.Height() - 1; h > 0 && h+epochLen >= blk.Height(); h-- {
+		prev, err := bc.getBlockByHeight(h)
+		if err != nil {
+			break
+		}
+		if _, ok := pubkeys[prev.ProducerAddress()]; !ok && len(prev.Header.DKGPubkey) > 0 {
+			pubkeys[prev.ProducerAddress()] = prev.Header.DKGPubkey
+		}
+	}
+	return pubkeys
+}
+
 func (bc *blockchain) validateBlock(blk *Block, containCoinbase bool) error {
 	if bc.validator == nil {
 		logger.Panic().Msg("no block validator")
@@ -959,11 +1119,26 @@ func (bc *blockchain) validateBlock(blk *Block, containCoinbase bool) error {
 	if err != nil {
 		logger.Panic().Err(err).Msgf("Failed to update state on height %d", bc.tipHeight)
 	}
+	if err := bc.verifyLogsBloom(blk); err != nil {
+		return errors.Wrapf(err, "Failed to validate block on height %d", blk.Height())
+	}
 	// attach working set to be committed to state factory
 	blk.workingSet = ws
 	return nil
 }
 
+// verifyLogsBloom verifies the logs bloom of a block from the height the logs bloom is activated. Below it, the block
+// must not carry a logs bloom, so that the block hash stays the same as before the activation
+func (bc *blockchain) verifyLogsBloom(blk *Block) error {
+	if blk.Height() >= bc.config.Chain.LogsBloomHeight {
+		return blk.VerifyLogsBloom()
+	}
+	if blk.LogsBloom() != ZeroLogsBloom {
+		return errors.Errorf("logs bloom is not activated until height %d", bc.config.Chain.LogsBloomHeight)
+	}
+	return nil
+}
+
 // commitBlock commits a block to the chain
 func (bc *blockchain) commitBlock(blk *Block) error {
 	// write block into DB
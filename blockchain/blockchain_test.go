@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/facebookgo/clock"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	_hash "github.com/iotexproject/iotex-core/pkg/hash"
@@ -835,7 +837,7 @@ func TestActions(t *testing.T) {
 	require.NoError(err)
 	require.NoError(sf.Commit(ws))
 
-	val := validator{sf, ""}
+	val := validator{sf: sf}
 	acts := []action.Action{}
 	for i := 0; i < 5000; i++ {
 		tsf, err := action.NewTransfer(1, big.NewInt(2), a.RawAddress, c.RawAddress, []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
@@ -941,3 +943,174 @@ func TestMintDKGBlock(t *testing.T) {
 	require.NoError(err)
 	require.Equal(21, len(candidates))
 }
+
+type reorgSubscriber struct {
+	reorgs chan *Reorg
+}
+
+func (s *reorgSubscriber) HandleBlock(*Block) error { return nil }
+
+func (s *reorgSubscriber) HandleReorg(reorg *Reorg) error {
+	s.reorgs <- reorg
+	return nil
+}
+
+func TestReorg(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Explorer.Enabled = true
+	newChain := func() Blockchain {
+		sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+		require.NoError(err)
+		require.NoError(sf.Start(context.Background()))
+		require.NoError(addCreatorToFactory(sf))
+		bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption())
+		require.NotNil(bc)
+		require.NoError(bc.Start(context.Background()))
+		return bc
+	}
+	mint := func(bc Blockchain, acts ...action.Action) *Block {
+		blk, err := bc.MintNewBlock(acts, ta.Addrinfo["producer"], nil, nil, "")
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk, true))
+		require.NoError(bc.CommitBlock(blk))
+		return blk
+	}
+	transfer := func(nonce uint64, recipient string, amount int64) action.Action {
+		tsf, err := testutil.SignedTransfer(ta.Addrinfo["producer"], ta.Addrinfo[recipient], nonce, big.NewInt(amount),
+			[]byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+		require.NoError(err)
+		return tsf
+	}
+
+	chain := newChain()
+	defer func() {
+		require.NoError(chain.Stop(context.Background()))
+	}()
+	fork := newChain()
+	defer func() {
+		require.NoError(fork.Stop(context.Background()))
+	}()
+	sub := &reorgSubscriber{reorgs: make(chan *Reorg, 1)}
+	require.NoError(chain.AddSubscriber(sub))
+
+	// both chains share block 1, then chain has 1 <- 2, and fork has 1 <- 2' <- 3'
+	blk1 := mint(chain, transfer(1, "alfa", 10))
+	require.NoError(fork.ValidateBlock(blk1, true))
+	require.NoError(fork.CommitBlock(blk1))
+	orphan := transfer(2, "bravo", 20)
+	blk2 := mint(chain, orphan)
+	blk2b := mint(fork, transfer(2, "charlie", 30))
+	blk3b := mint(fork, transfer(3, "charlie", 40))
+
+	// a block with unknown parent is rejected
+	require.Error(chain.CommitBlock(blk3b))
+	// a block on another branch doesn't pass the validation against the tip, but is kept as a side block
+	require.Error(chain.ValidateBlock(blk2b, true))
+	require.NoError(chain.CommitBlock(blk2b))
+	require.Equal(uint64(2), chain.TipHeight())
+	require.Equal(blk2.HashBlock(), chain.TipHash())
+
+	// the chain switches to the longer branch
+	require.NoError(chain.CommitBlock(blk3b))
+	require.Equal(uint64(3), chain.TipHeight())
+	require.Equal(blk3b.HashBlock(), chain.TipHash())
+	h, err := chain.GetHashByHeight(2)
+	require.NoError(err)
+	require.Equal(blk2b.HashBlock(), h)
+	require.Equal(fork.GetFactory().RootHash(), chain.GetFactory().RootHash())
+	_, err = chain.GetBlockHashByTransferHash(orphan.Hash())
+	require.Error(err)
+	total, err := chain.GetTotalTransfers()
+	require.NoError(err)
+	forkTotal, err := fork.GetTotalTransfers()
+	require.NoError(err)
+	require.Equal(forkTotal, total)
+
+	select {
+	case reorg := <-sub.reorgs:
+		require.Equal(uint64(1), reorg.ForkHeight)
+		require.Equal(1, len(reorg.Orphaned))
+		require.Equal(blk2.HashBlock(), reorg.Orphaned[0].HashBlock())
+		require.Equal(2, len(reorg.Applied))
+		acts := reorg.OrphanedActions()
+		require.Equal(1, len(acts))
+		require.Equal(orphan.Hash(), acts[0].Hash())
+	case <-time.After(time.Second):
+		require.Fail("reorg is not notified")
+	}
+
+	// a longer branch with an invalid block is dropped, and the chain stays on its branch
+	rootHash := chain.GetFactory().RootHash()
+	producer := ta.Addrinfo["producer"]
+	blk3a := NewBlock(cfg.Chain.ID, 3, blk2.HashBlock(), testutil.TimestampNow(), producer.PublicKey,
+		[]action.Action{action.NewCoinBaseTransfer(3, Gen.BlockReward, producer.RawAddress)})
+	require.NoError(blk3a.SignBlock(producer))
+	blk4a := NewBlock(cfg.Chain.ID, 4, blk3a.HashBlock(), testutil.TimestampNow(), producer.PublicKey,
+		[]action.Action{action.NewCoinBaseTransfer(4, Gen.BlockReward, producer.RawAddress)})
+	require.NoError(blk4a.SignBlock(producer))
+	require.NoError(chain.CommitBlock(blk3a))
+	require.Error(chain.CommitBlock(blk4a))
+	require.Equal(uint64(3), chain.TipHeight())
+	require.Equal(blk3b.HashBlock(), chain.TipHash())
+	require.Equal(rootHash, chain.GetFactory().RootHash())
+	h, err = chain.GetHashByHeight(2)
+	require.NoError(err)
+	require.Equal(blk2b.HashBlock(), h)
+	_, err = chain.(*blockchain).dao.getSideBlock(blk4a.HashBlock())
+	require.Error(err)
+	// the original block 2 is still kept
+	_, err = chain.(*blockchain).dao.getSideBlock(blk2.HashBlock())
+	require.NoError(err)
+
+	newSideBlock := func(height uint64, prevHash hash.Hash32B) *Block {
+		blk := NewBlock(cfg.Chain.ID, height, prevHash, testutil.TimestampNow(), producer.PublicKey,
+			[]action.Action{action.NewCoinBaseTransfer(height, Gen.BlockReward, producer.RawAddress)})
+		require.NoError(blk.SignBlock(producer))
+		return blk
+	}
+	bc := chain.(*blockchain)
+	// under RollDPoS, a side block is accepted only if its producer is the scheduled proposer, and its footer carries
+	// the endorsements of a quorum of the delegates
+	bc.config.Consensus.Scheme = config.RollDPoSScheme
+	bc.config.Consensus.RollDPoS.NumDelegates = 2
+	require.Error(chain.CommitBlock(newSideBlock(2, blk1.HashBlock())))
+	delegates := []string{ta.Addrinfo["alfa"].RawAddress, ta.Addrinfo["producer"].RawAddress}
+	bc.delegates = func(uint64) ([]string, error) { return delegates, nil }
+	require.Error(chain.CommitBlock(newSideBlock(2, blk1.HashBlock())))
+	delegates[0], delegates[1] = delegates[1], delegates[0]
+	blk2d := newSideBlock(2, blk1.HashBlock())
+	require.Error(chain.CommitBlock(blk2d))
+	set := endorsement.NewSet(blk2d.HashBlock())
+	lock := endorsement.NewConsensusVote(blk2d.HashBlock(), 2, 0, endorsement.LOCK)
+	require.NoError(set.AddEndorsement(endorsement.NewEndorsement(lock, ta.Addrinfo["producer"])))
+	blk2d.Footer = NewBlockFooter(set, testutil.TimestampNow())
+	require.Error(chain.CommitBlock(blk2d))
+	require.NoError(set.AddEndorsement(endorsement.NewEndorsement(lock, ta.Addrinfo["alfa"])))
+	require.NoError(chain.CommitBlock(blk2d))
+	bc.config.Consensus.Scheme = config.NOOPScheme
+	bc.delegates = nil
+	// a side block out of the height window is rejected
+	bc.config.Chain.SideBlocks.HeightWindow = 1
+	genesisHash, err := chain.GetHashByHeight(0)
+	require.NoError(err)
+	require.Error(chain.CommitBlock(newSideBlock(1, genesisHash)))
+	// no more side blocks are kept beyond the max number
+	count, err := bc.dao.countSideBlocks()
+	require.NoError(err)
+	bc.config.Chain.SideBlocks.MaxNum = count
+	require.Error(chain.CommitBlock(newSideBlock(2, blk1.HashBlock())))
+	bc.config.Chain.SideBlocks.MaxNum = count + 1
+	blk2c := newSideBlock(2, blk1.HashBlock())
+	require.NoError(chain.CommitBlock(blk2c))
+	// the side blocks falling below the height window are deleted as the tip grows
+	mint(chain)
+	for _, blk := range []*Block{blk2, blk2c} {
+		_, err = bc.dao.getSideBlock(blk.HashBlock())
+		require.Error(err)
+	}
+	count, err = bc.dao.countSideBlocks()
+	require.NoError(err)
+	require.Equal(uint64(0), count)
+}
@@ -6,7 +6,53 @@
 
 package blockchain
 
+import (
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
 // BlockCreationSubscriber is an interface which will get notified when a block is created
 type BlockCreationSubscriber interface {
 	HandleBlock(*Block) error
 }
+
+// ReorgSubscriber is an interface which will get notified when the chain switches to another branch. A
+// BlockCreationSubscriber added to the chain gets notified if it also implements this interface. HandleBlock() is
+// called on the blocks of the new branch as well
+type ReorgSubscriber interface {
+	HandleReorg(*Reorg) error
+}
+
+// Reorg describes a switch of the chain to another branch
+type Reorg struct {
+	// ForkHeight is the height of the last block shared by both branches
+	ForkHeight uint64
+	// Orphaned are the blocks removed from the chain, in the ascending order of height
+	Orphaned []*Block
+	// Applied are the blocks of the new branch, in the ascending order of height
+	Applied []*Block
+}
+
+// OrphanedActions returns the actions in the orphaned blocks which are not in the new branch, so that they could be
+// put back into the action pool. The coinbase transfers are excluded
+func (r *Reorg) OrphanedActions() []action.Action {
+	applied := make(map[hash.Hash32B]struct{})
+	for _, blk := range r.Applied {
+		for _, act := range blk.Actions {
+			applied[act.Hash()] = struct{}{}
+		}
+	}
+	var orphaned []action.Action
+	for _, blk := range r.Orphaned {
+		for _, act := range blk.Actions {
+			if tsf, ok := act.(*action.Transfer); ok && tsf.IsCoinbase() {
+				continue
+			}
+			if _, ok := applied[act.Hash()]; ok {
+				continue
+			}
+			orphaned = append(orphaned, act)
+		}
+	}
+	return orphaned
+}
@@ -8,11 +8,17 @@ package blockchain
 
 import (
 	"context"
+	"encoding/binary"
+	"math"
+	"sort"
+	"sync"
 
+	"github.com/boltdb/bolt"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
@@ -21,6 +27,8 @@ import (
 
 const (
 	blockNS                             = "blocks"
+	blockSideNS                         = "side-blocks"
+	blockSideHeightMappingNS            = "height<->side-block"
 	blockHashHeightMappingNS            = "hash<->height"
 	blockTransferBlockMappingNS         = "transfer<->block"
 	blockVoteBlockMappingNS             = "vote<->block"
@@ -35,6 +43,8 @@ const (
 	blockAddressExecutionCountMappingNS = "address<->executioncount"
 	blockAddressActionMappingNS         = "address<->action"
 	blockAddressActionCountMappingNS    = "address<->actioncount"
+	blockAddressLogMappingNS            = "address<->log"
+	blockTopicLogMappingNS              = "topic<->log"
 )
 
 var (
@@ -59,14 +69,25 @@ var (
 	executionToPrefix   = []byte("execution-to")
 	actionFromPrefix    = []byte("action-from")
 	actionToPrefix      = []byte("action-to")
+	logAddressPrefix    = []byte("log-address.")
+	logTopicPrefix      = []byte("log-topic.")
+	// logIndexHeightKey is the height up to which the logs of the blocks written before the log index are backfilled,
+	// which is math.MaxUint64 once all of them are
+	logIndexHeightKey = []byte("log-index-height")
 )
 
+// logBackfillBatchSize is the number of blocks whose logs are backfilled in a batch
+const logBackfillBatchSize = 100
+
 var _ lifecycle.StartStopper = (*blockDAO)(nil)
 
 type blockDAO struct {
 	writeIndex bool
 	kvstore    db.KVStore
 	lifecycle  lifecycle.Lifecycle
+	// stop and wg stop the log backfill running in background
+	stop chan struct{}
+	wg   sync.WaitGroup
 }
 
 // newBlockDAO instantiates a block DAO
@@ -90,7 +111,7 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 	if err := dao.kvstore.PutIfNotExists(blockNS, topHeightKey, make([]byte, 8)); err != nil {
 		// ok on none-fresh db
 		if err == db.ErrAlreadyExist {
-			return nil
+			return dao.startBackfillLogs()
 		}
 
 		return errors.Wrap(err, "failed to write initial value for top height")
@@ -116,11 +137,95 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 		return errors.Wrap(err, "failed to write initial value for total actions")
 	}
 
+	return dao.startBackfillLogs()
+}
+
+// startBackfillLogs backfills the logs in background, so that a long backfill doesn't hold up the start
+func (dao *blockDAO) startBackfillLogs() error {
+	if !dao.writeIndex {
+		// the logs of the blocks written from now on are not indexed, so they need to be backfilled again
+		return dao.kvstore.Delete(blockNS, logIndexHeightKey)
+	}
+	dao.stop = make(chan struct{})
+	dao.wg.Add(1)
+	go func() {
+		defer dao.wg.Done()
+		if err := dao.backfillLogs(dao.stop); err != nil {
+			logger.Error().Err(err).Msg("Failed to backfill the log index")
+		}
+	}()
 	return nil
 }
 
+// backfillLogs indexes the logs of the blocks written before the log index, or while the index is not written, up to
+// the tip height on start, as the blocks written since are indexed on writing. The blocks are indexed in batches, along
+// with the progress, so that it's resumed on the next start if interrupted. It returns early once stop is closed
+func (dao *blockDAO) backfillLogs(stop <-chan struct{}) error {
+	var startHeight uint64 = 1
+	value, err := dao.kvstore.Get(blockNS, logIndexHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		height := byteutil.BytesToUint64(value)
+		if height == math.MaxUint64 {
+			return nil
+		}
+		startHeight = height + 1
+	case db.ErrNotExist, bolt.ErrBucketNotFound:
+	default:
+		return errors.Wrap(err, "failed to get the height of the log index")
+	}
+	tipHeight, err := dao.getBlockchainHeight()
+	if err != nil {
+		return err
+	}
+	if startHeight <= tipHeight {
+		logger.Info().Uint64("from", startHeight).Uint64("to", tipHeight).Msg("Backfilling the log index")
+	}
+	for start := startHeight; start <= tipHeight; start += logBackfillBatchSize {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+		end := start + logBackfillBatchSize - 1
+		if end > tipHeight {
+			end = tipHeight
+		}
+		batch := db.NewBatch()
+		for height := start; height <= end; height++ {
+			blkHash, err := dao.getBlockHash(height)
+			if err != nil {
+				return err
+			}
+			blk, err := dao.getBlock(blkHash)
+			if err != nil {
+				return err
+			}
+			receipts, err := dao.getBlockReceipts(blk)
+			if err != nil {
+				return err
+			}
+			blk.SetReceipts(receipts)
+			putLogs(blk, batch)
+		}
+		batch.Put(blockNS, logIndexHeightKey, byteutil.Uint64ToBytes(end), "failed to put the height of the log index")
+		if err := dao.kvstore.Commit(batch); err != nil {
+			return errors.Wrapf(err, "failed to backfill the logs from height %d to %d", start, end)
+		}
+	}
+	logger.Info().Uint64("height", tipHeight).Msg("Backfilled the log index")
+	return dao.kvstore.Put(blockNS, logIndexHeightKey, byteutil.Uint64ToBytes(math.MaxUint64))
+}
+
 // Stop stops block DAO.
-func (dao *blockDAO) Stop(ctx context.Context) error { return dao.lifecycle.OnStop(ctx) }
+func (dao *blockDAO) Stop(ctx context.Context) error {
+	if dao.stop != nil {
+		close(dao.stop)
+		dao.wg.Wait()
+		dao.stop = nil
+	}
+	return dao.lifecycle.OnStop(ctx)
+}
 
 // getBlockHash returns the block hash by height
 func (dao *blockDAO) getBlockHash(height uint64) (hash.Hash32B, error) {
@@ -166,6 +271,19 @@ func (dao *blockDAO) getBlock(hash hash.Hash32B) (*Block, error) {
 	return &blk, nil
 }
 
+// getSideBlock returns a block which is not on the canonical chain
+func (dao *blockDAO) getSideBlock(hash hash.Hash32B) (*Block, error) {
+	value, err := dao.kvstore.Get(blockSideNS, hash[:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get side block %x", hash)
+	}
+	blk := Block{}
+	if err = blk.Deserialize(value); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize side block")
+	}
+	return &blk, nil
+}
+
 func (dao *blockDAO) getBlockHashByTransferHash(h hash.Hash32B) (hash.Hash32B, error) {
 	blkHash := hash.ZeroHash32B
 	key := append(transferPrefix, h[:]...)
@@ -1005,9 +1123,81 @@ func (dao *blockDAO) putReceipts(blk *Block) error {
 		}
 		batch.Put(blockExecutionReceiptMappingNS, r.Hash[:], v[:], "failed to put receipt for execution %x", r.Hash[:])
 	}
+	if dao.writeIndex {
+		putLogs(blk, batch)
+	}
+	return dao.kvstore.Commit(batch)
+}
+
+// putSideBlock puts a block which is not on the canonical chain. The side blocks are also indexed by height, so that
+// they could be counted and deleted by height
+func (dao *blockDAO) putSideBlock(blk *Block) error {
+	serialized, err := blk.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize block")
+	}
+	hash := blk.HashBlock()
+	batch := db.NewBatch()
+	batch.Put(blockSideNS, hash[:], serialized, "failed to put side block %x", hash)
+	batch.Put(blockSideHeightMappingNS, sideBlockKey(blk.Height(), hash), hash[:],
+		"failed to put height of side block %x", hash)
+	return dao.kvstore.Commit(batch)
+}
+
+// deleteSideBlock deletes a block which is not on the canonical chain
+func (dao *blockDAO) deleteSideBlock(blk *Block) error {
+	hash := blk.HashBlock()
+	batch := db.NewBatch()
+	batch.Delete(blockSideNS, hash[:], "failed to delete side block %x", hash)
+	batch.Delete(blockSideHeightMappingNS, sideBlockKey(blk.Height(), hash),
+		"failed to delete height of side block %x", hash)
+	return dao.kvstore.Commit(batch)
+}
+
+// countSideBlocks returns the number of the side blocks
+func (dao *blockDAO) countSideBlocks() (uint64, error) {
+	iter, err := dao.kvstore.Iterate(blockSideHeightMappingNS, db.Range{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to iterate side blocks")
+	}
+	var count uint64
+	for iter.Next() {
+		count++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, errors.Wrap(err, "failed to iterate side blocks")
+	}
+	return count, nil
+}
+
+// deleteSideBlocksBelow deletes the side blocks lower than height
+func (dao *blockDAO) deleteSideBlocksBelow(height uint64) error {
+	iter, err := dao.kvstore.Iterate(blockSideHeightMappingNS, db.Range{Limit: sideBlockKey(height, hash.ZeroHash32B)})
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate side blocks")
+	}
+	batch := db.NewBatch()
+	for iter.Next() {
+		batch.Delete(blockSideNS, iter.Value(), "failed to delete side block %x", iter.Value())
+		batch.Delete(blockSideHeightMappingNS, iter.Key(), "failed to delete height of side block %x", iter.Value())
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "failed to iterate side blocks")
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
 	return dao.kvstore.Commit(batch)
 }
 
+// sideBlockKey returns the key of a side block in the height index, which is made of the height in big endian and the
+// hash, so that the side blocks are visited in the order of height
+func sideBlockKey(height uint64, hash hash.Hash32B) []byte {
+	key := make([]byte, 8, 8+len(hash))
+	binary.BigEndian.PutUint64(key, height)
+	return append(key, hash[:]...)
+}
+
 // deleteBlock deletes the tip block
 func (dao *blockDAO) deleteTipBlock() error {
 	batch := db.NewBatch()
@@ -1046,6 +1236,11 @@ func (dao *blockDAO) deleteTipBlock() error {
 	topHeightValue := byteutil.Uint64ToBytes(topHeight)
 	batch.Put(blockNS, topHeightKey, topHeightValue, "failed to put top height")
 
+	// Receipts are written regardless of the index
+	if err = deleteReceipts(blk, batch); err != nil {
+		return err
+	}
+
 	if !dao.writeIndex {
 		return dao.kvstore.Commit(batch)
 	}
@@ -1082,6 +1277,16 @@ func (dao *blockDAO) deleteTipBlock() error {
 	totalExecutionsBytes := byteutil.Uint64ToBytes(totalExecutions)
 	batch.Put(blockNS, totalExecutionsKey, totalExecutionsBytes, "failed to put total executions")
 
+	// Update total action count
+	value, err = dao.kvstore.Get(blockNS, totalActionsKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get total actions")
+	}
+	totalActions := enc.MachineEndian.Uint64(value)
+	totalActions -= uint64(len(blk.Actions) - len(transfers) - len(votes) - len(executions))
+	totalActionsBytes := byteutil.Uint64ToBytes(totalActions)
+	batch.Put(blockNS, totalActionsKey, totalActionsBytes, "failed to put total actions")
+
 	// Delete transfer hash -> block hash mapping
 	for _, transfer := range transfers {
 		transferHash := transfer.Hash()
@@ -1103,6 +1308,17 @@ func (dao *blockDAO) deleteTipBlock() error {
 		batch.Delete(blockExecutionBlockMappingNS, hashKey, "failed to delete execution hash %x", executionHash)
 	}
 
+	// Delete action hash -> block hash mapping
+	for _, act := range blk.Actions {
+		switch act.(type) {
+		case *action.Transfer, *action.Vote, *action.Execution:
+			continue
+		}
+		actHash := act.Hash()
+		hashKey := append(actionPrefix, actHash[:]...)
+		batch.Delete(blockActionBlockMappingNS, hashKey, "failed to delete action hash %x", actHash)
+	}
+
 	if err = deleteTransfers(dao, blk, batch); err != nil {
 		return err
 	}
@@ -1115,7 +1331,11 @@ func (dao *blockDAO) deleteTipBlock() error {
 		return err
 	}
 
-	if err = deleteReceipts(blk, batch); err != nil {
+	if err = deleteActions(dao, blk, batch); err != nil {
+		return err
+	}
+
+	if err = deleteLogs(dao, blk, batch); err != nil {
 		return err
 	}
 
@@ -1332,12 +1552,253 @@ func deleteExecutions(dao *blockDAO, blk *Block, batch db.KVStoreBatch) error {
 	return nil
 }
 
+// deleteActions deletes action information from db
+func deleteActions(dao *blockDAO, blk *Block, batch db.KVStoreBatch) error {
+	var actions []action.Action
+	for _, act := range blk.Actions {
+		// we only process the actions that are not transfer, vote or execution, the same as putActions
+		switch act.(type) {
+		case *action.Transfer, *action.Vote, *action.Execution:
+			continue
+		}
+		actions = append(actions, act)
+	}
+	// First get the total count of actions by sender and recipient respectively in the block
+	senderCount := make(map[string]uint64)
+	recipientCount := make(map[string]uint64)
+	for _, act := range actions {
+		senderCount[act.SrcAddr()]++
+		recipientCount[act.DstAddr()]++
+	}
+	// Roll back the status of address -> actionCount mapping to the previous block
+	for sender, count := range senderCount {
+		senderActionCount, err := dao.getActionCountBySenderAddress(sender)
+		if err != nil {
+			return errors.Wrapf(err, "for sender %s", sender)
+		}
+		senderActionCountKey := append(actionFromPrefix, sender...)
+		senderCount[sender] = senderActionCount - count
+		batch.Put(blockAddressActionCountMappingNS, senderActionCountKey,
+			byteutil.Uint64ToBytes(senderCount[sender]), "failed to update action count for sender %s", sender)
+	}
+	for recipient, count := range recipientCount {
+		recipientActionCount, err := dao.getActionCountByRecipientAddress(recipient)
+		if err != nil {
+			return errors.Wrapf(err, "for recipient %s", recipient)
+		}
+		recipientActionCountKey := append(actionToPrefix, recipient...)
+		recipientCount[recipient] = recipientActionCount - count
+		batch.Put(blockAddressActionCountMappingNS, recipientActionCountKey,
+			byteutil.Uint64ToBytes(recipientCount[recipient]), "failed to update action count for recipient %s", recipient)
+	}
+
+	// The actions of an address in the block take the slots from the rolled back count
+	for _, act := range actions {
+		actHash := act.Hash()
+
+		senderKey := append(actionFromPrefix, act.SrcAddr()...)
+		senderKey = append(senderKey, byteutil.Uint64ToBytes(senderCount[act.SrcAddr()])...)
+		batch.Delete(blockAddressActionMappingNS, senderKey, "failed to delete action hash %x for sender %s",
+			actHash, act.SrcAddr())
+		senderCount[act.SrcAddr()]++
+
+		recipientKey := append(actionToPrefix, act.DstAddr()...)
+		recipientKey = append(recipientKey, byteutil.Uint64ToBytes(recipientCount[act.DstAddr()])...)
+		batch.Delete(blockAddressActionMappingNS, recipientKey, "failed to delete action hash %x for recipient %s",
+			actHash, act.DstAddr())
+		recipientCount[act.DstAddr()]++
+	}
+
+	return nil
+}
+
 // deleteReceipts deletes receipt information from db
 func deleteReceipts(blk *Block, batch db.KVStoreBatch) error {
-	for _, r := range blk.receipts {
-		batch.Delete(blockExecutionReceiptMappingNS, r.Hash[:], "failed to delete receipt for execution %x", r.Hash[:])
+	// the receipts are not attached to the block read from db, so the action hashes are used instead
+	for _, act := range blk.Actions {
+		actHash := act.Hash()
+		batch.Delete(blockExecutionReceiptMappingNS, actHash[:], "failed to delete receipt for action %x", actHash)
+	}
+	return nil
+}
+
+// getLogs returns the logs matching the filter in the blocks from fromHeight to toHeight in order, at most limit of
+// them if limit is positive. The candidates are looked up by each address and topic in the log index, and then
+// checked against the filter, as the index doesn't record the position of a topic
+func (dao *blockDAO) getLogs(filter *LogFilter, fromHeight, toHeight, limit uint64) ([]*action.Log, error) {
+	// candidates maps the height and position of a log to where it is in the receipts
+	var candidates map[string][]byte
+	intersect := func(keys map[string][]byte) {
+		if candidates == nil {
+			candidates = keys
+			return
+		}
+		for k := range candidates {
+			if _, ok := keys[k]; !ok {
+				delete(candidates, k)
+			}
+		}
+	}
+	if len(filter.Addresses) > 0 {
+		keys := make(map[string][]byte)
+		for _, addr := range filter.Addresses {
+			if err := dao.getLogKeys(
+				blockAddressLogMappingNS, logAddressPrefix, []byte(addr), fromHeight, toHeight, keys,
+			); err != nil {
+				return nil, err
+			}
+		}
+		intersect(keys)
+	}
+	for _, topics := range filter.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		keys := make(map[string][]byte)
+		for _, topic := range topics {
+			if err := dao.getLogKeys(
+				blockTopicLogMappingNS, logTopicPrefix, topic[:], fromHeight, toHeight, keys,
+			); err != nil {
+				return nil, err
+			}
+		}
+		intersect(keys)
+	}
+
+	positions := make([]string, 0, len(candidates))
+	for k := range candidates {
+		positions = append(positions, k)
+	}
+	sort.Strings(positions)
+	var logs []*action.Log
+	receipts := make(map[hash.Hash32B]*action.Receipt)
+	for _, pos := range positions {
+		value := candidates[pos]
+		exHash := byteutil.BytesTo32B(value[:len(hash.ZeroHash32B)])
+		receipt, ok := receipts[exHash]
+		if !ok {
+			var err error
+			if receipt, err = dao.getReceiptByExecutionHash(exHash); err != nil {
+				return nil, err
+			}
+			receipts[exHash] = receipt
+		}
+		index := binary.BigEndian.Uint32(value[len(hash.ZeroHash32B):])
+		if int(index) >= len(receipt.Logs) {
+			return nil, errors.Errorf("log %d is missing in the receipt of execution %x", index, exHash)
+		}
+		if log := receipt.Logs[index]; filter.Match(log) {
+			logs = append(logs, log)
+			if limit > 0 && uint64(len(logs)) >= limit {
+				break
+			}
+		}
+	}
+	return logs, nil
+}
+
+// getLogKeys adds the positions of the logs indexed by the subject, i.e., an address or a topic, in the blocks from
+// fromHeight to toHeight into keys
+func (dao *blockDAO) getLogKeys(
+	namespace string,
+	prefix []byte,
+	subject []byte,
+	fromHeight uint64,
+	toHeight uint64,
+	keys map[string][]byte,
+) error {
+	subjectKey := append(append([]byte{}, prefix...), subject...)
+	r := db.Range{Prefix: subjectKey, Start: logKey(prefix, subject, fromHeight, 0)}
+	if toHeight < math.MaxUint64 {
+		r.Limit = logKey(prefix, subject, toHeight+1, 0)
+	}
+	iter, err := dao.kvstore.Iterate(namespace, r)
+	if err != nil {
+		return errors.Wrapf(err, "failed to iterate logs of %x", subject)
+	}
+	for iter.Next() {
+		key := iter.Key()
+		// skip the keys of another subject which has this one as prefix
+		if len(key) != len(subjectKey)+12 {
+			continue
+		}
+		keys[string(key[len(subjectKey):])] = iter.Value()
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrapf(err, "failed to iterate logs of %x", subject)
 	}
 	return nil
 }
 
-// TODO: delete actions from db
+// putLogs indexes the logs in the receipts of the block by the contract address and the topics. The key is made of the
+// address or the topic, the block height and the position of the log in the block, so that the logs are visited in
+// order, and the value is the execution hash and the position of the log in the receipt
+func putLogs(blk *Block, batch db.KVStoreBatch) {
+	var pos uint32
+	for _, receipt := range blk.Receipts() {
+		for i, log := range receipt.Logs {
+			value := make([]byte, len(receipt.Hash)+4)
+			copy(value, receipt.Hash[:])
+			binary.BigEndian.PutUint32(value[len(receipt.Hash):], uint32(i))
+			batch.Put(blockAddressLogMappingNS, logKey(logAddressPrefix, []byte(log.Address), blk.Height(), pos), value,
+				"failed to put log %d of execution %x for address %s", i, receipt.Hash, log.Address)
+			for _, topic := range log.Topics {
+				batch.Put(blockTopicLogMappingNS, logKey(logTopicPrefix, topic[:], blk.Height(), pos), value,
+					"failed to put log %d of execution %x for topic %x", i, receipt.Hash, topic)
+			}
+			pos++
+		}
+	}
+}
+
+// getBlockReceipts reads back the receipts of the executions in a block in order, as the receipts are not attached to
+// the block read from db
+func (dao *blockDAO) getBlockReceipts(blk *Block) ([]*action.Receipt, error) {
+	var receipts []*action.Receipt
+	for _, act := range blk.Actions {
+		if _, ok := act.(*action.Execution); !ok {
+			continue
+		}
+		receipt, err := dao.getReceiptByExecutionHash(act.Hash())
+		switch errors.Cause(err) {
+		case nil:
+			receipts = append(receipts, receipt)
+		case db.ErrNotExist, bolt.ErrBucketNotFound:
+		default:
+			return nil, err
+		}
+	}
+	return receipts, nil
+}
+
+// deleteLogs deletes the log index of the block from db
+func deleteLogs(dao *blockDAO, blk *Block, batch db.KVStoreBatch) error {
+	receipts, err := dao.getBlockReceipts(blk)
+	if err != nil {
+		return err
+	}
+	var pos uint32
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			batch.Delete(blockAddressLogMappingNS, logKey(logAddressPrefix, []byte(log.Address), blk.Height(), pos),
+				"failed to delete log %d on height %d for address %s", pos, blk.Height(), log.Address)
+			for _, topic := range log.Topics {
+				batch.Delete(blockTopicLogMappingNS, logKey(logTopicPrefix, topic[:], blk.Height(), pos),
+					"failed to delete log %d on height %d for topic %x", pos, blk.Height(), topic)
+			}
+			pos++
+		}
+	}
+	return nil
+}
+
+// logKey returns the key of a log in the log index. The height and position are big endian, so that the keys are
+// sorted by them
+func logKey(prefix []byte, subject []byte, height uint64, pos uint32) []byte {
+	key := make([]byte, len(prefix)+len(subject)+12)
+	n := copy(key, prefix)
+	n += copy(key[n:], subject)
+	binary.BigEndian.PutUint64(key[n:], height)
+	binary.BigEndian.PutUint32(key[n+8:], pos)
+	return key
+}
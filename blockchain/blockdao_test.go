@@ -9,6 +9,7 @@ package blockchain
 import (
 	"context"
 	"hash/fnv"
+	"math"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -650,3 +652,88 @@ func TestBlockDAO(t *testing.T) {
 		testDeleteDao(db.NewBoltDB(path, cfg), t)
 	})
 }
+
+func TestBlockDAO_Logs(t *testing.T) {
+	require := require.New(t)
+
+	execution1, err := action.NewExecution(
+		testaddress.Addrinfo["alfa"].RawAddress, "c1", 1, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+	execution2, err := action.NewExecution(
+		testaddress.Addrinfo["alfa"].RawAddress, "c2", 2, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+	execution3, err := action.NewExecution(
+		testaddress.Addrinfo["alfa"].RawAddress, "c1", 3, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+	topic1 := hash.Hash32B{1}
+	topic2 := hash.Hash32B{2}
+	topic3 := hash.Hash32B{3}
+	logA := &action.Log{Address: "c1", Topics: []hash.Hash32B{topic1, topic2}, BlockNumber: 1}
+	logB := &action.Log{Address: "c2", Topics: []hash.Hash32B{topic1}, BlockNumber: 1}
+	logC := &action.Log{Address: "c1", Topics: []hash.Hash32B{topic3}, BlockNumber: 1}
+	logD := &action.Log{Address: "c1", Topics: []hash.Hash32B{topic1}, BlockNumber: 2}
+
+	blk1 := NewBlock(0, 1, hash.ZeroHash32B, testutil.TimestampNow(), testaddress.Addrinfo["producer"].PublicKey,
+		[]action.Action{execution1, execution2})
+	blk1.SetReceipts([]*action.Receipt{
+		{Hash: execution1.Hash(), Logs: []*action.Log{logA, logB}},
+		{Hash: execution2.Hash(), Logs: []*action.Log{logC}},
+	})
+	blk2 := NewBlock(0, 2, blk1.HashBlock(), testutil.TimestampNow(), testaddress.Addrinfo["producer"].PublicKey,
+		[]action.Action{execution3})
+	blk2.SetReceipts([]*action.Receipt{{Hash: execution3.Hash(), Logs: []*action.Log{logD}}})
+
+	ctx := context.Background()
+	dao := newBlockDAO(db.NewMemKVStore(), true)
+	require.NoError(dao.Start(ctx))
+	defer func() {
+		require.NoError(dao.Stop(ctx))
+	}()
+	for _, blk := range []*Block{blk1, blk2} {
+		require.NoError(dao.putBlock(blk))
+		require.NoError(dao.putReceipts(blk))
+	}
+
+	getLogs := func(filter *LogFilter, from, to, limit uint64) []*action.Log {
+		logs, err := dao.getLogs(filter, from, to, limit)
+		require.NoError(err)
+		return logs
+	}
+	require.Equal([]*action.Log{logA, logC, logD}, getLogs(&LogFilter{Addresses: []string{"c1"}}, 1, 2, 0))
+	require.Equal([]*action.Log{logA, logB, logC, logD}, getLogs(&LogFilter{Addresses: []string{"c2", "c1"}}, 0, 3, 0))
+	require.Equal([]*action.Log{logA, logC}, getLogs(&LogFilter{Addresses: []string{"c1"}}, 1, 2, 2))
+	require.Equal([]*action.Log{logD}, getLogs(&LogFilter{Addresses: []string{"c1"}}, 2, 2, 0))
+	// an address having another one as prefix doesn't match
+	require.Equal(0, len(getLogs(&LogFilter{Addresses: []string{"c"}}, 1, 2, 0)))
+	filter := &LogFilter{Addresses: []string{"c1"}, Topics: [][]hash.Hash32B{{topic1}}}
+	require.Equal([]*action.Log{logA, logD}, getLogs(filter, 1, 2, 0))
+	filter = &LogFilter{Topics: [][]hash.Hash32B{nil, {topic2, topic3}}}
+	require.Equal([]*action.Log{logA}, getLogs(filter, 1, 2, 0))
+	// the topic is indexed, but not at the position of the filter
+	filter = &LogFilter{Topics: [][]hash.Hash32B{{topic2}}}
+	require.Equal(0, len(getLogs(filter, 1, 2, 0)))
+
+	// the logs are deleted with the tip block
+	require.NoError(dao.deleteTipBlock())
+	require.Equal([]*action.Log{logA, logC}, getLogs(&LogFilter{Addresses: []string{"c1"}}, 1, 2, 0))
+	require.Equal([]*action.Log{logA, logB}, getLogs(&LogFilter{Topics: [][]hash.Hash32B{{topic1}}}, 1, 2, 0))
+
+	// the logs of the blocks written without the index are backfilled on start
+	kvstore := db.NewMemKVStore()
+	dao = newBlockDAO(kvstore, false)
+	require.NoError(dao.Start(ctx))
+	for _, blk := range []*Block{blk1, blk2} {
+		require.NoError(dao.putBlock(blk))
+		require.NoError(dao.putReceipts(blk))
+	}
+	require.Equal(0, len(getLogs(&LogFilter{Addresses: []string{"c1"}}, 1, 2, 0)))
+	require.NoError(dao.Stop(ctx))
+	dao = newBlockDAO(kvstore, true)
+	require.NoError(dao.Start(ctx))
+	// the backfill runs in background
+	dao.wg.Wait()
+	require.Equal([]*action.Log{logA, logC, logD}, getLogs(&LogFilter{Addresses: []string{"c1"}}, 1, 2, 0))
+	value, err := kvstore.Get(blockNS, logIndexHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(math.MaxUint64), byteutil.BytesToUint64(value))
+}
@@ -32,6 +32,8 @@ type Validator interface {
 type validator struct {
 	sf            factory.Factory
 	validatorAddr string
+	// verifyFooter verifies the endorsements in the footer of a block if set
+	verifyFooter func(*Block) error
 }
 
 var (
@@ -59,6 +61,11 @@ func (v *validator) Validate(blk *Block, tipHeight uint64, tipHash hash.Hash32B,
 	if err := verifySigAndRoot(blk); err != nil {
 		return errors.Wrap(err, "failed to verify block's signature and merkle root")
 	}
+	if v.verifyFooter != nil {
+		if err := v.verifyFooter(blk); err != nil {
+			return errors.Wrap(err, "failed to verify block's footer")
+		}
+	}
 
 	if v.sf != nil {
 		return v.verifyActions(blk, containCoinbase)
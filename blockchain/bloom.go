@@ -0,0 +1,64 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// LogsBloomLength is the length of a logs bloom in bytes
+const LogsBloomLength = 256
+
+// LogsBloom is the 2048-bit bloom filter of the contract addresses and topics of the logs in a block, the same as the
+// one in Ethereum. It's carried by the block header, so that the blocks without any log of interest could be skipped
+type LogsBloom [LogsBloomLength]byte
+
+// ZeroLogsBloom is the bloom of a block without any log
+var ZeroLogsBloom = LogsBloom{}
+
+// NewLogsBloom creates the bloom of the logs in the receipts
+func NewLogsBloom(receipts []*action.Receipt) LogsBloom {
+	var bloom LogsBloom
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			bloom.Add([]byte(log.Address))
+			for _, topic := range log.Topics {
+				bloom.Add(topic[:])
+			}
+		}
+	}
+	return bloom
+}
+
+// Add adds the data into the bloom
+func (b *LogsBloom) Add(data []byte) {
+	for _, bit := range bloomBits(data) {
+		b[LogsBloomLength-1-bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test returns false if the data is definitely not in the bloom
+func (b *LogsBloom) Test(data []byte) bool {
+	for _, bit := range bloomBits(data) {
+		if b[LogsBloomLength-1-bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomBits returns the 3 bits of the bloom set by the data, each of which is the lower 11 bits of a pair of bytes of
+// the data's hash
+func bloomBits(data []byte) [3]uint {
+	h := hash.Hash256b(data)
+	var bits [3]uint
+	for i := range bits {
+		bits[i] = (uint(h[2*i])<<8 | uint(h[2*i+1])) & (LogsBloomLength*8 - 1)
+	}
+	return bits
+}
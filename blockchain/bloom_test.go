@@ -0,0 +1,55 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+func TestLogsBloom(t *testing.T) {
+	require := require.New(t)
+
+	var bloom LogsBloom
+	require.False(bloom.Test([]byte("contract")))
+	bloom.Add([]byte("contract"))
+	require.True(bloom.Test([]byte("contract")))
+	require.False(bloom.Test([]byte("another contract")))
+
+	topic := hash.Hash32B{1}
+	receipts := []*action.Receipt{
+		{Logs: []*action.Log{{Address: "contract", Topics: []hash.Hash32B{topic}}}},
+		{},
+	}
+	bloom = NewLogsBloom(receipts)
+	require.True(bloom.Test([]byte("contract")))
+	require.True(bloom.Test(topic[:]))
+	require.False(bloom.Test([]byte("another contract")))
+	require.Equal(ZeroLogsBloom, NewLogsBloom(nil))
+	require.Equal(ZeroLogsBloom, NewLogsBloom(receipts[1:]))
+}
+
+func TestLogFilter_MatchBloom(t *testing.T) {
+	require := require.New(t)
+
+	topicA := hash.Hash32B{1}
+	topicB := hash.Hash32B{2}
+	topicC := hash.Hash32B{3}
+	bloom := NewLogsBloom([]*action.Receipt{
+		{Logs: []*action.Log{{Address: "contract", Topics: []hash.Hash32B{topicA, topicB}}}},
+	})
+	require.True((&LogFilter{}).MatchBloom(&bloom))
+	require.False((&LogFilter{}).MatchBloom(&ZeroLogsBloom))
+	require.True((&LogFilter{Addresses: []string{"another", "contract"}}).MatchBloom(&bloom))
+	require.False((&LogFilter{Addresses: []string{"another"}}).MatchBloom(&bloom))
+	require.True((&LogFilter{Topics: [][]hash.Hash32B{nil, {topicC, topicB}}}).MatchBloom(&bloom))
+	require.False((&LogFilter{Topics: [][]hash.Hash32B{{topicC}}}).MatchBloom(&bloom))
+}
@@ -164,6 +164,13 @@ func executeContract(
 		return nil, err
 	}
 	retval, depositGas, remainingGas, contractAddress, err := executeInEVM(ps, stateDB, gasLimit)
+	// remove the code and storage of the contracts killed by SELFDESTRUCT
+	if delErr := stateDB.deleteSuicidedContracts(); delErr != nil {
+		logger.Error().Err(delErr).Msg("executeContract")
+		if err == nil {
+			err = delErr
+		}
+	}
 	if !enableGasCharge {
 		remainingGas = depositGas
 	}
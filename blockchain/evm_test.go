@@ -13,6 +13,8 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/CoderZhi/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -24,6 +26,7 @@ import (
 	"github.com/iotexproject/iotex-core/state"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
+	"github.com/iotexproject/iotex-core/trie"
 )
 
 func TestEVM(t *testing.T) {
@@ -453,3 +456,124 @@ func TestERC20(t *testing.T) {
 	amount := binary.BigEndian.Uint64(h)
 	require.Equal(uint64(10000), amount)
 }
+
+func TestSelfDestruct(t *testing.T) {
+	require := require.New(t)
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	cfg.Explorer.Enabled = true
+	bc := NewBlockchain(cfg, DefaultStateFactoryOption(), BoltDBDaoOption())
+	require.NoError(bc.Start(ctx))
+	require.NotNil(bc)
+	defer func() {
+		err := bc.Stop(ctx)
+		require.NoError(err)
+	}()
+	sf := bc.GetFactory()
+	require.NotNil(sf)
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = ws.LoadOrCreateAccountState(ta.Addrinfo["producer"].RawAddress, Gen.TotalSupply)
+	require.NoError(err)
+	_, err = ws.LoadOrCreateAccountState(ta.Addrinfo["alfa"].RawAddress, big.NewInt(1000000))
+	require.NoError(err)
+	gasLimit := testutil.TestGasLimit
+	ctx = state.WithRunActionsCtx(ctx,
+		state.RunActionsCtx{
+			ProducerAddr:    ta.Addrinfo["producer"].RawAddress,
+			GasLimit:        &gasLimit,
+			EnableGasCharge: testutil.EnableGasCharge,
+		})
+	_, _, err = ws.RunActions(ctx, 0, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+
+	logger.Info().Msg("Deploy contract")
+	// the constructor stores 1 in slot 0, and the runtime code is "CALLER SELFDESTRUCT", which sends the balance to
+	// the caller and kills the contract
+	data, _ := hex.DecodeString("60016000556002601160003960026000f333ff")
+	execution, err := action.NewExecution(
+		ta.Addrinfo["alfa"].RawAddress, action.EmptyAddress, 1, big.NewInt(500000), uint64(100000), big.NewInt(0), data)
+	require.NoError(err)
+	require.NoError(action.Sign(execution, ta.Addrinfo["alfa"].PrivateKey))
+	blk, err := bc.MintNewBlock([]action.Action{execution}, ta.Addrinfo["producer"],
+		nil, nil, "")
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk, true))
+	require.Nil(bc.CommitBlock(blk))
+
+	eHash := execution.Hash()
+	r, _ := bc.GetReceiptByExecutionHash(eHash)
+	require.Equal(eHash, r.Hash)
+	require.Equal(SuccessStatus, r.Status)
+	contractAddr := r.ContractAddress
+	h, _ := iotxaddress.GetPubkeyHash(contractAddr)
+	contractAddrHash := byteutil.BytesTo20B(h)
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	code, err := ws.GetCode(contractAddrHash)
+	require.NoError(err)
+	require.Equal(data[17:], code)
+	slot := hash.ZeroHash32B
+	value, err := ws.GetContractState(contractAddrHash, slot)
+	require.NoError(err)
+	require.Equal(byteutil.BytesTo32B(common.LeftPadBytes([]byte{1}, 32)), value)
+	deployHeight := blk.Height()
+	balance, err := bc.Balance(contractAddr)
+	require.NoError(err)
+	require.Equal(0, balance.Cmp(big.NewInt(500000)))
+	balance, err = bc.Balance(ta.Addrinfo["alfa"].RawAddress)
+	require.NoError(err)
+	require.Equal(0, balance.Cmp(big.NewInt(500000)))
+
+	logger.Info().Msg("Kill contract")
+	execution, err = action.NewExecution(
+		ta.Addrinfo["alfa"].RawAddress, contractAddr, 2, big.NewInt(0), uint64(100000), big.NewInt(0), []byte{})
+	require.NoError(err)
+	require.NoError(action.Sign(execution, ta.Addrinfo["alfa"].PrivateKey))
+	blk, err = bc.MintNewBlock([]action.Action{execution}, ta.Addrinfo["producer"],
+		nil, nil, "")
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk, true))
+	require.Nil(bc.CommitBlock(blk))
+
+	eHash = execution.Hash()
+	r, _ = bc.GetReceiptByExecutionHash(eHash)
+	require.Equal(eHash, r.Hash)
+	require.Equal(SuccessStatus, r.Status)
+	// the balance goes back to the caller, and the contract is removed from the state
+	balance, err = bc.Balance(ta.Addrinfo["alfa"].RawAddress)
+	require.NoError(err)
+	require.Equal(0, balance.Cmp(big.NewInt(1000000)))
+	balance, err = bc.Balance(contractAddr)
+	require.NoError(err)
+	require.Equal(0, balance.Sign())
+	var account state.Account
+	require.Equal(state.ErrStateNotExist, errors.Cause(sf.State(contractAddrHash, &account)))
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = ws.GetCode(contractAddrHash)
+	require.Error(err)
+	// the storage is gone with the account
+	_, err = ws.GetContractState(contractAddrHash, slot)
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+	stateDB := NewEVMStateDBAdapter(bc, ws, blk.Height()+1, hash.ZeroHash32B, uint(0), hash.ZeroHash32B)
+	require.Equal(common.Hash{}, stateDB.GetState(common.BytesToAddress(contractAddrHash[:]), common.Hash{}))
+	_, err = sf.StorageProofByHeight(contractAddrHash, slot, blk.Height())
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+	// the storage trie is orphaned rather than deleted, as the states of the earlier heights still refer to it
+	deployed, err := sf.AccountStateByHeight(contractAddr, deployHeight)
+	require.NoError(err)
+	proof, err := sf.StorageProofByHeight(contractAddrHash, slot, deployHeight)
+	require.NoError(err)
+	v, err := trie.VerifyProof(deployed.Root, slot[:], proof)
+	require.NoError(err)
+	require.Equal(value[:], v)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"math/big"
+
+	"github.com/CoderZhi/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+type (
+	// journalEntry is a modification of the working set, which can be reverted
+	journalEntry interface {
+		revert(*EVMStateDBAdapter) error
+	}
+
+	// revision maps a snapshot id to the length of the journal when the snapshot is taken
+	revision struct {
+		id           int
+		journalIndex int
+	}
+
+	createAccountChange struct {
+		addr common.Address
+	}
+
+	balanceChange struct {
+		addr common.Address
+		prev *big.Int
+	}
+
+	nonceChange struct {
+		addr common.Address
+		prev uint64
+	}
+
+	codeChange struct {
+		addr     common.Address
+		prevCode []byte
+	}
+
+	storageChange struct {
+		addr    common.Address
+		key     hash.Hash32B
+		prev    hash.Hash32B
+		existed bool
+	}
+
+	suicideChange struct {
+		addr        common.Address
+		prev        bool // whether the account had already been killed
+		prevBalance *big.Int
+	}
+
+	addLogChange struct{}
+)
+
+func (ch createAccountChange) revert(stateDB *EVMStateDBAdapter) error {
+	return stateDB.ws.DelState(byteutil.BytesTo20B(ch.addr[:]))
+}
+
+func (ch balanceChange) revert(stateDB *EVMStateDBAdapter) error {
+	addr := address.New(stateDB.bc.ChainID(), ch.addr.Bytes())
+	state, err := stateDB.ws.CachedAccountState(addr.IotxAddress())
+	if err != nil {
+		return errors.Wrapf(err, "failed to revert balance of %s", addr.IotxAddress())
+	}
+	state.Balance = ch.prev
+	return nil
+}
+
+func (ch nonceChange) revert(stateDB *EVMStateDBAdapter) error {
+	addr := address.New(stateDB.bc.ChainID(), ch.addr.Bytes())
+	state, err := stateDB.ws.CachedAccountState(addr.IotxAddress())
+	if err != nil {
+		return errors.Wrapf(err, "failed to revert nonce of %s", addr.IotxAddress())
+	}
+	state.Nonce = ch.prev
+	return nil
+}
+
+func (ch codeChange) revert(stateDB *EVMStateDBAdapter) error {
+	return stateDB.ws.SetCode(byteutil.BytesTo20B(ch.addr[:]), ch.prevCode)
+}
+
+func (ch storageChange) revert(stateDB *EVMStateDBAdapter) error {
+	if ch.existed {
+		return stateDB.ws.SetContractState(byteutil.BytesTo20B(ch.addr[:]), ch.key, ch.prev)
+	}
+	return stateDB.ws.DelContractState(byteutil.BytesTo20B(ch.addr[:]), ch.key)
+}
+
+func (ch suicideChange) revert(stateDB *EVMStateDBAdapter) error {
+	if !ch.prev {
+		delete(stateDB.suicided, ch.addr)
+	}
+	addr := address.New(stateDB.bc.ChainID(), ch.addr.Bytes())
+	state, err := stateDB.ws.CachedAccountState(addr.IotxAddress())
+	if err != nil {
+		return errors.Wrapf(err, "failed to revert suicide of %s", addr.IotxAddress())
+	}
+	state.Balance = ch.prevBalance
+	return nil
+}
+
+func (ch addLogChange) revert(stateDB *EVMStateDBAdapter) error {
+	stateDB.logs = stateDB.logs[:len(stateDB.logs)-1]
+	return nil
+}
@@ -7,10 +7,13 @@
 package blockchain
 
 import (
+	"bytes"
 	"math/big"
+	"sort"
 
 	"github.com/CoderZhi/go-ethereum/common"
 	"github.com/CoderZhi/go-ethereum/core/types"
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/address"
@@ -18,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/trie"
 )
 
 // EVMStateDBAdapter represents the state db adapter for evm to access iotx blockchain
@@ -30,6 +34,10 @@ type EVMStateDBAdapter struct {
 	blockHash      hash.Hash32B
 	executionIndex uint
 	executionHash  hash.Hash32B
+	suicided       map[common.Address]struct{} // contracts killed during the execution
+	journal        []journalEntry              // changes made during the execution, in order
+	validRevisions []revision
+	nextRevisionID int
 }
 
 // NewEVMStateDBAdapter creates a new state db with iotx blockchain
@@ -43,6 +51,10 @@ func NewEVMStateDBAdapter(bc Blockchain, ws factory.WorkingSet, blockHeight uint
 		blockHash,
 		executionIndex,
 		executionHash,
+		make(map[common.Address]struct{}),
+		[]journalEntry{},
+		[]revision{},
+		0,
 	}
 }
 
@@ -60,12 +72,16 @@ func (stateDB *EVMStateDBAdapter) Error() error {
 // CreateAccount creates an account in iotx blockchain
 func (stateDB *EVMStateDBAdapter) CreateAccount(evmAddr common.Address) {
 	addr := address.New(stateDB.bc.ChainID(), evmAddr.Bytes())
+	existed := stateDB.Exist(evmAddr)
 	_, err := stateDB.ws.LoadOrCreateAccountState(addr.IotxAddress(), big.NewInt(0))
 	if err != nil {
 		logger.Error().Err(err).Msg("CreateAccount")
 		// stateDB.logError(err)
 		return
 	}
+	if !existed {
+		stateDB.journal = append(stateDB.journal, createAccountChange{evmAddr})
+	}
 	logger.Debug().Hex("addrHash", evmAddr[:]).Msg("CreateAccount")
 }
 
@@ -83,6 +99,7 @@ func (stateDB *EVMStateDBAdapter) SubBalance(evmAddr common.Address, amount *big
 		stateDB.logError(err)
 		return
 	}
+	stateDB.journal = append(stateDB.journal, balanceChange{evmAddr, new(big.Int).Set(state.Balance)})
 	state.SubBalance(amount)
 	// stateDB.GetBalance(evmAddr)
 }
@@ -96,12 +113,17 @@ func (stateDB *EVMStateDBAdapter) AddBalance(evmAddr common.Address, amount *big
 	logger.Debug().Msgf("AddBalance %v to %s", amount, evmAddr.Hex())
 
 	addr := address.New(stateDB.bc.ChainID(), evmAddr.Bytes())
+	existed := stateDB.Exist(evmAddr)
 	state, err := stateDB.ws.LoadOrCreateAccountState(addr.IotxAddress(), big.NewInt(0))
 	if err != nil {
 		logger.Error().Err(err).Hex("addrHash", evmAddr[:]).Msg("AddBalance")
 		stateDB.logError(err)
 		return
 	}
+	if !existed {
+		stateDB.journal = append(stateDB.journal, createAccountChange{evmAddr})
+	}
+	stateDB.journal = append(stateDB.journal, balanceChange{evmAddr, new(big.Int).Set(state.Balance)})
 	state.AddBalance(amount)
 }
 
@@ -121,19 +143,28 @@ func (stateDB *EVMStateDBAdapter) GetBalance(evmAddr common.Address) *big.Int {
 // GetNonce gets the nonce of account
 func (stateDB *EVMStateDBAdapter) GetNonce(evmAddr common.Address) uint64 {
 	addr := address.New(stateDB.bc.ChainID(), evmAddr.Bytes())
-	nonce, err := stateDB.ws.Nonce(addr.IotxAddress())
+	state, err := stateDB.ws.CachedAccountState(addr.IotxAddress())
 	if err != nil {
 		logger.Error().Err(err).Msg("GetNonce")
 		// stateDB.logError(err)
 		return 0
 	}
-	logger.Debug().Uint64("nonce", nonce).Msg("GetNonce")
-	return nonce
+	logger.Debug().Uint64("nonce", state.Nonce).Msg("GetNonce")
+	return state.Nonce
 }
 
 // SetNonce sets the nonce of account
-func (stateDB *EVMStateDBAdapter) SetNonce(common.Address, uint64) {
-	logger.Error().Msg("SetNonce is not implemented")
+func (stateDB *EVMStateDBAdapter) SetNonce(evmAddr common.Address, nonce uint64) {
+	addr := address.New(stateDB.bc.ChainID(), evmAddr.Bytes())
+	state, err := stateDB.ws.CachedAccountState(addr.IotxAddress())
+	if err != nil {
+		logger.Error().Err(err).Msg("SetNonce")
+		// stateDB.logError(err)
+		return
+	}
+	stateDB.journal = append(stateDB.journal, nonceChange{evmAddr, state.Nonce})
+	state.Nonce = nonce
+	logger.Debug().Uint64("nonce", nonce).Msg("SetNonce")
 }
 
 // GetCodeHash gets the code hash of account
@@ -165,9 +196,12 @@ func (stateDB *EVMStateDBAdapter) GetCode(evmAddr common.Address) []byte {
 
 // SetCode sets the code saved in hash
 func (stateDB *EVMStateDBAdapter) SetCode(evmAddr common.Address, code []byte) {
+	prevCode := stateDB.GetCode(evmAddr)
 	if err := stateDB.ws.SetCode(byteutil.BytesTo20B(evmAddr[:]), code); err != nil {
 		logger.Error().Err(err).Msg("SetCode")
+		return
 	}
+	stateDB.journal = append(stateDB.journal, codeChange{evmAddr, prevCode})
 	logger.Debug().Hex("code", code).Hex("hash", hash.Hash256b(code)[:]).Msg("SetCode")
 }
 
@@ -207,23 +241,47 @@ func (stateDB *EVMStateDBAdapter) GetState(evmAddr common.Address, k common.Hash
 
 // SetState sets state
 func (stateDB *EVMStateDBAdapter) SetState(evmAddr common.Address, k, v common.Hash) {
-	if err := stateDB.ws.SetContractState(byteutil.BytesTo20B(evmAddr[:]), byteutil.BytesTo32B(k[:]), byteutil.BytesTo32B(v[:])); err != nil {
+	addrHash := byteutil.BytesTo20B(evmAddr[:])
+	key := byteutil.BytesTo32B(k[:])
+	// only a missing slot is deleted on revert, so that a failed read never wipes an existing value
+	existed := true
+	prev, err := stateDB.ws.GetContractState(addrHash, key)
+	if err != nil {
+		if errors.Cause(err) != trie.ErrNotExist {
+			logger.Error().Err(err).Msg("SetState")
+			return
+		}
+		existed = false
+	}
+	if err := stateDB.ws.SetContractState(addrHash, key, byteutil.BytesTo32B(v[:])); err != nil {
 		logger.Error().Err(err).Msg("SetState")
 		return
 	}
+	stateDB.journal = append(stateDB.journal, storageChange{evmAddr, key, prev, existed})
 	logger.Debug().Hex("addrHash", evmAddr[:]).Hex("k", k[:]).Hex("v", v[:]).Msg("SetState")
 }
 
-// Suicide kills the contract
-func (stateDB *EVMStateDBAdapter) Suicide(common.Address) bool {
-	logger.Error().Msg("Suicide is not implemented")
-	return false
+// Suicide kills the contract, the account will be removed at the end of the execution
+func (stateDB *EVMStateDBAdapter) Suicide(evmAddr common.Address) bool {
+	addr := address.New(stateDB.bc.ChainID(), evmAddr.Bytes())
+	state, err := stateDB.ws.CachedAccountState(addr.IotxAddress())
+	if err != nil {
+		logger.Debug().Err(err).Msg("Suicide")
+		return false
+	}
+	_, killed := stateDB.suicided[evmAddr]
+	stateDB.journal = append(stateDB.journal, suicideChange{evmAddr, killed, new(big.Int).Set(state.Balance)})
+	// the remaining balance has already been transferred to the beneficiary by evm
+	state.Balance = big.NewInt(0)
+	stateDB.suicided[evmAddr] = struct{}{}
+	logger.Debug().Hex("addrHash", evmAddr[:]).Msg("Suicide")
+	return true
 }
 
 // HasSuicided returns whether the contract has been killed
-func (stateDB *EVMStateDBAdapter) HasSuicided(common.Address) bool {
-	logger.Error().Msg("HasSuicide is not implemented")
-	return false
+func (stateDB *EVMStateDBAdapter) HasSuicided(evmAddr common.Address) bool {
+	_, ok := stateDB.suicided[evmAddr]
+	return ok
 }
 
 // Exist checks the existence of an address
@@ -237,21 +295,46 @@ func (stateDB *EVMStateDBAdapter) Exist(evmAddr common.Address) bool {
 	return true
 }
 
-// Empty empties the contract
-func (stateDB *EVMStateDBAdapter) Empty(common.Address) bool {
-	logger.Debug().Msg("Empty is not implemented")
-	return false
+// Empty returns true if the account does not exist or has zero nonce, balance and code
+func (stateDB *EVMStateDBAdapter) Empty(evmAddr common.Address) bool {
+	addr := address.New(stateDB.bc.ChainID(), evmAddr.Bytes())
+	state, err := stateDB.ws.CachedAccountState(addr.IotxAddress())
+	if err != nil || state == nil {
+		return true
+	}
+	return state.Nonce == 0 && state.Balance.Sign() == 0 && len(state.CodeHash) == 0
 }
 
 // RevertToSnapshot reverts the state factory to snapshot
-func (stateDB *EVMStateDBAdapter) RevertToSnapshot(int) {
-	logger.Debug().Msg("RevertToSnapshot is not implemented")
+func (stateDB *EVMStateDBAdapter) RevertToSnapshot(snapshot int) {
+	idx := sort.Search(len(stateDB.validRevisions), func(i int) bool {
+		return stateDB.validRevisions[i].id >= snapshot
+	})
+	if idx == len(stateDB.validRevisions) || stateDB.validRevisions[idx].id != snapshot {
+		err := errors.Errorf("failed to revert to snapshot %d", snapshot)
+		logger.Error().Err(err).Msg("RevertToSnapshot")
+		stateDB.logError(err)
+		return
+	}
+	// undo the changes in reverse order
+	journalIndex := stateDB.validRevisions[idx].journalIndex
+	for i := len(stateDB.journal) - 1; i >= journalIndex; i-- {
+		if err := stateDB.journal[i].revert(stateDB); err != nil {
+			logger.Error().Err(err).Msg("RevertToSnapshot")
+			stateDB.logError(err)
+		}
+	}
+	stateDB.journal = stateDB.journal[:journalIndex]
+	stateDB.validRevisions = stateDB.validRevisions[:idx]
+	logger.Debug().Int("snapshot", snapshot).Msg("RevertToSnapshot")
 }
 
 // Snapshot returns the snapshot id
 func (stateDB *EVMStateDBAdapter) Snapshot() int {
-	logger.Debug().Msg("Snapshot is not implemented")
-	return 0
+	id := stateDB.nextRevisionID
+	stateDB.nextRevisionID++
+	stateDB.validRevisions = append(stateDB.validRevisions, revision{id, len(stateDB.journal)})
+	return id
 }
 
 // AddLog adds log
@@ -274,6 +357,7 @@ func (stateDB *EVMStateDBAdapter) AddLog(evmLog *types.Log) {
 		stateDB.executionIndex,
 	}
 	stateDB.logs = append(stateDB.logs, log)
+	stateDB.journal = append(stateDB.journal, addLogChange{})
 }
 
 // Logs returns the logs
@@ -281,6 +365,27 @@ func (stateDB *EVMStateDBAdapter) Logs() []*action.Log {
 	return stateDB.logs
 }
 
+// deleteSuicidedContracts removes the contracts killed during the execution from the working set. Along with the
+// account, the code and storage are no longer reachable. The storage trie nodes are not deleted, as the states of the
+// earlier heights still refer to them, and they are swept by the pruner once no retained height does. The code is
+// kept as it's stored by its hash, which other contracts may share
+func (stateDB *EVMStateDBAdapter) deleteSuicidedContracts() error {
+	addrs := make([]common.Address, 0, len(stateDB.suicided))
+	for addr := range stateDB.suicided {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	for _, addr := range addrs {
+		if err := stateDB.ws.DelState(byteutil.BytesTo20B(addr[:])); err != nil {
+			return errors.Wrapf(err, "failed to delete contract %x", addr[:])
+		}
+	}
+	stateDB.suicided = make(map[common.Address]struct{})
+	return nil
+}
+
 // AddPreimage adds the preimage
 func (stateDB *EVMStateDBAdapter) AddPreimage(common.Hash, []byte) {
 	logger.Error().Msg("AddPreimage is not implemented")
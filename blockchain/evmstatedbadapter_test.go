@@ -12,11 +12,17 @@ import (
 	"testing"
 
 	"github.com/CoderZhi/go-ethereum/common"
+	"github.com/CoderZhi/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/mock/mock_factory"
 	"github.com/iotexproject/iotex-core/testutil"
+	"github.com/iotexproject/iotex-core/trie"
 )
 
 func TestAddBalance(t *testing.T) {
@@ -51,3 +57,134 @@ func TestAddBalance(t *testing.T) {
 	amount = stateDB.GetBalance(addr)
 	require.Equal(0, amount.Cmp(big.NewInt(80000)))
 }
+
+func TestSnapshotAndRevert(t *testing.T) {
+	require := require.New(t)
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	bc := NewBlockchain(cfg, DefaultStateFactoryOption(), BoltDBDaoOption())
+	require.NoError(bc.Start(ctx))
+	require.NotNil(bc)
+	defer func() {
+		err := bc.Stop(ctx)
+		require.NoError(err)
+	}()
+	sf := bc.GetFactory()
+	require.NotNil(sf)
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	addr1 := common.HexToAddress("02ae2a956d21e8d481c3a69e146633470cf625ec")
+	addr2 := common.HexToAddress("7fbc4d8d8d2e6e9c6b8ff1e3a2c9bd5d4b1e7d0a")
+	k1 := common.BytesToHash([]byte("key1"))
+	k2 := common.BytesToHash([]byte("key2"))
+	v1 := common.BytesToHash([]byte("value1"))
+	v2 := common.BytesToHash([]byte("value2"))
+	code1 := []byte("code1")
+	code2 := []byte("code2")
+	stateDB := NewEVMStateDBAdapter(bc, ws, 1, hash.ZeroHash32B, uint(0), hash.ZeroHash32B)
+	stateDB.CreateAccount(addr1)
+	stateDB.AddBalance(addr1, big.NewInt(40000))
+	stateDB.SetCode(addr1, code1)
+	stateDB.SetState(addr1, k1, v1)
+
+	snapshot1 := stateDB.Snapshot()
+	stateDB.SubBalance(addr1, big.NewInt(10000))
+	stateDB.SetNonce(addr1, 3)
+	stateDB.SetState(addr1, k1, v2)
+	stateDB.SetState(addr1, k2, v2)
+	stateDB.AddBalance(addr2, big.NewInt(10000))
+	stateDB.AddLog(&types.Log{Address: addr1})
+	require.True(stateDB.Exist(addr2))
+
+	snapshot2 := stateDB.Snapshot()
+	stateDB.SetCode(addr1, code2)
+	require.Equal(code2, stateDB.GetCode(addr1))
+
+	stateDB.RevertToSnapshot(snapshot2)
+	require.Equal(code1, stateDB.GetCode(addr1))
+	require.Equal(0, stateDB.GetBalance(addr1).Cmp(big.NewInt(30000)))
+	require.Equal(v2, stateDB.GetState(addr1, k1))
+	require.Equal(1, len(stateDB.Logs()))
+
+	stateDB.RevertToSnapshot(snapshot1)
+	require.Equal(0, stateDB.GetBalance(addr1).Cmp(big.NewInt(40000)))
+	require.Equal(v1, stateDB.GetState(addr1, k1))
+	require.Equal(common.Hash{}, stateDB.GetState(addr1, k2))
+	require.False(stateDB.Exist(addr2))
+	require.Equal(0, len(stateDB.Logs()))
+	require.NoError(stateDB.Error())
+
+	// a reverted snapshot cannot be reverted again
+	stateDB.RevertToSnapshot(snapshot2)
+	require.Error(stateDB.Error())
+}
+
+func TestSetNonce(t *testing.T) {
+	require := require.New(t)
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	bc := NewBlockchain(cfg, DefaultStateFactoryOption(), BoltDBDaoOption())
+	require.NoError(bc.Start(ctx))
+	require.NotNil(bc)
+	defer func() {
+		err := bc.Stop(ctx)
+		require.NoError(err)
+	}()
+	sf := bc.GetFactory()
+	require.NotNil(sf)
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	addr := common.HexToAddress("02ae2a956d21e8d481c3a69e146633470cf625ec")
+	stateDB := NewEVMStateDBAdapter(bc, ws, 1, hash.ZeroHash32B, uint(0), hash.ZeroHash32B)
+	stateDB.CreateAccount(addr)
+	require.Equal(uint64(0), stateDB.GetNonce(addr))
+	stateDB.SetNonce(addr, 3)
+	require.Equal(uint64(3), stateDB.GetNonce(addr))
+
+	snapshot := stateDB.Snapshot()
+	stateDB.SetNonce(addr, 5)
+	require.Equal(uint64(5), stateDB.GetNonce(addr))
+	stateDB.RevertToSnapshot(snapshot)
+	require.Equal(uint64(3), stateDB.GetNonce(addr))
+	require.NoError(stateDB.Error())
+}
+
+func TestSetStateRevert(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ws := mock_factory.NewMockWorkingSet(ctrl)
+	addr := common.HexToAddress("02ae2a956d21e8d481c3a69e146633470cf625ec")
+	addrHash := byteutil.BytesTo20B(addr[:])
+	missing := byteutil.BytesTo32B(common.HexToHash("01").Bytes())
+	unreadable := byteutil.BytesTo32B(common.HexToHash("02").Bytes())
+	value := byteutil.BytesTo32B(common.HexToHash("03").Bytes())
+	stateDB := NewEVMStateDBAdapter(nil, ws, 1, hash.ZeroHash32B, uint(0), hash.ZeroHash32B)
+
+	// a missing slot is deleted on revert
+	ws.EXPECT().GetContractState(addrHash, missing).
+		Return(hash.ZeroHash32B, errors.Wrap(trie.ErrNotExist, "key = 01")).Times(1)
+	ws.EXPECT().SetContractState(addrHash, missing, value).Return(nil).Times(1)
+	ws.EXPECT().DelContractState(addrHash, missing).Return(nil).Times(1)
+	// a slot failing to be read is neither written nor deleted on revert
+	ws.EXPECT().GetContractState(addrHash, unreadable).Return(hash.ZeroHash32B, errors.New("db error")).Times(1)
+
+	snapshot := stateDB.Snapshot()
+	stateDB.SetState(addr, common.BytesToHash(missing[:]), common.BytesToHash(value[:]))
+	stateDB.SetState(addr, common.BytesToHash(unreadable[:]), common.BytesToHash(value[:]))
+	stateDB.RevertToSnapshot(snapshot)
+	require.NoError(stateDB.Error())
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// The chain keeps the blocks on the competing branches as side blocks. The fork choice rule is the longest chain: once
+// a branch becomes higher than the tip, the chain rolls the blocks and the states back to the fork point, and applies
+// the blocks of the branch. Between the branches of the same height, the one seen first is kept
+
+// isSideBlock tells whether a block doesn't extend the tip
+func isSideBlock(blk *Block, tipHash hash.Hash32B) bool {
+	return blk != nil && blk.PrevHash() != tipHash
+}
+
+// validateSideBlock validates a side block against its parent, which is either on the chain or a side block. The
+// actions are verified against the states when its branch is switched to
+func (bc *blockchain) validateSideBlock(blk *Block) error {
+	blkHash := blk.HashBlock()
+	if _, err := bc.dao.getBlockHeight(blkHash); err == nil {
+		return errors.Wrapf(ErrInvalidBlock, "block %x is already on the chain", blkHash)
+	}
+	parent, err := bc.parent(blk)
+	if err != nil {
+		return err
+	}
+	if err := verifyHeightAndHash(blk, parent.Height(), blk.PrevHash()); err != nil {
+		return errors.Wrap(err, "failed to verify side block's height and hash")
+	}
+	if err := verifySigAndRoot(blk); err != nil {
+		return errors.Wrap(err, "failed to verify side block's signature and merkle root")
+	}
+	if bc.config.Consensus.Scheme != config.RollDPoSScheme {
+		return nil
+	}
+	if bc.delegates == nil {
+		return errors.Wrap(ErrInvalidBlock, "no delegates to verify side block against")
+	}
+	delegates, err := bc.delegates(blk.Height())
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the delegates of side block %d", blk.Height())
+	}
+	if err := bc.verifyProducer(blk, parent, delegates); err != nil {
+		return err
+	}
+	return bc.verifyCommit(blk, delegates)
+}
+
+// verifyProducer verifies that the producer of a side block is the proposer RollDPoS schedules on the height and the
+// time slot of the block, which is counted from the timestamp of the parent. With DKG, the delegates are the candidates
+// in an order unknown without the seed of the epoch, so the producer only needs to be one of them
+func (bc *blockchain) verifyProducer(blk *Block, parent *Block, delegates []string) error {
+	if len(delegates) == 0 {
+		return errors.Wrapf(ErrInvalidBlock, "no delegate for side block %d", blk.Height())
+	}
+	producer := blk.ProducerAddress()
+	rolldpos := bc.config.Consensus.RollDPoS
+	if rolldpos.EnableDKG {
+		for _, delegate := range delegates {
+			if delegate == producer {
+				return nil
+			}
+		}
+		return errors.Wrapf(ErrInvalidBlock, "producer %s of side block %d is not a delegate", producer, blk.Height())
+	}
+	slot := uint64(0)
+	if rolldpos.TimeBasedRotation && rolldpos.ProposerInterval > 0 {
+		duration := blk.Header.Timestamp().Sub(parent.Header.Timestamp())
+		if duration > rolldpos.ProposerInterval {
+			slot = uint64(duration/rolldpos.ProposerInterval) - 1
+		}
+	}
+	if proposer := delegates[(blk.Height()+slot)%uint64(len(delegates))]; proposer != producer {
+		return errors.Wrapf(
+			ErrInvalidBlock,
+			"producer %s of side block %d is not the scheduled proposer %s",
+			producer,
+			blk.Height(),
+			proposer,
+		)
+	}
+	return nil
+}
+
+// verifyCommit verifies that the footer of a block carries the endorsements of more than 2/3 of the delegates
+// locking or committing the block, so that a single delegate can't build a branch on its own
+func (bc *blockchain) verifyCommit(blk *Block, delegates []string) error {
+	if blk.Footer == nil || blk.Footer.Endorsements() == nil {
+		return errors.Wrapf(ErrInvalidBlock, "no endorsement on block %d", blk.Height())
+	}
+	set := blk.Footer.Endorsements()
+	if set.BlockHash() != blk.HashBlock() {
+		return errors.Wrapf(ErrInvalidBlock, "endorsements of block %d are on another block", blk.Height())
+	}
+	var dkgPubkeys map[string][]byte
+	if set.IsAggregate() {
+		dkgPubkeys = bc.dkgPubkeys(blk)
+	}
+	quorum := endorsement.Quorum(int(bc.config.Consensus.RollDPoS.NumDelegates))
+	if err := set.VerifyCommit(blk.Height(), delegates, dkgPubkeys, quorum); err != nil {
+		return errors.Wrapf(ErrInvalidBlock, "block %d isn't committed: %v", blk.Height(), err)
+	}
+	return nil
+}
+
+// checkSideBlockLimits rejects a side block out of the height window around the tip, or beyond the max number of the
+// side blocks, so that the side blocks kept are bounded
+func (bc *blockchain) checkSideBlockLimits(blk *Block) error {
+	window := bc.config.Chain.SideBlocks.HeightWindow
+	if blk.Height()+window < bc.tipHeight || blk.Height() > bc.tipHeight+window {
+		return errors.Wrapf(
+			ErrInvalidBlock,
+			"side block %d is out of the height window %d around tip %d",
+			blk.Height(),
+			window,
+			bc.tipHeight,
+		)
+	}
+	count, err := bc.dao.countSideBlocks()
+	if err != nil {
+		return err
+	}
+	if count >= bc.config.Chain.SideBlocks.MaxNum {
+		return errors.Wrapf(ErrInvalidBlock, "too many side blocks kept: %d", count)
+	}
+	return nil
+}
+
+// pruneSideBlocks deletes the side blocks below the height window of the tip, which would never be switched to
+func (bc *blockchain) pruneSideBlocks() error {
+	window := bc.config.Chain.SideBlocks.HeightWindow
+	if bc.tipHeight <= window {
+		return nil
+	}
+	return bc.dao.deleteSideBlocksBelow(bc.tipHeight - window)
+}
+
+// parent returns the parent of a block, which is either on the chain or a side block
+func (bc *blockchain) parent(blk *Block) (*Block, error) {
+	prevHash := blk.PrevHash()
+	if parent, err := bc.dao.getBlock(prevHash); err == nil {
+		return parent, nil
+	}
+	parent, err := bc.dao.getSideBlock(prevHash)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidBlock, "parent %x of block %d is unknown", prevHash, blk.Height())
+	}
+	return parent, nil
+}
+
+// commitSideBlock stores a side block, and switches to its branch if the branch becomes the longest
+func (bc *blockchain) commitSideBlock(blk *Block) error {
+	if err := bc.checkSideBlockLimits(blk); err != nil {
+		return err
+	}
+	if err := bc.validateSideBlock(blk); err != nil {
+		return err
+	}
+	if err := bc.dao.putSideBlock(blk); err != nil {
+		return errors.Wrapf(err, "failed to put side block on height %d", blk.Height())
+	}
+	blkHash := blk.HashBlock()
+	if blk.Height() <= bc.tipHeight {
+		logger.Info().
+			Uint64("height", blk.Height()).
+			Hex("hash", blkHash[:]).
+			Msg("keep a side block")
+		return nil
+	}
+	return bc.switchBranch(blk)
+}
+
+// switchBranch switches the chain to the branch ending with head. If a block of the branch turns out to be invalid,
+// the chain is restored to the original branch
+func (bc *blockchain) switchBranch(head *Block) error {
+	if bc.sf == nil {
+		return errors.New("statefactory cannot be nil")
+	}
+	// collect the side blocks down to the fork point
+	applied := []*Block{head}
+	for {
+		prevHash := applied[0].PrevHash()
+		if _, err := bc.dao.getBlockHeight(prevHash); err == nil {
+			break
+		}
+		parent, err := bc.dao.getSideBlock(prevHash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find the fork point of block %d", head.Height())
+		}
+		applied = append([]*Block{parent}, applied...)
+	}
+	forkHeight := applied[0].Height() - 1
+	// the states of the fork point may have been pruned
+	if _, err := bc.sf.RootHashByHeight(forkHeight); err != nil {
+		return errors.Wrapf(err, "failed to switch to the branch forked on height %d", forkHeight)
+	}
+
+	orphaned, err := bc.rollback(forkHeight)
+	if err != nil {
+		return errors.Wrapf(err, "failed to roll back to height %d", forkHeight)
+	}
+	for i, blk := range applied {
+		err := bc.applyBlock(blk)
+		if err == nil {
+			continue
+		}
+		logger.Error().Err(err).
+			Uint64("height", blk.Height()).
+			Uint64("forkHeight", forkHeight).
+			Msg("failed to switch to the new branch, restoring the original branch")
+		// the invalid block and its descendants are dropped
+		for _, invalid := range applied[i:] {
+			if err := bc.dao.deleteSideBlock(invalid); err != nil {
+				return errors.Wrapf(err, "failed to delete invalid side block on height %d", invalid.Height())
+			}
+		}
+		if _, err := bc.rollback(forkHeight); err != nil {
+			return errors.Wrapf(err, "failed to roll back to height %d", forkHeight)
+		}
+		for _, original := range orphaned {
+			if err := bc.applyBlock(original); err != nil {
+				return errors.Wrapf(err, "failed to restore block on height %d", original.Height())
+			}
+		}
+		return errors.Wrapf(err, "failed to apply block on height %d of the new branch", blk.Height())
+	}
+
+	logger.Info().
+		Uint64("forkHeight", forkHeight).
+		Int("orphaned", len(orphaned)).
+		Int("applied", len(applied)).
+		Uint64("height", bc.tipHeight).
+		Hex("hash", bc.tipHash[:]).
+		Msg("switch to a new branch")
+	bc.emitReorgToSubscribers(&Reorg{
+		ForkHeight: forkHeight,
+		Orphaned:   orphaned,
+		Applied:    applied,
+	})
+	return nil
+}
+
+// rollback removes the blocks higher than height from the chain and keeps them as side blocks, and rolls the states
+// back to height. The removed blocks are returned in the ascending order of height
+func (bc *blockchain) rollback(height uint64) ([]*Block, error) {
+	// the states are rolled back first, so that the chain is never lower than the factory, which is recoverable
+	if err := bc.sf.Rollback(height); err != nil {
+		return nil, err
+	}
+	var removed []*Block
+	for bc.tipHeight > height {
+		blk, err := bc.getBlockByHeight(bc.tipHeight)
+		if err != nil {
+			return nil, err
+		}
+		if err := bc.dao.putSideBlock(blk); err != nil {
+			return nil, errors.Wrapf(err, "failed to put side block on height %d", blk.Height())
+		}
+		if err := bc.dao.deleteTipBlock(); err != nil {
+			return nil, err
+		}
+		removed = append([]*Block{blk}, removed...)
+		bc.tipHeight--
+	}
+	tipHash, err := bc.dao.getBlockHash(height)
+	if err != nil {
+		return nil, err
+	}
+	bc.tipHash = tipHash
+	return removed, nil
+}
+
+// applyBlock validates a block against the tip and commits it. Unlike validateBlock(), the failure of running the
+// actions is returned instead of panicking, since the block comes from a branch which has not been verified
+func (bc *blockchain) applyBlock(blk *Block) error {
+	if err := bc.validator.Validate(blk, bc.tipHeight, bc.tipHash, true); err != nil {
+		return errors.Wrapf(err, "failed to validate block on height %d", blk.Height())
+	}
+	ws, err := bc.sf.NewWorkingSet()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	if _, err := bc.runActions(blk, ws, true); err != nil {
+		return errors.Wrapf(err, "failed to update state on height %d", blk.Height())
+	}
+	blk.workingSet = ws
+	if err := bc.commitBlock(blk); err != nil {
+		return err
+	}
+	return bc.dao.deleteSideBlock(blk)
+}
+
+func (bc *blockchain) emitReorgToSubscribers(reorg *Reorg) {
+	for _, s := range bc.blocklistener {
+		rs, ok := s.(ReorgSubscriber)
+		if !ok {
+			continue
+		}
+		go func(rs ReorgSubscriber) {
+			if err := rs.HandleReorg(reorg); err != nil {
+				logger.Error().Err(err).Msg("Failed to handle chain reorganisation")
+			}
+		}(rs)
+	}
+}
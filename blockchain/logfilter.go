@@ -0,0 +1,101 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// LogFilter matches the logs emitted by any of the contracts, and having any of the topics at each position, the same
+// as the log filter of Ethereum. Empty addresses match any contract, and empty topics at a position match any topic
+type LogFilter struct {
+	Addresses []string
+	Topics    [][]hash.Hash32B
+}
+
+// Match returns true if the log matches the filter
+func (f *LogFilter) Match(log *action.Log) bool {
+	if len(f.Addresses) > 0 && !containsString(f.Addresses, log.Address) {
+		return false
+	}
+	if len(f.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range f.Topics {
+		if len(topics) > 0 && !containsHash(topics, log.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchBloom returns false if the block of the bloom definitely has no log matching the filter
+func (f *LogFilter) MatchBloom(bloom *LogsBloom) bool {
+	if *bloom == ZeroLogsBloom {
+		return false
+	}
+	if len(f.Addresses) > 0 {
+		matched := false
+		for _, addr := range f.Addresses {
+			if bloom.Test([]byte(addr)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, topics := range f.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		matched := false
+		for _, topic := range topics {
+			if bloom.Test(topic[:]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// isIndexable returns true if the filter has any address or topic to look up in the log index
+func (f *LogFilter) isIndexable() bool {
+	if len(f.Addresses) > 0 {
+		return true
+	}
+	for _, topics := range f.Topics {
+		if len(topics) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+func containsHash(hashes []hash.Hash32B, h hash.Hash32B) bool {
+	for _, hh := range hashes {
+		if hh == h {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+const (
+	// TxRootName is the name of the action merkle root posted to the parent chain
+	TxRootName = "tx"
+	// StateRootName is the name of the state root posted to the parent chain
+	StateRootName = "state"
+	// ReceiptRootName is the name of the receipt merkle root posted to the parent chain
+	ReceiptRootName = "receipt"
+)
+
+var (
+	// EmptyReceiptRoot is the receipt root of a block without any receipt. It's the hash of empty input rather than
+	// the zero hash, so that it's always posted to the parent chain along with the other roots
+	EmptyReceiptRoot = hash.Hash32B(blake2b.Sum256(nil))
+	// ErrProofNotFound indicates that the hash to prove is not in the block
+	ErrProofNotFound = errors.New("hash is not in the block")
+	// ErrInvalidProof indicates that the merkle proof doesn't match the root
+	ErrInvalidProof = errors.New("invalid merkle proof")
+)
+
+// MerkleProof is the proof that a hash is the leaf at Index of a merkle tree of a block
+type MerkleProof struct {
+	Index uint64
+	// Size is the number of leaves of the merkle tree, which bounds the index
+	Size uint64
+	// Path is the list of sibling hashes from the leaf level up to the level right below the root
+	Path []hash.Hash32B
+}
+
+// ActionProof returns the merkle proof of the action against the tx root of the block
+func (b *Block) ActionProof(actHash hash.Hash32B) (*MerkleProof, error) {
+	return merkleProof(b.txLeaves(), actHash)
+}
+
+// CalculateReceiptRoot returns the merkle root of the receipts of the actions in this block, in the order of the
+// actions, or EmptyReceiptRoot if there is none. The receipts are only available on the block which has been run
+// against the states
+func (b *Block) CalculateReceiptRoot() hash.Hash32B {
+	leaves := b.receiptLeaves()
+	if len(leaves) == 0 {
+		return EmptyReceiptRoot
+	}
+	return crypto.NewMerkleTree(leaves).HashTree()
+}
+
+// ReceiptProof returns the merkle proof of the receipt of the action against the receipt root of the block
+func (b *Block) ReceiptProof(actHash hash.Hash32B) (*MerkleProof, error) {
+	receipt, ok := b.receipts[actHash]
+	if !ok {
+		return nil, errors.Wrapf(ErrProofNotFound, "no receipt of action %x", actHash)
+	}
+	return merkleProof(b.receiptLeaves(), ReceiptHash(receipt))
+}
+
+// Receipts returns the receipts of the actions in this block, in the order of the actions. The receipts are only
+// available on the block which has been run against the states, or has them set
+func (b *Block) Receipts() []*action.Receipt {
+	var receipts []*action.Receipt
+	for _, act := range b.Actions {
+		if receipt, ok := b.receipts[act.Hash()]; ok {
+			receipts = append(receipts, receipt)
+		}
+	}
+	return receipts
+}
+
+// HasReceipts returns whether the receipts of the actions in this block are available, i.e., the block has been run
+// against the states or its receipts have been set, so that the receipt root is meaningful
+func (b *Block) HasReceipts() bool { return b.receipts != nil }
+
+// SetReceipts sets the receipts of the actions in this block, e.g., the ones read back from the DB, so that the
+// receipt root and proofs could be calculated
+func (b *Block) SetReceipts(receipts []*action.Receipt) {
+	b.receipts = make(map[hash.Hash32B]*action.Receipt)
+	for _, receipt := range receipts {
+		b.receipts[receipt.Hash] = receipt
+	}
+}
+
+// ReceiptHash returns the hash of the receipt, which is the leaf of the receipt merkle tree
+func ReceiptHash(receipt *action.Receipt) hash.Hash32B {
+	// Serialize never fails on a receipt
+	data, _ := receipt.Serialize()
+	return blake2b.Sum256(data)
+}
+
+// VerifyMerkleProof verifies the proof of the leaf against the root. It's stateless, so that it could be used by light
+// clients and other chains with the roots posted by PutBlock
+func VerifyMerkleProof(root hash.Hash32B, leaf hash.Hash32B, proof *MerkleProof) error {
+	if proof == nil || !crypto.VerifyMerkleProof(root, leaf, proof.Index, proof.Size, proof.Path) {
+		return errors.Wrapf(ErrInvalidProof, "leaf %x, root %x", leaf, root)
+	}
+	return nil
+}
+
+// VerifyActionProof verifies the proof of the action against the tx root among the roots of a block
+func VerifyActionProof(roots map[string]hash.Hash32B, actHash hash.Hash32B, proof *MerkleProof) error {
+	root, ok := roots[TxRootName]
+	if !ok {
+		return errors.Errorf("%s root is missing", TxRootName)
+	}
+	return VerifyMerkleProof(root, actHash, proof)
+}
+
+// VerifyReceiptProof verifies the proof of the receipt against the receipt root among the roots of a block
+func VerifyReceiptProof(roots map[string]hash.Hash32B, receipt *action.Receipt, proof *MerkleProof) error {
+	root, ok := roots[ReceiptRootName]
+	if !ok {
+		return errors.Errorf("%s root is missing", ReceiptRootName)
+	}
+	return VerifyMerkleProof(root, ReceiptHash(receipt), proof)
+}
+
+func (b *Block) txLeaves() []hash.Hash32B {
+	var h []hash.Hash32B
+	for _, sp := range b.SecretProposals {
+		h = append(h, sp.Hash())
+	}
+	if b.SecretWitness != nil {
+		h = append(h, b.SecretWitness.Hash())
+	}
+	for _, act := range b.Actions {
+		h = append(h, act.Hash())
+	}
+	return h
+}
+
+func (b *Block) receiptLeaves() []hash.Hash32B {
+	var h []hash.Hash32B
+	for _, act := range b.Actions {
+		if receipt, ok := b.receipts[act.Hash()]; ok {
+			h = append(h, ReceiptHash(receipt))
+		}
+	}
+	return h
+}
+
+func merkleProof(leaves []hash.Hash32B, leaf hash.Hash32B) (*MerkleProof, error) {
+	for i, h := range leaves {
+		if h != leaf {
+			continue
+		}
+		path, err := crypto.NewMerkleTree(leaves).Proof(uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		return &MerkleProof{Index: uint64(i), Size: uint64(len(leaves)), Path: path}, nil
+	}
+	return nil, errors.Wrapf(ErrProofNotFound, "hash %x", leaf)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestActionAndReceiptProof(t *testing.T) {
+	require := require.New(t)
+
+	var acts []action.Action
+	for i := 0; i < 5; i++ {
+		tsf, err := action.NewTransfer(
+			uint64(i+1),
+			big.NewInt(10),
+			ta.Addrinfo["producer"].RawAddress,
+			ta.Addrinfo["alfa"].RawAddress,
+			[]byte{},
+			testutil.TestGasLimit,
+			big.NewInt(0),
+		)
+		require.NoError(err)
+		acts = append(acts, tsf)
+	}
+	blk := NewBlock(1, 1, hash.ZeroHash32B, 0, ta.Addrinfo["producer"].PublicKey, acts)
+	roots := map[string]hash.Hash32B{TxRootName: blk.TxRoot()}
+	for i, act := range acts {
+		proof, err := blk.ActionProof(act.Hash())
+		require.NoError(err)
+		require.Equal(uint64(i), proof.Index)
+		require.NoError(VerifyActionProof(roots, act.Hash(), proof))
+		// the proof of an action doesn't prove another one
+		if i > 0 {
+			require.Equal(ErrInvalidProof, errors.Cause(VerifyActionProof(roots, acts[0].Hash(), proof)))
+		}
+	}
+	// the last action cannot be proved at the index of its padded copy
+	proof, err := blk.ActionProof(acts[4].Hash())
+	require.NoError(err)
+	require.Equal(uint64(len(acts)), proof.Size)
+	proof.Index = proof.Size
+	require.Equal(ErrInvalidProof, errors.Cause(VerifyActionProof(roots, acts[4].Hash(), proof)))
+	_, err = blk.ActionProof(hash.ZeroHash32B)
+	require.Equal(ErrProofNotFound, errors.Cause(err))
+	require.Error(VerifyActionProof(map[string]hash.Hash32B{}, acts[0].Hash(), &MerkleProof{}))
+
+	// Only the actions with receipts are in the receipt merkle tree
+	require.Equal(EmptyReceiptRoot, blk.CalculateReceiptRoot())
+	require.NotEqual(hash.ZeroHash32B, EmptyReceiptRoot)
+	receipts := []*action.Receipt{
+		{Hash: acts[1].Hash(), GasConsumed: 10, Status: 1},
+		{Hash: acts[3].Hash(), GasConsumed: 20, ContractAddress: ta.Addrinfo["alfa"].RawAddress},
+	}
+	blk.SetReceipts([]*action.Receipt{receipts[1], receipts[0]})
+	require.Equal(receipts, blk.Receipts())
+	roots[ReceiptRootName] = blk.CalculateReceiptRoot()
+	require.NotEqual(EmptyReceiptRoot, roots[ReceiptRootName])
+	for _, receipt := range receipts {
+		proof, err := blk.ReceiptProof(receipt.Hash)
+		require.NoError(err)
+		require.NoError(VerifyReceiptProof(roots, receipt, proof))
+	}
+	proof, err = blk.ReceiptProof(acts[3].Hash())
+	require.NoError(err)
+	require.Error(VerifyReceiptProof(roots, &action.Receipt{Hash: acts[3].Hash()}, proof))
+	_, err = blk.ReceiptProof(acts[0].Hash())
+	require.Equal(ErrProofNotFound, errors.Cause(err))
+}
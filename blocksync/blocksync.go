@@ -17,7 +17,9 @@ import (
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
 	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	pb "github.com/iotexproject/iotex-core/proto"
 )
 
@@ -28,6 +30,8 @@ type BlockSync interface {
 	TargetHeight() uint64
 	P2P() network.Overlay
 	ProcessSyncRequest(sender string, sync *pb.BlockSync) error
+	ProcessHeaderSyncRequest(sender string, sync *pb.BlockHeaderSync) error
+	ProcessStateProofRequest(sender string, req *pb.StateProofRequest) error
 	ProcessBlock(blk *blockchain.Block) error
 	ProcessBlockSync(blk *blockchain.Block) error
 }
@@ -150,3 +154,47 @@ func (bs *blockSyncer) ProcessSyncRequest(sender string, sync *pb.BlockSync) err
 	}
 	return nil
 }
+
+// ProcessHeaderSyncRequest processes a header sync request from a light client, and sends back the headers and footers
+// of the blocks in the range
+func (bs *blockSyncer) ProcessHeaderSyncRequest(sender string, sync *pb.BlockHeaderSync) error {
+	if !bs.ackSyncReq {
+		// node is not meant to handle sync request, simply exit
+		return nil
+	}
+	end := sync.End
+	if tip := bs.bc.TipHeight(); end > tip {
+		end = tip
+	}
+	for i := sync.Start; i <= end; i++ {
+		blk, err := bs.bc.GetBlockByHeight(i)
+		if err != nil {
+			return err
+		}
+		if err := bs.p2p.Tell(bs.bc.ChainID(), node.NewTCPNode(sender), blk.ConvertToBlockHeaderContainer()); err != nil {
+			logger.Warn().Err(err).Msg("Failed to response to ProcessHeaderSyncRequest.")
+		}
+	}
+	return nil
+}
+
+// ProcessStateProofRequest processes a state proof request from a light client, and sends back the trie proof of the
+// state at the height. For a state not existing, the proof proves its absence
+func (bs *blockSyncer) ProcessStateProofRequest(sender string, req *pb.StateProofRequest) error {
+	if !bs.ackSyncReq {
+		// node is not meant to handle sync request, simply exit
+		return nil
+	}
+	if len(req.Key) != hash.PKHashSize {
+		return errors.Errorf("invalid state key %x", req.Key)
+	}
+	proof, err := bs.bc.GetFactory().StateProofByHeight(byteutil.BytesTo20B(req.Key), req.Height)
+	if err != nil {
+		return errors.Wrapf(err, "failed to prove state %x on height %d", req.Key, req.Height)
+	}
+	res := &pb.StateProofResponse{Height: req.Height, Key: req.Key, Proof: proof}
+	if err := bs.p2p.Tell(bs.bc.ChainID(), node.NewTCPNode(sender), res); err != nil {
+		logger.Warn().Err(err).Msg("Failed to response to ProcessStateProofRequest.")
+	}
+	return nil
+}
@@ -21,8 +21,11 @@ import (
 	"github.com/iotexproject/iotex-core/network"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
+	"github.com/iotexproject/iotex-core/test/mock/mock_factory"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -235,6 +238,56 @@ func TestBlockSyncerProcessSyncRequestError(t *testing.T) {
 	require.Error(bs.ProcessSyncRequest("", pbBs))
 }
 
+func TestBlockSyncerProcessLightRequests(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mBc := mock_blockchain.NewMockBlockchain(ctrl)
+	mBc.EXPECT().ChainID().AnyTimes().Return(config.Default.Chain.ID)
+	mBc.EXPECT().TipHeight().AnyTimes().Return(uint64(2))
+	blk := bc.NewBlock(
+		uint32(123),
+		uint64(2),
+		hash.Hash32B{},
+		testutil.TimestampNow(),
+		ta.Addrinfo["producer"].PublicKey,
+		nil,
+	)
+	mBc.EXPECT().GetBlockByHeight(gomock.Any()).Times(2).Return(blk, nil)
+	sf := mock_factory.NewMockFactory(ctrl)
+	mBc.EXPECT().GetFactory().AnyTimes().Return(sf)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+
+	cfgFullNode := config.Config{
+		NodeType: config.FullNodeType,
+	}
+	bs, err := NewBlockSyncer(cfgFullNode, mBc, ap, p2p)
+	require.NoError(err)
+
+	// headers beyond the tip are skipped
+	p2p.EXPECT().Tell(config.Default.Chain.ID, gomock.Any(), blk.ConvertToBlockHeaderContainer()).Times(2).Return(nil)
+	require.NoError(bs.ProcessHeaderSyncRequest("127.0.0.1:10000", &pb.BlockHeaderSync{Start: 1, End: 5}))
+
+	pkHash := hash.PKHash{1}
+	proof := [][]byte{{1, 2, 3}}
+	sf.EXPECT().StateProofByHeight(pkHash, uint64(2)).Return(proof, nil)
+	p2p.EXPECT().Tell(
+		config.Default.Chain.ID,
+		gomock.Any(),
+		&pb.StateProofResponse{Height: 2, Key: pkHash[:], Proof: proof},
+	).Return(nil)
+	require.NoError(bs.ProcessStateProofRequest("127.0.0.1:10000", &pb.StateProofRequest{Height: 2, Key: pkHash[:]}))
+	require.Error(bs.ProcessStateProofRequest("127.0.0.1:10000", &pb.StateProofRequest{Height: 2, Key: []byte{1}}))
+
+	// lightweight nodes don't serve light clients
+	bs.(*blockSyncer).ackSyncReq = false
+	require.NoError(bs.ProcessHeaderSyncRequest("127.0.0.1:10000", &pb.BlockHeaderSync{Start: 1, End: 5}))
+	require.NoError(bs.ProcessStateProofRequest("127.0.0.1:10000", &pb.StateProofRequest{Height: 2, Key: pkHash[:]}))
+}
+
 func TestBlockSyncerProcessBlockTipHeight(t *testing.T) {
 	require := require.New(t)
 	ctx := context.Background()
@@ -44,6 +44,13 @@ func (b *blockBuffer) Flush(blk *blockchain.Block) (bool, bCheckinResult) {
 	// check
 	blkHeight := blk.Height()
 	if blkHeight <= confirmedHeight {
+		// a block on a competing branch is kept by the chain, which switches to the branch once it becomes the longest
+		committedBlk, err := b.bc.GetBlockByHeight(blkHeight)
+		if err == nil && committedBlk.HashBlock() != blk.HashBlock() {
+			if err := commitBlock(b.bc, b.ap, blk); err != nil {
+				logger.Debug().Err(err).Uint64("recvHeight", blkHeight).Msg("Failed to commit the side block.")
+			}
+		}
 		return false, bCheckinLower
 	}
 	if _, ok := b.blocks[blkHeight]; ok {
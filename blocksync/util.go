@@ -15,8 +15,11 @@ import (
 )
 
 func commitBlock(bc blockchain.Blockchain, ap actpool.ActPool, blk *blockchain.Block) error {
-	if err := bc.ValidateBlock(blk, true); err != nil {
-		return err
+	// a block on a competing branch is validated by the chain when it's committed as a side block
+	if blk.PrevHash() == bc.TipHash() {
+		if err := bc.ValidateBlock(blk, true); err != nil {
+			return err
+		}
 	}
 	if err := bc.CommitBlock(blk); err != nil {
 		return err
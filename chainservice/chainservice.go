@@ -8,6 +8,7 @@ package chainservice
 
 import (
 	"context"
+	"math/big"
 	"os"
 
 	"github.com/pkg/errors"
@@ -19,13 +20,18 @@ import (
 	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/explorer"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/indexservice"
+	"github.com/iotexproject/iotex-core/lightclient"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 // ChainService is a blockchain service with all blockchain components.
@@ -33,9 +39,11 @@ type ChainService struct {
 	actpool       actpool.ActPool
 	blocksync     blocksync.BlockSync
 	consensus     consensus.Consensus
+	evidence      *evidence.Collector
 	chain         blockchain.Blockchain
 	explorer      *explorer.Server
 	indexservice  *indexservice.Server
+	lightclient   lightclient.LightClient
 	protocols     []protocol.Protocol
 	runningStatus bool
 }
@@ -74,12 +82,21 @@ func New(cfg config.Config, p2p network.Overlay, dispatcher dispatcher.Dispatche
 	}
 
 	var chainOpts []blockchain.Option
-	if ops.isTesting {
+	// a lightweight node keeps the headers only, and its blockchain holds nothing but the genesis block
+	if ops.isTesting || cfg.IsLightweight() {
 		chainOpts = []blockchain.Option{blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption()}
 	} else {
 		chainOpts = []blockchain.Option{blockchain.DefaultStateFactoryOption(), blockchain.BoltDBDaoOption()}
 	}
 
+	// the producers and the endorsements of the side blocks are verified against the delegates of their epochs
+	delegatesOpt := blockchain.DelegatesOption(
+		func(candidatesByHeight func(uint64) ([]*state.Candidate, error)) func(uint64) ([]string, error) {
+			return evidence.NewDelegatesFunc(cfg, candidatesByHeight)
+		},
+	)
+	chainOpts = append(chainOpts, delegatesOpt)
+
 	// create Blockchain
 	chain := blockchain.NewBlockchain(cfg, chainOpts...)
 	if chain == nil && cfg.Chain.EnableFallBackToFreshDB {
@@ -90,22 +107,40 @@ func New(cfg config.Config, p2p network.Overlay, dispatcher dispatcher.Dispatche
 		if err := os.Rename(cfg.Chain.TrieDBPath, cfg.Chain.TrieDBPath+".old"); err != nil {
 			return nil, errors.Wrap(err, "failed to rename old trie db")
 		}
-		chain = blockchain.NewBlockchain(cfg, blockchain.DefaultStateFactoryOption(), blockchain.BoltDBDaoOption())
+		chain = blockchain.NewBlockchain(
+			cfg,
+			blockchain.DefaultStateFactoryOption(),
+			blockchain.BoltDBDaoOption(),
+			delegatesOpt,
+		)
 	}
 
 	// Create ActPool
-	actPool, err := actpool.NewActPool(chain, cfg.ActPool)
+	var apOpts []actpool.Option
+	if cfg.ActPool.JournalPath != "" && !ops.isTesting {
+		apOpts = append(apOpts, actpool.JournalOption(db.NewOnDiskDB(cfg.ActPool.JournalPath, cfg.DB)))
+	}
+	actPool, err := actpool.NewActPool(chain, cfg.ActPool, apOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create actpool")
 	}
+	// put the orphaned actions back into actpool when the chain switches to another branch
+	if err := chain.AddSubscriber(actpool.NewReorgHandler(actPool)); err != nil {
+		return nil, errors.Wrap(err, "failed to subscribe actpool to chain reorganisation")
+	}
 	bs, err := blocksync.NewBlockSyncer(cfg, chain, actPool, p2p)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create blockSyncer")
 	}
 
-	var copts []consensus.Option
+	collector := evidence.NewCollector(
+		reportEvidence(cfg, actPool),
+		chain.TipHeight,
+		evidence.NewDelegatesFunc(cfg, chain.CandidatesByHeight),
+	)
+	copts := []consensus.Option{consensus.WithEvidenceCollector(collector)}
 	if ops.rootChainAPI != nil {
-		copts = []consensus.Option{consensus.WithRootChainAPI(ops.rootChainAPI)}
+		copts = append(copts, consensus.WithRootChainAPI(ops.rootChainAPI))
 	}
 	consensus := consensus.NewConsensus(cfg, chain, actPool, p2p, copts...)
 	if consensus == nil {
@@ -120,9 +155,23 @@ func New(cfg config.Config, p2p network.Overlay, dispatcher dispatcher.Dispatche
 		}
 	}
 
+	var lc lightclient.LightClient
+	if cfg.IsLightweight() {
+		headerDB := db.NewOnDiskDB(cfg.Chain.HeaderDBPath, cfg.DB)
+		if ops.isTesting {
+			headerDB = db.NewMemKVStore()
+		}
+		if lc, err = lightclient.NewLightClient(cfg, chain, p2p, headerDB); err != nil {
+			return nil, errors.Wrap(err, "failed to create light client")
+		}
+	}
+
 	var exp *explorer.Server
 	if cfg.Explorer.Enabled {
 		exp = explorer.NewServer(cfg.Explorer, chain, consensus, dispatcher, actPool, p2p, idx)
+		if lc != nil {
+			exp.SetLightClient(lc)
+		}
 	}
 
 	return &ChainService{
@@ -130,7 +179,9 @@ func New(cfg config.Config, p2p network.Overlay, dispatcher dispatcher.Dispatche
 		chain:         chain,
 		blocksync:     bs,
 		consensus:     consensus,
+		evidence:      collector,
 		indexservice:  idx,
+		lightclient:   lc,
 		explorer:      exp,
 		runningStatus: false,
 	}, nil
@@ -146,6 +197,14 @@ func (cs *ChainService) Start(ctx context.Context) error {
 	if err := cs.chain.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting blockchain")
 	}
+	if err := cs.actpool.Start(ctx); err != nil {
+		return errors.Wrap(err, "error when starting actpool")
+	}
+	if cs.lightclient != nil {
+		if err := cs.lightclient.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting light client")
+		}
+	}
 	if err := cs.consensus.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting consensus")
 	}
@@ -182,6 +241,14 @@ func (cs *ChainService) Stop(ctx context.Context) error {
 	if err := cs.blocksync.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping blocksync")
 	}
+	if cs.lightclient != nil {
+		if err := cs.lightclient.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping light client")
+		}
+	}
+	if err := cs.actpool.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping actpool")
+	}
 	if err := cs.chain.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping blockchain")
 	}
@@ -196,27 +263,8 @@ func (cs *ChainService) IsRunning() bool {
 
 // HandleAction handles incoming action request.
 func (cs *ChainService) HandleAction(actPb *pb.ActionPb) error {
-	var act action.Action
-	if actPb.GetTransfer() != nil {
-		act = &action.Transfer{}
-	} else if actPb.GetVote() != nil {
-		act = &action.Vote{}
-	} else if actPb.GetExecution() != nil {
-		act = &action.Execution{}
-	} else if actPb.GetPutBlock() != nil {
-		act = &action.PutBlock{}
-	} else if actPb.GetStartSubChain() != nil {
-		act = &action.StartSubChain{}
-	} else if actPb.GetStopSubChain() != nil {
-		act = &action.StopSubChain{}
-	} else if actPb.GetCreateDeposit() != nil {
-		act = &action.CreateDeposit{}
-	} else if actPb.GetSettleDeposit() != nil {
-		act = &action.SettleDeposit{}
-	} else {
-		return errors.New("no appliable action to handle in action proto")
-	}
-	if err := act.LoadProto(actPb); err != nil {
+	act, err := action.LoadAction(actPb)
+	if err != nil {
 		return err
 	}
 	if err := cs.actpool.Add(act); err != nil {
@@ -236,6 +284,9 @@ func (cs *ChainService) HandleBlock(pbBlock *pb.BlockPb) error {
 	if err := blk.ConvertFromBlockPb(pbBlock); err != nil {
 		return err
 	}
+	if cs.lightclient != nil {
+		return cs.lightclient.ProcessBlockHeader(blk)
+	}
 	return cs.blocksync.ProcessBlock(blk)
 }
 
@@ -253,6 +304,36 @@ func (cs *ChainService) HandleSyncRequest(sender string, sync *pb.BlockSync) err
 	return cs.blocksync.ProcessSyncRequest(sender, sync)
 }
 
+// HandleHeaderSyncRequest handles incoming header sync request from a light client.
+func (cs *ChainService) HandleHeaderSyncRequest(sender string, sync *pb.BlockHeaderSync) error {
+	return cs.blocksync.ProcessHeaderSyncRequest(sender, sync)
+}
+
+// HandleBlockHeader handles incoming block header synced by the light client.
+func (cs *ChainService) HandleBlockHeader(container *pb.BlockHeaderContainer) error {
+	if cs.lightclient == nil {
+		return nil
+	}
+	blk := &blockchain.Block{}
+	if err := blk.ConvertFromBlockHeaderContainer(container); err != nil {
+		return err
+	}
+	return cs.lightclient.ProcessBlockHeader(blk)
+}
+
+// HandleStateProofRequest handles incoming state proof request from a light client.
+func (cs *ChainService) HandleStateProofRequest(sender string, req *pb.StateProofRequest) error {
+	return cs.blocksync.ProcessStateProofRequest(sender, req)
+}
+
+// HandleStateProof handles incoming state proof requested by the light client.
+func (cs *ChainService) HandleStateProof(res *pb.StateProofResponse) error {
+	if cs.lightclient == nil {
+		return nil
+	}
+	return cs.lightclient.ProcessStateProof(res)
+}
+
 // HandleBlockPropose handles incoming block propose request.
 func (cs *ChainService) HandleBlockPropose(propose *pb.ProposePb) error {
 	return cs.consensus.HandleBlockPropose(propose)
@@ -263,6 +344,16 @@ func (cs *ChainService) HandleEndorse(endorse *pb.EndorsePb) error {
 	return cs.consensus.HandleEndorse(endorse)
 }
 
+// CollectBlockPropose collects a block proposal to detect equivocations.
+func (cs *ChainService) CollectBlockPropose(propose *pb.ProposePb) {
+	cs.evidence.CollectBlockPropose(propose)
+}
+
+// CollectEndorse collects an endorsement to detect equivocations.
+func (cs *ChainService) CollectEndorse(endorse *pb.EndorsePb) {
+	cs.evidence.CollectEndorse(endorse)
+}
+
 // ChainID returns ChainID.
 func (cs *ChainService) ChainID() uint32 { return cs.chain.ChainID() }
 
@@ -286,6 +377,11 @@ func (cs *ChainService) BlockSync() blocksync.BlockSync {
 	return cs.blocksync
 }
 
+// LightClient returns the light client, which is nil unless the node is lightweight
+func (cs *ChainService) LightClient() lightclient.LightClient {
+	return cs.lightclient
+}
+
 // IndexService returns the indexservice instance
 func (cs *ChainService) IndexService() *indexservice.Server {
 	return cs.indexservice
@@ -309,3 +405,44 @@ func (cs *ChainService) AddProtocols(protocols ...protocol.Protocol) {
 		cs.actpool.AddActionValidators(protocol)
 	}
 }
+
+// reportEvidence returns the reporter putting the evidence signed by the block producer of the node into the actpool
+func reportEvidence(cfg config.Config, ap actpool.ActPool) evidence.Reporter {
+	return func(evidenceType action.EvidenceType, first []byte, second []byte) {
+		_, sk, err := cfg.KeyPair()
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to get the key pair to report evidence")
+			return
+		}
+		if sk == keypair.ZeroPrivateKey {
+			return
+		}
+		addr, err := cfg.BlockchainAddress()
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to get the address to report evidence")
+			return
+		}
+		reporter := addr.IotxAddress()
+		nonce, err := ap.GetPendingNonce(reporter)
+		if err != nil {
+			logger.Error().Err(err).Str("reporter", reporter).Msg("Failed to get the pending nonce")
+			return
+		}
+		act := action.NewEvidence(
+			nonce,
+			reporter,
+			evidenceType,
+			first,
+			second,
+			action.EvidenceIntrinsicGas,
+			big.NewInt(0),
+		)
+		if err := action.Sign(act, sk); err != nil {
+			logger.Error().Err(err).Msg("Failed to sign evidence")
+			return
+		}
+		if err := ap.Add(act); err != nil {
+			logger.Error().Err(err).Msg("Failed to add evidence into actpool")
+		}
+	}
+}
@@ -8,6 +8,7 @@ package config
 
 import (
 	"flag"
+	"math"
 	"os"
 	"time"
 
@@ -51,6 +52,18 @@ const (
 	StandaloneScheme = "STANDALONE"
 	// NOOPScheme means that the node does not create only block
 	NOOPScheme = "NOOP"
+
+	// BoltDBEngine means that the on-disk KV store is backed by bolt DB
+	BoltDBEngine = "bolt"
+	// LevelDBEngine means that the on-disk KV store is backed by LevelDB
+	LevelDBEngine = "leveldb"
+
+	// MySQLDialect means that the index service is backed by MySQL, e.g., AWS RDS
+	MySQLDialect = "mysql"
+	// PostgresDialect means that the index service is backed by PostgreSQL
+	PostgresDialect = "postgres"
+	// SQLiteDialect means that the index service is backed by an embedded SQLite DB file
+	SQLiteDialect = "sqlite3"
 )
 
 var (
@@ -89,6 +102,7 @@ var (
 		Chain: Chain{
 			ChainDBPath:                  "/tmp/chain.db",
 			TrieDBPath:                   "/tmp/trie.db",
+			HeaderDBPath:                 "/tmp/header.db",
 			ID:                           1,
 			Address:                      "",
 			ProducerPubKey:               keypair.EncodePublicKey(keypair.ZeroPublicKey),
@@ -100,11 +114,26 @@ var (
 			EnableFallBackToFreshDB:      false,
 			EnableSubChainStartInGenesis: false,
 			EnableGasCharge:              false,
+			CandidatesRootHeight:         math.MaxUint64,
+			LogsBloomHeight:              math.MaxUint64,
+			TriePruning: TriePruning{
+				Enabled:         false,
+				RetainedHeights: 720,
+				Interval:        10 * time.Minute,
+			},
+			SideBlocks: SideBlocks{
+				HeightWindow: 720,
+				MaxNum:       1000,
+			},
 		},
 		ActPool: ActPool{
-			MaxNumActsPerPool: 32000,
-			MaxNumActsPerAcct: 2000,
-			MaxNumActsToPick:  0,
+			MaxNumActsPerPool:  32000,
+			MaxNumActsPerAcct:  2000,
+			MaxNumActsToPick:   0,
+			JournalPath:        "",
+			ActionTTL:          3 * time.Hour,
+			AccountIdleTimeout: time.Hour,
+			SweepInterval:      time.Minute,
 		},
 		Consensus: Consensus{
 			Scheme: NOOPScheme,
@@ -117,12 +146,14 @@ var (
 				AcceptProposeTTL:         time.Second,
 				AcceptProposalEndorseTTL: time.Second,
 				AcceptCommitEndorseTTL:   time.Second,
-				Delay:             5 * time.Second,
-				NumSubEpochs:      1,
-				EventChanSize:     10000,
-				NumDelegates:      21,
-				TimeBasedRotation: false,
-				EnableDKG:         false,
+				Delay:                    5 * time.Second,
+				NumSubEpochs:             1,
+				EventChanSize:            10000,
+				NumDelegates:             21,
+				TimeBasedRotation:        false,
+				EnableDKG:                false,
+				WALPath:                  "/tmp/consensus.wal.db",
+				AggregateEndorsements:    false,
 			},
 			BlockCreationInterval: 10 * time.Second,
 		},
@@ -144,10 +175,17 @@ var (
 				Percentile:         60,
 			},
 			MaxTransferPayloadBytes: 1024,
+			StreamPort:              0,
+			APIPort:                 0,
+			APIGatewayPort:          0,
+			EthPort:                 0,
 		},
 		Indexer: Indexer{
-			Enabled:  false,
-			NodeAddr: "",
+			Enabled:            false,
+			NodeAddr:           "",
+			BackfillBatchSize:  100,
+			ReindexStartHeight: 0,
+			ReindexEndHeight:   0,
 		},
 		System: System{
 			HeartbeatInterval:     10 * time.Second,
@@ -157,6 +195,10 @@ var (
 		},
 		DB: DB{
 			NumRetries: 3,
+			Engine:     BoltDBEngine,
+			RDS: RDS{
+				Dialect: MySQLDialect,
+			},
 		},
 	}
 
@@ -173,6 +215,8 @@ var (
 		ValidateNetwork,
 		ValidateActPool,
 		ValidateChain,
+		ValidateDB,
+		ValidateIndexer,
 	}
 )
 
@@ -213,6 +257,8 @@ type (
 	Chain struct {
 		ChainDBPath string `yaml:"chainDBPath"`
 		TrieDBPath  string `yaml:"trieDBPath"`
+		// HeaderDBPath is the path of the DB keeping the block headers synced by a lightweight node
+		HeaderDBPath string `yaml:"headerDBPath"`
 
 		ID              uint32 `yaml:"id"`
 		Address         string `yaml:"address"`
@@ -229,6 +275,34 @@ type (
 
 		// enable gas charge for block producer
 		EnableGasCharge bool `yaml:"enableGasCharge"`
+		// CandidatesRootHeight is the height from which the candidate list is committed by the state root, so that
+		// lightweight nodes could prove the candidates. It changes the state roots, so it has to be set to a height not
+		// reached yet on a running chain
+		CandidatesRootHeight uint64 `yaml:"candidatesRootHeight"`
+		// LogsBloomHeight is the height from which the block headers carry the bloom of the logs. It changes the block
+		// hashes, so it has to be set to a height not reached yet on a running chain
+		LogsBloomHeight uint64 `yaml:"logsBloomHeight"`
+
+		TriePruning TriePruning `yaml:"triePruning"`
+		SideBlocks  SideBlocks  `yaml:"sideBlocks"`
+	}
+
+	// TriePruning is the config struct for pruning the state trie nodes which are no longer reachable
+	TriePruning struct {
+		Enabled bool `yaml:"enabled"`
+		// RetainedHeights is the number of the latest heights whose states are kept
+		RetainedHeights uint64 `yaml:"retainedHeights"`
+		// Interval is the interval between two rounds of pruning
+		Interval time.Duration `yaml:"interval"`
+	}
+
+	// SideBlocks is the config struct for keeping the blocks on the competing branches
+	SideBlocks struct {
+		// HeightWindow is the max distance between the height of a side block and the tip. The side blocks out of the
+		// window are rejected, and the ones falling below it as the tip grows are deleted
+		HeightWindow uint64 `yaml:"heightWindow"`
+		// MaxNum is the max number of the side blocks kept
+		MaxNum uint64 `yaml:"maxNum"`
 	}
 
 	// Consensus is the config struct for consensus package
@@ -261,6 +335,12 @@ type (
 		NumDelegates             uint          `yaml:"numDelegates"`
 		TimeBasedRotation        bool          `yaml:"timeBasedRotation"`
 		EnableDKG                bool          `yaml:"enableDKG"`
+		// WALPath is the path of the write-ahead log of the proposals and endorsements, which a delegate must not clear
+		WALPath string `yaml:"walPath"`
+		// AggregateEndorsements signs the commit endorsements with the DKG keys as well, and folds them into an
+		// aggregate signature in the block footers. It takes effect once the DKG keys of the epoch are generated and a
+		// quorum of the delegates have signed with them
+		AggregateEndorsements bool `yaml:"aggregateEndorsements"`
 	}
 
 	// Dispatcher is the dispatcher config
@@ -278,6 +358,15 @@ type (
 		GasStation GasStation `yaml:"gasStation"`
 		// MaxTransferPayloadBytes limits how many bytes a playload can contain at most
 		MaxTransferPayloadBytes uint64 `yaml:"maxTransferPayloadBytes"`
+		// StreamPort is the port of the gRPC streaming service pushing the new blocks, actions and logs. 0 disables
+		// the service
+		StreamPort int `yaml:"streamPort"`
+		// APIPort is the port of the gRPC API service. 0 disables the service
+		APIPort int `yaml:"apiPort"`
+		// APIGatewayPort is the port of the gateway serving the API over HTTP JSON. 0 disables the gateway
+		APIGatewayPort int `yaml:"apiGatewayPort"`
+		// EthPort is the port of the Ethereum compatible JSON-RPC service. 0 disables the service
+		EthPort int `yaml:"ethPort"`
 	}
 
 	// GasStation is the gas station config
@@ -291,6 +380,12 @@ type (
 	Indexer struct {
 		Enabled  bool   `yaml:"enabled"`
 		NodeAddr string `yaml:"nodeAddr"`
+		// BackfillBatchSize is the number of blocks indexed in a transaction when catching up with the tip
+		BackfillBatchSize uint64 `yaml:"backfillBatchSize"`
+		// ReindexStartHeight and ReindexEndHeight are the range of the blocks to reindex once the backfill catches up
+		// with the tip, e.g., the ones indexed by a buggy version. No block is reindexed if the end height is 0
+		ReindexStartHeight uint64 `yaml:"reindexStartHeight"`
+		ReindexEndHeight   uint64 `yaml:"reindexEndHeight"`
 	}
 
 	// System is the system config
@@ -312,19 +407,40 @@ type (
 		// MaxNumActsToPick indicates maximum number of actions to pick to mint a block. Default is 0, which means no
 		// limit on the number of actions to pick.
 		MaxNumActsToPick uint64 `yaml:"maxNumActsToPick"`
-	}
-
-	// DB is the blotDB config
+		// JournalPath is the path of the journal of the accepted actions, which are replayed on restart. The actions
+		// aren't journaled if empty
+		JournalPath string `yaml:"journalPath"`
+		// ActionTTL is how long an action can stay in the actpool before it's dropped as expired. The actions don't
+		// expire if 0
+		ActionTTL time.Duration `yaml:"actionTTL"`
+		// AccountIdleTimeout is how long an account can stay in the actpool without any of its actions accepted or
+		// confirmed, before all its actions are dropped. The accounts don't time out if 0
+		AccountIdleTimeout time.Duration `yaml:"accountIdleTimeout"`
+		// SweepInterval is the interval to sweep the expired actions and the idle accounts
+		SweepInterval time.Duration `yaml:"sweepInterval"`
+	}
+
+	// DB is the on-disk KV store config
 	DB struct {
 		// NumRetries is the number of retries
 		NumRetries uint8 `yaml:"numRetries"`
+		// Engine is the embedded DB backing the on-disk KV store, either bolt or leveldb
+		Engine string `yaml:"engine"`
 
 		// RDS is the config fot rds
 		RDS RDS `yaml:"RDS"`
 	}
 
-	// RDS is the cloud rds config
+	// RDS is the config of the SQL DB backing the index service. The endpoint, port, user, pass and DB name are shared
+	// by MySQL and PostgreSQL
 	RDS struct {
+		// Dialect is the SQL dialect of the DB, either mysql, postgres or sqlite3
+		Dialect string `yaml:"dialect"`
+		// SQLitePath is the path of the SQLite DB file
+		SQLitePath string `yaml:"sqlitePath"`
+		// SSLMode is the SSL mode of the PostgreSQL connection, e.g., disable, require or verify-full. The driver's
+		// default is used if it's empty
+		SSLMode string `yaml:"sslMode"`
 		// AwsRDSEndpoint is the endpoint of aws rds
 		AwsRDSEndpoint string `yaml:"awsRDSEndpoint"`
 		// AwsRDSPort is the port of aws rds
@@ -494,6 +610,15 @@ func ValidateChain(cfg Config) error {
 	if cfg.Consensus.Scheme == RollDPoSScheme && cfg.Chain.NumCandidates < cfg.Consensus.RollDPoS.NumDelegates {
 		return errors.Wrapf(ErrInvalidCfg, "candidate number should be greater than or equal to delegate number")
 	}
+	if cfg.Chain.TriePruning.Enabled && cfg.Chain.TriePruning.RetainedHeights == 0 {
+		return errors.Wrapf(ErrInvalidCfg, "number of retained heights should be greater than 0 when pruning trie")
+	}
+	if cfg.Chain.TriePruning.Enabled && cfg.Chain.TriePruning.Interval <= 0 {
+		return errors.Wrapf(ErrInvalidCfg, "trie pruning interval should be greater than 0")
+	}
+	if cfg.IsLightweight() && (cfg.Chain.HeaderDBPath == "" || cfg.Chain.HeaderDBPath == cfg.Chain.ChainDBPath) {
+		return errors.Wrapf(ErrInvalidCfg, "header db path of lightweight node should be set apart from chain db path")
+	}
 	return nil
 }
 
@@ -539,6 +664,9 @@ func ValidateRollDPoS(cfg Config) error {
 	if ttl >= rollDPoS.ProposerInterval {
 		return errors.Wrap(ErrInvalidCfg, "roll-DPoS ttl sum is larger than proposer interval")
 	}
+	if cfg.NodeType == DelegateType && rollDPoS.WALPath == "" {
+		return errors.Wrap(ErrInvalidCfg, "roll-DPoS delegate should have a write-ahead log path")
+	}
 
 	return nil
 }
@@ -548,6 +676,36 @@ func ValidateExplorer(cfg Config) error {
 	if cfg.Explorer.Enabled && cfg.Explorer.TpsWindow <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "tps window is not a positive integer when the explorer is enabled")
 	}
+	if cfg.Explorer.Enabled && cfg.Explorer.StreamPort != 0 && cfg.Explorer.StreamPort == cfg.Explorer.Port {
+		return errors.Wrap(ErrInvalidCfg, "stream port cannot be the same as the explorer port")
+	}
+	if cfg.Explorer.Enabled && cfg.Explorer.APIGatewayPort != 0 {
+		if cfg.Explorer.APIPort == 0 {
+			return errors.Wrap(ErrInvalidCfg, "api gateway requires the api port")
+		}
+		if cfg.Explorer.APIGatewayPort == cfg.Explorer.APIPort {
+			return errors.Wrap(ErrInvalidCfg, "api gateway port cannot be the same as the api port")
+		}
+	}
+	if cfg.Explorer.Enabled && cfg.Explorer.APIPort != 0 {
+		for _, port := range []int{cfg.Explorer.Port, cfg.Explorer.StreamPort, cfg.Explorer.APIGatewayPort} {
+			if port == cfg.Explorer.APIPort {
+				return errors.Wrap(ErrInvalidCfg, "api port cannot be the same as the other explorer ports")
+			}
+		}
+	}
+	if cfg.Explorer.Enabled && cfg.Explorer.EthPort != 0 {
+		for _, port := range []int{
+			cfg.Explorer.Port,
+			cfg.Explorer.StreamPort,
+			cfg.Explorer.APIPort,
+			cfg.Explorer.APIGatewayPort,
+		} {
+			if port == cfg.Explorer.EthPort {
+				return errors.Wrap(ErrInvalidCfg, "eth port cannot be the same as the other explorer ports")
+			}
+		}
+	}
 	return nil
 }
 
@@ -575,6 +733,45 @@ func ValidateActPool(cfg Config) error {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		)
 	}
+	if cfg.ActPool.ActionTTL < 0 || cfg.ActPool.AccountIdleTimeout < 0 {
+		return errors.Wrap(ErrInvalidCfg, "action TTL or account idle timeout cannot be negative")
+	}
+	expiring := cfg.ActPool.ActionTTL > 0 || cfg.ActPool.AccountIdleTimeout > 0
+	if expiring && cfg.ActPool.SweepInterval <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "sweep interval must be positive when actions or accounts expire")
+	}
+	return nil
+}
+
+// ValidateDB validates the DB configs
+func ValidateDB(cfg Config) error {
+	switch cfg.DB.Engine {
+	case BoltDBEngine, LevelDBEngine:
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown DB engine %s", cfg.DB.Engine)
+	}
+	switch cfg.DB.RDS.Dialect {
+	case MySQLDialect, PostgresDialect:
+	case SQLiteDialect:
+		if cfg.DB.RDS.SQLitePath == "" {
+			return errors.Wrap(ErrInvalidCfg, "SQLite DB path cannot be empty")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown SQL dialect %s", cfg.DB.RDS.Dialect)
+	}
+	return nil
+}
+
+// ValidateIndexer validates the index service configs
+func ValidateIndexer(cfg Config) error {
+	if cfg.Indexer.ReindexEndHeight > 0 && cfg.Indexer.ReindexStartHeight > cfg.Indexer.ReindexEndHeight {
+		return errors.Wrapf(
+			ErrInvalidCfg,
+			"reindex start height %d is higher than end height %d",
+			cfg.Indexer.ReindexStartHeight,
+			cfg.Indexer.ReindexEndHeight,
+		)
+	}
 	return nil
 }
 
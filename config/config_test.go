@@ -207,6 +207,38 @@ func TestValidateExplorer(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "tps window is not a positive integer when the explorer is enabled"),
 	)
+
+	cfg.Explorer.TpsWindow = 10
+	cfg.Explorer.StreamPort = cfg.Explorer.Port
+	err = ValidateExplorer(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "stream port cannot be the same as the explorer port"))
+
+	cfg.Explorer.StreamPort = 0
+	cfg.Explorer.APIGatewayPort = 14015
+	err = ValidateExplorer(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "api gateway requires the api port"))
+
+	cfg.Explorer.APIPort = cfg.Explorer.Port
+	err = ValidateExplorer(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "api port cannot be the same as the other explorer ports"))
+
+	cfg.Explorer.APIPort = 14014
+	require.NoError(t, ValidateExplorer(cfg))
+
+	cfg.Explorer.EthPort = cfg.Explorer.APIGatewayPort
+	err = ValidateExplorer(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "eth port cannot be the same as the other explorer ports"))
+
+	cfg.Explorer.EthPort = 14016
+	require.NoError(t, ValidateExplorer(cfg))
 }
 
 func TestValidateChain(t *testing.T) {
@@ -232,6 +264,17 @@ func TestValidateChain(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "candidate number should be greater than or equal to delegate number"),
 	)
+
+	cfg = Default
+	cfg.Chain.TriePruning.Enabled = true
+	cfg.Chain.TriePruning.RetainedHeights = 0
+	err = ValidateChain(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(
+		t,
+		strings.Contains(err.Error(), "number of retained heights should be greater than 0"),
+	)
 }
 
 func TestValidateConsensusScheme(t *testing.T) {
@@ -312,6 +355,22 @@ func TestValidateRollDPoS(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "roll-DPoS event delegate number should be greater than 0"),
 	)
+
+	cfg.Consensus.RollDPoS.NumDelegates = 1
+	cfg.Consensus.RollDPoS.ProposerInterval = 10 * time.Second
+	// the default write-ahead log path is on disk, so the delegates without the path set keep working
+	require.NoError(t, ValidateRollDPoS(cfg))
+	cfg.Consensus.RollDPoS.WALPath = ""
+	err = ValidateRollDPoS(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(
+		t,
+		strings.Contains(err.Error(), "roll-DPoS delegate should have a write-ahead log path"),
+	)
+
+	cfg.Consensus.RollDPoS.WALPath = filepath.Join(os.TempDir(), "consensus.wal.db")
+	require.NoError(t, ValidateRollDPoS(cfg))
 }
 
 func TestValidateNetwork(t *testing.T) {
@@ -364,6 +423,66 @@ func TestValidateActPool(t *testing.T) {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		),
 	)
+
+	cfg.ActPool.MaxNumActsPerPool = 100
+	cfg.ActPool.ActionTTL = -time.Second
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "action TTL or account idle timeout cannot be negative"))
+
+	cfg.ActPool.ActionTTL = time.Hour
+	cfg.ActPool.SweepInterval = 0
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "sweep interval must be positive when actions or accounts expire"))
+
+	cfg.ActPool.ActionTTL = 0
+	cfg.ActPool.AccountIdleTimeout = 0
+	require.NoError(t, ValidateActPool(cfg))
+}
+
+func TestValidateDB(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateDB(cfg))
+	cfg.DB.Engine = LevelDBEngine
+	require.NoError(t, ValidateDB(cfg))
+
+	cfg.DB.Engine = "rocksdb"
+	err := ValidateDB(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown DB engine rocksdb"))
+
+	cfg = Default
+	cfg.DB.RDS.Dialect = PostgresDialect
+	require.NoError(t, ValidateDB(cfg))
+	cfg.DB.RDS.Dialect = SQLiteDialect
+	err = ValidateDB(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "SQLite DB path cannot be empty"))
+	cfg.DB.RDS.SQLitePath = "/tmp/index.db"
+	require.NoError(t, ValidateDB(cfg))
+
+	cfg.DB.RDS.Dialect = "oracle"
+	err = ValidateDB(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown SQL dialect oracle"))
+}
+
+func TestValidateIndexer(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateIndexer(cfg))
+	cfg.Indexer.ReindexStartHeight = 10
+	cfg.Indexer.ReindexEndHeight = 20
+	require.NoError(t, ValidateIndexer(cfg))
+
+	cfg.Indexer.ReindexEndHeight = 5
+	err := ValidateIndexer(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "reindex start height 10 is higher than end height 5"))
 }
 
 func TestCheckNodeType(t *testing.T) {
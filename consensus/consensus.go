@@ -17,8 +17,10 @@ import (
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/db"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/logger"
@@ -45,7 +47,8 @@ type IotxConsensus struct {
 }
 
 type optionParams struct {
-	rootChainAPI explorerapi.Explorer
+	rootChainAPI      explorerapi.Explorer
+	evidenceCollector *evidence.Collector
 }
 
 // Option sets Consensus construction parameter.
@@ -59,6 +62,14 @@ func WithRootChainAPI(exp explorerapi.Explorer) Option {
 	}
 }
 
+// WithEvidenceCollector is an option to collect the consensus messages to detect equivocations.
+func WithEvidenceCollector(collector *evidence.Collector) Option {
+	return func(ops *optionParams) error {
+		ops.evidenceCollector = collector
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg config.Config,
@@ -125,7 +136,8 @@ func NewConsensus(
 			SetBlockchain(bc).
 			SetActPool(ap).
 			SetClock(clock).
-			SetP2P(p2p)
+			SetP2P(p2p).
+			SetDelegatesFunc(evidence.NewDelegatesFunc(cfg, bc.CandidatesByHeight))
 		if ops.rootChainAPI != nil {
 			bd = bd.SetCandidatesByHeightFunc(func(h uint64) ([]*state.Candidate, error) {
 				rawcs, err := ops.rootChainAPI.GetCandidateMetricsByHeight(int64(h))
@@ -161,6 +173,14 @@ func NewConsensus(
 			})
 			bd = bd.SetRootChainAPI(ops.rootChainAPI)
 		}
+		if ops.evidenceCollector != nil {
+			bd = bd.SetEvidenceCollector(ops.evidenceCollector)
+		}
+		// the write-ahead log is kept in memory only if the path is cleared, which is for tests, as a delegate
+		// without the path doesn't pass the config validation
+		if cfg.Consensus.RollDPoS.WALPath != "" {
+			bd = bd.SetWALStore(db.NewOnDiskDB(cfg.Consensus.RollDPoS.WALPath, cfg.DB))
+		}
 		cs.scheme, err = bd.Build()
 		if err != nil {
 			logger.Panic().Err(err).Msg("error when constructing RollDPoS")
@@ -0,0 +1,236 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+// keepHeights is the number of the heights below and above the tip whose messages are kept by the collector
+const keepHeights = 10
+
+var evidenceMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_consensus_evidence",
+		Help: "Equivocations found in consensus messages",
+	},
+	[]string{"type"},
+)
+
+func init() {
+	prometheus.MustRegister(evidenceMtc)
+}
+
+// Reporter reports the two conflicting messages of an equivocation found by the collector
+type Reporter func(evidenceType action.EvidenceType, first []byte, second []byte)
+
+// Collector collects the consensus messages of the heights around the tip from the delegates of these heights, and
+// reports an equivocation once the messages of a delegate conflict with each other. Each offence is reported once
+type Collector struct {
+	mutex        sync.Mutex
+	report       Reporter
+	tipHeight    func() uint64
+	delegates    DelegatesFunc
+	height       uint64
+	endorsements map[endorseKey]*message
+	proposals    map[proposalKey]*message
+	reported     map[Offence]bool
+	// delegateSets caches the delegates of the heights around the tip
+	delegateSets map[uint64]map[string]bool
+}
+
+// message is a collected message and the hash of the block it's on
+type message struct {
+	blkHash hash.Hash32B
+	data    []byte
+}
+
+type endorseKey struct {
+	endorser string
+	height   uint64
+	round    uint32
+	topic    iproto.EndorsePb_ConsensusVoteTopic
+}
+
+type proposalKey struct {
+	producer string
+	height   uint64
+	prevHash hash.Hash32B
+}
+
+// NewCollector creates a collector reporting the equivocations with report. tipHeight returns the height of the tip
+// of the chain, and delegates returns the delegates of a height
+func NewCollector(report Reporter, tipHeight func() uint64, delegates DelegatesFunc) *Collector {
+	return &Collector{
+		report:       report,
+		tipHeight:    tipHeight,
+		delegates:    delegates,
+		endorsements: make(map[endorseKey]*message),
+		proposals:    make(map[proposalKey]*message),
+		reported:     make(map[Offence]bool),
+		delegateSets: make(map[uint64]map[string]bool),
+	}
+}
+
+// CollectEndorse collects an endorsement
+func (c *Collector) CollectEndorse(ePb *iproto.EndorsePb) {
+	data, err := proto.Marshal(ePb)
+	if err != nil {
+		logger.Debug().Err(err).Msg("failed to marshal endorsement")
+		return
+	}
+	key := endorseKey{
+		endorser: ePb.Endorser,
+		height:   ePb.Height,
+		round:    ePb.Round,
+		topic:    ePb.Topic,
+	}
+	// the same message is usually received more than once, which is skipped before verifying the signature
+	c.mutex.Lock()
+	recorded, ok := c.endorsements[key]
+	c.mutex.Unlock()
+	if ok && bytes.Equal(recorded.data, data) {
+		return
+	}
+	en, err := decodeEndorsement(data)
+	if err != nil {
+		logger.Debug().Err(err).Msg("skip invalid endorsement")
+		return
+	}
+	msg := &message{blkHash: en.ConsensusVote().BlkHash, data: data}
+	offence := Offence{Offender: en.Endorser(), Height: ePb.Height}
+
+	c.mutex.Lock()
+	record, conflict := c.check(offence, c.endorsements[key], msg)
+	if record {
+		c.endorsements[key] = msg
+	}
+	c.mutex.Unlock()
+	if conflict != nil {
+		c.emit(action.DoubleEndorsement, offence, conflict.data, data)
+	}
+}
+
+// CollectBlockPropose collects a block proposal. The proposal is keyed by the producer of the block rather than the
+// proposer, who may propose a block produced in an earlier round
+func (c *Collector) CollectBlockPropose(pPb *iproto.ProposePb) {
+	data, err := proto.Marshal(pPb)
+	if err != nil {
+		logger.Debug().Err(err).Msg("failed to marshal proposal")
+		return
+	}
+	blk, err := decodeProposal(data)
+	if err != nil {
+		logger.Debug().Err(err).Msg("skip invalid proposal")
+		return
+	}
+	key := proposalKey{producer: blk.ProducerAddress(), height: blk.Height(), prevHash: blk.PrevHash()}
+	msg := &message{blkHash: blk.HashBlock(), data: data}
+	offence := Offence{Offender: key.producer, Height: key.height}
+
+	c.mutex.Lock()
+	record, conflict := c.check(offence, c.proposals[key], msg)
+	if record {
+		c.proposals[key] = msg
+	}
+	c.mutex.Unlock()
+	if conflict != nil {
+		c.emit(action.DoubleProposal, offence, conflict.data, data)
+	}
+}
+
+// check checks a message against the one recorded with the same key. It returns whether the message should be
+// recorded, and the recorded message if they conflict and the offence hasn't been reported. The messages out of the
+// heights around the tip, or not from a delegate of the height, are skipped. It must be called with the mutex held
+func (c *Collector) check(offence Offence, recorded *message, msg *message) (bool, *message) {
+	c.advance(c.tipHeight())
+	if offence.Height+keepHeights <= c.height || offence.Height > c.height+keepHeights {
+		return false, nil
+	}
+	if !c.isDelegate(offence) {
+		return false, nil
+	}
+	if recorded == nil {
+		return true, nil
+	}
+	if recorded.blkHash == msg.blkHash || c.reported[offence] {
+		return false, nil
+	}
+	c.reported[offence] = true
+	return false, recorded
+}
+
+// isDelegate returns whether the offender is a delegate of the height. It must be called with the mutex held
+func (c *Collector) isDelegate(offence Offence) bool {
+	delegates, ok := c.delegateSets[offence.Height]
+	if !ok {
+		addrs, err := c.delegates(offence.Height)
+		if err != nil {
+			logger.Debug().Err(err).Uint64("height", offence.Height).Msg("failed to get delegates")
+			return false
+		}
+		delegates = make(map[string]bool, len(addrs))
+		for _, addr := range addrs {
+			delegates[addr] = true
+		}
+		c.delegateSets[offence.Height] = delegates
+	}
+	return delegates[offence.Offender]
+}
+
+// advance moves the collector to a new tip height, and drops the messages out of the heights around it
+func (c *Collector) advance(height uint64) {
+	if height <= c.height {
+		return
+	}
+	c.height = height
+	for key := range c.endorsements {
+		if key.height+keepHeights <= height {
+			delete(c.endorsements, key)
+		}
+	}
+	for key := range c.proposals {
+		if key.height+keepHeights <= height {
+			delete(c.proposals, key)
+		}
+	}
+	for offence := range c.reported {
+		if offence.Height+keepHeights <= height {
+			delete(c.reported, offence)
+		}
+	}
+	for h := range c.delegateSets {
+		if h+keepHeights <= height {
+			delete(c.delegateSets, h)
+		}
+	}
+}
+
+func (c *Collector) emit(evidenceType action.EvidenceType, offence Offence, first []byte, second []byte) {
+	label := "endorsement"
+	if evidenceType == action.DoubleProposal {
+		label = "proposal"
+	}
+	evidenceMtc.WithLabelValues(label).Inc()
+	logger.Warn().
+		Str("offender", offence.Offender).
+		Uint64("height", offence.Height).
+		Str("type", label).
+		Msg("found an equivocation")
+	if c.report != nil {
+		c.report(evidenceType, first, second)
+	}
+}
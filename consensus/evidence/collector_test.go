@@ -0,0 +1,102 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestCollector(t *testing.T) {
+	require := require.New(t)
+
+	type report struct {
+		evidenceType action.EvidenceType
+		first        []byte
+		second       []byte
+	}
+	var reports []report
+	alfa := testaddress.Addrinfo["alfa"]
+	bravo := testaddress.Addrinfo["bravo"]
+	charlie := testaddress.Addrinfo["charlie"]
+	var tipHeight uint64
+	c := NewCollector(
+		func(evidenceType action.EvidenceType, first []byte, second []byte) {
+			reports = append(reports, report{evidenceType, first, second})
+		},
+		func() uint64 { return tipHeight },
+		func(uint64) ([]string, error) { return []string{alfa.RawAddress, bravo.RawAddress}, nil },
+	)
+	collectEndorse := func(data []byte) {
+		ePb := &iproto.EndorsePb{}
+		require.NoError(proto.Unmarshal(data, ePb))
+		c.CollectEndorse(ePb)
+	}
+	collectPropose := func(data []byte) {
+		pPb := &iproto.ProposePb{}
+		require.NoError(proto.Unmarshal(data, pPb))
+		c.CollectBlockPropose(pPb)
+	}
+
+	en1 := endorse(t, alfa, hash.Hash32B{1}, 2, 0, endorsement.PROPOSAL)
+	en2 := endorse(t, alfa, hash.Hash32B{2}, 2, 0, endorsement.PROPOSAL)
+	collectEndorse(en1)
+	collectEndorse(en1)
+	collectEndorse(endorse(t, alfa, hash.Hash32B{2}, 2, 1, endorsement.PROPOSAL))
+	collectEndorse(endorse(t, bravo, hash.Hash32B{2}, 2, 0, endorsement.PROPOSAL))
+	require.Empty(reports)
+	collectEndorse(en2)
+	require.Equal([]report{{action.DoubleEndorsement, en1, en2}}, reports)
+	offence, err := Verify(reports[0].evidenceType, reports[0].first, reports[0].second)
+	require.NoError(err)
+	require.Equal(alfa.RawAddress, offence.Offender)
+	// the offence is reported once
+	collectEndorse(endorse(t, alfa, hash.Hash32B{3}, 2, 0, endorsement.PROPOSAL))
+	collectEndorse(endorse(t, alfa, hash.Hash32B{2}, 2, 0, endorsement.LOCK))
+	collectEndorse(endorse(t, alfa, hash.Hash32B{3}, 2, 0, endorsement.LOCK))
+	require.Len(reports, 1)
+
+	reports = nil
+	p1 := propose(t, bravo, 3, hash.Hash32B{1}, 1, 0)
+	p2 := propose(t, bravo, 3, hash.Hash32B{1}, 2, 1)
+	collectPropose(p1)
+	// the same block proposed again by another delegate in the next round
+	relayed := &iproto.ProposePb{}
+	require.NoError(proto.Unmarshal(p1, relayed))
+	relayed.Round = 1
+	relayed.Proposer = alfa.RawAddress
+	c.CollectBlockPropose(relayed)
+	require.Empty(reports)
+	collectPropose(p2)
+	require.Equal([]report{{action.DoubleProposal, p1, p2}}, reports)
+
+	// the messages from a non-delegate are skipped
+	reports = nil
+	collectEndorse(endorse(t, charlie, hash.Hash32B{1}, 3, 0, endorsement.PROPOSAL))
+	collectEndorse(endorse(t, charlie, hash.Hash32B{2}, 3, 0, endorsement.PROPOSAL))
+	require.Empty(reports)
+
+	// the messages too far above the tip are skipped
+	collectEndorse(endorse(t, bravo, hash.Hash32B{1}, 1+keepHeights, 0, endorsement.PROPOSAL))
+	require.Len(c.endorsements, 4)
+
+	// the messages out of the heights around the tip are dropped as the tip grows
+	tipHeight = 3 + keepHeights
+	collectEndorse(endorse(t, bravo, hash.Hash32B{1}, 3+keepHeights, 0, endorsement.PROPOSAL))
+	collectEndorse(endorse(t, bravo, hash.Hash32B{1}, 2, 0, endorsement.PROPOSAL))
+	require.Empty(reports)
+	require.Len(c.endorsements, 1)
+	require.Empty(c.proposals)
+}
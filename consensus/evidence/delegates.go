@@ -0,0 +1,76 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// DelegatesFunc returns the delegates of the epoch of a block height
+type DelegatesFunc func(height uint64) ([]string, error)
+
+// NewDelegatesFunc returns the DelegatesFunc of roll-DPoS, which chooses the delegates of an epoch from the candidates
+// on the height before the epoch starts. As the seed of an epoch is only known to the consensus when DKG is enabled,
+// all these candidates are taken as the delegates in that case
+func NewDelegatesFunc(
+	cfg config.Config,
+	candidatesByHeight func(uint64) ([]*state.Candidate, error),
+) DelegatesFunc {
+	return func(height uint64) ([]string, error) {
+		if cfg.Consensus.Scheme != config.RollDPoSScheme {
+			return nil, errors.Errorf("no delegates under consensus scheme %s", cfg.Consensus.Scheme)
+		}
+		rollDPoS := cfg.Consensus.RollDPoS
+		numSubEpochs := uint64(1)
+		if rollDPoS.NumSubEpochs > 0 {
+			numSubEpochs = uint64(rollDPoS.NumSubEpochs)
+		}
+		if rollDPoS.EnableDKG {
+			numSubEpochs++
+		}
+		epochLen := uint64(rollDPoS.NumDelegates) * numSubEpochs
+		epochNum := uint64(1)
+		if height > 0 {
+			epochNum = (height-1)/epochLen + 1
+		}
+		candidatesHeight := epochLen * (epochNum - 1)
+		candidates, err := candidatesByHeight(candidatesHeight)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get candidates on height %d", candidatesHeight)
+		}
+		addrs := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			addrs = append(addrs, candidate.Address)
+		}
+		if rollDPoS.EnableDKG {
+			return addrs, nil
+		}
+		if len(addrs) < int(rollDPoS.NumDelegates) {
+			return nil, errors.Errorf("only %d candidates on height %d", len(addrs), candidatesHeight)
+		}
+		crypto.SortCandidates(addrs, epochNum, crypto.CryptoSeed)
+		return addrs[:rollDPoS.NumDelegates], nil
+	}
+}
+
+// IsDelegate returns whether an address is a delegate of the epoch of a block height
+func IsDelegate(delegates DelegatesFunc, addr string, height uint64) (bool, error) {
+	addrs, err := delegates(height)
+	if err != nil {
+		return false, err
+	}
+	for _, a := range addrs {
+		if a == addr {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+// ErrInvalidEvidence indicates the evidence doesn't prove an equivocation
+var ErrInvalidEvidence = errors.New("invalid evidence")
+
+// Offence is an equivocation of a delegate on a height. A delegate is penalised at most once per height no matter how
+// many conflicting messages it has signed
+type Offence struct {
+	Offender string
+	Height   uint64
+}
+
+// Verify verifies that the two messages of an evidence are signed by the same delegate and conflict with each other,
+// and returns the offence they prove. Endorsements conflict if they are on different blocks with the same height,
+// round and topic. Proposals conflict if they are different blocks on the same parent, since a delegate proposes the
+// same block in all the rounds of a height
+func Verify(evidenceType action.EvidenceType, first []byte, second []byte) (*Offence, error) {
+	switch evidenceType {
+	case action.DoubleEndorsement:
+		return verifyDoubleEndorsement(first, second)
+	case action.DoubleProposal:
+		return verifyDoubleProposal(first, second)
+	default:
+		return nil, errors.Wrapf(ErrInvalidEvidence, "unknown evidence type %d", evidenceType)
+	}
+}
+
+func verifyDoubleEndorsement(first []byte, second []byte) (*Offence, error) {
+	en1, err := decodeEndorsement(first)
+	if err != nil {
+		return nil, err
+	}
+	en2, err := decodeEndorsement(second)
+	if err != nil {
+		return nil, err
+	}
+	if en1.Endorser() != en2.Endorser() {
+		return nil, errors.Wrapf(
+			ErrInvalidEvidence,
+			"endorsements are signed by %s and %s",
+			en1.Endorser(),
+			en2.Endorser(),
+		)
+	}
+	v1 := en1.ConsensusVote()
+	v2 := en2.ConsensusVote()
+	if v1.Height != v2.Height || v1.Round != v2.Round || v1.Topic != v2.Topic {
+		return nil, errors.Wrap(ErrInvalidEvidence, "endorsements are not on the same height, round and topic")
+	}
+	if v1.BlkHash == v2.BlkHash {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "endorsements are on the same block %x", v1.BlkHash)
+	}
+	return &Offence{Offender: en1.Endorser(), Height: v1.Height}, nil
+}
+
+func verifyDoubleProposal(first []byte, second []byte) (*Offence, error) {
+	blk1, err := decodeProposal(first)
+	if err != nil {
+		return nil, err
+	}
+	blk2, err := decodeProposal(second)
+	if err != nil {
+		return nil, err
+	}
+	if blk1.ProducerAddress() != blk2.ProducerAddress() {
+		return nil, errors.Wrapf(
+			ErrInvalidEvidence,
+			"blocks are produced by %s and %s",
+			blk1.ProducerAddress(),
+			blk2.ProducerAddress(),
+		)
+	}
+	if blk1.Height() != blk2.Height() || blk1.PrevHash() != blk2.PrevHash() {
+		return nil, errors.Wrap(ErrInvalidEvidence, "blocks are not on the same height and parent")
+	}
+	if blk1.HashBlock() == blk2.HashBlock() {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "blocks are the same block %x", blk1.HashBlock())
+	}
+	return &Offence{Offender: blk1.ProducerAddress(), Height: blk1.Height()}, nil
+}
+
+// decodeEndorsement decodes a serialized EndorsePb, and verifies that it's signed by the endorser
+func decodeEndorsement(data []byte) (*endorsement.Endorsement, error) {
+	ePb := &iproto.EndorsePb{}
+	if err := proto.Unmarshal(data, ePb); err != nil {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "failed to unmarshal endorsement: %v", err)
+	}
+	en, err := endorsement.FromProtoMsg(ePb)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "failed to convert endorsement: %v", err)
+	}
+	pkHash, err := iotxaddress.AddressToPKHash(en.Endorser())
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "invalid endorser address %s", en.Endorser())
+	}
+	if pkHash != keypair.HashPubKey(en.EndorserPublicKey()) {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "public key doesn't match endorser %s", en.Endorser())
+	}
+	if !en.VerifySignature() {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "invalid signature of endorser %s", en.Endorser())
+	}
+	return en, nil
+}
+
+// decodeProposal decodes a serialized ProposePb, and verifies that the block is signed by its producer
+func decodeProposal(data []byte) (*blockchain.Block, error) {
+	pPb := &iproto.ProposePb{}
+	if err := proto.Unmarshal(data, pPb); err != nil {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "failed to unmarshal proposal: %v", err)
+	}
+	if pPb.Block == nil {
+		return nil, errors.Wrap(ErrInvalidEvidence, "proposal without block")
+	}
+	blk := &blockchain.Block{}
+	if err := blk.ConvertFromBlockPb(pPb.Block); err != nil {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "failed to convert proposed block: %v", err)
+	}
+	if !blk.VerifySignature() {
+		return nil, errors.Wrapf(ErrInvalidEvidence, "invalid signature of producer %s", blk.ProducerAddress())
+	}
+	return blk, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestVerify(t *testing.T) {
+	require := require.New(t)
+
+	alfa := testaddress.Addrinfo["alfa"]
+	bravo := testaddress.Addrinfo["bravo"]
+
+	t.Run("double-endorsement", func(t *testing.T) {
+		first := endorse(t, alfa, hash.Hash32B{1}, 2, 0, endorsement.LOCK)
+		offence, err := Verify(action.DoubleEndorsement, first, endorse(t, alfa, hash.Hash32B{2}, 2, 0, endorsement.LOCK))
+		require.NoError(err)
+		require.Equal(&Offence{Offender: alfa.RawAddress, Height: 2}, offence)
+
+		for _, second := range [][]byte{
+			// the same block
+			endorse(t, alfa, hash.Hash32B{1}, 2, 0, endorsement.LOCK),
+			// another round
+			endorse(t, alfa, hash.Hash32B{2}, 2, 1, endorsement.LOCK),
+			// another topic
+			endorse(t, alfa, hash.Hash32B{2}, 2, 0, endorsement.COMMIT),
+			// another endorser
+			endorse(t, bravo, hash.Hash32B{2}, 2, 0, endorsement.LOCK),
+			// not an endorsement
+			[]byte("invalid"),
+		} {
+			_, err := Verify(action.DoubleEndorsement, first, second)
+			require.Equal(ErrInvalidEvidence, errors.Cause(err))
+		}
+
+		// the endorsement signed by someone else than the endorser
+		ePb := &iproto.EndorsePb{}
+		require.NoError(proto.Unmarshal(endorse(t, bravo, hash.Hash32B{2}, 2, 0, endorsement.LOCK), ePb))
+		ePb.Endorser = alfa.RawAddress
+		forged, err := proto.Marshal(ePb)
+		require.NoError(err)
+		_, err = Verify(action.DoubleEndorsement, first, forged)
+		require.Equal(ErrInvalidEvidence, errors.Cause(err))
+	})
+
+	t.Run("double-proposal", func(t *testing.T) {
+		first := propose(t, alfa, 2, hash.Hash32B{1}, 1, 0)
+		offence, err := Verify(action.DoubleProposal, first, propose(t, alfa, 2, hash.Hash32B{1}, 2, 3))
+		require.NoError(err)
+		require.Equal(&Offence{Offender: alfa.RawAddress, Height: 2}, offence)
+
+		for _, second := range [][]byte{
+			// the same block proposed in another round
+			propose(t, alfa, 2, hash.Hash32B{1}, 1, 1),
+			// another parent
+			propose(t, alfa, 2, hash.Hash32B{2}, 2, 0),
+			// another producer
+			propose(t, bravo, 2, hash.Hash32B{1}, 2, 0),
+		} {
+			_, err := Verify(action.DoubleProposal, first, second)
+			require.Equal(ErrInvalidEvidence, errors.Cause(err))
+		}
+	})
+
+	_, err := Verify(action.EvidenceType(2), nil, nil)
+	require.Equal(ErrInvalidEvidence, errors.Cause(err))
+}
+
+// endorse returns a serialized EndorsePb signed by addr
+func endorse(
+	t *testing.T,
+	addr *iotxaddress.Address,
+	blkHash hash.Hash32B,
+	height uint64,
+	round uint32,
+	topic endorsement.ConsensusVoteTopic,
+) []byte {
+	vote := endorsement.NewConsensusVote(blkHash, height, round, topic)
+	data, err := proto.Marshal(endorsement.NewEndorsement(vote, addr).ToProtoMsg())
+	require.NoError(t, err)
+	return data
+}
+
+// propose returns a serialized ProposePb of a block produced by addr
+func propose(
+	t *testing.T,
+	addr *iotxaddress.Address,
+	height uint64,
+	prevHash hash.Hash32B,
+	timestamp uint64,
+	round uint32,
+) []byte {
+	blk := blockchain.NewBlock(config.Default.Chain.ID, height, prevHash, timestamp, addr.PublicKey, nil)
+	require.NoError(t, blk.SignBlock(addr))
+	data, err := proto.Marshal(&iproto.ProposePb{
+		Block:    blk.ConvertToBlockPb(),
+		Round:    round,
+		Proposer: addr.RawAddress,
+	})
+	require.NoError(t, err)
+	return data
+}
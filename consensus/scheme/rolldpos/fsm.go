@@ -203,6 +203,7 @@ func (m *cFSM) Start(c context.Context) error {
 					logger.Debug().Msg("timeoutEvt is stale")
 					continue
 				}
+				m.collectEvidence(evt)
 				chainHeight := m.ctx.chain.TipHeight()
 				eventHeight := evt.height()
 				if _, ok := evt.(*proposeBlkEvt); ok && eventHeight <= chainHeight {
@@ -256,6 +257,24 @@ func (m *cFSM) currentState() fsm.State {
 	return m.fsm.CurrentState()
 }
 
+// collectEvidence passes the proposals and the endorsements of other delegates to the evidence collector, including
+// the stale ones, which are still signed
+func (m *cFSM) collectEvidence(evt iConsensusEvt) {
+	if m.ctx.evidence == nil {
+		return
+	}
+	switch e := evt.(type) {
+	case *proposeBlkEvt:
+		if e.block.ProducerAddress() != m.ctx.addr.RawAddress {
+			m.ctx.evidence.CollectBlockPropose(e.toProtoMsg())
+		}
+	case *endorseEvt:
+		if e.endorse.Endorser() != m.ctx.addr.RawAddress {
+			m.ctx.evidence.CollectEndorse(e.toProtoMsg())
+		}
+	}
+}
+
 // produce adds an event into the queue for the consensus FSM to process
 func (m *cFSM) produce(evt iConsensusEvt, delay time.Duration) {
 	if delay > 0 {
@@ -362,6 +381,10 @@ func (m *cFSM) handleStartRoundEvt(_ fsm.Event) (fsm.State, error) {
 		return sEpochStart, err
 	}
 	if m.ctx.round.height != height {
+		// the heights lower than the new round have been committed
+		if err := m.ctx.wal.prune(height - 1); err != nil {
+			logger.Error().Err(err).Uint64("height", height).Msg("error when pruning the WAL")
+		}
 		m.ctx.round = roundCtx{
 			height:          height,
 			endorsementSets: make(map[hash.Hash32B]*endorsement.Set),
@@ -394,6 +417,12 @@ func (m *cFSM) handleInitBlockProposeEvt(evt fsm.Event) (fsm.State, error) {
 	}
 	log.Msg("current node is the proposer")
 	blk := m.ctx.round.block
+	if blk == nil {
+		var err error
+		if blk, err = m.ctx.ownProposal(); err != nil {
+			return sEpochStart, errors.Wrap(err, "error when reading the proposals from the WAL")
+		}
+	}
 	if blk == nil {
 		var err error
 		blk, err = m.ctx.mintBlock()
@@ -401,6 +430,9 @@ func (m *cFSM) handleInitBlockProposeEvt(evt fsm.Event) (fsm.State, error) {
 			return sEpochStart, errors.Wrap(err, "error when minting a block")
 		}
 	}
+	if err := m.ctx.wal.writeProposal(blk, m.ctx.round.number); err != nil {
+		return sEpochStart, errors.Wrap(err, "error when writing the proposal into the WAL")
+	}
 	proposeBlkEvt := m.newProposeBlkEvt(blk)
 	proposeBlkEvtProto := proposeBlkEvt.toProtoMsg()
 	// Notify itself
@@ -472,8 +504,13 @@ func (m *cFSM) handleProposeBlockEvt(evt fsm.Event) (fsm.State, error) {
 	if !m.validateProposeBlock(proposeBlkEvt.block, m.ctx.round.proposer) {
 		return sAcceptPropose, nil
 	}
+	if err := m.ctx.wal.writeProposal(proposeBlkEvt.block, m.ctx.round.number); err != nil {
+		return sAcceptPropose, errors.Wrap(err, "error when writing the proposal into the WAL")
+	}
+	if err := m.broadcastConsensusVote(proposeBlkEvt.block.HashBlock(), endorsement.PROPOSAL); err != nil {
+		return sAcceptPropose, err
+	}
 	m.ctx.round.block = proposeBlkEvt.block
-	m.broadcastConsensusVote(m.ctx.round.block.HashBlock(), endorsement.PROPOSAL)
 
 	return sAcceptProposalEndorse, nil
 }
@@ -587,11 +624,16 @@ func (m *cFSM) addEndorsement(
 	return nil, nil
 }
 
+// broadcastConsensusVote endorses the block with the topic. The endorsement is written into the WAL before being sent,
+// and an endorsement conflicting with the ones made on the same height is refused
 func (m *cFSM) broadcastConsensusVote(
 	blkHash hash.Hash32B,
 	topic endorsement.ConsensusVoteTopic,
-) {
+) error {
 	cEvt := m.newEndorseEvt(blkHash, topic)
+	if err := m.ctx.wal.writeEndorsement(cEvt.endorse); err != nil {
+		return errors.Wrap(err, "error when writing the endorsement into the WAL")
+	}
 	cEvtProto := cEvt.toProtoMsg()
 	// Notify itself
 	m.produce(cEvt, 0)
@@ -601,6 +643,7 @@ func (m *cFSM) broadcastConsensusVote(
 			Err(err).
 			Msg("error when broadcasting commitEvtProto")
 	}
+	return nil
 }
 
 func (m *cFSM) handleEndorseProposalEvt(evt fsm.Event) (fsm.State, error) {
@@ -619,8 +662,10 @@ func (m *cFSM) handleEndorseProposalEvt(evt fsm.Event) (fsm.State, error) {
 		return sAcceptProposalEndorse, err
 	}
 	// Gather enough proposal endorsements
+	if err := m.broadcastConsensusVote(endorsementSet.BlockHash(), endorsement.LOCK); err != nil {
+		return sAcceptProposalEndorse, err
+	}
 	m.ctx.round.proofOfLock = endorsementSet
-	m.broadcastConsensusVote(endorsementSet.BlockHash(), endorsement.LOCK)
 
 	return sAcceptLockEndorse, nil
 }
@@ -652,7 +697,9 @@ func (m *cFSM) handleEndorseLockEvt(evt fsm.Event) (fsm.State, error) {
 		// Wait for more lock votes to come
 		return sAcceptLockEndorse, err
 	}
-	m.broadcastConsensusVote(endorsementSet.BlockHash(), endorsement.COMMIT)
+	if err := m.broadcastConsensusVote(endorsementSet.BlockHash(), endorsement.COMMIT); err != nil {
+		return sAcceptLockEndorse, err
+	}
 
 	return sAcceptCommitEndorse, nil
 }
@@ -683,6 +730,17 @@ func (m *cFSM) handleEndorseCommitEvt(evt fsm.Event) (fsm.State, error) {
 			}
 		}
 	}
+	// Attach the endorsements committing the block, including the commit endorsement of the event
+	if _, err := m.processEndorseEvent(
+		evt,
+		eEndorseCommit,
+		map[endorsement.ConsensusVoteTopic]bool{endorsement.COMMIT: true},
+	); err != nil {
+		logger.Debug().
+			Err(err).
+			Msg("error when adding the commit endorsement")
+	}
+	pendingBlock.Footer = m.ctx.blockFooter(pendingBlock)
 	// Commit and broadcast the pending block
 	if err := m.ctx.chain.CommitBlock(pendingBlock); err != nil {
 		logger.Error().
@@ -804,7 +862,17 @@ func (m *cFSM) newEndorseEvtWithEndorsePb(ePb *iproto.EndorsePb) (*endorseEvt, e
 }
 
 func (m *cFSM) newEndorseEvt(blkHash hash.Hash32B, topic endorsement.ConsensusVoteTopic) *endorseEvt {
-	return newEndorseEvt(topic, blkHash, m.ctx.round.height, m.ctx.round.number, m.ctx.addr, m.ctx.clock)
+	evt := newEndorseEvt(topic, blkHash, m.ctx.round.height, m.ctx.round.number, m.ctx.addr, m.ctx.clock)
+	// Sign the commit endorsement with the DKG key as well, so that it could be aggregated into the block footer
+	dkgPriKey := m.ctx.epoch.dkgAddress.PrivateKey
+	if topic == endorsement.COMMIT && m.ctx.cfg.AggregateEndorsements && len(dkgPriKey) > 0 {
+		if err := evt.endorse.SignDKGShare(dkgPriKey); err != nil {
+			logger.Error().
+				Err(err).
+				Msg("error when signing the commit endorsement with the DKG key")
+		}
+	}
+	return evt
 }
 
 func (m *cFSM) newTimeoutEvt(t fsm.EventType) *timeoutEvt {
@@ -779,6 +779,16 @@ func TestHandleCommitEndorseEvt(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, sRoundStart, state)
 		assert.Equal(t, eFinishEpoch, (<-cfsm.evtq).Type())
+		// the endorsements committing the block are attached as the footer
+		assert.NotNil(t, blk.Footer)
+		assert.Equal(t, 1, blk.Footer.Endorsements().NumOfValidEndorsements(
+			map[endorsement.ConsensusVoteTopic]bool{endorsement.COMMIT: true},
+			delegates,
+		))
+		assert.Equal(t, 15, blk.Footer.Endorsements().NumOfValidEndorsements(
+			map[endorsement.ConsensusVoteTopic]bool{endorsement.LOCK: true, endorsement.COMMIT: true},
+			delegates,
+		))
 	})
 	t.Run("timeout-blocking", func(t *testing.T) {
 		cfsm := newTestCFSM(
@@ -20,8 +20,10 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/iotxaddress"
@@ -68,6 +70,10 @@ type rollDPoSCtx struct {
 	// candidatesByHeightFunc is only used for testing purpose
 	candidatesByHeightFunc func(uint64) ([]*state.Candidate, error)
 	sync                   blocksync.BlockSync
+	wal                    *wal
+	evidence               *evidence.Collector
+	// delegatesFunc returns the delegates the aggregate endorsements refer to, which the blockchain verifies them with
+	delegatesFunc evidence.DelegatesFunc
 }
 
 var (
@@ -235,6 +241,21 @@ func (ctx *rollDPoSCtx) mintBlock() (*blockchain.Block, error) {
 	return ctx.mintCommonBlock()
 }
 
+// ownProposal returns the block the node has proposed on the current height if any. The block is proposed again in the
+// later rounds, since proposing another block on the same parent is an equivocation
+func (ctx *rollDPoSCtx) ownProposal() (*blockchain.Block, error) {
+	records, err := ctx.wal.load(ctx.round.height)
+	if err != nil {
+		return nil, err
+	}
+	for _, blk := range records.proposals {
+		if blk.ProducerAddress() == ctx.addr.RawAddress && blk.PrevHash() == ctx.chain.TipHash() {
+			return blk, nil
+		}
+	}
+	return nil, nil
+}
+
 // mintSecretBlock collects DKG secret proposals and witness and creates a block to propose
 func (ctx *rollDPoSCtx) mintSecretBlock() (*blockchain.Block, error) {
 	secrets := ctx.epoch.secrets
@@ -369,6 +390,112 @@ func (ctx *rollDPoSCtx) updateSeed() ([]byte, error) {
 	return aggregateSig, nil
 }
 
+// blockFooter builds the footer of the block to commit with the endorsements on it. When aggregating endorsements,
+// the commit endorsements are folded into an aggregate signature if there are enough DKG signature shares to reach the
+// quorum, otherwise the endorsements are kept as they are
+func (ctx *rollDPoSCtx) blockFooter(blk *blockchain.Block) *blockchain.BlockFooter {
+	endorsementSet, ok := ctx.round.endorsementSets[blk.HashBlock()]
+	if !ok {
+		return nil
+	}
+	if ctx.cfg.AggregateEndorsements {
+		aggregated, err := ctx.aggregate(blk, endorsementSet)
+		if err != nil {
+			logger.Warn().
+				Err(err).
+				Uint64("block", blk.Height()).
+				Msg("error when aggregating the commit endorsements")
+		} else {
+			endorsementSet = aggregated
+		}
+	}
+	return blockchain.NewBlockFooter(endorsementSet, uint64(ctx.clock.Now().Unix()))
+}
+
+// aggregate folds the commit endorsements on a block into an aggregate set. The signers refer to the delegates the
+// blockchain knows, and the set is verified the same way as the blockchain does before being put into the footer
+func (ctx *rollDPoSCtx) aggregate(blk *blockchain.Block, endorsementSet *endorsement.Set) (*endorsement.Set, error) {
+	if ctx.delegatesFunc == nil {
+		return nil, errors.New("no delegates to aggregate the endorsements against")
+	}
+	delegates, err := ctx.delegatesFunc(blk.Height())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when getting the delegates of block %d", blk.Height())
+	}
+	dkgPubkeys := ctx.dkgPubkeys(blk)
+	quorum := endorsement.Quorum(int(ctx.cfg.NumDelegates))
+	aggregated, err := endorsementSet.Aggregate(delegates, dkgPubkeys, quorum)
+	if err != nil {
+		return nil, err
+	}
+	if err := aggregated.VerifyCommit(blk.Height(), delegates, dkgPubkeys, quorum); err != nil {
+		return nil, err
+	}
+	return aggregated, nil
+}
+
+// dkgPubkeys collects the DKG public keys of the current epoch, which the delegates put into the headers of the blocks
+// they produce, including the block to commit. A key not in the headers isn't used, since the blockchain can't verify
+// the aggregate signature with it
+func (ctx *rollDPoSCtx) dkgPubkeys(blk *blockchain.Block) map[string][]byte {
+	pubkeys := make(map[string][]byte)
+	for h := ctx.epoch.height; h > 0 && h <= ctx.chain.TipHeight(); h++ {
+		prev, err := ctx.chain.GetBlockByHeight(h)
+		if err != nil {
+			continue
+		}
+		if len(prev.Header.DKGPubkey) > 0 {
+			pubkeys[prev.ProducerAddress()] = prev.Header.DKGPubkey
+		}
+	}
+	if len(blk.Header.DKGPubkey) > 0 {
+		pubkeys[blk.ProducerAddress()] = blk.Header.DKGPubkey
+	}
+	return pubkeys
+}
+
+// replayWAL restores the round of the next height from the write-ahead log, so that the node resumes with the
+// proposal and the endorsements it has made before restarting. The records of the committed heights are pruned
+func (ctx *rollDPoSCtx) replayWAL() error {
+	tipHeight := ctx.chain.TipHeight()
+	if err := ctx.wal.prune(tipHeight); err != nil {
+		return errors.Wrap(err, "error when pruning the WAL")
+	}
+	height := tipHeight + 1
+	records, err := ctx.wal.load(height)
+	if err != nil {
+		return errors.Wrap(err, "error when loading the WAL")
+	}
+	blk := records.proposal()
+	if blk == nil && len(records.endorsements) == 0 {
+		return nil
+	}
+	ctx.round = roundCtx{
+		height:          height,
+		endorsementSets: make(map[hash.Hash32B]*endorsement.Set),
+	}
+	if blk != nil && blk.PrevHash() == ctx.chain.TipHash() {
+		ctx.round.block = blk
+	}
+	for _, en := range records.endorsements {
+		blkHash := en.ConsensusVote().BlkHash
+		endorsementSet, ok := ctx.round.endorsementSets[blkHash]
+		if !ok {
+			endorsementSet = endorsement.NewSet(blkHash)
+			ctx.round.endorsementSets[blkHash] = endorsementSet
+		}
+		if err := endorsementSet.AddEndorsement(en); err != nil {
+			logger.Warn().Err(err).Uint64("height", height).Msg("error when restoring the endorsement from the WAL")
+		}
+	}
+	logger.Info().
+		Uint64("height", height).
+		Bool("proposal", ctx.round.block != nil).
+		Int("endorsements", len(records.endorsements)).
+		Msg("resume the round from the WAL")
+	return nil
+}
+
 // epochCtx keeps the context data for the current epoch
 type epochCtx struct {
 	// num is the ordinal number of an epoch
@@ -409,6 +536,12 @@ type RollDPoS struct {
 
 // Start starts RollDPoS consensus
 func (r *RollDPoS) Start(ctx context.Context) error {
+	if err := r.ctx.wal.Start(ctx); err != nil {
+		return errors.Wrap(err, "error when starting the WAL")
+	}
+	if err := r.ctx.replayWAL(); err != nil {
+		return errors.Wrap(err, "error when replaying the WAL")
+	}
 	if err := r.cfsm.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting the consensus FSM")
 	}
@@ -418,7 +551,10 @@ func (r *RollDPoS) Start(ctx context.Context) error {
 
 // Stop stops RollDPoS consensus
 func (r *RollDPoS) Stop(ctx context.Context) error {
-	return errors.Wrap(r.cfsm.Stop(ctx), "error when stopping the consensus FSM")
+	if err := r.cfsm.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping the consensus FSM")
+	}
+	return errors.Wrap(r.ctx.wal.Stop(ctx), "error when stopping the WAL")
 }
 
 // HandleBlockPropose handles incoming block propose
@@ -506,6 +642,9 @@ type Builder struct {
 	clock                  clock.Clock
 	rootChainAPI           explorerapi.Explorer
 	candidatesByHeightFunc func(uint64) ([]*state.Candidate, error)
+	walStore               db.KVStore
+	evidenceCollector      *evidence.Collector
+	delegatesFunc          evidence.DelegatesFunc
 }
 
 // NewRollDPoSBuilder instantiates a Builder instance
@@ -563,6 +702,25 @@ func (b *Builder) SetCandidatesByHeightFunc(
 	return b
 }
 
+// SetWALStore sets the KV store of the write-ahead log, which is in memory by default
+func (b *Builder) SetWALStore(walStore db.KVStore) *Builder {
+	b.walStore = walStore
+	return b
+}
+
+// SetEvidenceCollector sets the collector of the consensus messages to detect equivocations
+func (b *Builder) SetEvidenceCollector(collector *evidence.Collector) *Builder {
+	b.evidenceCollector = collector
+	return b
+}
+
+// SetDelegatesFunc sets the function returning the delegates of the epoch of a height as the blockchain knows them,
+// which the signers of the aggregate endorsements refer to. The endorsements aren't aggregated without it
+func (b *Builder) SetDelegatesFunc(delegatesFunc evidence.DelegatesFunc) *Builder {
+	b.delegatesFunc = delegatesFunc
+	return b
+}
+
 // Build builds a RollDPoS consensus module
 func (b *Builder) Build() (*RollDPoS, error) {
 	if b.chain == nil {
@@ -577,6 +735,9 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if b.clock == nil {
 		b.clock = clock.New()
 	}
+	if b.walStore == nil {
+		b.walStore = db.NewMemKVStore()
+	}
 	ctx := rollDPoSCtx{
 		cfg:                    b.cfg,
 		addr:                   b.addr,
@@ -586,6 +747,9 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		clock:                  b.clock,
 		rootChainAPI:           b.rootChainAPI,
 		candidatesByHeightFunc: b.candidatesByHeightFunc,
+		wal:                    newWAL(b.walStore),
+		evidence:               b.evidenceCollector,
+		delegatesFunc:          b.delegatesFunc,
 	}
 	cfsm, err := newConsensusFSM(&ctx)
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/network/node"
@@ -639,6 +640,7 @@ func makeTestRollDPoSCtx(
 		actPool: actPool,
 		p2p:     p2p,
 		clock:   clock,
+		wal:     newWAL(db.NewMemKVStore()),
 	}
 }
 
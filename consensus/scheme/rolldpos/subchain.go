@@ -69,8 +69,12 @@ func constructPutSubChainBlockRequest(rootChainAPI explorerapi.Explorer, subChai
 	}
 
 	rootm := make(map[string]hash.Hash32B)
-	rootm["state"] = b.StateRoot()
-	rootm["tx"] = b.TxRoot()
+	rootm[blockchain.StateRootName] = b.StateRoot()
+	rootm[blockchain.TxRootName] = b.TxRoot()
+	// receipts are only available if the block has been run against the states
+	if b.HasReceipts() {
+		rootm[blockchain.ReceiptRootName] = b.CalculateReceiptRoot()
+	}
 	pb := action.NewPutBlock(
 		uint64(senderPCAddrDetails.PendingNonce),
 		subChainAddr,
@@ -94,7 +98,7 @@ func constructPutSubChainBlockRequest(rootChainAPI explorerapi.Explorer, subChai
 		GasPrice:        pb.GasPrice().String(),
 		SubChainAddress: pb.SubChainAddress(),
 		Height:          int64(pb.Height()),
-		Roots:           make([]explorerapi.PutSubChainBlockMerkelRoot, 0, 2),
+		Roots:           make([]explorerapi.PutSubChainBlockMerkelRoot, 0, len(rootm)),
 	}
 
 	// put merkel roots
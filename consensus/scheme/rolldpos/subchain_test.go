@@ -92,6 +92,10 @@ func TestPutBlockToParentChain(t *testing.T) {
 		SubChainAddress: subAddr.RawAddress,
 		Height:          123456789,
 		Roots: []explorerapi.PutSubChainBlockMerkelRoot{
+			{
+				Name:  "receipt",
+				Value: hex.EncodeToString(blockchain.EmptyReceiptRoot[:]),
+			},
 			{
 				Name:  "state",
 				Value: hex.EncodeToString(stateRoot[:]),
@@ -104,8 +108,15 @@ func TestPutBlockToParentChain(t *testing.T) {
 	}
 
 	exp := mock_explorer.NewMockExplorer(ctrl)
-	exp.EXPECT().GetAddressDetails(addr.RawAddress).Return(explorerapi.AddressDetails{PendingNonce: 100}, nil).Times(1)
+	exp.EXPECT().GetAddressDetails(addr.RawAddress).Return(explorerapi.AddressDetails{PendingNonce: 100}, nil).Times(2)
 	exp.EXPECT().PutSubChainBlock(req).Times(1)
 
+	// the receipt root isn't posted before the block has been run
+	unrun, err := constructPutSubChainBlockRequest(exp, req.SubChainAddress, addr, &blk)
+	require.NoError(t, err)
+	require.Equal(req.Roots[1:], unrun.Roots)
+
+	// the block has been run without any receipt
+	blk.SetReceipts(nil)
 	putBlockToParentChain(exp, req.SubChainAddress, addr, &blk)
 }
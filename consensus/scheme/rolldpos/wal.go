@@ -0,0 +1,194 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/proto"
+)
+
+const (
+	walNS = "consensusWAL"
+
+	walProposal    byte = 'p'
+	walEndorsement byte = 'e'
+)
+
+// ErrDoubleEndorse indicates the endorsement conflicts with an endorsement the node has made
+var ErrDoubleEndorse = errors.New("conflicting endorsement on the same height")
+
+// wal is the write-ahead log of the consensus. The proposals the node accepts and the endorsements the node makes are
+// written into the log before they are broadcast, so that a node restarting in the middle of a round resumes with the
+// same proposal and never endorses a conflicting block. The records are keyed by height, and the records of the
+// committed heights are pruned
+type wal struct {
+	kvstore db.KVStore
+}
+
+// walRecords are the records of a height in the write-ahead log
+type walRecords struct {
+	// proposals are the proposals the node has accepted on the height, in the order of round
+	proposals []*blockchain.Block
+	// endorsements are the endorsements the node has made on the height
+	endorsements []*endorsement.Endorsement
+}
+
+func newWAL(kvstore db.KVStore) *wal {
+	return &wal{kvstore: kvstore}
+}
+
+// Start starts the underlying KV store
+func (w *wal) Start(ctx context.Context) error { return w.kvstore.Start(ctx) }
+
+// Stop stops the underlying KV store
+func (w *wal) Stop(ctx context.Context) error { return w.kvstore.Stop(ctx) }
+
+// writeProposal records the proposal of a round
+func (w *wal) writeProposal(blk *blockchain.Block, round uint32) error {
+	data, err := blk.Serialize()
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize proposal on height %d", blk.Height())
+	}
+	key := append(walHeightKey(blk.Height()), walProposal)
+	key = append(key, walRoundKey(round)...)
+	if err := w.kvstore.Put(walNS, key, data); err != nil {
+		return errors.Wrapf(err, "failed to write proposal on height %d", blk.Height())
+	}
+	return nil
+}
+
+// writeEndorsement records an endorsement made by the node. An endorsement conflicting with a recorded one, i.e., on
+// another block with the same height, round and topic, or on another block than the one locked on the height, is
+// rejected with ErrDoubleEndorse
+func (w *wal) writeEndorsement(en *endorsement.Endorsement) error {
+	vote := en.ConsensusVote()
+	records, err := w.load(vote.Height)
+	if err != nil {
+		return err
+	}
+	for _, recorded := range records.endorsements {
+		rv := recorded.ConsensusVote()
+		if rv.BlkHash == vote.BlkHash {
+			continue
+		}
+		locked := rv.Topic == endorsement.LOCK || rv.Topic == endorsement.COMMIT
+		if locked || rv.Round == vote.Round && rv.Topic == vote.Topic {
+			return errors.Wrapf(
+				ErrDoubleEndorse,
+				"block %x has been endorsed with topic %d in round %d on height %d",
+				rv.BlkHash,
+				rv.Topic,
+				rv.Round,
+				rv.Height,
+			)
+		}
+	}
+	data, err := proto.Marshal(en.ToProtoMsg())
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal endorsement on height %d", vote.Height)
+	}
+	key := append(walHeightKey(vote.Height), walEndorsement)
+	key = append(key, walRoundKey(vote.Round)...)
+	key = append(key, byte(vote.Topic))
+	if err := w.kvstore.Put(walNS, key, data); err != nil {
+		return errors.Wrapf(err, "failed to write endorsement on height %d", vote.Height)
+	}
+	return nil
+}
+
+// load reads the records of a height
+func (w *wal) load(height uint64) (*walRecords, error) {
+	iter, err := w.kvstore.Iterate(walNS, db.Range{Prefix: walHeightKey(height)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the records on height %d", height)
+	}
+	records := &walRecords{}
+	for iter.Next() {
+		switch iter.Key()[len(walHeightKey(height))] {
+		case walProposal:
+			blk := &blockchain.Block{}
+			if err := blk.Deserialize(iter.Value()); err != nil {
+				return nil, errors.Wrapf(err, "failed to deserialize proposal on height %d", height)
+			}
+			records.proposals = append(records.proposals, blk)
+		case walEndorsement:
+			ePb := &iproto.EndorsePb{}
+			if err := proto.Unmarshal(iter.Value(), ePb); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal endorsement on height %d", height)
+			}
+			en, err := endorsement.FromProtoMsg(ePb)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to convert endorsement on height %d", height)
+			}
+			records.endorsements = append(records.endorsements, en)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read the records on height %d", height)
+	}
+	return records, nil
+}
+
+// prune deletes the records of the heights up to height, which have been committed
+func (w *wal) prune(height uint64) error {
+	iter, err := w.kvstore.Iterate(walNS, db.Range{Limit: walHeightKey(height + 1)})
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate the records")
+	}
+	batch := db.NewBatch()
+	for iter.Next() {
+		batch.Delete(walNS, iter.Key(), "failed to delete record %x", iter.Key())
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "failed to iterate the records")
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
+	return w.kvstore.Commit(batch)
+}
+
+// proposal returns the block to resume the height with, which is the block the node has locked on if any, otherwise
+// the latest proposal
+func (r *walRecords) proposal() *blockchain.Block {
+	for _, en := range r.endorsements {
+		vote := en.ConsensusVote()
+		if vote.Topic != endorsement.LOCK && vote.Topic != endorsement.COMMIT {
+			continue
+		}
+		for _, blk := range r.proposals {
+			if blk.HashBlock() == vote.BlkHash {
+				return blk
+			}
+		}
+	}
+	if len(r.proposals) == 0 {
+		return nil
+	}
+	return r.proposals[len(r.proposals)-1]
+}
+
+// walHeightKey encodes the height in big endian, so that the records are iterated in the order of height
+func walHeightKey(height uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, height)
+	return key
+}
+
+func walRoundKey(round uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, round)
+	return key
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+)
+
+func TestWAL(t *testing.T) {
+	require := require.New(t)
+
+	w := newWAL(db.NewMemKVStore())
+	require.NoError(w.Start(context.Background()))
+	defer func() {
+		require.NoError(w.Stop(context.Background()))
+	}()
+
+	tipHash := hash.Hash32B{1}
+	blk1 := blockchain.NewBlock(1, 2, tipHash, 1, testAddrs[0].PublicKey, []action.Action{})
+	blk2 := blockchain.NewBlock(1, 2, tipHash, 2, testAddrs[0].PublicKey, []action.Action{})
+	endorse := func(blk *blockchain.Block, round uint32, topic endorsement.ConsensusVoteTopic) error {
+		vote := endorsement.NewConsensusVote(blk.HashBlock(), blk.Height(), round, topic)
+		return w.writeEndorsement(endorsement.NewEndorsement(vote, testAddrs[1]))
+	}
+
+	records, err := w.load(2)
+	require.NoError(err)
+	require.Nil(records.proposal())
+	require.Empty(records.endorsements)
+
+	require.NoError(w.writeProposal(blk1, 0))
+	require.NoError(endorse(blk1, 0, endorsement.PROPOSAL))
+	// endorsing the same block again is fine
+	require.NoError(endorse(blk1, 0, endorsement.PROPOSAL))
+	// endorsing another block in the same round is refused
+	require.Equal(ErrDoubleEndorse, errors.Cause(endorse(blk2, 0, endorsement.PROPOSAL)))
+
+	// another block could be endorsed in the next round before locking
+	require.NoError(w.writeProposal(blk2, 1))
+	records, err = w.load(2)
+	require.NoError(err)
+	require.Equal(blk2.HashBlock(), records.proposal().HashBlock())
+	require.NoError(endorse(blk2, 1, endorsement.PROPOSAL))
+	require.NoError(endorse(blk1, 2, endorsement.LOCK))
+	// once locked, no other block could be endorsed on the height
+	require.Equal(ErrDoubleEndorse, errors.Cause(endorse(blk2, 3, endorsement.PROPOSAL)))
+	require.NoError(endorse(blk1, 2, endorsement.COMMIT))
+
+	records, err = w.load(2)
+	require.NoError(err)
+	require.Len(records.proposals, 2)
+	require.Len(records.endorsements, 4)
+	// the locked block is resumed rather than the latest proposal
+	require.Equal(blk1.HashBlock(), records.proposal().HashBlock())
+
+	// the records of other heights are kept apart
+	blk3 := blockchain.NewBlock(1, 3, blk1.HashBlock(), 3, testAddrs[0].PublicKey, []action.Action{})
+	require.NoError(w.writeProposal(blk3, 0))
+	require.NoError(endorse(blk3, 0, endorsement.LOCK))
+
+	require.NoError(w.prune(2))
+	records, err = w.load(2)
+	require.NoError(err)
+	require.Empty(records.proposals)
+	require.Empty(records.endorsements)
+	records, err = w.load(3)
+	require.NoError(err)
+	require.Equal(blk3.HashBlock(), records.proposal().HashBlock())
+	require.Len(records.endorsements, 1)
+}
+
+func TestReplayWAL(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tipHash := hash.Hash32B{1}
+	ctx := makeTestRollDPoSCtx(
+		testAddrs[1],
+		ctrl,
+		config.RollDPoS{},
+		func(chain *mock_blockchain.MockBlockchain) {
+			chain.EXPECT().TipHeight().Return(uint64(1)).AnyTimes()
+			chain.EXPECT().TipHash().Return(tipHash).AnyTimes()
+		},
+		func(_ *mock_actpool.MockActPool) {},
+		func(_ *mock_network.MockOverlay) {},
+		clock.New(),
+	)
+	require.NoError(ctx.wal.Start(context.Background()))
+	defer func() {
+		require.NoError(ctx.wal.Stop(context.Background()))
+	}()
+
+	// nothing to replay
+	require.NoError(ctx.replayWAL())
+	require.Equal(uint64(0), ctx.round.height)
+
+	blk1 := blockchain.NewBlock(1, 1, hash.ZeroHash32B, 1, testAddrs[0].PublicKey, []action.Action{})
+	blk2 := blockchain.NewBlock(1, 2, tipHash, 2, testAddrs[0].PublicKey, []action.Action{})
+	require.NoError(ctx.wal.writeProposal(blk1, 0))
+	require.NoError(ctx.wal.writeProposal(blk2, 0))
+	vote := endorsement.NewConsensusVote(blk2.HashBlock(), 2, 0, endorsement.LOCK)
+	require.NoError(ctx.wal.writeEndorsement(endorsement.NewEndorsement(vote, testAddrs[1])))
+
+	require.NoError(ctx.replayWAL())
+	require.Equal(uint64(2), ctx.round.height)
+	require.Equal(blk2.HashBlock(), ctx.round.block.HashBlock())
+	require.Equal(1, len(ctx.round.endorsementSets))
+	set, ok := ctx.round.endorsementSets[blk2.HashBlock()]
+	require.True(ok)
+	require.Equal(1, set.NumOfValidEndorsements(
+		map[endorsement.ConsensusVoteTopic]bool{endorsement.LOCK: true},
+		[]string{testAddrs[1].RawAddress},
+	))
+	// the committed height is pruned
+	records, err := ctx.wal.load(1)
+	require.NoError(err)
+	require.Empty(records.proposals)
+
+	// the restored node refuses to lock on another block
+	cfsm := &cFSM{ctx: ctx}
+	blk3 := blockchain.NewBlock(1, 2, tipHash, 3, testAddrs[0].PublicKey, []action.Action{})
+	require.Equal(ErrDoubleEndorse, errors.Cause(cfsm.broadcastConsensusVote(blk3.HashBlock(), endorsement.LOCK)))
+}
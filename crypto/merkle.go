@@ -7,6 +7,7 @@
 package crypto
 
 import (
+	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotexproject/iotex-core/logger"
@@ -40,10 +41,9 @@ func NewMerkleTree(leaves []hash.Hash32B) *Merkle {
 		return mk
 	}
 
-	// copy the last hash if original size is odd number
+	// copy the last hash if original size is odd number, size is kept as the number of the leaves given
 	if size != len(mk.leaf) {
 		mk.leaf[size] = mk.leaf[size-1]
-		mk.size = len(mk.leaf)
 	}
 
 	return mk
@@ -55,7 +55,7 @@ func (mk *Merkle) HashTree() hash.Hash32B {
 		return mk.root
 	}
 
-	length := mk.size >> 1
+	length := len(mk.leaf) >> 1
 	merkle := make([]hash.Hash32B, length)
 
 	// first round, compute hash from original leaf
@@ -83,3 +83,68 @@ func (mk *Merkle) HashTree() hash.Hash32B {
 	mk.root = merkle[0]
 	return mk.root
 }
+
+// Proof returns the merkle path of the leaf at index, which is the list of sibling hashes from the leaf level up to the
+// level right below the root
+func (mk *Merkle) Proof(index uint64) ([]hash.Hash32B, error) {
+	// the padded copy of the last leaf isn't a leaf to prove
+	if index >= uint64(mk.size) {
+		return nil, errors.Errorf("leaf index %d is out of range", index)
+	}
+	if mk.size == 1 {
+		return []hash.Hash32B{}, nil
+	}
+
+	level := make([]hash.Hash32B, len(mk.leaf))
+	copy(level, mk.leaf)
+	var path []hash.Hash32B
+	for len(level) > 1 {
+		// copy the last hash if the level has odd number of hashes, as HashTree() does
+		if len(level)&1 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+		path = append(path, level[index^1])
+		next := make([]hash.Hash32B, len(level)>>1)
+		for i := range next {
+			next[i] = hashPair(level[i<<1], level[i<<1+1])
+		}
+		level = next
+		index >>= 1
+	}
+	return path, nil
+}
+
+// VerifyMerkleProof verifies that the leaf is at index of the merkle tree of size leaves with the root, given the
+// merkle path returned by Proof(). As Proof() does, it rejects the index of the padded copy of the last leaf
+func VerifyMerkleProof(root hash.Hash32B, leaf hash.Hash32B, index uint64, size uint64, path []hash.Hash32B) bool {
+	if index >= size || len(path) != pathLength(size) {
+		return false
+	}
+	h := leaf
+	for _, sibling := range path {
+		if index&1 == 0 {
+			h = hashPair(h, sibling)
+		} else {
+			h = hashPair(sibling, h)
+		}
+		index >>= 1
+	}
+	return index == 0 && h == root
+}
+
+// pathLength returns the length of the merkle path of a leaf in a tree of size leaves, which is the number of levels
+// below the root
+func pathLength(size uint64) int {
+	length := 0
+	for ; size > 1; size = (size + 1) >> 1 {
+		length++
+	}
+	return length
+}
+
+func hashPair(left hash.Hash32B, right hash.Hash32B) hash.Hash32B {
+	h := make([]byte, 0, 64)
+	h = append(h, left[:]...)
+	h = append(h, right[:]...)
+	return blake2b.Sum256(h)
+}
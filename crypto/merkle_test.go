@@ -69,3 +69,40 @@ func TestMerkleTree(t *testing.T) {
 	assert.Equal(t, 0, bytes.Compare(expected[:], actual5[:]))
 	assert.Equal(t, -1, bytes.Compare(actual5[:], actual4[:]))
 }
+
+func TestMerkleProof(t *testing.T) {
+	inputs := []hash.Hash32B{
+		decodeHash("aeedd06eb44f08abbcc72a2293aff580f13662fa59cc1b0aa4a15ee7c118e4eb"),
+		decodeHash("9de6306b08158c423330f7a27243a1a5cbe39bfd764f07818437882d21241567"),
+		decodeHash("7959228bfdb316949973c08d8bb7bea2a21227a7b4ed85c35d247bf3d6b15a11"),
+		decodeHash("6368616e676520746869732070617373776f726420746f206120736563726574"),
+		decodeHash("0bbcd13e801fdf4d70c62b3788173edaf52113e7bfca4603eb5d486f4a011411"),
+	}
+	for size := 1; size <= len(inputs); size++ {
+		m := NewMerkleTree(inputs[:size])
+		root := m.HashTree()
+		for i := 0; i < size; i++ {
+			path, err := m.Proof(uint64(i))
+			assert.NoError(t, err)
+			assert.True(t, VerifyMerkleProof(root, inputs[i], uint64(i), uint64(size), path))
+			// the proof doesn't hold for another leaf or another position
+			assert.False(t, VerifyMerkleProof(root, inputs[(i+1)%len(inputs)], uint64(i), uint64(size), path))
+			if i^1 < size {
+				assert.False(t, VerifyMerkleProof(root, inputs[i], uint64(i)^1, uint64(size), path))
+			}
+		}
+		if size&1 != 0 && size > 1 {
+			// the path of the last leaf also leads to the root from the padded copy of it, which isn't a leaf
+			path, err := m.Proof(uint64(size - 1))
+			assert.NoError(t, err)
+			assert.False(t, VerifyMerkleProof(root, inputs[size-1], uint64(size), uint64(size), path))
+		}
+	}
+
+	m := NewMerkleTree(inputs)
+	_, err := m.Proof(uint64(len(inputs) + 1))
+	assert.Error(t, err)
+	// the copy of the last leaf padded to the odd number of leaves cannot be proved
+	_, err = m.Proof(uint64(len(inputs)))
+	assert.Error(t, err)
+}
@@ -7,7 +7,9 @@
 package db
 
 import (
+	"bytes"
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/boltdb/bolt"
@@ -40,18 +42,23 @@ type KVStore interface {
 	Delete(string, []byte) error
 	// Commit commits a batch
 	Commit(KVStoreBatch) error
+	// Iterate returns an iterator over the records of a namespace within the range
+	Iterate(string, Range) (Iterator, error)
 }
 
-const (
-	keyDelimiter = "."
-)
-
 // memKVStore is the in-memory implementation of KVStore for testing purpose
 type memKVStore struct {
 	data   *sync.Map
 	bucket map[string]struct{}
 }
 
+// memKey is the key of a record in memKVStore, which keeps the namespace apart from the key, so that a namespace
+// doesn't collide with another one prefixed by it
+type memKey struct {
+	namespace string
+	key       string
+}
+
 // NewMemKVStore instantiates an in-memory KV store
 func NewMemKVStore() KVStore {
 	return &memKVStore{
@@ -67,14 +74,14 @@ func (m *memKVStore) Stop(_ context.Context) error { return nil }
 // Put inserts a <key, value> record
 func (m *memKVStore) Put(namespace string, key, value []byte) error {
 	m.bucket[namespace] = struct{}{}
-	m.data.Store(namespace+keyDelimiter+string(key), value)
+	m.data.Store(memKey{namespace, string(key)}, value)
 	return nil
 }
 
 // PutIfNotExists inserts a <key, value> record only if it does not exist yet, otherwise return ErrAlreadyExist
 func (m *memKVStore) PutIfNotExists(namespace string, key, value []byte) error {
 	m.bucket[namespace] = struct{}{}
-	_, loaded := m.data.LoadOrStore(namespace+keyDelimiter+string(key), value)
+	_, loaded := m.data.LoadOrStore(memKey{namespace, string(key)}, value)
 	if loaded {
 		return ErrAlreadyExist
 	}
@@ -86,7 +93,7 @@ func (m *memKVStore) Get(namespace string, key []byte) ([]byte, error) {
 	if _, ok := m.bucket[namespace]; !ok {
 		return nil, errors.Wrapf(bolt.ErrBucketNotFound, "bucket = %s", namespace)
 	}
-	value, _ := m.data.Load(namespace + keyDelimiter + string(key))
+	value, _ := m.data.Load(memKey{namespace, string(key)})
 	if value != nil {
 		return value.([]byte), nil
 	}
@@ -95,10 +102,30 @@ func (m *memKVStore) Get(namespace string, key []byte) ([]byte, error) {
 
 // Delete deletes a record
 func (m *memKVStore) Delete(namespace string, key []byte) error {
-	m.data.Delete(namespace + keyDelimiter + string(key))
+	m.data.Delete(memKey{namespace, string(key)})
 	return nil
 }
 
+// Iterate returns an iterator over the records of a namespace within the range. As the records are kept in an
+// unordered map, the ones in the range are collected and sorted when the iterator is created
+func (m *memKVStore) Iterate(namespace string, r Range) (Iterator, error) {
+	var pairs []kvPair
+	m.data.Range(func(k, v interface{}) bool {
+		if k.(memKey).namespace != namespace {
+			return true
+		}
+		key := []byte(k.(memKey).key)
+		if r.contains(key) {
+			pairs = append(pairs, kvPair{key, v.([]byte)})
+		}
+		return true
+	})
+	sort.Slice(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].key, pairs[j].key) < 0
+	})
+	return newSliceIterator(pairs, r.Reverse), nil
+}
+
 // Commit commits a batch
 func (m *memKVStore) Commit(b KVStoreBatch) (e error) {
 	succeed := false
@@ -150,6 +177,14 @@ type boltDB struct {
 	config config.DB
 }
 
+// NewOnDiskDB instantiates an on-disk KV store of the engine set in config, which is BoltDB by default
+func NewOnDiskDB(path string, cfg config.DB) KVStore {
+	if cfg.Engine == config.LevelDBEngine {
+		return NewLevelDB(path, cfg)
+	}
+	return NewBoltDB(path, cfg)
+}
+
 // NewBoltDB instantiates a boltdb based KV store
 func NewBoltDB(path string, cfg config.DB) KVStore {
 	return &boltDB{db: nil, path: path, config: cfg}
@@ -283,6 +318,64 @@ func (b *boltDB) Delete(namespace string, key []byte) error {
 	return err
 }
 
+// Iterate returns an iterator over the records of a namespace within the range. The records are read a page at a time,
+// each in its own read transaction, so that a long iteration neither holds the whole namespace in memory nor blocks
+// the writes
+func (b *boltDB) Iterate(namespace string, r Range) (Iterator, error) {
+	return newPageIterator(r, func(r Range, n int) ([]kvPair, error) {
+		b.mutex.RLock()
+		defer b.mutex.RUnlock()
+
+		pairs := make([]kvPair, 0, n)
+		err := b.db.View(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket([]byte(namespace))
+			if bucket == nil {
+				return nil
+			}
+			c := bucket.Cursor()
+			var k, v []byte
+			if r.Reverse {
+				k, v = c.Last()
+				if end := r.upperBound(); end != nil {
+					// the range excludes the upper bound
+					if k, v = c.Seek(end); k == nil {
+						k, v = c.Last()
+					} else {
+						k, v = c.Prev()
+					}
+				}
+			} else {
+				k, v = c.First()
+				if start := r.lowerBound(); start != nil {
+					k, v = c.Seek(start)
+				}
+			}
+			for ; k != nil && len(pairs) < n; k, v = b.move(c, r.Reverse) {
+				if (!r.Reverse && r.exceeds(k)) || (r.Reverse && r.precedes(k)) {
+					break
+				}
+				// the memory returned by bolt is only valid during the transaction
+				pair := kvPair{make([]byte, len(k)), make([]byte, len(v))}
+				copy(pair.key, k)
+				copy(pair.value, v)
+				pairs = append(pairs, pair)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to iterate bucket %s", namespace)
+		}
+		return pairs, nil
+	}), nil
+}
+
+func (b *boltDB) move(c *bolt.Cursor, reverse bool) ([]byte, []byte) {
+	if reverse {
+		return c.Prev()
+	}
+	return c.Next()
+}
+
 // Commit commits a batch
 func (b *boltDB) Commit(batch KVStoreBatch) (err error) {
 	b.mutex.Lock()
@@ -0,0 +1,363 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Range defines the records of a namespace to visit by an iterator
+	// An empty range visits all the records in the namespace
+	Range struct {
+		Start   []byte // the first key to visit (inclusive), nil starts from the smallest key
+		Limit   []byte // the key to stop at (exclusive), nil stops after the largest key
+		Prefix  []byte // only visit the keys with this prefix
+		Reverse bool   // visit the keys in descending order
+	}
+
+	// Iterator iterates over the <key, value> records of a namespace in key order
+	// An iterator is positioned before the first record, so Next() has to be called before reading Key() and Value()
+	Iterator interface {
+		// Next moves to the next record, returns false if there are no more records or reading the records fails
+		Next() bool
+		// Key returns the key of the current record
+		Key() []byte
+		// Value returns the value of the current record
+		Value() []byte
+		// Error returns the error that stops the iteration, if any
+		Error() error
+	}
+
+	kvPair struct {
+		key   []byte
+		value []byte
+	}
+
+	// sliceIterator implements the Iterator interface over the records loaded in memory
+	sliceIterator struct {
+		pairs []kvPair
+		index int
+	}
+
+	// pageReader reads at most n records of the range in the order of the range
+	pageReader func(r Range, n int) ([]kvPair, error)
+
+	// pageIterator implements the Iterator interface by reading a page of records at a time, so that only a page of
+	// the namespace is held in memory. Each page is read separately, so the records written during the iteration may
+	// or may not be visited
+	pageIterator struct {
+		r     Range
+		read  pageReader
+		pairs []kvPair
+		index int
+		done  bool
+		err   error
+	}
+
+	// overlayWrite is the net effect of the pending writes of a batch on a key
+	overlayWrite struct {
+		key       []byte
+		value     []byte
+		writeType int32
+	}
+
+	// overlayIterator implements the Iterator interface by merging the pending writes of a batch into the records of
+	// the KV store
+	overlayIterator struct {
+		base       Iterator
+		baseValid  bool
+		writes     []overlayWrite
+		reverse    bool
+		key, value []byte
+		err        error
+	}
+)
+
+// iteratorPageSize is the number of records a pageIterator reads at a time
+var iteratorPageSize = 256
+
+// Prefix returns a range that visits all the keys with the prefix
+func Prefix(prefix []byte) Range {
+	return Range{Prefix: prefix}
+}
+
+// IterateWithBatch iterates over a namespace of the KV store, as if the pending writes of the batch were committed.
+// The pending writes in the range are held in memory, while the records of the KV store are streamed
+func IterateWithBatch(kvStore KVStore, batch KVStoreBatch, namespace string, r Range) (Iterator, error) {
+	batch.Lock()
+	// replay the pending writes in order to get the net effect on each key
+	overlay := make(map[string]*overlayWrite)
+	for i := 0; i < batch.Size(); i++ {
+		write, err := batch.Entry(i)
+		if err != nil {
+			batch.Unlock()
+			return nil, err
+		}
+		if write.namespace != namespace || !r.contains(write.key) {
+			continue
+		}
+		prev, ok := overlay[string(write.key)]
+		switch {
+		case write.writeType != PutIfNotExists:
+			overlay[string(write.key)] = &overlayWrite{write.key, write.value, write.writeType}
+		case !ok:
+			// whether the key exists is only known when merging with the KV store
+			overlay[string(write.key)] = &overlayWrite{write.key, write.value, PutIfNotExists}
+		case prev.writeType == Delete:
+			overlay[string(write.key)] = &overlayWrite{write.key, write.value, Put}
+		}
+	}
+	batch.Unlock()
+
+	writes := make([]overlayWrite, 0, len(overlay))
+	for _, write := range overlay {
+		writes = append(writes, *write)
+	}
+	sort.Slice(writes, func(i, j int) bool {
+		if r.Reverse {
+			return bytes.Compare(writes[i].key, writes[j].key) > 0
+		}
+		return bytes.Compare(writes[i].key, writes[j].key) < 0
+	})
+	iter, err := kvStore.Iterate(namespace, r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate namespace %s", namespace)
+	}
+	baseValid := iter.Next()
+	if !baseValid && iter.Error() != nil {
+		return nil, errors.Wrapf(iter.Error(), "failed to iterate namespace %s", namespace)
+	}
+	return &overlayIterator{base: iter, baseValid: baseValid, writes: writes, reverse: r.Reverse}, nil
+}
+
+//======================================
+// Range functions
+//======================================
+
+// lowerBound returns the smallest key that could be in the range
+func (r *Range) lowerBound() []byte {
+	if bytes.Compare(r.Start, r.Prefix) > 0 {
+		return r.Start
+	}
+	return r.Prefix
+}
+
+// exceeds returns true if an ascending scan that started at lowerBound() has passed the range
+func (r *Range) exceeds(key []byte) bool {
+	if r.Limit != nil && bytes.Compare(key, r.Limit) >= 0 {
+		return true
+	}
+	return !bytes.HasPrefix(key, r.Prefix)
+}
+
+// upperBound returns the key that a descending scan starts before, nil if it starts from the largest key
+func (r *Range) upperBound() []byte {
+	if r.Prefix == nil {
+		return r.Limit
+	}
+	prefixLimit := prefixLimit(r.Prefix)
+	if r.Limit == nil || (prefixLimit != nil && bytes.Compare(prefixLimit, r.Limit) < 0) {
+		return prefixLimit
+	}
+	return r.Limit
+}
+
+// precedes returns true if a descending scan that started before upperBound() has passed the range
+func (r *Range) precedes(key []byte) bool {
+	if r.Start != nil && bytes.Compare(key, r.Start) < 0 {
+		return true
+	}
+	return !bytes.HasPrefix(key, r.Prefix)
+}
+
+// contains returns true if the key is in the range
+func (r *Range) contains(key []byte) bool {
+	if !bytes.HasPrefix(key, r.Prefix) {
+		return false
+	}
+	if r.Start != nil && bytes.Compare(key, r.Start) < 0 {
+		return false
+	}
+	return r.Limit == nil || bytes.Compare(key, r.Limit) < 0
+}
+
+//======================================
+// sliceIterator implementation
+//======================================
+
+// newSliceIterator creates an iterator over pairs sorted in ascending key order
+func newSliceIterator(pairs []kvPair, reverse bool) Iterator {
+	if reverse {
+		for i, j := 0, len(pairs)-1; i < j; i, j = i+1, j-1 {
+			pairs[i], pairs[j] = pairs[j], pairs[i]
+		}
+	}
+	return &sliceIterator{pairs: pairs, index: -1}
+}
+
+// Next moves to the next record
+func (it *sliceIterator) Next() bool {
+	if it.index < len(it.pairs) {
+		it.index++
+	}
+	return it.index < len(it.pairs)
+}
+
+// Key returns the key of the current record
+func (it *sliceIterator) Key() []byte {
+	if it.index < 0 || it.index >= len(it.pairs) {
+		return nil
+	}
+	return it.pairs[it.index].key
+}
+
+// Value returns the value of the current record
+func (it *sliceIterator) Value() []byte {
+	if it.index < 0 || it.index >= len(it.pairs) {
+		return nil
+	}
+	return it.pairs[it.index].value
+}
+
+// Error returns nil as the records are already in memory
+func (it *sliceIterator) Error() error { return nil }
+
+// prefixLimit returns the smallest key larger than all the keys with the prefix, nil if there is no such key
+func prefixLimit(prefix []byte) []byte {
+	limit := make([]byte, len(prefix))
+	copy(limit, prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		if limit[i] < 0xff {
+			limit[i]++
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
+
+//======================================
+// pageIterator implementation
+//======================================
+
+// newPageIterator creates an iterator that reads the records of the range a page at a time
+func newPageIterator(r Range, read pageReader) Iterator {
+	return &pageIterator{r: r, read: read, index: -1}
+}
+
+// Next moves to the next record, and reads the next page when the current one has been visited
+func (it *pageIterator) Next() bool {
+	if it.index < len(it.pairs) {
+		it.index++
+	}
+	if it.index < len(it.pairs) {
+		return true
+	}
+	if it.done {
+		return false
+	}
+	if len(it.pairs) > 0 {
+		// continue after the last visited key
+		last := it.pairs[len(it.pairs)-1].key
+		if it.r.Reverse {
+			it.r.Limit = last
+		} else {
+			it.r.Start = append(append(make([]byte, 0, len(last)+1), last...), 0)
+		}
+	}
+	pairs, err := it.read(it.r, iteratorPageSize)
+	if err != nil {
+		it.err = err
+		it.done = true
+		it.pairs = nil
+		return false
+	}
+	it.pairs = pairs
+	it.index = 0
+	it.done = len(pairs) < iteratorPageSize
+	return len(pairs) > 0
+}
+
+// Key returns the key of the current record
+func (it *pageIterator) Key() []byte {
+	if it.index < 0 || it.index >= len(it.pairs) {
+		return nil
+	}
+	return it.pairs[it.index].key
+}
+
+// Value returns the value of the current record
+func (it *pageIterator) Value() []byte {
+	if it.index < 0 || it.index >= len(it.pairs) {
+		return nil
+	}
+	return it.pairs[it.index].value
+}
+
+// Error returns the error of reading a page
+func (it *pageIterator) Error() error { return it.err }
+
+//======================================
+// overlayIterator implementation
+//======================================
+
+// Next moves to the next record that exists after applying the pending writes
+func (it *overlayIterator) Next() bool {
+	for it.err == nil && (it.baseValid || len(it.writes) > 0) {
+		var cmp int
+		switch {
+		case !it.baseValid:
+			cmp = 1
+		case len(it.writes) == 0:
+			cmp = -1
+		default:
+			cmp = bytes.Compare(it.base.Key(), it.writes[0].key)
+			if it.reverse {
+				cmp = -cmp
+			}
+		}
+		if cmp < 0 {
+			// the record isn't touched by the batch
+			it.key, it.value = it.base.Key(), it.base.Value()
+			it.advanceBase()
+			return true
+		}
+		write := it.writes[0]
+		it.writes = it.writes[1:]
+		it.key, it.value = write.key, write.value
+		if cmp == 0 {
+			if write.writeType == PutIfNotExists {
+				it.value = it.base.Value()
+			}
+			it.advanceBase()
+		}
+		if write.writeType != Delete {
+			return true
+		}
+	}
+	it.key, it.value = nil, nil
+	return false
+}
+
+// Key returns the key of the current record
+func (it *overlayIterator) Key() []byte { return it.key }
+
+// Value returns the value of the current record
+func (it *overlayIterator) Value() []byte { return it.value }
+
+// Error returns the error of reading the KV store
+func (it *overlayIterator) Error() error { return it.err }
+
+func (it *overlayIterator) advanceBase() {
+	it.baseValid = it.base.Next()
+	if !it.baseValid {
+		it.err = it.base.Error()
+	}
+}
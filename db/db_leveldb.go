@@ -0,0 +1,215 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+// levelDB is KVStore implementation based on LevelDB
+// LevelDB has a single key space, so each key is prefixed with the length and the name of its namespace
+type levelDB struct {
+	mutex  sync.RWMutex
+	db     *leveldb.DB
+	path   string
+	config config.DB
+}
+
+// NewLevelDB instantiates a LevelDB based KV store
+func NewLevelDB(path string, cfg config.DB) KVStore {
+	return &levelDB{db: nil, path: path, config: cfg}
+}
+
+// Start opens the LevelDB (creates new directory if not existing yet)
+func (l *levelDB) Start(_ context.Context) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.db != nil {
+		return nil
+	}
+
+	db, err := leveldb.OpenFile(l.path, nil)
+	if err != nil {
+		return err
+	}
+	l.db = db
+	return nil
+}
+
+// Stop closes the LevelDB
+func (l *levelDB) Stop(_ context.Context) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.db != nil {
+		err := l.db.Close()
+		l.db = nil
+		return err
+	}
+	return nil
+}
+
+// Put inserts a <key, value> record
+func (l *levelDB) Put(namespace string, key, value []byte) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.db.Put(namespaceKey(namespace, key), value, nil)
+}
+
+// PutIfNotExists inserts a <key, value> record only if it does not exist yet, otherwise return ErrAlreadyExist
+func (l *levelDB) PutIfNotExists(namespace string, key, value []byte) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	nsKey := namespaceKey(namespace, key)
+	exist, err := l.db.Has(nsKey, nil)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return ErrAlreadyExist
+	}
+	return l.db.Put(nsKey, value, nil)
+}
+
+// Get retrieves a record
+func (l *levelDB) Get(namespace string, key []byte) ([]byte, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	value, err := l.db.Get(namespaceKey(namespace, key), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, errors.Wrapf(ErrNotExist, "key = %x", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Delete deletes a record
+func (l *levelDB) Delete(namespace string, key []byte) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.db.Delete(namespaceKey(namespace, key), nil)
+}
+
+// Commit commits a batch
+func (l *levelDB) Commit(batch KVStoreBatch) (err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	succeed := false
+	batch.Lock()
+	defer func() {
+		if succeed {
+			// clear the batch if commit succeeds
+			batch.ClearAndUnlock()
+		} else {
+			batch.Unlock()
+		}
+	}()
+	writes := new(leveldb.Batch)
+	// existence of the keys written earlier in this batch, which is not visible in DB until the batch is written
+	staged := make(map[string]bool)
+	for i := 0; i < batch.Size(); i++ {
+		write, err := batch.Entry(i)
+		if err != nil {
+			return err
+		}
+		nsKey := namespaceKey(write.namespace, write.key)
+		if write.writeType == Put {
+			writes.Put(nsKey, write.value)
+			staged[string(nsKey)] = true
+		} else if write.writeType == PutIfNotExists {
+			exist, ok := staged[string(nsKey)]
+			if !ok {
+				if exist, err = l.db.Has(nsKey, nil); err != nil {
+					return errors.Wrapf(err, write.errorFormat, write.errorArgs)
+				}
+			}
+			if exist {
+				return ErrAlreadyExist
+			}
+			writes.Put(nsKey, write.value)
+			staged[string(nsKey)] = true
+		} else if write.writeType == Delete {
+			writes.Delete(nsKey)
+			staged[string(nsKey)] = false
+		}
+	}
+	// a LevelDB batch is applied atomically
+	if err = l.db.Write(writes, nil); err != nil {
+		return err
+	}
+	succeed = true
+	return nil
+}
+
+// Iterate returns an iterator over the records of a namespace within the range. The records are read a page at a time,
+// so that a long iteration doesn't hold the whole namespace in memory
+func (l *levelDB) Iterate(namespace string, r Range) (Iterator, error) {
+	return newPageIterator(r, func(r Range, n int) ([]kvPair, error) {
+		l.mutex.RLock()
+		defer l.mutex.RUnlock()
+
+		nsPrefix := namespaceKey(namespace, nil)
+		limit := util.BytesPrefix(nsPrefix).Limit
+		if end := r.upperBound(); end != nil {
+			limit = namespaceKey(namespace, end)
+		}
+		iter := l.db.NewIterator(&util.Range{Start: namespaceKey(namespace, r.lowerBound()), Limit: limit}, nil)
+		defer iter.Release()
+
+		pairs := make([]kvPair, 0, n)
+		valid := iter.Next()
+		if r.Reverse {
+			valid = iter.Last()
+		}
+		for ; valid && len(pairs) < n; valid = l.move(iter, r.Reverse) {
+			key := iter.Key()[len(nsPrefix):]
+			if (!r.Reverse && r.exceeds(key)) || (r.Reverse && r.precedes(key)) {
+				break
+			}
+			// the memory returned by the iterator is reused in the next move
+			pair := kvPair{make([]byte, len(key)), make([]byte, len(iter.Value()))}
+			copy(pair.key, key)
+			copy(pair.value, iter.Value())
+			pairs = append(pairs, pair)
+		}
+		if err := iter.Error(); err != nil {
+			return nil, errors.Wrapf(err, "failed to iterate namespace %s", namespace)
+		}
+		return pairs, nil
+	}), nil
+}
+
+func (l *levelDB) move(iter iterator.Iterator, reverse bool) bool {
+	if reverse {
+		return iter.Prev()
+	}
+	return iter.Next()
+}
+
+// namespaceKey returns the key in LevelDB's key space, which is <len(namespace)><namespace><key>
+func namespaceKey(namespace string, key []byte) []byte {
+	nsKey := make([]byte, 0, 1+len(namespace)+len(key))
+	nsKey = append(nsKey, byte(len(namespace)))
+	nsKey = append(nsKey, namespace...)
+	return append(nsKey, key...)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+)
+
+// MigrateBoltDB copies all the records of the BoltDB file at path into another KV store, which has to be started
+// already. Each bucket of the BoltDB becomes a namespace of the same name. Records are written in batches of
+// batchSize, so a failed migration can simply be rerun
+func MigrateBoltDB(path string, to KVStore, batchSize int) error {
+	if batchSize <= 0 {
+		return errors.Errorf("invalid batch size %d", batchSize)
+	}
+	from, err := bolt.Open(path, fileMode, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return errors.Wrapf(err, "failed to open BoltDB %s", path)
+	}
+	defer from.Close()
+
+	batch := NewBatch()
+	return from.View(func(tx *bolt.Tx) error {
+		if err := tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			namespace := string(name)
+			return bucket.ForEach(func(k, v []byte) error {
+				// bolt only guarantees the key and value to be valid during the transaction
+				key := make([]byte, len(k))
+				copy(key, k)
+				value := make([]byte, len(v))
+				copy(value, v)
+				batch.Put(namespace, key, value, "failed to migrate key %x", key)
+				if batch.Size() < batchSize {
+					return nil
+				}
+				return errors.Wrapf(to.Commit(batch), "failed to migrate bucket %s", namespace)
+			})
+		}); err != nil {
+			return err
+		}
+		if batch.Size() == 0 {
+			return nil
+		}
+		return errors.Wrap(to.Commit(batch), "failed to migrate the last batch")
+	})
+}
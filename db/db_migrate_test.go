@@ -0,0 +1,66 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestMigrateBoltDB(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	fromPath := "/tmp/test-migrate-from-" + strconv.Itoa(rand.Int())
+	toPath := "/tmp/test-migrate-to-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, fromPath)
+	testutil.CleanupPath(t, toPath)
+	defer testutil.CleanupPath(t, fromPath)
+	defer testutil.CleanupPath(t, toPath)
+
+	from := NewBoltDB(fromPath, cfg)
+	require.NoError(from.Start(ctx))
+	for i := range testK1 {
+		require.NoError(from.Put(bucket1, testK1[i], testV1[i]))
+		require.NoError(from.Put(bucket2, testK2[i], testV2[i]))
+	}
+	require.NoError(from.Stop(ctx))
+
+	to := NewLevelDB(toPath, cfg)
+	require.NoError(to.Start(ctx))
+	defer func() {
+		require.NoError(to.Stop(ctx))
+	}()
+	require.Error(MigrateBoltDB(fromPath, to, 0))
+	// a batch size smaller than the number of records commits several batches
+	require.NoError(MigrateBoltDB(fromPath, to, 2))
+
+	for i := range testK1 {
+		value, err := to.Get(bucket1, testK1[i])
+		require.NoError(err)
+		require.Equal(testV1[i], value)
+		value, err = to.Get(bucket2, testK2[i])
+		require.NoError(err)
+		require.Equal(testV2[i], value)
+	}
+	// records stay in their own namespace
+	_, err := to.Get(bucket2, testK1[0])
+	require.Error(err)
+	iter, err := to.Iterate(bucket1, Range{})
+	require.NoError(err)
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	require.Equal(len(testK1), count)
+}
@@ -78,6 +78,12 @@ func TestKVStorePutGet(t *testing.T) {
 		defer testutil.CleanupPath(t, path)
 		testKVStorePutGet(NewBoltDB(path, cfg), t)
 	})
+
+	t.Run("LevelDB", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testKVStorePutGet(NewLevelDB(path, cfg), t)
+	})
 }
 
 func TestBatchRollback(t *testing.T) {
@@ -184,70 +190,69 @@ func TestDBBatch(t *testing.T) {
 		require := require.New(t)
 
 		ctx := context.Background()
-		kvboltDB := kvStore.(*boltDB)
 		batch := NewBatch()
 
-		err := kvboltDB.Start(ctx)
+		err := kvStore.Start(ctx)
 		require.Nil(err)
 		defer func() {
-			err = kvboltDB.Stop(ctx)
+			err = kvStore.Stop(ctx)
 			require.Nil(err)
 		}()
 
-		err = kvboltDB.Put(bucket1, testK1[0], testV1[1])
+		err = kvStore.Put(bucket1, testK1[0], testV1[1])
 		require.Nil(err)
 
-		err = kvboltDB.Put(bucket2, testK2[1], testV2[0])
+		err = kvStore.Put(bucket2, testK2[1], testV2[0])
 		require.Nil(err)
 
-		err = kvboltDB.Put(bucket1, testK1[2], testV1[0])
+		err = kvStore.Put(bucket1, testK1[2], testV1[0])
 		require.Nil(err)
 
 		batch.Put(bucket1, testK1[0], testV1[0], "")
 		batch.Put(bucket2, testK2[1], testV2[1], "")
-		value, err := kvboltDB.Get(bucket1, testK1[0])
+		value, err := kvStore.Get(bucket1, testK1[0])
 		require.Nil(err)
 		require.Equal(testV1[1], value)
 
-		value, err = kvboltDB.Get(bucket2, testK2[1])
+		value, err = kvStore.Get(bucket2, testK2[1])
 		require.Nil(err)
 		require.Equal(testV2[0], value)
-		require.Nil(kvboltDB.Commit(batch))
+		require.Nil(kvStore.Commit(batch))
 
-		value, err = kvboltDB.Get(bucket1, testK1[0])
+		value, err = kvStore.Get(bucket1, testK1[0])
 		require.Nil(err)
 		require.Equal(testV1[0], value)
 
-		value, err = kvboltDB.Get(bucket2, testK2[1])
+		value, err = kvStore.Get(bucket2, testK2[1])
 		require.Nil(err)
 		require.Equal(testV2[1], value)
 
-		value, err = kvboltDB.Get(bucket1, testK1[2])
+		value, err = kvStore.Get(bucket1, testK1[2])
 		require.Nil(err)
 		require.Equal(testV1[0], value)
 
 		batch.Put(bucket1, testK1[0], testV1[1], "")
 		err = batch.PutIfNotExists(bucket2, testK2[1], testV2[0], "")
 		require.Nil(err)
-		err = kvboltDB.Commit(batch)
+		err = kvStore.Commit(batch)
 		require.Equal(err, ErrAlreadyExist)
 		// need to clear the batch in case of commit error
 		batch.Clear()
 
-		value, err = kvboltDB.Get(bucket2, testK2[1])
+		value, err = kvStore.Get(bucket2, testK2[1])
 		require.Nil(err)
 		require.Equal(testV2[1], value)
 
-		value, err = kvboltDB.Get(bucket1, testK1[0])
+		value, err = kvStore.Get(bucket1, testK1[0])
 		require.Nil(err)
 		require.Equal(testV1[0], value)
 
 		err = batch.PutIfNotExists(bucket3, testK2[0], testV2[0], "")
 		require.Nil(err)
-		err = kvboltDB.Commit(batch)
+		err = kvStore.Commit(batch)
 		require.Nil(err)
 
-		value, err = kvboltDB.Get(bucket3, testK2[0])
+		value, err = kvStore.Get(bucket3, testK2[0])
 		require.Nil(err)
 		require.Equal(testV2[0], value)
 
@@ -256,26 +261,26 @@ func TestDBBatch(t *testing.T) {
 		// cause transaction rollback
 		err = batch.PutIfNotExists(bucket3, testK2[0], testV2[1], "")
 		require.Nil(err)
-		require.NotNil(kvboltDB.Commit(batch))
+		require.NotNil(kvStore.Commit(batch))
 
-		value, err = kvboltDB.Get(bucket1, testK1[2])
+		value, err = kvStore.Get(bucket1, testK1[2])
 		require.Nil(err)
 		require.Equal(testV1[0], value)
 
-		value, err = kvboltDB.Get(bucket2, testK2[1])
+		value, err = kvStore.Get(bucket2, testK2[1])
 		require.Nil(err)
 		require.Equal(testV2[1], value)
 
 		batch.Clear()
 		batch.Put(bucket1, testK1[2], testV1[2], "")
 		batch.Delete(bucket2, testK2[1], "")
-		require.Nil(kvboltDB.Commit(batch))
+		require.Nil(kvStore.Commit(batch))
 
-		value, err = kvboltDB.Get(bucket1, testK1[2])
+		value, err = kvStore.Get(bucket1, testK1[2])
 		require.Nil(err)
 		require.Equal(testV1[2], value)
 
-		_, err = kvboltDB.Get(bucket2, testK2[1])
+		_, err = kvStore.Get(bucket2, testK2[1])
 		require.NotNil(err)
 	}
 
@@ -285,6 +290,13 @@ func TestDBBatch(t *testing.T) {
 		defer testutil.CleanupPath(t, path)
 		testBatchRollback(NewBoltDB(path, cfg), t)
 	})
+
+	t.Run("LevelDB", func(t *testing.T) {
+		path := "/tmp/test-batch-rollback-" + strconv.Itoa(rand.Int())
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testBatchRollback(NewLevelDB(path, cfg), t)
+	})
 }
 
 func TestCacheKV(t *testing.T) {
@@ -358,6 +370,18 @@ func TestCacheKV(t *testing.T) {
 		}()
 		testFunc(kv, t)
 	})
+
+	t.Run("LevelDB", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		kv := NewLevelDB(path, cfg)
+		require.Nil(t, kv.Start(context.Background()))
+		defer func() {
+			err := kv.Stop(context.Background())
+			require.Nil(t, err)
+		}()
+		testFunc(kv, t)
+	})
 }
 
 func TestCachedBatch(t *testing.T) {
@@ -394,3 +418,139 @@ func TestCachedBatch(t *testing.T) {
 	require.Equal(testV1[0], w.value)
 	require.Equal(PutIfNotExists, w.writeType)
 }
+
+func TestKVStoreIterate(t *testing.T) {
+	testFunc := func(kv KVStore, t *testing.T) {
+		require := require.New(t)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(kv.Put(bucket1, testK1[i], testV1[i]))
+			require.NoError(kv.Put(bucket1, testK2[i], testV2[i]))
+		}
+		require.NoError(kv.Put(bucket1, []byte("other"), []byte("other")))
+		require.NoError(kv.Put(bucket2, testK1[0], testV1[0]))
+
+		readAll := func(iter Iterator) (keys [][]byte, values [][]byte) {
+			for iter.Next() {
+				keys = append(keys, iter.Key())
+				values = append(values, iter.Value())
+			}
+			return
+		}
+
+		// the whole namespace in ascending order
+		iter, err := kv.Iterate(bucket1, Range{})
+		require.NoError(err)
+		keys, values := readAll(iter)
+		require.Equal(7, len(keys))
+		require.Equal(testK1[0], keys[0])
+		require.Equal(testV1[0], values[0])
+		require.Equal([]byte("other"), keys[6])
+		require.False(iter.Next())
+		require.Nil(iter.Key())
+
+		// prefix scan
+		iter, err = kv.Iterate(bucket1, Prefix([]byte("key_")))
+		require.NoError(err)
+		keys, values = readAll(iter)
+		require.Equal([][]byte{testK1[0], testK1[1], testK1[2], testK2[0], testK2[1], testK2[2]}, keys)
+		require.Equal([][]byte{testV1[0], testV1[1], testV1[2], testV2[0], testV2[1], testV2[2]}, values)
+
+		// range scan in reverse order
+		iter, err = kv.Iterate(bucket1, Range{Start: testK1[1], Limit: testK2[1], Prefix: []byte("key_"), Reverse: true})
+		require.NoError(err)
+		keys, _ = readAll(iter)
+		require.Equal([][]byte{testK2[0], testK1[2], testK1[1]}, keys)
+
+		// non-existing namespace
+		iter, err = kv.Iterate(bucket3, Range{})
+		require.NoError(err)
+		require.False(iter.Next())
+
+		// a namespace prefixed by another one isn't iterated with it
+		require.NoError(kv.Put(bucket1+".sub", testK1[0], testV1[0]))
+		iter, err = kv.Iterate(bucket1, Range{})
+		require.NoError(err)
+		keys, _ = readAll(iter)
+		require.Equal(7, len(keys))
+		require.NoError(kv.Delete(bucket1+".sub", testK1[0]))
+
+		// overlay the pending writes in a batch
+		cb := NewCachedBatch()
+		cb.Put(bucket1, testK1[1], testV2[1], "")
+		cb.Delete(bucket1, testK1[2], "")
+		cb.Put(bucket1, []byte("key_0"), testV1[0], "")
+		cb.Put(bucket2, []byte("key_7"), testV1[0], "")
+		iter, err = IterateWithBatch(kv, cb, bucket1, Range{Prefix: []byte("key_"), Limit: testK2[0]})
+		require.NoError(err)
+		keys, values = readAll(iter)
+		require.Equal([][]byte{[]byte("key_0"), testK1[0], testK1[1]}, keys)
+		require.Equal([][]byte{testV1[0], testV1[0], testV2[1]}, values)
+
+		// the underlying store is not changed until the batch is committed
+		iter, err = kv.Iterate(bucket1, Range{Prefix: []byte("key_"), Limit: testK2[0]})
+		require.NoError(err)
+		keys, _ = readAll(iter)
+		require.Equal([][]byte{testK1[0], testK1[1], testK1[2]}, keys)
+		require.NoError(kv.Commit(cb))
+		iter, err = kv.Iterate(bucket1, Range{Prefix: []byte("key_"), Limit: testK2[0], Reverse: true})
+		require.NoError(err)
+		keys, _ = readAll(iter)
+		require.Equal([][]byte{testK1[1], testK1[0], []byte("key_0")}, keys)
+		require.NoError(iter.Error())
+
+		// read the records a page at a time
+		pageSize := iteratorPageSize
+		iteratorPageSize = 2
+		defer func() { iteratorPageSize = pageSize }()
+		iter, err = kv.Iterate(bucket1, Range{Prefix: []byte("key_"), Start: testK1[1]})
+		require.NoError(err)
+		keys, _ = readAll(iter)
+		require.Equal([][]byte{testK1[1], testK2[0], testK2[1], testK2[2]}, keys)
+		iter, err = kv.Iterate(bucket1, Range{Prefix: []byte("key_"), Reverse: true})
+		require.NoError(err)
+		keys, _ = readAll(iter)
+		require.Equal([][]byte{testK2[2], testK2[1], testK2[0], testK1[1], testK1[0], []byte("key_0")}, keys)
+		require.NoError(iter.Error())
+
+		// a key put only if it doesn't exist keeps the value in the KV store
+		require.NoError(cb.PutIfNotExists(bucket1, testK1[0], testV2[0], ""))
+		require.NoError(cb.PutIfNotExists(bucket1, testK1[2], testV1[2], ""))
+		cb.Delete(bucket1, testK2[0], "")
+		iter, err = IterateWithBatch(kv, cb, bucket1, Range{Prefix: []byte("key_"), Reverse: true})
+		require.NoError(err)
+		keys, values = readAll(iter)
+		require.Equal([][]byte{testK2[2], testK2[1], testK1[2], testK1[1], testK1[0], []byte("key_0")}, keys)
+		require.Equal([][]byte{testV2[2], testV2[1], testV1[2], testV2[1], testV1[0], testV1[0]}, values)
+	}
+
+	t.Run("In-memory KV Store", func(t *testing.T) {
+		kv := NewMemKVStore()
+		testFunc(kv, t)
+	})
+
+	path := "/tmp/test-kv-store-" + strconv.Itoa(rand.Int())
+	t.Run("Bolt DB", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		kv := NewBoltDB(path, cfg)
+		require.Nil(t, kv.Start(context.Background()))
+		defer func() {
+			err := kv.Stop(context.Background())
+			require.Nil(t, err)
+		}()
+		testFunc(kv, t)
+	})
+
+	t.Run("LevelDB", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		kv := NewLevelDB(path, cfg)
+		require.Nil(t, kv.Start(context.Background()))
+		defer func() {
+			err := kv.Stop(context.Background())
+			require.Nil(t, err)
+		}()
+		testFunc(kv, t)
+	})
+}
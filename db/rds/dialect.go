@@ -0,0 +1,80 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rds
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+// Dialect abstracts the differences between the SQL dialects the store supports. The queries are written with "?"
+// placeholders, and rebound into the ones of the dialect before being executed
+type Dialect interface {
+	// Name returns the name of the dialect, which is also the name of its database/sql driver
+	Name() string
+	// Rebind converts the "?" placeholders of a query into the ones of the dialect
+	Rebind(query string) string
+	// BinaryType returns the column type of the binary data of at most size bytes
+	BinaryType(size int) string
+	// BlobType returns the column type of the binary data of arbitrary size
+	BlobType() string
+}
+
+var (
+	// MySQL is the dialect of MySQL
+	MySQL Dialect = mysqlDialect{}
+	// Postgres is the dialect of PostgreSQL
+	Postgres Dialect = postgresDialect{}
+	// SQLite is the dialect of SQLite
+	SQLite Dialect = sqliteDialect{}
+)
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string { return config.MySQLDialect }
+
+func (mysqlDialect) Rebind(query string) string { return query }
+
+func (mysqlDialect) BinaryType(size int) string { return fmt.Sprintf("VARBINARY(%d)", size) }
+
+func (mysqlDialect) BlobType() string { return "LONGBLOB" }
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return config.PostgresDialect }
+
+// Rebind converts the placeholders into the numbered ones, i.e., $1, $2, ...
+func (postgresDialect) Rebind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c != '?' {
+			b.WriteRune(c)
+			continue
+		}
+		n++
+		b.WriteString("$" + strconv.Itoa(n))
+	}
+	return b.String()
+}
+
+func (postgresDialect) BinaryType(int) string { return "BYTEA" }
+
+func (postgresDialect) BlobType() string { return "BYTEA" }
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return config.SQLiteDialect }
+
+func (sqliteDialect) Rebind(query string) string { return query }
+
+func (sqliteDialect) BinaryType(int) string { return "BLOB" }
+
+func (sqliteDialect) BlobType() string { return "BLOB" }
@@ -0,0 +1,39 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestDialect(t *testing.T) {
+	require := require.New(t)
+
+	query := "SELECT * FROM t WHERE a=? AND b=?"
+	require.Equal(query, MySQL.Rebind(query))
+	require.Equal(query, SQLite.Rebind(query))
+	require.Equal("SELECT * FROM t WHERE a=$1 AND b=$2", Postgres.Rebind(query))
+
+	require.Equal("VARBINARY(32)", MySQL.BinaryType(32))
+	require.Equal("BYTEA", Postgres.BinaryType(32))
+	require.Equal("BLOB", SQLite.BinaryType(32))
+	require.Equal("LONGBLOB", MySQL.BlobType())
+	require.Equal("BYTEA", Postgres.BlobType())
+	require.Equal("BLOB", SQLite.BlobType())
+
+	for _, d := range []Dialect{MySQL, Postgres, SQLite} {
+		store, err := NewStore(&config.RDS{Dialect: d.Name(), SQLitePath: "/tmp/index.db"})
+		require.NoError(err)
+		require.Equal(d, store.Dialect())
+	}
+	_, err := NewStore(&config.RDS{Dialect: "oracle"})
+	require.Error(err)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rds
+
+import (
+	"database/sql"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+// migrationTable records the versions of the migrations applied to the schema
+const migrationTable = "schema_migrations"
+
+// Migration is a step of the schema evolution, which is applied once, in the order of version
+type Migration struct {
+	Version     uint64
+	Description string
+	// Statements returns the statements of the migration in the dialect
+	Statements func(Dialect) []string
+	// Baseline is a table created by the migration. If no migration is recorded yet but the table exists, the schema
+	// was created before the migrations were introduced, and the migration is recorded without being applied
+	Baseline string
+}
+
+// Migrate applies the migrations of higher versions than the schema's, each in a transaction, and records their
+// versions. Note that MySQL commits the DDL statements implicitly, so a migration failing halfway is not rolled back
+func Migrate(store Store, migrations []Migration) error {
+	db := store.GetDB()
+	if db == nil {
+		return errors.New("store is not started")
+	}
+	if _, err := db.Exec(
+		"CREATE TABLE IF NOT EXISTS " + migrationTable + " (version BIGINT PRIMARY KEY, description VARCHAR(255))",
+	); err != nil {
+		return errors.Wrap(err, "failed to create migration table")
+	}
+	var current sql.NullInt64
+	if err := db.QueryRow("SELECT MAX(version) FROM " + migrationTable).Scan(&current); err != nil {
+		return errors.Wrap(err, "failed to get schema version")
+	}
+
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i, m := range sorted {
+		if i > 0 && m.Version == sorted[i-1].Version {
+			return errors.Errorf("duplicate migration version %d", m.Version)
+		}
+	}
+
+	dialect := store.Dialect()
+	insertQuery := dialect.Rebind("INSERT INTO " + migrationTable + " (version, description) VALUES (?, ?)")
+	for _, m := range sorted {
+		if current.Valid && m.Version <= uint64(current.Int64) {
+			continue
+		}
+		if !current.Valid && m.Baseline != "" && tableExists(db, m.Baseline) {
+			if _, err := db.Exec(insertQuery, m.Version, m.Description); err != nil {
+				return errors.Wrapf(err, "failed to record baseline migration %d", m.Version)
+			}
+			logger.Info().Uint64("version", m.Version).Str("description", m.Description).Msg("Recorded baseline schema")
+			continue
+		}
+		if err := store.Transact(func(tx *sql.Tx) error {
+			for _, stmt := range m.Statements(dialect) {
+				if _, err := tx.Exec(stmt); err != nil {
+					return errors.Wrapf(err, "failed to execute %s", stmt)
+				}
+			}
+			_, err := tx.Exec(insertQuery, m.Version, m.Description)
+			return err
+		}); err != nil {
+			return errors.Wrapf(err, "failed to apply migration %d", m.Version)
+		}
+		logger.Info().Uint64("version", m.Version).Str("description", m.Description).Msg("Applied schema migration")
+	}
+	return nil
+}
+
+// tableExists returns whether a table exists, by querying no row of it, as the dialects don't share a catalog query
+func tableExists(db *sql.DB, table string) bool {
+	rows, err := db.Query("SELECT 1 FROM " + table + " WHERE 1 = 0")
+	if err != nil {
+		return false
+	}
+	return rows.Close() == nil
+}
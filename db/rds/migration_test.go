@@ -0,0 +1,101 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rds
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	path := "/tmp/test-rds-migrate-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+
+	ctx := context.Background()
+	store := NewSQLite(path)
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	db := store.GetDB()
+
+	statements := func(stmts ...string) func(Dialect) []string {
+		return func(Dialect) []string { return stmts }
+	}
+	migrations := []Migration{
+		{Version: 2, Description: "add b", Statements: statements("ALTER TABLE t ADD COLUMN b INTEGER")},
+		{Version: 1, Description: "create t", Statements: statements("CREATE TABLE t (a INTEGER)")},
+	}
+	require.NoError(Migrate(store, migrations))
+	_, err := db.Exec("INSERT INTO t (a, b) VALUES (1, 2)")
+	require.NoError(err)
+	// the applied migrations are skipped
+	require.NoError(Migrate(store, migrations))
+
+	// a failing migration is rolled back
+	migrations = append(migrations, Migration{
+		Version:     3,
+		Description: "add c",
+		Statements:  statements("ALTER TABLE t ADD COLUMN c INTEGER", "INSERT INTO nonexistent VALUES (1)"),
+	})
+	require.Error(Migrate(store, migrations))
+	_, err = db.Exec("INSERT INTO t (a, c) VALUES (1, 2)")
+	require.Error(err)
+	var version uint64
+	require.NoError(db.QueryRow("SELECT MAX(version) FROM " + migrationTable).Scan(&version))
+	require.Equal(uint64(2), version)
+
+	migrations[2].Statements = statements("ALTER TABLE t ADD COLUMN c INTEGER")
+	require.NoError(Migrate(store, migrations))
+	_, err = db.Exec("INSERT INTO t (a, c) VALUES (1, 2)")
+	require.NoError(err)
+
+	migrations = append(migrations, Migration{Version: 3, Statements: statements()})
+	require.Error(Migrate(store, migrations))
+}
+
+func TestMigrateBaseline(t *testing.T) {
+	require := require.New(t)
+
+	path := "/tmp/test-rds-baseline-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+
+	ctx := context.Background()
+	store := NewSQLite(path)
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	db := store.GetDB()
+
+	// the table is created before the migrations
+	_, err := db.Exec("CREATE TABLE t (a INTEGER)")
+	require.NoError(err)
+	statements := func(stmts ...string) func(Dialect) []string {
+		return func(Dialect) []string { return stmts }
+	}
+	migrations := []Migration{
+		{Version: 1, Description: "create t", Statements: statements("CREATE TABLE t (a INTEGER)"), Baseline: "t"},
+		{Version: 2, Description: "add b", Statements: statements("ALTER TABLE t ADD COLUMN b INTEGER")},
+	}
+	require.NoError(Migrate(store, migrations))
+	_, err = db.Exec("INSERT INTO t (a, b) VALUES (1, 2)")
+	require.NoError(err)
+	var count int
+	require.NoError(db.QueryRow("SELECT COUNT(*) FROM " + migrationTable).Scan(&count))
+	require.Equal(2, count)
+}
@@ -10,12 +10,18 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 
 	"database/sql"
 
 	// we need mysql import because it's called in file, (but compile will complain because there is no display)
 	_ "github.com/go-sql-driver/mysql"
+	// the drivers of PostgreSQL and SQLite are registered the same way
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 )
@@ -29,18 +35,63 @@ type Store interface {
 
 	// Transact wrap the transaction
 	Transact(txFunc func(*sql.Tx) error) error
+
+	// Dialect returns the SQL dialect of the DB
+	Dialect() Dialect
 }
 
-// rds is RDSStore implementation based aws RDS
+// rds is RDSStore implementation based on a database/sql driver
 type rds struct {
-	mutex  sync.RWMutex
-	db     *sql.DB
-	config *config.RDS
+	mutex   sync.RWMutex
+	db      *sql.DB
+	dialect Dialect
+	dsn     string
+}
+
+// NewStore instantiates a store of the dialect in the config
+func NewStore(cfg *config.RDS) (Store, error) {
+	switch cfg.Dialect {
+	case config.MySQLDialect:
+		return NewAwsRDS(cfg), nil
+	case config.PostgresDialect:
+		return NewPostgres(cfg), nil
+	case config.SQLiteDialect:
+		return NewSQLite(cfg.SQLitePath), nil
+	default:
+		return nil, errors.Errorf("unknown SQL dialect %s", cfg.Dialect)
+	}
 }
 
 // NewAwsRDS instantiates an aws RDS based RDS
 func NewAwsRDS(cfg *config.RDS) Store {
-	return &rds{db: nil, config: cfg}
+	return &rds{
+		dialect: MySQL,
+		dsn: fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
+			cfg.AwsRDSUser, cfg.AwsPass, cfg.AwsRDSEndpoint, cfg.AwsRDSPort, cfg.AwsDBName,
+		),
+	}
+}
+
+// NewPostgres instantiates a PostgreSQL based RDS
+func NewPostgres(cfg *config.RDS) Store {
+	dsn := fmt.Sprintf("host='%s' port=%d user='%s' password='%s' dbname='%s'",
+		quoteValue(cfg.AwsRDSEndpoint), cfg.AwsRDSPort, quoteValue(cfg.AwsRDSUser), quoteValue(cfg.AwsPass),
+		quoteValue(cfg.AwsDBName),
+	)
+	if cfg.SSLMode != "" {
+		dsn += " sslmode=" + cfg.SSLMode
+	}
+	return &rds{dialect: Postgres, dsn: dsn}
+}
+
+// quoteValue escapes the backslashes and single quotes in a quoted value of a PostgreSQL connection string
+func quoteValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
+// NewSQLite instantiates an RDS embedded in the SQLite DB file, which is created if not existing yet
+func NewSQLite(path string) Store {
+	return &rds{dialect: SQLite, dsn: path}
 }
 
 // Start opens the RDS (creates new file if not existing yet)
@@ -52,15 +103,15 @@ func (r *rds) Start(_ context.Context) error {
 		return nil
 	}
 
-	connectStr := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
-		r.config.AwsRDSUser, r.config.AwsPass, r.config.AwsRDSEndpoint, r.config.AwsRDSPort, r.config.AwsDBName,
-	)
-
 	// Use db to perform SQL operations on database
-	db, err := sql.Open("mysql", connectStr)
+	db, err := sql.Open(r.dialect.Name(), r.dsn)
 	if err != nil {
 		return err
 	}
+	if r.dialect == SQLite {
+		// SQLite allows a single writer at a time
+		db.SetMaxOpenConns(1)
+	}
 	r.db = db
 	return nil
 }
@@ -86,6 +137,9 @@ func (r *rds) GetDB() *sql.DB {
 	return r.db
 }
 
+// Dialect returns the SQL dialect of the DB
+func (r *rds) Dialect() Dialect { return r.dialect }
+
 // Transact wrap the transaction
 func (r *rds) Transact(txFunc func(*sql.Tx) error) error {
 	r.mutex.Lock()
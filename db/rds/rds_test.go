@@ -251,3 +251,52 @@ func TestRDSStoreTransaction(t *testing.T) {
 		testRDSStoreTransaction(NewAwsRDS(cfg), t)
 	})
 }
+
+func TestParseRows(t *testing.T) {
+	require := require.New(t)
+
+	path := "/tmp/test-rds-parse-rows-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+
+	ctx := context.Background()
+	store := NewSQLite(path)
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	db := store.GetDB()
+
+	_, err := db.Exec("CREATE TABLE transfer_history (node_address TEXT, user_address TEXT, transfer_hash BLOB)")
+	require.NoError(err)
+	for _, userAddress := range []string{"bbb1", "bbb2"} {
+		_, err := db.Exec("INSERT INTO transfer_history VALUES (?, ?, ?)", "aaa", userAddress, []byte{1})
+		require.NoError(err)
+	}
+	rows, err := db.Query("SELECT * FROM transfer_history WHERE node_address=? ORDER BY user_address", "aaa")
+	require.NoError(err)
+	var transferHistory TransferHistory
+	parsedRows, err := ParseRows(rows, &transferHistory)
+	require.NoError(err)
+	// each row is parsed into its own struct
+	require.Equal(2, len(parsedRows))
+	require.Equal("bbb1", parsedRows[0].(*TransferHistory).UserAddress)
+	require.Equal("bbb2", parsedRows[1].(*TransferHistory).UserAddress)
+}
+
+func TestNewPostgres(t *testing.T) {
+	require := require.New(t)
+
+	store := NewPostgres(&config.RDS{
+		AwsRDSEndpoint: "localhost",
+		AwsRDSPort:     5432,
+		AwsRDSUser:     "user",
+		AwsPass:        `it's\' sslmode=disable`,
+		AwsDBName:      "db",
+		SSLMode:        "require",
+	})
+	require.Equal(
+		`host='localhost' port=5432 user='user' password='it\'s\\\' sslmode=disable' dbname='db' sslmode=require`,
+		store.(*rds).dsn,
+	)
+}
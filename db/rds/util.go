@@ -12,24 +12,25 @@ import (
 	"database/sql"
 )
 
-// ParseRows will parse the row
+// ParseRows will parse the row. Each row is scanned into a new instance of the schema struct, in the order of the
+// fields
 func ParseRows(rows *sql.Rows, schema interface{}) ([]interface{}, error) {
 	var parsedRows []interface{}
-	s := reflect.ValueOf(schema).Elem()
-
-	var fields []interface{}
-	for i := 0; i < s.NumField(); i++ {
-		fields = append(fields, s.Field(i).Addr().Interface())
-	}
+	t := reflect.TypeOf(schema).Elem()
 
 	// Fetch rows
 	for rows.Next() {
+		s := reflect.New(t).Elem()
+		var fields []interface{}
+		for i := 0; i < s.NumField(); i++ {
+			fields = append(fields, s.Field(i).Addr().Interface())
+		}
 		err := rows.Scan(fields...)
 		if err != nil {
 			return nil, err
 		}
 
-		parsedRows = append(parsedRows, schema)
+		parsedRows = append(parsedRows, s.Addr().Interface())
 	}
 
 	return parsedRows, nil
@@ -32,6 +32,20 @@ type Subscriber interface {
 	HandleEndorse(*pb.EndorsePb) error
 }
 
+// EvidenceCollector is implemented by the subscribers collecting the consensus messages to detect equivocations
+type EvidenceCollector interface {
+	CollectBlockPropose(*pb.ProposePb)
+	CollectEndorse(*pb.EndorsePb)
+}
+
+// LightSubscriber is implemented by the subscribers serving light clients, or following the chain as a light client
+type LightSubscriber interface {
+	HandleHeaderSyncRequest(string, *pb.BlockHeaderSync) error
+	HandleBlockHeader(*pb.BlockHeaderContainer) error
+	HandleStateProofRequest(string, *pb.StateProofRequest) error
+	HandleStateProof(*pb.StateProofResponse) error
+}
+
 // Dispatcher is used by peers, handles incoming block and header notifications and relays announcements of new blocks.
 type Dispatcher interface {
 	lifecycle.StartStopper
@@ -93,6 +107,19 @@ func (m actionMsg) ChainID() uint32 {
 	return m.chainID
 }
 
+// lightMsg packages a message from or to a light client.
+type lightMsg struct {
+	chainID uint32
+	sender  string
+	msgType uint32
+	msg     proto.Message
+	done    chan bool
+}
+
+func (m lightMsg) ChainID() uint32 {
+	return m.chainID
+}
+
 // IotxDispatcher is the request and event dispatcher for iotx node.
 type IotxDispatcher struct {
 	started        int32
@@ -179,6 +206,8 @@ loop:
 				d.handleBlockMsg(msg)
 			case *blockSyncMsg:
 				d.handleBlockSyncMsg(msg)
+			case *lightMsg:
+				d.handleLightMsg(msg)
 
 			default:
 				logger.Warn().
@@ -259,6 +288,34 @@ func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
 	}
 }
 
+// handleLightMsg handles the messages from or to light clients.
+func (d *IotxDispatcher) handleLightMsg(m *lightMsg) {
+	d.updateEventAudit(m.msgType)
+	subscriber, ok := d.subscribers[m.ChainID()]
+	if !ok {
+		logger.Info().Uint32("ChainID", m.ChainID()).Msg("No subscriber specified in the dispatcher")
+	} else if light, ok := subscriber.(LightSubscriber); ok {
+		var err error
+		switch m.msgType {
+		case pb.MsgBlockHeaderSyncReqType:
+			err = light.HandleHeaderSyncRequest(m.sender, m.msg.(*pb.BlockHeaderSync))
+		case pb.MsgBlockHeaderSyncDataType:
+			err = light.HandleBlockHeader(m.msg.(*pb.BlockHeaderContainer))
+		case pb.MsgStateProofReqType:
+			err = light.HandleStateProofRequest(m.sender, m.msg.(*pb.StateProofRequest))
+		case pb.MsgStateProofDataType:
+			err = light.HandleStateProof(m.msg.(*pb.StateProofResponse))
+		}
+		if err != nil {
+			logger.Debug().Err(err).Uint32("msgType", m.msgType).Msg("failed to handle light client message")
+		}
+	}
+	// signal to let caller know we are done
+	if m.done != nil {
+		m.done <- true
+	}
+}
+
 // dispatchAction adds the passed action message to the news handling queue.
 func (d *IotxDispatcher) dispatchAction(chainID uint32, msg proto.Message, done chan bool) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
@@ -304,6 +361,23 @@ func (d *IotxDispatcher) dispatchBlockSyncData(chainID uint32, msg proto.Message
 	d.enqueueEvent(&blockMsg{chainID, data.Block, pb.MsgBlockSyncDataType, done})
 }
 
+// dispatchLight adds the passed message from or to a light client to the news handling queue.
+func (d *IotxDispatcher) dispatchLight(
+	chainID uint32,
+	sender string,
+	msgType uint32,
+	msg proto.Message,
+	done chan bool,
+) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		if done != nil {
+			close(done)
+		}
+		return
+	}
+	d.enqueueEvent(&lightMsg{chainID, sender, msgType, msg, done})
+}
+
 // HandleBroadcast handles incoming broadcast message
 func (d *IotxDispatcher) HandleBroadcast(chainID uint32, message proto.Message, done chan bool) {
 	msgType, err := pb.GetTypeFromProtoMsg(message)
@@ -322,6 +396,9 @@ func (d *IotxDispatcher) HandleBroadcast(chainID uint32, message proto.Message,
 
 	switch msgType {
 	case pb.MsgProposeProtoMsgType:
+		if collector, ok := subscriber.(EvidenceCollector); ok {
+			collector.CollectBlockPropose(message.(*pb.ProposePb))
+		}
 		err := subscriber.HandleBlockPropose(message.(*pb.ProposePb))
 		if err != nil {
 			logger.Error().
@@ -332,6 +409,9 @@ func (d *IotxDispatcher) HandleBroadcast(chainID uint32, message proto.Message,
 			done <- true
 		}
 	case pb.MsgEndorseProtoMsgType:
+		if collector, ok := subscriber.(EvidenceCollector); ok {
+			collector.CollectEndorse(message.(*pb.EndorsePb))
+		}
 		err := subscriber.HandleEndorse(message.(*pb.EndorsePb))
 		if err != nil {
 			logger.Error().
@@ -365,6 +445,8 @@ func (d *IotxDispatcher) HandleTell(chainID uint32, sender net.Addr, message pro
 		d.dispatchBlockSyncReq(chainID, sender.String(), message, done)
 	case pb.MsgBlockSyncDataType:
 		d.dispatchBlockSyncData(chainID, message, done)
+	case pb.MsgBlockHeaderSyncReqType, pb.MsgBlockHeaderSyncDataType, pb.MsgStateProofReqType, pb.MsgStateProofDataType:
+		d.dispatchLight(chainID, sender.String(), msgType, message, done)
 	default:
 		logger.Warn().
 			Uint32("msgType", msgType).
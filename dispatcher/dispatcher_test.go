@@ -53,6 +53,10 @@ func setTestCase() []proto.Message {
 		&pb.BlockSync{},
 		&pb.BlockContainer{},
 		&pb.BlockContainer{Block: &pb.BlockPb{}},
+		&pb.BlockHeaderSync{},
+		&pb.BlockHeaderContainer{},
+		&pb.StateProofRequest{},
+		&pb.StateProofResponse{},
 		&pb.TestPayload{},
 	}
 }
@@ -115,3 +119,19 @@ func (s *DummySubscriber) HandleBlockPropose(*pb.ProposePb) error {
 func (s *DummySubscriber) HandleEndorse(*pb.EndorsePb) error {
 	return nil
 }
+
+func (s *DummySubscriber) HandleHeaderSyncRequest(string, *pb.BlockHeaderSync) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleBlockHeader(*pb.BlockHeaderContainer) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleStateProofRequest(string, *pb.StateProofRequest) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleStateProof(*pb.StateProofResponse) error {
+	return nil
+}
@@ -65,6 +65,7 @@ type Endorsement struct {
 	endorser       string
 	endorserPubkey keypair.PublicKey
 	signature      []byte
+	dkgSignature   []byte
 }
 
 // NewEndorsement creates an Endorsement for an consensus vote
@@ -98,6 +99,23 @@ func (en *Endorsement) Signature() []byte {
 	return en.signature
 }
 
+// SignDKGShare signs the consensus vote with the DKG private key of the endorser as well, so that the endorsement
+// could be folded into an aggregate signature
+func (en *Endorsement) SignDKGShare(dkgPrivateKey []uint32) error {
+	hash := en.object.Hash()
+	_, sig, err := crypto.BLS.SignShare(dkgPrivateKey, hash[:])
+	if err != nil {
+		return errors.Wrap(err, "failed to sign the consensus vote with the DKG private key")
+	}
+	en.dkgSignature = sig
+	return nil
+}
+
+// DKGSignature returns the DKG signature share of this endorsement, which is empty if not signed
+func (en *Endorsement) DKGSignature() []byte {
+	return en.dkgSignature
+}
+
 // VerifySignature verifies that the endorse with pubkey
 func (en *Endorsement) VerifySignature() bool {
 	hash := en.object.Hash()
@@ -129,6 +147,7 @@ func (en *Endorsement) ToProtoMsg() *iproto.EndorsePb {
 		EndorserPubKey: pubkey[:],
 		Decision:       true,
 		Signature:      en.Signature(),
+		DkgSignature:   en.DKGSignature(),
 	}
 }
 
@@ -164,5 +183,6 @@ func FromProtoMsg(endorsePb *iproto.EndorsePb) (*Endorsement, error) {
 		endorser:       endorsePb.Endorser,
 		endorserPubkey: pubKey,
 		signature:      endorsePb.Signature,
+		dkgSignature:   endorsePb.DkgSignature,
 	}, nil
 }
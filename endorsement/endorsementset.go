@@ -11,7 +11,11 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/proto"
 )
@@ -23,13 +27,27 @@ var (
 	ErrInvalidHash = errors.New("the endorsement hash is different from the set")
 	// ErrInvalidEndorsement indicates that the signature of the endorsement is invalid
 	ErrInvalidEndorsement = errors.New("the endorsement's signature is invalid")
+	// ErrNotEnoughShares indicates that there aren't enough DKG signature shares to aggregate
+	ErrNotEnoughShares = errors.New("not enough DKG signature shares to aggregate")
+	// ErrInvalidAggregateSignature indicates that the aggregate signature of the set is invalid
+	ErrInvalidAggregateSignature = errors.New("the aggregate signature is invalid")
+	// ErrNotCommitted indicates that the endorsements of the set don't reach the quorum to commit the block
+	ErrNotCommitted = errors.New("the endorsements don't commit the block")
 )
 
-// Set is a collection of endorsements for block
+// Quorum returns the number of the delegates whose endorsements commit a block, which is more than 2/3 of them
+func Quorum(numDelegates int) int {
+	return numDelegates*2/3 + 1
+}
+
+// Set is a collection of endorsements for block. The commit endorsements could be folded into an aggregate signature,
+// which replaces the endorsements of its signers with the signature and the bitmap of the signers among the delegates
 type Set struct {
-	blkHash      hash.Hash32B
-	round        uint32 // locked round number
-	endorsements []*Endorsement
+	blkHash            hash.Hash32B
+	round              uint32 // locked round number, or the round of the commit endorsements being aggregated
+	endorsements       []*Endorsement
+	aggregateSignature []byte
+	signers            []byte
 }
 
 // NewSet creates an endorsement set
@@ -44,6 +62,8 @@ func NewSet(blkHash hash.Hash32B) *Set {
 func (s *Set) FromProto(sPb *iproto.EndorsementSet) error {
 	s.blkHash = byteutil.BytesTo32B(sPb.BlockHash)
 	s.round = sPb.Round
+	s.aggregateSignature = sPb.AggregateSignature
+	s.signers = sPb.Signers
 	s.endorsements = []*Endorsement{}
 	for _, ePb := range sPb.Endorsements {
 		en, err := FromProtoMsg(ePb)
@@ -82,6 +102,11 @@ func (s *Set) AddEndorsement(en *Endorsement) error {
 	return nil
 }
 
+// Endorsements returns the endorsements in the set
+func (s *Set) Endorsements() []*Endorsement {
+	return s.endorsements
+}
+
 // BlockHash returns the hash of the endorsed block
 func (s *Set) BlockHash() hash.Hash32B {
 	return s.blkHash
@@ -118,6 +143,50 @@ func (s *Set) NumOfValidEndorsements(topics map[ConsensusVoteTopic]bool, endorse
 	return cnt
 }
 
+// VerifyCommit verifies that the set commits the block of the height, i.e., at least quorum delegates have locked or
+// committed the block with valid signatures, which is what rollDPoS requires to commit a block. The signers of an
+// aggregate set are counted once the aggregate signature is verified with their DKG public keys
+func (s *Set) VerifyCommit(height uint64, delegates []string, dkgPubkeys map[string][]byte, quorum int) error {
+	delegateSet := make(map[string]bool)
+	for _, delegate := range delegates {
+		delegateSet[delegate] = true
+	}
+	endorsers := make(map[string]bool)
+	if s.IsAggregate() {
+		if err := s.VerifyAggregate(height, delegates, dkgPubkeys); err != nil {
+			return err
+		}
+		signers, err := s.Signers(delegates)
+		if err != nil {
+			return errors.Wrap(ErrInvalidAggregateSignature, err.Error())
+		}
+		for _, signer := range signers {
+			endorsers[signer] = true
+		}
+	}
+	for _, en := range s.endorsements {
+		vote := en.ConsensusVote()
+		if vote.BlkHash != s.blkHash || vote.Height != height {
+			continue
+		}
+		if vote.Topic != LOCK && vote.Topic != COMMIT {
+			continue
+		}
+		if endorsers[en.Endorser()] || !delegateSet[en.Endorser()] {
+			continue
+		}
+		pkHash, err := iotxaddress.AddressToPKHash(en.Endorser())
+		if err != nil || pkHash != keypair.HashPubKey(en.EndorserPublicKey()) || !en.VerifySignature() {
+			continue
+		}
+		endorsers[en.Endorser()] = true
+	}
+	if len(endorsers) < quorum {
+		return errors.Wrapf(ErrNotCommitted, "only %d delegates endorsed the block, %d needed", len(endorsers), quorum)
+	}
+	return nil
+}
+
 // ToProto convert the endorsement set to protobuf
 func (s *Set) ToProto() *iproto.EndorsementSet {
 	endorsements := make([]*iproto.EndorsePb, 0, len(s.endorsements))
@@ -126,8 +195,123 @@ func (s *Set) ToProto() *iproto.EndorsementSet {
 	}
 
 	return &iproto.EndorsementSet{
-		BlockHash:    s.blkHash[:],
-		Round:        s.round,
-		Endorsements: endorsements,
+		BlockHash:          s.blkHash[:],
+		Round:              s.round,
+		Endorsements:       endorsements,
+		AggregateSignature: s.aggregateSignature,
+		Signers:            s.signers,
+	}
+}
+
+// IsAggregate returns whether the endorsements are folded into an aggregate signature
+func (s *Set) IsAggregate() bool {
+	return len(s.aggregateSignature) > 0
+}
+
+// Signers returns the delegates who sign the aggregate signature, given the delegates the bitmap refers to
+func (s *Set) Signers(delegates []string) ([]string, error) {
+	if len(s.signers) > (len(delegates)+7)/8 {
+		return nil, errors.Errorf("the bitmap of %d bytes refers to more than %d delegates", len(s.signers), len(delegates))
 	}
+	signers := []string{}
+	for i, delegate := range delegates {
+		if i/8 < len(s.signers) && s.signers[i/8]&(1<<uint(i%8)) != 0 {
+			signers = append(signers, delegate)
+		}
+	}
+	return signers, nil
+}
+
+// Aggregate folds the DKG signature shares of the commit endorsements into an aggregate set, once the valid shares on
+// the same round reach the commit quorum. As the threshold signature only proves the crypto.Degree+1 signers whose
+// shares it takes, the commit endorsements of the other signers up to the quorum are kept in the set, so that the set
+// still proves the quorum. Each share is verified with the DKG public key of the delegate first
+func (s *Set) Aggregate(delegates []string, dkgPubkeys map[string][]byte, quorum int) (*Set, error) {
+	numShares := quorum
+	if numShares < crypto.Degree+1 {
+		numShares = crypto.Degree + 1
+	}
+	endorsements := make(map[string]*Endorsement)
+	for _, en := range s.endorsements {
+		if en.ConsensusVote().Topic == COMMIT && len(en.DKGSignature()) > 0 {
+			endorsements[en.Endorser()] = en
+		}
+	}
+	// the signers are taken in the order of the delegates, so that every node folds the same shares
+	shares := make(map[uint32][]int)
+	for i, delegate := range delegates {
+		en, ok := endorsements[delegate]
+		if !ok {
+			continue
+		}
+		pubkey, ok := dkgPubkeys[delegate]
+		if !ok {
+			continue
+		}
+		vote := en.ConsensusVote()
+		hash := vote.Hash()
+		if err := crypto.BLS.VerifyShare(pubkey, hash[:], en.DKGSignature()); err != nil {
+			logger.Debug().Err(err).Str("endorser", delegate).Msg("skip invalid DKG signature share")
+			continue
+		}
+		shares[vote.Round] = append(shares[vote.Round], i)
+		if len(shares[vote.Round]) < numShares {
+			continue
+		}
+		ids := make([][]uint8, 0, crypto.Degree+1)
+		sigs := make([][]byte, 0, crypto.Degree+1)
+		signers := make([]byte, (len(delegates)+7)/8)
+		kept := make([]*Endorsement, 0, numShares-crypto.Degree-1)
+		for _, j := range shares[vote.Round] {
+			if len(ids) > crypto.Degree {
+				kept = append(kept, endorsements[delegates[j]])
+				continue
+			}
+			ids = append(ids, iotxaddress.CreateID(delegates[j]))
+			sigs = append(sigs, endorsements[delegates[j]].DKGSignature())
+			signers[j/8] |= 1 << uint(j%8)
+		}
+		aggregateSignature, err := crypto.BLS.SignAggregate(ids, sigs)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to aggregate the DKG signature shares")
+		}
+		return &Set{
+			blkHash:            s.blkHash,
+			round:              vote.Round,
+			endorsements:       kept,
+			aggregateSignature: aggregateSignature,
+			signers:            signers,
+		}, nil
+	}
+	return nil, ErrNotEnoughShares
+}
+
+// VerifyAggregate verifies the aggregate signature of the commit endorsements on the block of the height, given the
+// delegates the bitmap refers to and their DKG public keys
+func (s *Set) VerifyAggregate(height uint64, delegates []string, dkgPubkeys map[string][]byte) error {
+	if !s.IsAggregate() {
+		return errors.Wrap(ErrInvalidAggregateSignature, "the endorsements aren't aggregated")
+	}
+	signers, err := s.Signers(delegates)
+	if err != nil {
+		return errors.Wrap(ErrInvalidAggregateSignature, err.Error())
+	}
+	if len(signers) != crypto.Degree+1 {
+		return errors.Wrapf(ErrInvalidAggregateSignature, "%d signers instead of %d", len(signers), crypto.Degree+1)
+	}
+	ids := make([][]uint8, 0, len(signers))
+	pubkeys := make([][]byte, 0, len(signers))
+	for _, signer := range signers {
+		pubkey, ok := dkgPubkeys[signer]
+		if !ok {
+			return errors.Wrapf(ErrInvalidAggregateSignature, "missing DKG public key of signer %s", signer)
+		}
+		ids = append(ids, iotxaddress.CreateID(signer))
+		pubkeys = append(pubkeys, pubkey)
+	}
+	hash := NewConsensusVote(s.blkHash, height, s.round, COMMIT).Hash()
+	if err := crypto.BLS.VerifyAggregate(ids, pubkeys, hash[:], s.aggregateSignature); err != nil {
+		return errors.Wrap(ErrInvalidAggregateSignature, err.Error())
+	}
+	return nil
 }
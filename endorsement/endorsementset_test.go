@@ -9,8 +9,11 @@ package endorsement
 import (
 	"testing"
 
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -69,3 +72,114 @@ func TestAddEndorsement(t *testing.T) {
 		testaddress.Addrinfo["alfa"].RawAddress,
 	}))
 }
+
+func TestAggregate(t *testing.T) {
+	require := require.New(t)
+	addrs, dkgPriKeys, dkgPubkeys := generateDKGKeys(t)
+	delegates := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		delegates = append(delegates, addr.RawAddress)
+	}
+	quorum := Quorum(len(delegates))
+	blkHash := byteutil.BytesTo32B([]byte{'2', '1'})
+	set := NewSet(blkHash)
+	for i := 0; i < crypto.Degree; i++ {
+		en := NewEndorsement(NewConsensusVote(blkHash, 5, 1, COMMIT), addrs[i])
+		require.NoError(en.SignDKGShare(dkgPriKeys[i]))
+		require.NoError(set.AddEndorsement(en))
+	}
+	// lock endorsements and commit endorsements without shares aren't aggregated
+	require.NoError(set.AddEndorsement(NewEndorsement(NewConsensusVote(blkHash, 5, 1, LOCK), addrs[crypto.Degree])))
+	require.NoError(set.AddEndorsement(NewEndorsement(NewConsensusVote(blkHash, 5, 1, COMMIT), addrs[crypto.Degree+1])))
+	// a share signed with another key is skipped
+	en := NewEndorsement(NewConsensusVote(blkHash, 5, 1, COMMIT), addrs[crypto.Degree+2])
+	require.NoError(en.SignDKGShare(dkgPriKeys[0]))
+	require.NoError(set.AddEndorsement(en))
+	_, err := set.Aggregate(delegates, dkgPubkeys, quorum)
+	require.Equal(ErrNotEnoughShares, err)
+
+	// the shares are aggregated once they reach the quorum of the delegates
+	sign := func(i int) {
+		en := NewEndorsement(NewConsensusVote(blkHash, 5, 1, COMMIT), addrs[i])
+		require.NoError(en.SignDKGShare(dkgPriKeys[i]))
+		require.NoError(set.AddEndorsement(en))
+	}
+	sign(len(addrs) - 1)
+	_, err = set.Aggregate(delegates, dkgPubkeys, quorum)
+	require.Equal(ErrNotEnoughShares, err)
+	for i := crypto.Degree + 3; i < crypto.Degree+7; i++ {
+		sign(i)
+	}
+	aggregated, err := set.Aggregate(delegates, dkgPubkeys, quorum)
+	require.NoError(err)
+	require.True(aggregated.IsAggregate())
+	require.False(set.IsAggregate())
+	require.Equal(uint32(1), aggregated.Round())
+	signers, err := aggregated.Signers(delegates)
+	require.NoError(err)
+	require.Equal(append(append([]string{}, delegates[:crypto.Degree]...), delegates[crypto.Degree+3]), signers)
+	require.NoError(aggregated.VerifyAggregate(5, delegates, dkgPubkeys))
+	// the commit endorsements of the other signers up to the quorum are kept
+	require.Equal(quorum-crypto.Degree-1, len(aggregated.Endorsements()))
+	require.NoError(aggregated.VerifyCommit(5, delegates, dkgPubkeys, quorum))
+
+	// the aggregate set survives the proto conversion
+	converted := &Set{}
+	require.NoError(converted.FromProto(aggregated.ToProto()))
+	require.Equal(quorum-crypto.Degree-1, len(converted.ToProto().Endorsements))
+	require.NoError(converted.VerifyAggregate(5, delegates, dkgPubkeys))
+	require.NoError(converted.VerifyCommit(5, delegates, dkgPubkeys, quorum))
+
+	require.Equal(ErrInvalidAggregateSignature, errors.Cause(converted.VerifyAggregate(6, delegates, dkgPubkeys)))
+	require.Equal(ErrInvalidAggregateSignature, errors.Cause(set.VerifyAggregate(5, delegates, dkgPubkeys)))
+	// the threshold signers alone don't reach the quorum
+	converted.endorsements = converted.endorsements[1:]
+	require.Equal(ErrNotCommitted, errors.Cause(converted.VerifyCommit(5, delegates, dkgPubkeys, quorum)))
+	_, err = converted.Signers(delegates[:8])
+	require.Error(err)
+	converted.signers[0] ^= 1
+	require.Equal(ErrInvalidAggregateSignature, errors.Cause(converted.VerifyAggregate(5, delegates, dkgPubkeys)))
+}
+
+// generateDKGKeys generates the addresses of the delegates and their DKG key pairs
+func generateDKGKeys(t *testing.T) ([]*iotxaddress.Address, [][]uint32, map[string][]byte) {
+	require := require.New(t)
+	numNodes := 21
+	addrs := make([]*iotxaddress.Address, numNodes)
+	idList := make([][]uint8, numNodes)
+	sharesList := make([][][]uint32, numNodes)
+	witnessesList := make([][][]byte, numNodes)
+	for i := 0; i < numNodes; i++ {
+		addr, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, []byte{0x00, 0x00, 0x00, 0x01})
+		require.NoError(err)
+		addrs[i] = addr
+		idList[i] = iotxaddress.CreateID(addr.RawAddress)
+	}
+	for i := 0; i < numNodes; i++ {
+		var err error
+		_, sharesList[i], witnessesList[i], err = crypto.DKG.Init(crypto.DKG.SkGeneration(), idList)
+		require.NoError(err)
+	}
+	priKeys := make([][]uint32, numNodes)
+	pubkeys := make(map[string][]byte)
+	shares := make([][]uint32, numNodes)
+	statusMatrix := make([][21]bool, numNodes)
+	for i := 0; i < numNodes; i++ {
+		for j := 0; j < numNodes; j++ {
+			shares[j] = sharesList[j][i]
+		}
+		var err error
+		statusMatrix[i], err = crypto.DKG.SharesCollect(idList[i], shares, witnessesList)
+		require.NoError(err)
+	}
+	for i := 0; i < numNodes; i++ {
+		for j := 0; j < numNodes; j++ {
+			shares[j] = sharesList[j][i]
+		}
+		_, pubkey, priKey, err := crypto.DKG.KeyPairGeneration(shares, statusMatrix)
+		require.NoError(err)
+		priKeys[i] = priKey
+		pubkeys[addrs[i].RawAddress] = pubkey
+	}
+	return addrs, priKeys, pubkeys
+}
@@ -0,0 +1,529 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/address"
+	iotexapi "github.com/iotexproject/iotex-core/explorer/proto/v1"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	pb "github.com/iotexproject/iotex-core/proto"
+)
+
+// maxPageLimit is the maximum number of items in a page of a list
+const maxPageLimit = 1000
+
+var _ iotexapi.APIServiceServer = (*APIService)(nil)
+
+// APIService serves the public API over gRPC, and over HTTP JSON through the gateway if the gateway port is set. It
+// answers the queries with the explorer service
+type APIService struct {
+	port        int
+	gatewayPort int
+	exp         *Service
+	grpcSvr     *grpc.Server
+	gatewaySvr  *http.Server
+	// cancel closes the connection of the gateway to the gRPC server
+	cancel context.CancelFunc
+}
+
+// NewAPIService creates an API service listening on the port, and the gateway on the gateway port if it's not 0
+func NewAPIService(port int, gatewayPort int, exp *Service) *APIService {
+	return &APIService{
+		port:        port,
+		gatewayPort: gatewayPort,
+		exp:         exp,
+	}
+}
+
+// Start starts serving the API requests, and the gateway translating the HTTP JSON requests into them
+func (api *APIService) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(api.port))
+	if err != nil {
+		return errors.Wrap(err, "error when creating network listener")
+	}
+	api.port = listener.Addr().(*net.TCPAddr).Port
+	api.grpcSvr = grpc.NewServer()
+	iotexapi.RegisterAPIServiceServer(api.grpcSvr, api)
+	logger.Info().Msgf("Starting API server on %s", listener.Addr().String())
+	go func() {
+		if err := api.grpcSvr.Serve(listener); err != nil {
+			logger.Error().Err(err).Msg("error when serving API requests")
+		}
+	}()
+	if api.gatewayPort <= 0 {
+		return nil
+	}
+
+	gwCtx, cancel := context.WithCancel(context.Background())
+	api.cancel = cancel
+	mux := runtime.NewServeMux()
+	endpoint := "127.0.0.1:" + strconv.Itoa(api.port)
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if err := iotexapi.RegisterAPIServiceHandlerFromEndpoint(gwCtx, mux, endpoint, opts); err != nil {
+		return errors.Wrap(err, "error when registering API gateway")
+	}
+	gwListener, err := net.Listen("tcp", ":"+strconv.Itoa(api.gatewayPort))
+	if err != nil {
+		return errors.Wrap(err, "error when creating network listener")
+	}
+	api.gatewayPort = gwListener.Addr().(*net.TCPAddr).Port
+	api.gatewaySvr = &http.Server{Handler: mux}
+	logger.Info().Msgf("Starting API gateway on %s", gwListener.Addr().String())
+	go func() {
+		if err := api.gatewaySvr.Serve(gwListener); err != nil && err != http.ErrServerClosed {
+			logger.Error().Err(err).Msg("error when serving API gateway requests")
+		}
+	}()
+	return nil
+}
+
+// Stop stops the gateway and the gRPC server
+func (api *APIService) Stop(ctx context.Context) error {
+	if api.gatewaySvr != nil {
+		if err := api.gatewaySvr.Shutdown(ctx); err != nil {
+			return errors.Wrap(err, "error when shutting down API gateway")
+		}
+	}
+	if api.cancel != nil {
+		api.cancel()
+	}
+	if api.grpcSvr != nil {
+		api.grpcSvr.Stop()
+	}
+	return nil
+}
+
+// Port returns the actually binding port of the gRPC server
+func (api *APIService) Port() int { return api.port }
+
+// GatewayPort returns the actually binding port of the gateway
+func (api *APIService) GatewayPort() int { return api.gatewayPort }
+
+// GetAccount returns the balance and the nonces of an account
+func (api *APIService) GetAccount(
+	_ context.Context,
+	req *iotexapi.GetAccountRequest,
+) (*iotexapi.GetAccountResponse, error) {
+	state, err := api.exp.stateByAddr(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	pendingNonce, err := api.exp.ap.GetPendingNonce(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetAccountResponse{AccountMeta: &iotexapi.AccountMeta{
+		Address:      req.Address,
+		Balance:      state.Balance.String(),
+		Nonce:        state.Nonce,
+		PendingNonce: pendingNonce,
+		IsCandidate:  state.IsCandidate,
+	}}, nil
+}
+
+// GetChainMeta returns the height of the chain and the number of the actions in it
+func (api *APIService) GetChainMeta(
+	_ context.Context,
+	_ *iotexapi.GetChainMetaRequest,
+) (*iotexapi.GetChainMetaResponse, error) {
+	var numActions uint64
+	for _, total := range []func() (uint64, error){
+		api.exp.bc.GetTotalTransfers,
+		api.exp.bc.GetTotalVotes,
+		api.exp.bc.GetTotalExecutions,
+		api.exp.bc.GetTotalActions,
+	} {
+		n, err := total()
+		if err != nil {
+			return nil, err
+		}
+		numActions += n
+	}
+	return &iotexapi.GetChainMetaResponse{ChainMeta: &iotexapi.ChainMeta{
+		Height:     api.exp.bc.TipHeight(),
+		NumActions: numActions,
+	}}, nil
+}
+
+// GetBlockMetas returns the metadata of the blocks, from the tip down to the genesis block
+func (api *APIService) GetBlockMetas(
+	_ context.Context,
+	req *iotexapi.GetBlockMetasRequest,
+) (*iotexapi.GetBlockMetasResponse, error) {
+	tip := api.exp.bc.TipHeight()
+	offset, limit, err := page(req.Pagination, tip+1)
+	if err != nil {
+		return nil, err
+	}
+	res := &iotexapi.GetBlockMetasResponse{Total: tip + 1}
+	for i := offset; i < offset+limit; i++ {
+		blk, err := api.exp.bc.GetBlockByHeight(tip - i)
+		if err != nil {
+			return nil, err
+		}
+		blkHash := blk.HashBlock()
+		res.BlkMetas = append(res.BlkMetas, &iotexapi.BlockMeta{
+			Hash:       hex.EncodeToString(blkHash[:]),
+			Height:     blk.Height(),
+			Timestamp:  blk.ConvertToBlockHeaderPb().Timestamp,
+			NumActions: uint64(len(blk.Actions)),
+			Producer:   keypair.EncodePublicKey(blk.Header.Pubkey),
+		})
+	}
+	return res, nil
+}
+
+// GetBlock returns a block by its hash if it's set, or by its height
+func (api *APIService) GetBlock(_ context.Context, req *iotexapi.GetBlockRequest) (*iotexapi.GetBlockResponse, error) {
+	var blkHash hash.Hash32B
+	if req.BlkHash != "" {
+		h, err := decodeHash(req.BlkHash)
+		if err != nil {
+			return nil, err
+		}
+		blkHash = h
+	} else {
+		h, err := api.exp.bc.GetHashByHeight(req.Height)
+		if err != nil {
+			return nil, err
+		}
+		blkHash = h
+	}
+	blk, err := api.exp.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetBlockResponse{BlkHash: hex.EncodeToString(blkHash[:]), Block: blk.ConvertToBlockPb()}, nil
+}
+
+// GetAction returns an action by its hash, which is either in a block or pending in the actpool
+func (api *APIService) GetAction(
+	_ context.Context,
+	req *iotexapi.GetActionRequest,
+) (*iotexapi.GetActionResponse, error) {
+	actHash, err := decodeHash(req.ActionHash)
+	if err != nil {
+		return nil, err
+	}
+	actInfo, err := api.getActionInfo(actHash)
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetActionResponse{ActionInfo: actInfo}, nil
+}
+
+// GetActionsByAddress returns the actions of all types sent or received by an address, which are read from the index
+// service if the explorer uses it
+func (api *APIService) GetActionsByAddress(
+	_ context.Context,
+	req *iotexapi.GetActionsByAddressRequest,
+) (*iotexapi.GetActionsResponse, error) {
+	var getters []func(string) ([]hash.Hash32B, error)
+	if api.exp.cfg.UseRDS {
+		indexer := api.exp.idx.Indexer()
+		getters = append(
+			getters,
+			indexer.GetTransferHistory,
+			indexer.GetVoteHistory,
+			indexer.GetExecutionHistory,
+			indexer.GetActionsFromAddress,
+			indexer.GetActionsToAddress,
+		)
+	} else {
+		getters = append(
+			getters,
+			api.exp.bc.GetTransfersFromAddress,
+			api.exp.bc.GetTransfersToAddress,
+			api.exp.bc.GetVotesFromAddress,
+			api.exp.bc.GetVotesToAddress,
+			api.exp.bc.GetExecutionsFromAddress,
+			api.exp.bc.GetExecutionsToAddress,
+			api.exp.bc.GetActionsFromAddress,
+			api.exp.bc.GetActionsToAddress,
+		)
+	}
+	// an action sent to the sender itself is in both of its histories
+	var actHashes []hash.Hash32B
+	seen := make(map[hash.Hash32B]bool)
+	for _, get := range getters {
+		hashes, err := get(req.Address)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hashes {
+			if seen[h] {
+				continue
+			}
+			seen[h] = true
+			actHashes = append(actHashes, h)
+		}
+	}
+
+	offset, limit, err := page(req.Pagination, uint64(len(actHashes)))
+	if err != nil {
+		return nil, err
+	}
+	res := &iotexapi.GetActionsResponse{Total: uint64(len(actHashes))}
+	for _, h := range actHashes[offset : offset+limit] {
+		actInfo, err := api.getActionInfo(h)
+		if err != nil {
+			return nil, err
+		}
+		res.ActionInfo = append(res.ActionInfo, actInfo)
+	}
+	return res, nil
+}
+
+// GetUnconfirmedActionsByAddress returns the actions of an address pending in the actpool
+func (api *APIService) GetUnconfirmedActionsByAddress(
+	_ context.Context,
+	req *iotexapi.GetActionsByAddressRequest,
+) (*iotexapi.GetActionsResponse, error) {
+	acts := api.exp.ap.GetUnconfirmedActs(req.Address)
+	offset, limit, err := page(req.Pagination, uint64(len(acts)))
+	if err != nil {
+		return nil, err
+	}
+	res := &iotexapi.GetActionsResponse{Total: uint64(len(acts))}
+	for _, act := range acts[offset : offset+limit] {
+		res.ActionInfo = append(res.ActionInfo, newActionInfo(act, nil))
+	}
+	return res, nil
+}
+
+// GetActionsByBlock returns the actions in a block
+func (api *APIService) GetActionsByBlock(
+	_ context.Context,
+	req *iotexapi.GetActionsByBlockRequest,
+) (*iotexapi.GetActionsResponse, error) {
+	blkHash, err := decodeHash(req.BlkHash)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := api.exp.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := page(req.Pagination, uint64(len(blk.Actions)))
+	if err != nil {
+		return nil, err
+	}
+	res := &iotexapi.GetActionsResponse{Total: uint64(len(blk.Actions))}
+	for _, act := range blk.Actions[offset : offset+limit] {
+		res.ActionInfo = append(res.ActionInfo, newActionInfo(act, &blkHash))
+	}
+	return res, nil
+}
+
+// GetReceiptByAction returns the receipt of an action, which is read from the index service if the explorer uses it
+func (api *APIService) GetReceiptByAction(
+	_ context.Context,
+	req *iotexapi.GetReceiptByActionRequest,
+) (*iotexapi.GetReceiptByActionResponse, error) {
+	actHash, err := decodeHash(req.ActionHash)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := api.exp.getReceipt(actHash)
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetReceiptByActionResponse{Receipt: receipt.ConvertToReceiptPb()}, nil
+}
+
+// SendAction broadcasts a signed action to the network, and puts it into the actpool
+func (api *APIService) SendAction(
+	_ context.Context,
+	req *iotexapi.SendActionRequest,
+) (res *iotexapi.SendActionResponse, err error) {
+	defer func() {
+		succeed := "true"
+		if err != nil {
+			succeed = "false"
+		}
+		requestMtc.WithLabelValues("APISendAction", succeed).Inc()
+	}()
+	act, err := loadAction(req.Action)
+	if err != nil {
+		return nil, err
+	}
+	// broadcast to the network
+	if err := api.exp.p2p.Broadcast(api.exp.bc.ChainID(), req.Action); err != nil {
+		logger.Warn().Err(err).Msg("failed to broadcast SendAction request.")
+	}
+	// send to actpool via dispatcher
+	api.exp.dp.HandleBroadcast(api.exp.bc.ChainID(), req.Action, nil)
+
+	actHash := act.Hash()
+	return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(actHash[:])}, nil
+}
+
+// SuggestGasPrice suggests the gas price from the recent blocks
+func (api *APIService) SuggestGasPrice(
+	_ context.Context,
+	_ *iotexapi.SuggestGasPriceRequest,
+) (*iotexapi.SuggestGasPriceResponse, error) {
+	gasPrice, err := api.exp.gs.suggestGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.SuggestGasPriceResponse{GasPrice: uint64(gasPrice)}, nil
+}
+
+// EstimateGasForAction returns the gas an execution consumes against the latest states, or the intrinsic gas of the
+// other actions
+func (api *APIService) EstimateGasForAction(
+	_ context.Context,
+	req *iotexapi.EstimateGasForActionRequest,
+) (*iotexapi.EstimateGasForActionResponse, error) {
+	act, err := loadAction(req.Action)
+	if err != nil {
+		return nil, err
+	}
+	if sc, ok := act.(*action.Execution); ok {
+		receipt, err := api.executeContractRead(sc)
+		if err != nil {
+			return nil, err
+		}
+		return &iotexapi.EstimateGasForActionResponse{Gas: receipt.GasConsumed}, nil
+	}
+	gas, err := act.IntrinsicGas()
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.EstimateGasForActionResponse{Gas: gas}, nil
+}
+
+// ReadContract runs an execution against the latest states without committing it, and returns the return value
+func (api *APIService) ReadContract(
+	_ context.Context,
+	req *iotexapi.ReadContractRequest,
+) (*iotexapi.ReadContractResponse, error) {
+	act, err := loadAction(req.Action)
+	if err != nil {
+		return nil, err
+	}
+	sc, ok := act.(*action.Execution)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "action is not an execution")
+	}
+	if sc.Contract() == action.EmptyAddress {
+		return nil, status.Error(codes.InvalidArgument, "contract address is missing")
+	}
+	receipt, err := api.executeContractRead(sc)
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.ReadContractResponse{Data: hex.EncodeToString(receipt.ReturnValue)}, nil
+}
+
+// executeContractRead runs an execution against the latest states without committing it, after validating the
+// addresses of it. The contract address is empty if the execution deploys a contract
+func (api *APIService) executeContractRead(sc *action.Execution) (*action.Receipt, error) {
+	if _, err := address.IotxAddressToAddress(sc.Executor()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid executor address %s", sc.Executor())
+	}
+	if sc.Contract() != action.EmptyAddress {
+		if _, err := address.IotxAddressToAddress(sc.Contract()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid contract address %s", sc.Contract())
+		}
+	}
+	receipt, err := api.exp.bc.ExecuteContractRead(sc)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		scHash := sc.Hash()
+		return nil, status.Errorf(codes.Internal, "execution %x has no receipt", scHash)
+	}
+	return receipt, nil
+}
+
+// getActionInfo returns an action in a block, or the pending one in the actpool
+func (api *APIService) getActionInfo(actHash hash.Hash32B) (*iotexapi.ActionInfo, error) {
+	blkHash, err := getBlockHashByActionHash(api.exp.bc, actHash)
+	if err != nil {
+		// Try to fetch pending action from actpool
+		act, err := api.exp.ap.GetActionByHash(actHash)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "action %x is not found", actHash)
+		}
+		return newActionInfo(act, nil), nil
+	}
+	blk, err := api.exp.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, act := range blk.Actions {
+		if act.Hash() == actHash {
+			return newActionInfo(act, &blkHash), nil
+		}
+	}
+	return nil, errors.Errorf("block %x does not have action %x", blkHash, actHash)
+}
+
+// newActionInfo returns the info of an action, which is pending if the block hash is nil
+func newActionInfo(act action.Action, blkHash *hash.Hash32B) *iotexapi.ActionInfo {
+	actHash := act.Hash()
+	actInfo := &iotexapi.ActionInfo{Action: act.Proto(), ActionHash: hex.EncodeToString(actHash[:])}
+	if blkHash != nil {
+		actInfo.BlkHash = hex.EncodeToString(blkHash[:])
+	}
+	return actInfo
+}
+
+// loadAction converts the action proto in a request
+func loadAction(actPb *pb.ActionPb) (action.Action, error) {
+	if actPb == nil {
+		return nil, status.Error(codes.InvalidArgument, "action is missing")
+	}
+	act, err := action.LoadAction(actPb)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return act, nil
+}
+
+// decodeHash decodes a hash in hex
+func decodeHash(hashStr string) (hash.Hash32B, error) {
+	b, err := hex.DecodeString(hashStr)
+	if err != nil || len(b) != len(hash.ZeroHash32B) {
+		return hash.ZeroHash32B, status.Errorf(codes.InvalidArgument, "invalid hash %s", hashStr)
+	}
+	return byteutil.BytesTo32B(b), nil
+}
+
+// page returns the offset and the number of the items in the page of a list of total items
+func page(pagination *iotexapi.Pagination, total uint64) (uint64, uint64, error) {
+	if pagination == nil || pagination.Limit == 0 || pagination.Limit > maxPageLimit {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "page limit should be in [1, %d]", maxPageLimit)
+	}
+	if pagination.Offset >= total {
+		return total, 0, nil
+	}
+	limit := pagination.Limit
+	if limit > total-pagination.Offset {
+		limit = total - pagination.Offset
+	}
+	return pagination.Offset, limit, nil
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	iotexapi "github.com/iotexproject/iotex-core/explorer/proto/v1"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestAPIService_Blocks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["producer"], ta.Addrinfo["alfa"], 1, big.NewInt(1), nil,
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	blks := newTestBlocks(2)
+	blk := blockchain.NewBlock(config.Default.Chain.ID, 3, blks[2].HashBlock(), 3, ta.Addrinfo["producer"].PublicKey,
+		[]action.Action{tsf})
+	blkHash := blk.HashBlock()
+	blks = append(blks, blk)
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().TipHeight().Return(uint64(3)).AnyTimes()
+	for _, b := range blks {
+		bc.EXPECT().GetBlockByHeight(b.Height()).Return(b, nil).AnyTimes()
+	}
+	bc.EXPECT().GetHashByHeight(uint64(3)).Return(blkHash, nil).Times(1)
+	bc.EXPECT().GetBlockByHash(blkHash).Return(blk, nil).AnyTimes()
+	bc.EXPECT().GetTotalTransfers().Return(uint64(1), nil).Times(1)
+	bc.EXPECT().GetTotalVotes().Return(uint64(0), nil).Times(1)
+	bc.EXPECT().GetTotalExecutions().Return(uint64(0), nil).Times(1)
+	bc.EXPECT().GetTotalActions().Return(uint64(2), nil).Times(1)
+	api := NewAPIService(0, 0, &Service{bc: bc})
+	ctx := context.Background()
+
+	chainMeta, err := api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.Equal(&iotexapi.ChainMeta{Height: 3, NumActions: 3}, chainMeta.ChainMeta)
+
+	// the metas are listed from the tip down
+	metas, err := api.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+		Pagination: &iotexapi.Pagination{Offset: 0, Limit: 2},
+	})
+	require.NoError(err)
+	require.Equal(uint64(4), metas.Total)
+	require.Equal(2, len(metas.BlkMetas))
+	require.Equal(hex.EncodeToString(blkHash[:]), metas.BlkMetas[0].Hash)
+	require.Equal(uint64(1), metas.BlkMetas[0].NumActions)
+	require.Equal(uint64(2), metas.BlkMetas[1].Height)
+	metas, err = api.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+		Pagination: &iotexapi.Pagination{Offset: 3, Limit: 2},
+	})
+	require.NoError(err)
+	require.Equal(1, len(metas.BlkMetas))
+	require.Equal(uint64(0), metas.BlkMetas[0].Height)
+	metas, err = api.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+		Pagination: &iotexapi.Pagination{Offset: 4, Limit: 2},
+	})
+	require.NoError(err)
+	require.Equal(0, len(metas.BlkMetas))
+	_, err = api.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = api.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+		Pagination: &iotexapi.Pagination{Limit: maxPageLimit + 1},
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	res, err := api.GetBlock(ctx, &iotexapi.GetBlockRequest{Height: 3})
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(blkHash[:]), res.BlkHash)
+	require.Equal(blk.ConvertToBlockPb(), res.Block)
+	res, err = api.GetBlock(ctx, &iotexapi.GetBlockRequest{BlkHash: hex.EncodeToString(blkHash[:])})
+	require.NoError(err)
+	require.Equal(blk.ConvertToBlockPb(), res.Block)
+	_, err = api.GetBlock(ctx, &iotexapi.GetBlockRequest{BlkHash: "abc"})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	acts, err := api.GetActionsByBlock(ctx, &iotexapi.GetActionsByBlockRequest{
+		BlkHash:    hex.EncodeToString(blkHash[:]),
+		Pagination: &iotexapi.Pagination{Offset: 0, Limit: 10},
+	})
+	require.NoError(err)
+	require.Equal(uint64(1), acts.Total)
+	require.Equal(1, len(acts.ActionInfo))
+	require.Equal(tsf.Proto(), acts.ActionInfo[0].Action)
+	require.Equal(hex.EncodeToString(blkHash[:]), acts.ActionInfo[0].BlkHash)
+}
+
+func TestAPIService_Actions(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	producer := ta.Addrinfo["producer"]
+	tsf1, err := testutil.SignedTransfer(producer, ta.Addrinfo["alfa"], 1, big.NewInt(1), nil,
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	// a transfer to the sender itself
+	tsf2, err := testutil.SignedTransfer(producer, producer, 2, big.NewInt(1), nil,
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	pending, err := testutil.SignedTransfer(producer, ta.Addrinfo["bravo"], 3, big.NewInt(1), nil,
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	blk := blockchain.NewBlock(config.Default.Chain.ID, 1, hash.ZeroHash32B, 1, producer.PublicKey,
+		[]action.Action{tsf1, tsf2})
+	blkHash := blk.HashBlock()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	bc.EXPECT().GetBlockHashByActionHash(tsf1.Hash()).Return(blkHash, nil).AnyTimes()
+	bc.EXPECT().GetBlockHashByActionHash(tsf2.Hash()).Return(blkHash, nil).AnyTimes()
+	bc.EXPECT().GetBlockByHash(blkHash).Return(blk, nil).AnyTimes()
+	notExist := errors.New("not exist")
+	bc.EXPECT().GetBlockHashByActionHash(pending.Hash()).Return(hash.ZeroHash32B, notExist).AnyTimes()
+	bc.EXPECT().GetBlockHashByTransferHash(pending.Hash()).Return(hash.ZeroHash32B, notExist).AnyTimes()
+	bc.EXPECT().GetBlockHashByVoteHash(pending.Hash()).Return(hash.ZeroHash32B, notExist).AnyTimes()
+	bc.EXPECT().GetBlockHashByExecutionHash(pending.Hash()).Return(hash.ZeroHash32B, notExist).AnyTimes()
+	ap.EXPECT().GetActionByHash(pending.Hash()).Return(pending, nil).Times(1)
+	ap.EXPECT().GetUnconfirmedActs(producer.RawAddress).Return([]action.Action{pending}).Times(1)
+
+	bc.EXPECT().GetTransfersFromAddress(producer.RawAddress).
+		Return([]hash.Hash32B{tsf1.Hash(), tsf2.Hash()}, nil).AnyTimes()
+	bc.EXPECT().GetTransfersToAddress(producer.RawAddress).Return([]hash.Hash32B{tsf2.Hash()}, nil).AnyTimes()
+	bc.EXPECT().GetVotesFromAddress(producer.RawAddress).Return(nil, nil).AnyTimes()
+	bc.EXPECT().GetVotesToAddress(producer.RawAddress).Return(nil, nil).AnyTimes()
+	bc.EXPECT().GetExecutionsFromAddress(producer.RawAddress).Return(nil, nil).AnyTimes()
+	bc.EXPECT().GetExecutionsToAddress(producer.RawAddress).Return(nil, nil).AnyTimes()
+	bc.EXPECT().GetActionsFromAddress(producer.RawAddress).Return(nil, nil).AnyTimes()
+	bc.EXPECT().GetActionsToAddress(producer.RawAddress).Return(nil, nil).AnyTimes()
+	api := NewAPIService(0, 0, &Service{bc: bc, ap: ap})
+	ctx := context.Background()
+
+	tsf1Hash := tsf1.Hash()
+	res, err := api.GetAction(ctx, &iotexapi.GetActionRequest{ActionHash: hex.EncodeToString(tsf1Hash[:])})
+	require.NoError(err)
+	require.Equal(tsf1.Proto(), res.ActionInfo.Action)
+	require.Equal(hex.EncodeToString(blkHash[:]), res.ActionInfo.BlkHash)
+	pendingHash := pending.Hash()
+	res, err = api.GetAction(ctx, &iotexapi.GetActionRequest{ActionHash: hex.EncodeToString(pendingHash[:])})
+	require.NoError(err)
+	require.Equal(pending.Proto(), res.ActionInfo.Action)
+	require.Equal("", res.ActionInfo.BlkHash)
+	_, err = api.GetAction(ctx, &iotexapi.GetActionRequest{ActionHash: "xyz"})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// the self transfer is listed once
+	acts, err := api.GetActionsByAddress(ctx, &iotexapi.GetActionsByAddressRequest{
+		Address:    producer.RawAddress,
+		Pagination: &iotexapi.Pagination{Offset: 0, Limit: 10},
+	})
+	require.NoError(err)
+	require.Equal(uint64(2), acts.Total)
+	require.Equal(2, len(acts.ActionInfo))
+	require.Equal(tsf1.Proto(), acts.ActionInfo[0].Action)
+	require.Equal(tsf2.Proto(), acts.ActionInfo[1].Action)
+	acts, err = api.GetActionsByAddress(ctx, &iotexapi.GetActionsByAddressRequest{
+		Address:    producer.RawAddress,
+		Pagination: &iotexapi.Pagination{Offset: 1, Limit: 10},
+	})
+	require.NoError(err)
+	require.Equal(uint64(2), acts.Total)
+	require.Equal(1, len(acts.ActionInfo))
+	require.Equal(tsf2.Proto(), acts.ActionInfo[0].Action)
+
+	acts, err = api.GetUnconfirmedActionsByAddress(ctx, &iotexapi.GetActionsByAddressRequest{
+		Address:    producer.RawAddress,
+		Pagination: &iotexapi.Pagination{Offset: 0, Limit: 10},
+	})
+	require.NoError(err)
+	require.Equal(uint64(1), acts.Total)
+	require.Equal(pending.Proto(), acts.ActionInfo[0].Action)
+}
+
+func TestAPIService_SendAction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	dp := mock_dispatcher.NewMockDispatcher(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	api := NewAPIService(0, 0, &Service{bc: bc, dp: dp, p2p: p2p})
+	ctx := context.Background()
+
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["producer"], ta.Addrinfo["alfa"], 1, big.NewInt(1), nil,
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	bc.EXPECT().ChainID().Return(uint32(1)).Times(2)
+	p2p.EXPECT().Broadcast(uint32(1), tsf.Proto()).Return(nil).Times(1)
+	dp.EXPECT().HandleBroadcast(uint32(1), tsf.Proto(), gomock.Any()).Times(1)
+	res, err := api.SendAction(ctx, &iotexapi.SendActionRequest{Action: tsf.Proto()})
+	require.NoError(err)
+	tsfHash := tsf.Hash()
+	require.Equal(hex.EncodeToString(tsfHash[:]), res.ActionHash)
+	_, err = api.SendAction(ctx, &iotexapi.SendActionRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// the intrinsic gas of a transfer
+	gas, err := api.EstimateGasForAction(ctx, &iotexapi.EstimateGasForActionRequest{Action: tsf.Proto()})
+	require.NoError(err)
+	require.Equal(uint64(10000), gas.Gas)
+
+	execution, err := testutil.SignedExecution(ta.Addrinfo["producer"], ta.Addrinfo["delta"].RawAddress, 2,
+		big.NewInt(0), testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice), []byte{1})
+	require.NoError(err)
+	bc.EXPECT().ExecuteContractRead(gomock.Any()).
+		Return(&action.Receipt{GasConsumed: 1000, ReturnValue: []byte{0x12, 0x34}}, nil).Times(2)
+	gas, err = api.EstimateGasForAction(ctx, &iotexapi.EstimateGasForActionRequest{Action: execution.Proto()})
+	require.NoError(err)
+	require.Equal(uint64(1000), gas.Gas)
+	data, err := api.ReadContract(ctx, &iotexapi.ReadContractRequest{Action: execution.Proto()})
+	require.NoError(err)
+	require.Equal("1234", data.Data)
+	_, err = api.ReadContract(ctx, &iotexapi.ReadContractRequest{Action: tsf.Proto()})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// the addresses of the execution are validated before running it
+	execution, err = testutil.SignedExecution(ta.Addrinfo["producer"], "contract", 3,
+		big.NewInt(0), testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice), []byte{1})
+	require.NoError(err)
+	_, err = api.ReadContract(ctx, &iotexapi.ReadContractRequest{Action: execution.Proto()})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = api.EstimateGasForAction(ctx, &iotexapi.EstimateGasForActionRequest{Action: execution.Proto()})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	execution, err = testutil.SignedExecution(ta.Addrinfo["producer"], action.EmptyAddress, 4,
+		big.NewInt(0), testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice), []byte{1})
+	require.NoError(err)
+	_, err = api.ReadContract(ctx, &iotexapi.ReadContractRequest{Action: execution.Proto()})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// an execution without receipt fails
+	bc.EXPECT().ExecuteContractRead(gomock.Any()).Return(nil, nil).Times(1)
+	_, err = api.EstimateGasForAction(ctx, &iotexapi.EstimateGasForActionRequest{Action: execution.Proto()})
+	require.Equal(codes.Internal, status.Code(err))
+}
+
+func TestAPIService_Gateway(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	bc.EXPECT().GetTotalTransfers().Return(uint64(1), nil).AnyTimes()
+	bc.EXPECT().GetTotalVotes().Return(uint64(2), nil).AnyTimes()
+	bc.EXPECT().GetTotalExecutions().Return(uint64(3), nil).AnyTimes()
+	bc.EXPECT().GetTotalActions().Return(uint64(4), nil).AnyTimes()
+	api := NewAPIService(0, 14015, &Service{bc: bc})
+	ctx := context.Background()
+	require.NoError(api.Start(ctx))
+	defer func() {
+		require.NoError(api.Stop(ctx))
+	}()
+
+	resp, err := http.Get("http://127.0.0.1:" + strconv.Itoa(api.GatewayPort()) + "/v1/chainmeta")
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(err)
+	// 64-bit integers are encoded as strings in JSON
+	require.JSONEq(`{"chainMeta":{"height":"10","numActions":"10"}}`, string(body))
+}
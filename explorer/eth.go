@@ -0,0 +1,675 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/CoderZhi/go-ethereum/common"
+	"github.com/CoderZhi/go-ethereum/common/hexutil"
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/indexservice"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// maxEthRequestBytes is the maximum size of a request body
+	maxEthRequestBytes = 5 * 1024 * 1024
+	// maxEthLogs is the maximum number of logs returned by eth_getLogs
+	maxEthLogs = 10000
+)
+
+// The error codes of JSON-RPC 2.0
+const (
+	ethParseError     = -32700
+	ethInvalidRequest = -32600
+	ethMethodNotFound = -32601
+	ethInvalidParams  = -32602
+	ethServerError    = -32000
+)
+
+// EthService serves a subset of the Ethereum JSON-RPC API over HTTP, so that the Ethereum tooling could talk to the
+// node. The addresses are 20-byte addresses in hex, which are translated from and into the io1 addresses on the chain
+// with the same public key hash, while the io1 addresses are accepted as well. The differences from Ethereum are:
+//
+// 1. eth_getTransactionCount returns the nonce of the next action of the account, since the nonces start from 1
+//
+// 2. eth_sendRawTransaction takes the protobuf encoding of a signed ActionPb carrying a transfer or an execution,
+// instead of an RLP encoded Ethereum transaction, and returns the hash of the action
+//
+// 3. eth_getTransactionReceipt returns null for a transfer, which doesn't have a receipt
+type EthService struct {
+	port    int
+	exp     *Service
+	httpSvr *http.Server
+	methods map[string]ethMethod
+}
+
+// ethMethod handles a JSON-RPC method with the positional params, and returns the result to be encoded into JSON
+type ethMethod func(params []json.RawMessage) (interface{}, error)
+
+type (
+	ethRequest struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+	}
+	ethResponse struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  json.RawMessage `json:"result,omitempty"`
+		Error   *ethError       `json:"error,omitempty"`
+	}
+	ethError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+func (e *ethError) Error() string { return e.Message }
+
+func invalidParams(format string, args ...interface{}) error {
+	return &ethError{Code: ethInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+type (
+	ethCallArgs struct {
+		From     string          `json:"from"`
+		To       string          `json:"to"`
+		Gas      *hexutil.Uint64 `json:"gas"`
+		GasPrice *hexutil.Big    `json:"gasPrice"`
+		Value    *hexutil.Big    `json:"value"`
+		Data     hexutil.Bytes   `json:"data"`
+	}
+	// ethFilterArgs is the filter of eth_getLogs, whose address is either an address or a list of addresses, and each
+	// of whose topics is either null, a topic or a list of topics
+	ethFilterArgs struct {
+		FromBlock string            `json:"fromBlock"`
+		ToBlock   string            `json:"toBlock"`
+		Address   json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+		BlockHash *common.Hash      `json:"blockHash"`
+	}
+	ethReceipt struct {
+		TransactionHash  common.Hash     `json:"transactionHash"`
+		TransactionIndex hexutil.Uint64  `json:"transactionIndex"`
+		BlockHash        common.Hash     `json:"blockHash"`
+		BlockNumber      hexutil.Uint64  `json:"blockNumber"`
+		From             common.Address  `json:"from"`
+		To               *common.Address `json:"to"`
+		GasUsed          hexutil.Uint64  `json:"gasUsed"`
+		ContractAddress  *common.Address `json:"contractAddress"`
+		Logs             []*ethLog       `json:"logs"`
+		Status           hexutil.Uint64  `json:"status"`
+	}
+	ethLog struct {
+		Address         common.Address `json:"address"`
+		Topics          []common.Hash  `json:"topics"`
+		Data            hexutil.Bytes  `json:"data"`
+		BlockNumber     hexutil.Uint64 `json:"blockNumber"`
+		TransactionHash common.Hash    `json:"transactionHash"`
+		BlockHash       common.Hash    `json:"blockHash"`
+		LogIndex        hexutil.Uint   `json:"logIndex"`
+		Removed         bool           `json:"removed"`
+	}
+)
+
+// NewEthService creates an Ethereum compatible JSON-RPC service listening on the port, which answers the queries with
+// the explorer service
+func NewEthService(port int, exp *Service) *EthService {
+	s := &EthService{port: port, exp: exp}
+	s.methods = map[string]ethMethod{
+		"net_version":               s.netVersion,
+		"eth_chainId":               s.chainID,
+		"eth_blockNumber":           s.blockNumber,
+		"eth_gasPrice":              s.gasPrice,
+		"eth_getBalance":            s.getBalance,
+		"eth_getTransactionCount":   s.getTransactionCount,
+		"eth_call":                  s.call,
+		"eth_sendRawTransaction":    s.sendRawTransaction,
+		"eth_getTransactionReceipt": s.getTransactionReceipt,
+		"eth_getLogs":               s.getLogs,
+	}
+	return s
+}
+
+// Start starts serving the JSON-RPC requests
+func (s *EthService) Start(_ context.Context) error {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(s.port))
+	if err != nil {
+		return errors.Wrap(err, "error when creating network listener")
+	}
+	s.port = listener.Addr().(*net.TCPAddr).Port
+	s.httpSvr = &http.Server{Handler: s}
+	logger.Info().Msgf("Starting Ethereum JSON-RPC server on %s", listener.Addr().String())
+	go func() {
+		if err := s.httpSvr.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.Error().Err(err).Msg("error when serving Ethereum JSON-RPC requests")
+		}
+	}()
+	return nil
+}
+
+// Stop stops serving the JSON-RPC requests
+func (s *EthService) Stop(ctx context.Context) error {
+	if s.httpSvr == nil {
+		return nil
+	}
+	if err := s.httpSvr.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "error when shutting down Ethereum JSON-RPC server")
+	}
+	return nil
+}
+
+// Port returns the actually binding port
+func (s *EthService) Port() int { return s.port }
+
+// ServeHTTP handles a JSON-RPC request, or a batch of them
+func (s *EthService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	switch r.Method {
+	case http.MethodOptions:
+		return
+	case http.MethodPost:
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxEthRequestBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	body = bytes.TrimSpace(body)
+
+	var res interface{}
+	if len(body) > 0 && body[0] == '[' {
+		var reqs []*ethRequest
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			res = &ethResponse{JSONRPC: "2.0", Error: &ethError{Code: ethParseError, Message: err.Error()}}
+		} else {
+			batch := make([]*ethResponse, 0, len(reqs))
+			for _, req := range reqs {
+				batch = append(batch, s.handle(req))
+			}
+			res = batch
+		}
+	} else {
+		var req ethRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			res = &ethResponse{JSONRPC: "2.0", Error: &ethError{Code: ethParseError, Message: err.Error()}}
+		} else {
+			res = s.handle(&req)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		logger.Error().Err(err).Msg("error when writing Ethereum JSON-RPC response")
+	}
+}
+
+// handle dispatches a request to its method
+func (s *EthService) handle(req *ethRequest) *ethResponse {
+	if req == nil || req.Method == "" {
+		return &ethResponse{JSONRPC: "2.0", Error: &ethError{Code: ethInvalidRequest, Message: "method is missing"}}
+	}
+	res := &ethResponse{JSONRPC: "2.0", ID: req.ID}
+	method, ok := s.methods[req.Method]
+	if !ok {
+		res.Error = &ethError{Code: ethMethodNotFound, Message: fmt.Sprintf("method %s is not supported", req.Method)}
+		return res
+	}
+	var params []json.RawMessage
+	if len(req.Params) > 0 && string(req.Params) != "null" {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			res.Error = &ethError{Code: ethInvalidParams, Message: "params should be an array"}
+			return res
+		}
+	}
+	result, err := method(params)
+	if err == nil {
+		res.Result, err = json.Marshal(result)
+	}
+	if err != nil {
+		logger.Debug().Err(err).Msgf("error when handling Ethereum JSON-RPC method %s", req.Method)
+		if ethErr, ok := err.(*ethError); ok {
+			res.Error = ethErr
+		} else {
+			res.Error = &ethError{Code: ethServerError, Message: err.Error()}
+		}
+		res.Result = nil
+	}
+	return res
+}
+
+func (s *EthService) netVersion(_ []json.RawMessage) (interface{}, error) {
+	return strconv.FormatUint(uint64(s.exp.bc.ChainID()), 10), nil
+}
+
+func (s *EthService) chainID(_ []json.RawMessage) (interface{}, error) {
+	return hexutil.Uint64(s.exp.bc.ChainID()), nil
+}
+
+func (s *EthService) blockNumber(_ []json.RawMessage) (interface{}, error) {
+	return hexutil.Uint64(s.exp.bc.TipHeight()), nil
+}
+
+func (s *EthService) gasPrice(_ []json.RawMessage) (interface{}, error) {
+	gasPrice, err := s.exp.gs.suggestGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(big.NewInt(gasPrice)), nil
+}
+
+// getBalance returns the balance of an account at a block
+func (s *EthService) getBalance(params []json.RawMessage) (interface{}, error) {
+	var addr, tag string
+	if err := decodeParams(params, 1, &addr, &tag); err != nil {
+		return nil, err
+	}
+	account, err := s.state(addr, tag)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(account.Balance), nil
+}
+
+// getTransactionCount returns the nonce of the next action of an account at a block, or the pending one taking the
+// actions in the actpool into account
+func (s *EthService) getTransactionCount(params []json.RawMessage) (interface{}, error) {
+	var addr, tag string
+	if err := decodeParams(params, 1, &addr, &tag); err != nil {
+		return nil, err
+	}
+	if tag == "pending" {
+		iotxAddr, err := s.toIotxAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		pendingNonce, err := s.exp.ap.GetPendingNonce(iotxAddr)
+		if err != nil {
+			return nil, err
+		}
+		return hexutil.Uint64(pendingNonce), nil
+	}
+	account, err := s.state(addr, tag)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Uint64(account.Nonce + 1), nil
+}
+
+// call runs an execution against the latest states without committing it, and returns the return value
+func (s *EthService) call(params []json.RawMessage) (interface{}, error) {
+	var args ethCallArgs
+	var tag string
+	if err := decodeParams(params, 1, &args, &tag); err != nil {
+		return nil, err
+	}
+	if !isLatestTag(tag) {
+		return nil, invalidParams("only the latest block is supported")
+	}
+	var executor string
+	if args.From == "" {
+		executor = address.New(s.exp.bc.ChainID(), common.Address{}.Bytes()).IotxAddress()
+	} else {
+		from, err := s.toIotxAddress(args.From)
+		if err != nil {
+			return nil, err
+		}
+		executor = from
+	}
+	contract := action.EmptyAddress
+	if args.To != "" {
+		to, err := s.toIotxAddress(args.To)
+		if err != nil {
+			return nil, err
+		}
+		contract = to
+	}
+	gasLimit := blockchain.GasLimit
+	if args.Gas != nil {
+		gasLimit = uint64(*args.Gas)
+	}
+	gasPrice := big.NewInt(0)
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+	amount := big.NewInt(0)
+	if args.Value != nil {
+		amount = args.Value.ToInt()
+	}
+	// The nonce isn't checked in the read-only execution, and an executor without account has nonce 0
+	nonce, err := s.exp.ap.GetPendingNonce(executor)
+	if err != nil {
+		nonce = 0
+	}
+	sc, err := action.NewExecution(executor, contract, nonce, amount, gasLimit, gasPrice, args.Data)
+	if err != nil {
+		return nil, invalidParams("%v", err)
+	}
+	receipt, err := s.exp.bc.ExecuteContractRead(sc)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(receipt.ReturnValue), nil
+}
+
+// sendRawTransaction decodes the protobuf encoding of a signed ActionPb, which should carry a transfer or an
+// execution, broadcasts it to the network and puts it into the actpool
+func (s *EthService) sendRawTransaction(params []json.RawMessage) (res interface{}, err error) {
+	defer func() {
+		succeed := "true"
+		if err != nil {
+			succeed = "false"
+		}
+		requestMtc.WithLabelValues("ethSendRawTransaction", succeed).Inc()
+	}()
+	var data hexutil.Bytes
+	if err := decodeParams(params, 1, &data); err != nil {
+		return nil, err
+	}
+	actPb := &pb.ActionPb{}
+	if err := proto.Unmarshal(data, actPb); err != nil {
+		return nil, invalidParams("invalid action: %v", err)
+	}
+	act, err := action.LoadAction(actPb)
+	if err != nil {
+		return nil, invalidParams("invalid action: %v", err)
+	}
+	switch act.(type) {
+	case *action.Transfer, *action.Execution:
+	default:
+		return nil, invalidParams("action is neither a transfer nor an execution")
+	}
+	// broadcast to the network
+	if err := s.exp.p2p.Broadcast(s.exp.bc.ChainID(), actPb); err != nil {
+		logger.Warn().Err(err).Msg("failed to broadcast eth_sendRawTransaction request.")
+	}
+	// send to actpool via dispatcher
+	s.exp.dp.HandleBroadcast(s.exp.bc.ChainID(), actPb, nil)
+
+	return common.Hash(act.Hash()), nil
+}
+
+// getTransactionReceipt returns the receipt of an action, or nil if it doesn't exist
+func (s *EthService) getTransactionReceipt(params []json.RawMessage) (interface{}, error) {
+	var h common.Hash
+	if err := decodeParams(params, 1, &h); err != nil {
+		return nil, err
+	}
+	actHash := hash.Hash32B(h)
+	receipt, err := s.exp.getReceipt(actHash)
+	if err != nil {
+		switch errors.Cause(err) {
+		case db.ErrNotExist, bolt.ErrBucketNotFound, indexservice.ErrNotExist:
+			return nil, nil
+		}
+		return nil, err
+	}
+	blkHash, err := getBlockHashByActionHash(s.exp.bc, actHash)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := s.exp.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return nil, err
+	}
+	for i, act := range blk.Actions {
+		if act.Hash() != actHash {
+			continue
+		}
+		res := &ethReceipt{
+			TransactionHash:  h,
+			TransactionIndex: hexutil.Uint64(i),
+			BlockHash:        common.Hash(blkHash),
+			BlockNumber:      hexutil.Uint64(blk.Height()),
+			GasUsed:          hexutil.Uint64(receipt.GasConsumed),
+			Logs:             make([]*ethLog, 0, len(receipt.Logs)),
+			Status:           hexutil.Uint64(receipt.Status),
+		}
+		if res.From, err = toEthAddress(act.SrcAddr()); err != nil {
+			return nil, err
+		}
+		if act.DstAddr() != action.EmptyAddress {
+			to, err := toEthAddress(act.DstAddr())
+			if err != nil {
+				return nil, err
+			}
+			res.To = &to
+		} else if receipt.ContractAddress != action.EmptyAddress {
+			contract, err := toEthAddress(receipt.ContractAddress)
+			if err != nil {
+				return nil, err
+			}
+			res.ContractAddress = &contract
+		}
+		for _, log := range receipt.Logs {
+			l, err := newEthLog(log)
+			if err != nil {
+				return nil, err
+			}
+			res.Logs = append(res.Logs, l)
+		}
+		return res, nil
+	}
+	return nil, errors.Errorf("block %x does not have action %x", blkHash, actHash)
+}
+
+// getLogs returns the logs matching the filter, which are read from the index service if the explorer uses it
+func (s *EthService) getLogs(params []json.RawMessage) (interface{}, error) {
+	var args ethFilterArgs
+	if err := decodeParams(params, 1, &args); err != nil {
+		return nil, err
+	}
+	var from, to uint64
+	if args.BlockHash != nil {
+		if args.FromBlock != "" || args.ToBlock != "" {
+			return nil, invalidParams("blockHash cannot be used with fromBlock or toBlock")
+		}
+		blk, err := s.exp.bc.GetBlockByHash(hash.Hash32B(*args.BlockHash))
+		if err != nil {
+			return nil, err
+		}
+		from, to = blk.Height(), blk.Height()
+	} else {
+		var err error
+		if from, err = s.blockHeight(args.FromBlock); err != nil {
+			return nil, err
+		}
+		if to, err = s.blockHeight(args.ToBlock); err != nil {
+			return nil, err
+		}
+		if from > to {
+			return nil, invalidParams("invalid block range [%d, %d]", from, to)
+		}
+		if to-from >= maxLogsHeightRange {
+			return nil, invalidParams("block range is larger than %d", maxLogsHeightRange)
+		}
+	}
+
+	filter := &blockchain.LogFilter{}
+	var addrs []string
+	if len(args.Address) > 0 && string(args.Address) != "null" {
+		var addr string
+		if err := json.Unmarshal(args.Address, &addr); err == nil {
+			addrs = []string{addr}
+		} else if err := json.Unmarshal(args.Address, &addrs); err != nil {
+			return nil, invalidParams("invalid address %s", args.Address)
+		}
+	}
+	for _, addr := range addrs {
+		iotxAddr, err := s.toIotxAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Addresses = append(filter.Addresses, iotxAddr)
+	}
+	for _, rawTopics := range args.Topics {
+		topics, err := decodeTopics(rawTopics)
+		if err != nil {
+			return nil, err
+		}
+		filter.Topics = append(filter.Topics, topics)
+	}
+
+	logs, err := s.exp.getLogs(filter, from, to, maxEthLogs+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) > maxEthLogs {
+		return nil, errors.Errorf("query returns more than %d logs", maxEthLogs)
+	}
+	res := make([]*ethLog, 0, len(logs))
+	for _, log := range logs {
+		l, err := newEthLog(log)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, l)
+	}
+	return res, nil
+}
+
+// state returns the state of an account at a block, which is read from the light client if the explorer uses it and
+// the block is the latest one. A missing account is taken as an empty one
+func (s *EthService) state(addr string, tag string) (*state.Account, error) {
+	iotxAddr, err := s.toIotxAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	var account *state.Account
+	if isLatestTag(tag) {
+		account, err = s.exp.stateByAddr(iotxAddr)
+	} else {
+		var height uint64
+		if height, err = s.blockHeight(tag); err != nil {
+			return nil, err
+		}
+		account, err = s.exp.bc.StateByAddrAndHeight(iotxAddr, height)
+	}
+	// an account the chain has never seen has a zero balance and the starting nonce, as it does on Ethereum
+	if errors.Cause(err) == state.ErrStateNotExist {
+		return &state.Account{Balance: big.NewInt(0), VotingWeight: big.NewInt(0)}, nil
+	}
+	return account, err
+}
+
+// blockHeight returns the height of a block tag, which is "latest", "pending", "earliest" or a height in hex
+func (s *EthService) blockHeight(tag string) (uint64, error) {
+	if isLatestTag(tag) {
+		return s.exp.bc.TipHeight(), nil
+	}
+	if tag == "earliest" {
+		return 0, nil
+	}
+	height, err := hexutil.DecodeUint64(tag)
+	if err != nil {
+		return 0, invalidParams("invalid block %s", tag)
+	}
+	return height, nil
+}
+
+// toIotxAddress converts a 20-byte address in hex into the io1 address on the chain. An io1 address is returned as is
+func (s *EthService) toIotxAddress(addr string) (string, error) {
+	if common.IsHexAddress(addr) {
+		return address.New(s.exp.bc.ChainID(), common.HexToAddress(addr).Bytes()).IotxAddress(), nil
+	}
+	if _, err := address.IotxAddressToAddress(addr); err != nil {
+		return "", invalidParams("invalid address %s", addr)
+	}
+	return addr, nil
+}
+
+// toEthAddress converts an io1 address into the 20-byte address
+func toEthAddress(iotxAddr string) (common.Address, error) {
+	addr, err := address.IotxAddressToAddress(iotxAddr)
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "failed to convert address %s", iotxAddr)
+	}
+	return common.BytesToAddress(addr.Payload()), nil
+}
+
+func newEthLog(log *action.Log) (*ethLog, error) {
+	addr, err := toEthAddress(log.Address)
+	if err != nil {
+		return nil, err
+	}
+	topics := make([]common.Hash, 0, len(log.Topics))
+	for _, topic := range log.Topics {
+		topics = append(topics, common.Hash(topic))
+	}
+	return &ethLog{
+		Address:         addr,
+		Topics:          topics,
+		Data:            log.Data,
+		BlockNumber:     hexutil.Uint64(log.BlockNumber),
+		TransactionHash: common.Hash(log.TxnHash),
+		BlockHash:       common.Hash(log.BlockHash),
+		LogIndex:        hexutil.Uint(log.Index),
+	}, nil
+}
+
+// decodeTopics decodes the topics at a position of the filter, which is either null matching any topic, a topic or a
+// list of topics
+func decodeTopics(raw json.RawMessage) ([]hash.Hash32B, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var topic common.Hash
+	if err := json.Unmarshal(raw, &topic); err == nil {
+		return []hash.Hash32B{hash.Hash32B(topic)}, nil
+	}
+	var topics []common.Hash
+	if err := json.Unmarshal(raw, &topics); err != nil {
+		return nil, invalidParams("invalid topics %s", raw)
+	}
+	res := make([]hash.Hash32B, 0, len(topics))
+	for _, topic := range topics {
+		res = append(res, hash.Hash32B(topic))
+	}
+	return res, nil
+}
+
+// decodeParams decodes the positional params into args, of which the ones following the first required ones are
+// optional
+func decodeParams(params []json.RawMessage, required int, args ...interface{}) error {
+	if len(params) < required || len(params) > len(args) {
+		return invalidParams("expecting %d to %d params, got %d", required, len(args), len(params))
+	}
+	for i, param := range params {
+		if err := json.Unmarshal(param, args[i]); err != nil {
+			return invalidParams("invalid param %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func isLatestTag(tag string) bool { return tag == "" || tag == "latest" || tag == "pending" }
@@ -0,0 +1,295 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CoderZhi/go-ethereum/common"
+	"github.com/CoderZhi/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestEthService_Accounts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	bc.EXPECT().ChainID().Return(config.Default.Chain.ID).AnyTimes()
+	bc.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	s := NewEthService(0, &Service{bc: bc, ap: ap})
+
+	producer := ta.Addrinfo["producer"].RawAddress
+	producerHex := requireEthAddress(t, producer).Hex()
+	bc.EXPECT().StateByAddr(producer).Return(&state.Account{Nonce: 2, Balance: big.NewInt(100)}, nil).Times(3)
+	bc.EXPECT().StateByAddrAndHeight(producer, uint64(1)).Return(&state.Account{Balance: big.NewInt(5)}, nil).Times(1)
+	ap.EXPECT().GetPendingNonce(producer).Return(uint64(5), nil).Times(1)
+
+	// the hex address is translated into the io1 address, which is accepted as well
+	requireEthResult(t, s, "0x64", "eth_getBalance", producerHex, "latest")
+	requireEthResult(t, s, "0x64", "eth_getBalance", producer)
+	requireEthResult(t, s, "0x5", "eth_getBalance", producerHex, "0x1")
+	requireEthResult(t, s, "0x3", "eth_getTransactionCount", producerHex, "latest")
+	requireEthResult(t, s, "0x5", "eth_getTransactionCount", producerHex, "pending")
+	// an account the chain has never seen has a zero balance and the starting nonce
+	fresh := ta.Addrinfo["alfa"].RawAddress
+	freshHex := requireEthAddress(t, fresh).Hex()
+	bc.EXPECT().StateByAddr(fresh).Return(nil, errors.Wrap(state.ErrStateNotExist, "account does not exist")).Times(2)
+	bc.EXPECT().StateByAddrAndHeight(fresh, uint64(1)).Return(nil, state.ErrStateNotExist).Times(1)
+	requireEthResult(t, s, "0x0", "eth_getBalance", freshHex, "latest")
+	requireEthResult(t, s, "0x0", "eth_getBalance", freshHex, "0x1")
+	requireEthResult(t, s, "0x1", "eth_getTransactionCount", freshHex, "latest")
+	requireEthError(t, s, ethInvalidParams, "eth_getBalance", "0x1234")
+	requireEthError(t, s, ethInvalidParams, "eth_getBalance", producerHex, "next")
+	requireEthError(t, s, ethInvalidParams, "eth_getBalance")
+	requireEthError(t, s, ethMethodNotFound, "eth_mining")
+
+	// batch
+	req, err := json.Marshal([]*ethRequest{
+		{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "eth_chainId"},
+		{JSONRPC: "2.0", ID: json.RawMessage("2"), Method: "eth_blockNumber"},
+	})
+	require.NoError(err)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(req)))
+	require.Equal(http.StatusOK, w.Code)
+	var batch []*ethResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &batch))
+	require.Equal(2, len(batch))
+	require.Equal("1", string(batch[0].ID))
+	require.Equal(`"`+hexutil.EncodeUint64(uint64(config.Default.Chain.ID))+`"`, string(batch[0].Result))
+	require.Equal("2", string(batch[1].ID))
+	require.Equal(`"0xa"`, string(batch[1].Result))
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{")))
+	var res ethResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(ethParseError, res.Error.Code)
+}
+
+func TestEthService_Call(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	bc.EXPECT().ChainID().Return(config.Default.Chain.ID).AnyTimes()
+	s := NewEthService(0, &Service{bc: bc, ap: ap})
+
+	producer := ta.Addrinfo["producer"].RawAddress
+	contract := ta.Addrinfo["delta"].RawAddress
+	ap.EXPECT().GetPendingNonce(producer).Return(uint64(3), nil).Times(1)
+	var sc *action.Execution
+	bc.EXPECT().ExecuteContractRead(gomock.Any()).Do(func(ex *action.Execution) { sc = ex }).
+		Return(&action.Receipt{ReturnValue: []byte{0x12, 0x34}}, nil).Times(1)
+	requireEthResult(t, s, "0x1234", "eth_call", map[string]string{
+		"from":  requireEthAddress(t, producer).Hex(),
+		"to":    requireEthAddress(t, contract).Hex(),
+		"value": "0x1",
+		"data":  "0xabcd",
+	}, "latest")
+	require.Equal(producer, sc.Executor())
+	require.Equal(contract, sc.Contract())
+	require.Equal(uint64(3), sc.Nonce())
+	require.Equal(big.NewInt(1), sc.Amount())
+	require.Equal([]byte{0xab, 0xcd}, sc.Data())
+	require.Equal(blockchain.GasLimit, sc.GasLimit())
+
+	requireEthError(t, s, ethInvalidParams, "eth_call", map[string]string{"to": "0x1234"})
+	requireEthError(t, s, ethInvalidParams, "eth_call", map[string]string{
+		"to": requireEthAddress(t, contract).Hex(),
+	}, "0x1")
+}
+
+func TestEthService_SendRawTransaction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	dp := mock_dispatcher.NewMockDispatcher(ctrl)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	bc.EXPECT().ChainID().Return(config.Default.Chain.ID).AnyTimes()
+	s := NewEthService(0, &Service{bc: bc, dp: dp, p2p: p2p})
+
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["producer"], ta.Addrinfo["alfa"], 1, big.NewInt(1), nil,
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	data, err := proto.Marshal(tsf.Proto())
+	require.NoError(err)
+	p2p.EXPECT().Broadcast(config.Default.Chain.ID, gomock.Any()).Return(nil).Times(1)
+	dp.EXPECT().HandleBroadcast(config.Default.Chain.ID, gomock.Any(), gomock.Any()).Times(1)
+	tsfHash := tsf.Hash()
+	requireEthResult(t, s, "0x"+hex.EncodeToString(tsfHash[:]), "eth_sendRawTransaction", hexutil.Encode(data))
+
+	vote, err := testutil.SignedVote(ta.Addrinfo["producer"], ta.Addrinfo["producer"], 2,
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	data, err = proto.Marshal(vote.Proto())
+	require.NoError(err)
+	requireEthError(t, s, ethInvalidParams, "eth_sendRawTransaction", hexutil.Encode(data))
+	requireEthError(t, s, ethInvalidParams, "eth_sendRawTransaction", "0x0102")
+}
+
+func TestEthService_ReceiptsAndLogs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().ChainID().Return(config.Default.Chain.ID).AnyTimes()
+	bc.EXPECT().TipHeight().Return(uint64(3)).AnyTimes()
+	s := NewEthService(0, &Service{bc: bc})
+
+	producer := ta.Addrinfo["producer"].RawAddress
+	contract := ta.Addrinfo["delta"].RawAddress
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["producer"], ta.Addrinfo["alfa"], 1, big.NewInt(1), nil,
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	execution, err := testutil.SignedExecution(ta.Addrinfo["producer"], contract, 2, big.NewInt(0),
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice), []byte{1})
+	require.NoError(err)
+	blk := blockchain.NewBlock(config.Default.Chain.ID, 2, hash.ZeroHash32B, 2, ta.Addrinfo["producer"].PublicKey,
+		[]action.Action{tsf, execution})
+	blkHash := blk.HashBlock()
+	topic := hash.Hash32B{1, 2, 3}
+	log := &action.Log{
+		Address:     contract,
+		Topics:      []hash.Hash32B{topic},
+		Data:        []byte{4, 5},
+		BlockNumber: 2,
+		TxnHash:     execution.Hash(),
+		BlockHash:   blkHash,
+		Index:       1,
+	}
+	receipt := &action.Receipt{
+		Status:          blockchain.SuccessStatus,
+		Hash:            execution.Hash(),
+		GasConsumed:     1000,
+		ContractAddress: contract,
+		Logs:            []*action.Log{log},
+	}
+	bc.EXPECT().GetReceiptByExecutionHash(execution.Hash()).Return(receipt, nil).Times(1)
+	bc.EXPECT().GetBlockHashByActionHash(execution.Hash()).Return(blkHash, nil).Times(1)
+	bc.EXPECT().GetBlockByHash(blkHash).Return(blk, nil).Times(2)
+	bc.EXPECT().GetReceiptByExecutionHash(tsf.Hash()).
+		Return(nil, errors.Wrap(db.ErrNotExist, "receipt doesn't exist")).Times(1)
+
+	exHash := execution.Hash()
+	res := callEth(t, s, "eth_getTransactionReceipt", hexutil.Encode(exHash[:]))
+	require.Nil(res.Error)
+	var ethRcpt map[string]interface{}
+	require.NoError(json.Unmarshal(res.Result, &ethRcpt))
+	require.Equal(hexutil.Encode(exHash[:]), ethRcpt["transactionHash"])
+	require.Equal("0x1", ethRcpt["transactionIndex"])
+	require.Equal(hexutil.Encode(blkHash[:]), ethRcpt["blockHash"])
+	require.Equal("0x2", ethRcpt["blockNumber"])
+	require.Equal(strings.ToLower(requireEthAddress(t, producer).Hex()), ethRcpt["from"])
+	require.Equal(strings.ToLower(requireEthAddress(t, contract).Hex()), ethRcpt["to"])
+	require.Nil(ethRcpt["contractAddress"])
+	require.Equal("0x3e8", ethRcpt["gasUsed"])
+	require.Equal("0x1", ethRcpt["status"])
+	require.Equal(1, len(ethRcpt["logs"].([]interface{})))
+	// a transfer doesn't have a receipt
+	tsfHash := tsf.Hash()
+	requireEthResult(t, s, nil, "eth_getTransactionReceipt", hexutil.Encode(tsfHash[:]))
+
+	// the filter is translated into the one of the io1 addresses
+	filter := &blockchain.LogFilter{Addresses: []string{contract}, Topics: [][]hash.Hash32B{nil, {topic}}}
+	bc.EXPECT().GetLogs(filter, uint64(1), uint64(3), uint64(maxEthLogs+1)).Return([]*action.Log{log}, nil).Times(1)
+	bc.EXPECT().GetLogs(filter, uint64(2), uint64(2), uint64(maxEthLogs+1)).Return([]*action.Log{log}, nil).Times(1)
+	expected := []interface{}{map[string]interface{}{
+		"address":         strings.ToLower(requireEthAddress(t, contract).Hex()),
+		"topics":          []interface{}{hexutil.Encode(topic[:])},
+		"data":            "0x0405",
+		"blockNumber":     "0x2",
+		"transactionHash": hexutil.Encode(exHash[:]),
+		"blockHash":       hexutil.Encode(blkHash[:]),
+		"logIndex":        "0x1",
+		"removed":         false,
+	}}
+	requireEthResult(t, s, expected, "eth_getLogs", map[string]interface{}{
+		"fromBlock": "0x1",
+		"address":   requireEthAddress(t, contract).Hex(),
+		"topics":    []interface{}{nil, []string{hexutil.Encode(topic[:])}},
+	})
+	requireEthResult(t, s, expected, "eth_getLogs", map[string]interface{}{
+		"blockHash": hexutil.Encode(blkHash[:]),
+		"address":   []string{contract},
+		"topics":    []interface{}{nil, hexutil.Encode(topic[:])},
+	})
+	requireEthError(t, s, ethInvalidParams, "eth_getLogs", map[string]interface{}{"fromBlock": "0x4", "toBlock": "0x3"})
+	requireEthError(t, s, ethInvalidParams, "eth_getLogs", map[string]interface{}{
+		"fromBlock": "0x1",
+		"toBlock":   hexutil.EncodeUint64(maxLogsHeightRange + 1),
+	})
+}
+
+func requireEthAddress(t *testing.T, iotxAddr string) common.Address {
+	pkHash, err := iotxaddress.AddressToPKHash(iotxAddr)
+	require.NoError(t, err)
+	return common.BytesToAddress(pkHash[:])
+}
+
+func callEth(t *testing.T, s *EthService, method string, params ...interface{}) *ethResponse {
+	if params == nil {
+		params = []interface{}{}
+	}
+	rawParams, err := json.Marshal(params)
+	require.NoError(t, err)
+	req, err := json.Marshal(&ethRequest{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: rawParams})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(req)))
+	require.Equal(t, http.StatusOK, w.Code)
+	var res ethResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, "1", string(res.ID))
+	return &res
+}
+
+func requireEthResult(t *testing.T, s *EthService, expected interface{}, method string, params ...interface{}) {
+	res := callEth(t, s, method, params...)
+	require.Nil(t, res.Error)
+	var result interface{}
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Equal(t, expected, result)
+}
+
+func requireEthError(t *testing.T, s *EthService, code int, method string, params ...interface{}) {
+	res := callEth(t, s, method, params...)
+	require.NotNil(t, res.Error)
+	require.Equal(t, code, res.Error.Code)
+}
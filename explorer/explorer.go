@@ -25,11 +25,15 @@ import (
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/indexservice"
+	"github.com/iotexproject/iotex-core/lightclient"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/trie"
 )
 
 var (
@@ -74,20 +78,28 @@ type Service struct {
 	// TODO: the way to make explorer to access the data model managed by main-chain protocol is hack. We need to
 	// refactor the code later
 	mainChain *mainchain.Protocol
+	// lc answers the queries of the latest states on a lightweight node, which doesn't keep the chain
+	lc lightclient.LightClient
 }
 
 // SetMainChainProtocol sets the main-chain side multi-chain protocol
 func (exp *Service) SetMainChainProtocol(mainChain *mainchain.Protocol) { exp.mainChain = mainChain }
 
+// SetLightClient sets the light client answering the queries of the latest states
+func (exp *Service) SetLightClient(lc lightclient.LightClient) { exp.lc = lc }
+
 // GetBlockchainHeight returns the current blockchain tip height
 func (exp *Service) GetBlockchainHeight() (int64, error) {
+	if exp.lc != nil {
+		return int64(exp.lc.TipHeight()), nil
+	}
 	tip := exp.bc.TipHeight()
 	return int64(tip), nil
 }
 
 // GetAddressBalance returns the balance of an address
 func (exp *Service) GetAddressBalance(address string) (string, error) {
-	state, err := exp.bc.StateByAddr(address)
+	state, err := exp.stateByAddr(address)
 	if err != nil {
 		return "", err
 	}
@@ -96,7 +108,7 @@ func (exp *Service) GetAddressBalance(address string) (string, error) {
 
 // GetAddressDetails returns the properties of an address
 func (exp *Service) GetAddressDetails(address string) (explorer.AddressDetails, error) {
-	state, err := exp.bc.StateByAddr(address)
+	state, err := exp.stateByAddr(address)
 	if err != nil {
 		return explorer.AddressDetails{}, err
 	}
@@ -115,6 +127,37 @@ func (exp *Service) GetAddressDetails(address string) (explorer.AddressDetails,
 	return details, nil
 }
 
+// GetAddressBalanceByHeight returns the balance of an address at a block height
+func (exp *Service) GetAddressBalanceByHeight(address string, height int64) (string, error) {
+	if height < 0 {
+		return "", errors.New("invalid block height")
+	}
+	state, err := exp.bc.StateByAddrAndHeight(address, uint64(height))
+	if err != nil {
+		return "", err
+	}
+	return state.Balance.String(), nil
+}
+
+// GetAddressDetailsByHeight returns the properties of an address at a block height
+func (exp *Service) GetAddressDetailsByHeight(address string, height int64) (explorer.AddressDetails, error) {
+	if height < 0 {
+		return explorer.AddressDetails{}, errors.New("invalid block height")
+	}
+	state, err := exp.bc.StateByAddrAndHeight(address, uint64(height))
+	if err != nil {
+		return explorer.AddressDetails{}, err
+	}
+	// There are no pending actions at a past height, so the pending nonce is the next nonce
+	return explorer.AddressDetails{
+		Address:      address,
+		TotalBalance: state.Balance.String(),
+		Nonce:        int64(state.Nonce),
+		PendingNonce: int64(state.Nonce + 1),
+		IsCandidate:  state.IsCandidate,
+	}, nil
+}
+
 // GetLastTransfersByRange returns transfers in [-(offset+limit-1), -offset] from block
 // with height startBlockHeight
 func (exp *Service) GetLastTransfersByRange(startBlockHeight int64, offset int64, limit int64, showCoinBase bool) ([]explorer.Transfer, error) {
@@ -652,7 +695,7 @@ func (exp *Service) GetReceiptByExecutionID(id string) (explorer.Receipt, error)
 	}
 	var executionHash hash.Hash32B
 	copy(executionHash[:], bytes)
-	receipt, err := exp.bc.GetReceiptByExecutionHash(executionHash)
+	receipt, err := exp.getReceipt(executionHash)
 	if err != nil {
 		return explorer.Receipt{}, err
 	}
@@ -668,7 +711,7 @@ func (exp *Service) GetCreateDeposit(createDepositID string) (explorer.CreateDep
 	}
 	var createDepositHash hash.Hash32B
 	copy(createDepositHash[:], bytes)
-	return getCreateDeposit(exp.bc, exp.ap, createDepositHash)
+	return getCreateDeposit(exp.bc, exp.ap, createDepositHash, exp.idx, exp.cfg.UseRDS)
 }
 
 // GetCreateDepositsByAddress gets the relevant create deposits of an address
@@ -679,7 +722,7 @@ func (exp *Service) GetCreateDepositsByAddress(
 ) ([]explorer.CreateDeposit, error) {
 	res := make([]explorer.CreateDeposit, 0)
 
-	depositsFromAddress, err := exp.bc.GetActionsFromAddress(address)
+	depositsFromAddress, err := exp.getActionHashes(address, true)
 	if err != nil {
 		return []explorer.CreateDeposit{}, err
 	}
@@ -691,7 +734,7 @@ func (exp *Service) GetCreateDepositsByAddress(
 		if int64(len(res)) >= limit {
 			break
 		}
-		createDeposit, err := getCreateDeposit(exp.bc, exp.ap, depositHash)
+		createDeposit, err := getCreateDeposit(exp.bc, exp.ap, depositHash, exp.idx, exp.cfg.UseRDS)
 		if err != nil {
 			continue
 		}
@@ -710,7 +753,7 @@ func (exp *Service) GetSettleDeposit(settleDepositID string) (explorer.SettleDep
 	}
 	var settleDepositHash hash.Hash32B
 	copy(settleDepositHash[:], bytes)
-	return getSettleDeposit(exp.bc, exp.ap, settleDepositHash)
+	return getSettleDeposit(exp.bc, exp.ap, settleDepositHash, exp.idx, exp.cfg.UseRDS)
 }
 
 // GetSettleDepositsByAddress gets the relevant settle deposits of an address
@@ -721,7 +764,7 @@ func (exp *Service) GetSettleDepositsByAddress(
 ) ([]explorer.SettleDeposit, error) {
 	res := make([]explorer.SettleDeposit, 0)
 
-	depositsToAddress, err := exp.bc.GetActionsToAddress(address)
+	depositsToAddress, err := exp.getActionHashes(address, false)
 	if err != nil {
 		return []explorer.SettleDeposit{}, err
 	}
@@ -733,7 +776,7 @@ func (exp *Service) GetSettleDepositsByAddress(
 		if int64(len(res)) >= limit {
 			break
 		}
-		settleDeposit, err := getSettleDeposit(exp.bc, exp.ap, depositHash)
+		settleDeposit, err := getSettleDeposit(exp.bc, exp.ap, depositHash, exp.idx, exp.cfg.UseRDS)
 		if err != nil {
 			continue
 		}
@@ -1470,6 +1513,320 @@ func (exp *Service) EstimateGasForSmartContract(execution explorer.Execution) (i
 	return exp.gs.estimateGasForSmartContract(execution)
 }
 
+// GetActionProof gets the merkle proof of an action against the tx root of the block containing it, and the proof of
+// its receipt against the receipt root if the action has a receipt
+func (exp *Service) GetActionProof(hashStr string) (explorer.ActionProof, error) {
+	bytes, err := hex.DecodeString(hashStr)
+	if err != nil {
+		return explorer.ActionProof{}, err
+	}
+	var actHash hash.Hash32B
+	copy(actHash[:], bytes)
+	blkHash, err := getBlockHashByActionHash(exp.bc, actHash)
+	if err != nil {
+		return explorer.ActionProof{}, err
+	}
+	blk, err := exp.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return explorer.ActionProof{}, err
+	}
+	proof, err := blk.ActionProof(actHash)
+	if err != nil {
+		return explorer.ActionProof{}, err
+	}
+	txRoot := blk.TxRoot()
+	res := explorer.ActionProof{
+		ActionHash:  hashStr,
+		BlockHeight: int64(blk.Height()),
+		BlockHash:   hex.EncodeToString(blkHash[:]),
+		TxRoot:      hex.EncodeToString(txRoot[:]),
+		Index:       int64(proof.Index),
+		Size:        int64(proof.Size),
+		Path:        hashesToStrings(proof.Path),
+	}
+
+	// Receipts are not kept in the block read from the DB, so fetch them one by one
+	receipts := make([]*action.Receipt, 0, len(blk.Actions))
+	for _, act := range blk.Actions {
+		if receipt, err := exp.bc.GetReceiptByExecutionHash(act.Hash()); err == nil {
+			receipts = append(receipts, receipt)
+		}
+	}
+	blk.SetReceipts(receipts)
+	if receiptProof, err := blk.ReceiptProof(actHash); err == nil {
+		receiptRoot := blk.CalculateReceiptRoot()
+		res.ReceiptRoot = hex.EncodeToString(receiptRoot[:])
+		res.ReceiptIndex = int64(receiptProof.Index)
+		res.ReceiptSize = int64(receiptProof.Size)
+		res.ReceiptPath = hashesToStrings(receiptProof.Path)
+	}
+	return res, nil
+}
+
+// GetAccountProof returns the trie proof of an account state against the state root of the block on a height
+func (exp *Service) GetAccountProof(addr string, height int64) (explorer.AccountProof, error) {
+	pkHash, blk, proof, data, err := exp.accountProof(addr, height)
+	if err != nil {
+		return explorer.AccountProof{}, err
+	}
+	blkHash := blk.HashBlock()
+	stateRoot := blk.StateRoot()
+	return explorer.AccountProof{
+		Address:     addr,
+		BlockHeight: height,
+		BlockHash:   hex.EncodeToString(blkHash[:]),
+		StateRoot:   hex.EncodeToString(stateRoot[:]),
+		State:       hex.EncodeToString(data),
+		Proof:       proofToStrings(proof),
+	}, nil
+}
+
+// GetStorageProof returns the trie proof of a contract storage slot against the storage root of the contract, along
+// with the trie proof of the contract's account state against the state root of the block on a height. A slot never
+// written is proven absent, and its value is empty
+func (exp *Service) GetStorageProof(addr string, key string, height int64) (explorer.StorageProof, error) {
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil || len(keyBytes) != hash.HashSize {
+		return explorer.StorageProof{}, errors.Errorf("invalid storage key %s", key)
+	}
+	storageKey := byteutil.BytesTo32B(keyBytes)
+	pkHash, blk, accountProof, data, err := exp.accountProof(addr, height)
+	if err != nil {
+		return explorer.StorageProof{}, err
+	}
+	var account state.Account
+	if err := state.Deserialize(&account, data); err != nil {
+		return explorer.StorageProof{}, errors.Wrapf(err, "error when deserializing the state of %x", pkHash)
+	}
+	storageRoot := account.Root
+	if storageRoot == hash.ZeroHash32B {
+		storageRoot = trie.EmptyRoot
+	}
+	proof, err := exp.bc.GetFactory().StorageProofByHeight(pkHash, storageKey, uint64(height))
+	if err != nil {
+		return explorer.StorageProof{}, err
+	}
+	value, err := trie.VerifyProof(storageRoot, storageKey[:], proof)
+	if err != nil && errors.Cause(err) != trie.ErrNotExist {
+		return explorer.StorageProof{}, errors.Wrapf(err, "failed to prove the storage against block %d", height)
+	}
+	blkHash := blk.HashBlock()
+	stateRoot := blk.StateRoot()
+	return explorer.StorageProof{
+		Address:      addr,
+		Key:          key,
+		BlockHeight:  height,
+		BlockHash:    hex.EncodeToString(blkHash[:]),
+		StateRoot:    hex.EncodeToString(stateRoot[:]),
+		State:        hex.EncodeToString(data),
+		AccountProof: proofToStrings(accountProof),
+		StorageRoot:  hex.EncodeToString(storageRoot[:]),
+		Value:        hex.EncodeToString(value),
+		Proof:        proofToStrings(proof),
+	}, nil
+}
+
+// accountProof returns the trie proof of an account state on a height, which is verified against the state root of
+// the block on the height, and the state proven
+func (exp *Service) accountProof(
+	addr string,
+	height int64,
+) (hash.PKHash, *blockchain.Block, [][]byte, []byte, error) {
+	iotxAddr, err := address.IotxAddressToAddress(addr)
+	if err != nil {
+		return hash.ZeroPKHash, nil, nil, nil, err
+	}
+	if height < 0 {
+		return hash.ZeroPKHash, nil, nil, nil, errors.Errorf("invalid height %d", height)
+	}
+	pkHash := byteutil.BytesTo20B(iotxAddr.Payload())
+	blk, err := exp.bc.GetBlockByHeight(uint64(height))
+	if err != nil {
+		return hash.ZeroPKHash, nil, nil, nil, err
+	}
+	proof, err := exp.bc.GetFactory().StateProofByHeight(pkHash, uint64(height))
+	if err != nil {
+		return hash.ZeroPKHash, nil, nil, nil, err
+	}
+	data, err := trie.VerifyProof(blk.StateRoot(), pkHash[:], proof)
+	if err != nil {
+		if errors.Cause(err) == trie.ErrNotExist {
+			err = errors.Wrapf(state.ErrStateNotExist, "state of %x doesn't exist", pkHash)
+			return hash.ZeroPKHash, nil, nil, nil, err
+		}
+		return hash.ZeroPKHash, nil, nil, nil, errors.Wrapf(err, "failed to prove the state against block %d", height)
+	}
+	return pkHash, blk, proof, data, nil
+}
+
+// stateByAddr returns the latest account state of an address, which is proven by a full node on a lightweight node
+func (exp *Service) stateByAddr(address string) (*state.Account, error) {
+	if exp.lc != nil {
+		return exp.lc.AccountState(address)
+	}
+	return exp.bc.StateByAddr(address)
+}
+
+// The statuses of an action returned by GetActionStatus
+const (
+	actionConfirmed = "confirmed"
+	actionPending   = "pending"
+	actionDropped   = "dropped"
+	actionUnknown   = "unknown"
+)
+
+// GetActionStatus returns whether an action is confirmed, pending in actpool or dropped from actpool, and the reason
+// it's dropped. An action never received, or dropped long ago, is unknown
+func (exp *Service) GetActionStatus(hashStr string) (explorer.ActionStatus, error) {
+	bytes, err := hex.DecodeString(hashStr)
+	if err != nil {
+		return explorer.ActionStatus{}, err
+	}
+	var actHash hash.Hash32B
+	copy(actHash[:], bytes)
+	res := explorer.ActionStatus{Hash: hashStr, Status: actionUnknown}
+	if _, err := getBlockHashByActionHash(exp.bc, actHash); err == nil {
+		res.Status = actionConfirmed
+	} else if _, err := exp.ap.GetActionByHash(actHash); err == nil {
+		res.Status = actionPending
+	} else if reason, ok := exp.ap.GetDropReason(actHash); ok {
+		res.Status = actionDropped
+		res.DropReason = string(reason)
+	}
+	return res, nil
+}
+
+// The bounds of a GetLogs request
+const (
+	// maxLogsHeightRange is the maximum number of blocks to look into
+	maxLogsHeightRange = 10000
+	// maxLogsOffset is the maximum number of logs to skip
+	maxLogsOffset = 10000
+	// maxLogsLimit is the maximum number of logs to return
+	maxLogsLimit = 1000
+)
+
+// GetLogs returns the contract logs emitted by any of the addresses, and having any of the topics at each position, in
+// the blocks from the from height to the to height, skipping the first offset ones
+func (exp *Service) GetLogs(request explorer.GetLogsRequest) ([]explorer.Log, error) {
+	if request.FromHeight < 0 || request.ToHeight < request.FromHeight {
+		return []explorer.Log{}, errors.Errorf("invalid height range [%d, %d]", request.FromHeight, request.ToHeight)
+	}
+	if request.ToHeight-request.FromHeight >= maxLogsHeightRange {
+		return []explorer.Log{}, errors.Errorf("height range is larger than %d", maxLogsHeightRange)
+	}
+	if request.Offset < 0 || request.Offset > maxLogsOffset || request.Limit <= 0 || request.Limit > maxLogsLimit {
+		return []explorer.Log{}, errors.Errorf("invalid offset %d or limit %d", request.Offset, request.Limit)
+	}
+	filter := &blockchain.LogFilter{Addresses: request.Addresses}
+	for _, logTopics := range request.Topics {
+		topics := make([]hash.Hash32B, 0, len(logTopics.Topics))
+		for _, topicStr := range logTopics.Topics {
+			topic, err := hex.DecodeString(topicStr)
+			if err != nil {
+				return []explorer.Log{}, err
+			}
+			if len(topic) != len(hash.ZeroHash32B) {
+				return []explorer.Log{}, errors.Errorf("invalid topic %s", topicStr)
+			}
+			topics = append(topics, byteutil.BytesTo32B(topic))
+		}
+		filter.Topics = append(filter.Topics, topics)
+	}
+	logs, err := exp.getLogs(
+		filter,
+		uint64(request.FromHeight),
+		uint64(request.ToHeight),
+		uint64(request.Offset+request.Limit),
+	)
+	if err != nil {
+		return []explorer.Log{}, err
+	}
+	res := []explorer.Log{}
+	for i, log := range logs {
+		if int64(i) < request.Offset {
+			continue
+		}
+		res = append(res, convertLogToExplorerLog(log))
+	}
+	return res, nil
+}
+
+// getBlockHashByActionHash returns the hash of the block containing the action of any type
+func getBlockHashByActionHash(bc blockchain.Blockchain, h hash.Hash32B) (hash.Hash32B, error) {
+	if blkHash, err := bc.GetBlockHashByActionHash(h); err == nil {
+		return blkHash, nil
+	}
+	if blkHash, err := bc.GetBlockHashByTransferHash(h); err == nil {
+		return blkHash, nil
+	}
+	if blkHash, err := bc.GetBlockHashByVoteHash(h); err == nil {
+		return blkHash, nil
+	}
+	return bc.GetBlockHashByExecutionHash(h)
+}
+
+func hashesToStrings(hashes []hash.Hash32B) []string {
+	strs := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		strs = append(strs, hex.EncodeToString(h[:]))
+	}
+	return strs
+}
+
+func proofToStrings(proof [][]byte) []string {
+	strs := make([]string, 0, len(proof))
+	for _, node := range proof {
+		strs = append(strs, hex.EncodeToString(node))
+	}
+	return strs
+}
+
+// getReceipt returns the receipt of an action, which is read from the index service if the explorer uses RDS
+func (exp *Service) getReceipt(actHash hash.Hash32B) (*action.Receipt, error) {
+	if exp.cfg.UseRDS {
+		return exp.idx.Indexer().GetReceiptByAction(actHash)
+	}
+	return exp.bc.GetReceiptByExecutionHash(actHash)
+}
+
+// getActionHashes returns the hashes of the actions other than transfer, vote and execution sent by or to an address,
+// which are read from the index service if the explorer uses RDS
+func (exp *Service) getActionHashes(address string, sent bool) ([]hash.Hash32B, error) {
+	switch {
+	case exp.cfg.UseRDS && sent:
+		return exp.idx.Indexer().GetActionsFromAddress(address)
+	case exp.cfg.UseRDS:
+		return exp.idx.Indexer().GetActionsToAddress(address)
+	case sent:
+		return exp.bc.GetActionsFromAddress(address)
+	default:
+		return exp.bc.GetActionsToAddress(address)
+	}
+}
+
+// getLogs returns the logs matching a filter, which are read from the index service if the explorer uses RDS
+func (exp *Service) getLogs(filter *blockchain.LogFilter, fromHeight, toHeight, limit uint64) ([]*action.Log, error) {
+	if exp.cfg.UseRDS {
+		return exp.idx.Indexer().GetLogs(filter, fromHeight, toHeight, limit)
+	}
+	return exp.bc.GetLogs(filter, fromHeight, toHeight, limit)
+}
+
+// getBlockHashByOtherAction returns the hash of the block including an action other than transfer, vote and
+// execution, which is read from the index service if useRDS
+func getBlockHashByOtherAction(
+	bc blockchain.Blockchain,
+	actHash hash.Hash32B,
+	idx *indexservice.Server,
+	useRDS bool,
+) (hash.Hash32B, error) {
+	if useRDS {
+		return idx.Indexer().GetBlockByAction(actHash)
+	}
+	return bc.GetBlockHashByActionHash(actHash)
+}
+
 // getTransfer takes in a blockchain and transferHash and returns an Explorer Transfer
 func getTransfer(bc blockchain.Blockchain, ap actpool.ActPool, transferHash hash.Hash32B, idx *indexservice.Server, useRDS bool) (explorer.Transfer, error) {
 	explorerTransfer := explorer.Transfer{}
@@ -1607,6 +1964,8 @@ func getCreateDeposit(
 	bc blockchain.Blockchain,
 	ap actpool.ActPool,
 	createDepositHash hash.Hash32B,
+	idx *indexservice.Server,
+	useRDS bool,
 ) (explorer.CreateDeposit, error) {
 	pending := false
 	var act action.Action
@@ -1622,7 +1981,7 @@ func getCreateDeposit(
 	}
 
 	// Fetch from block
-	blkHash, err := bc.GetBlockHashByActionHash(createDepositHash)
+	blkHash, err := getBlockHashByOtherAction(bc, createDepositHash, idx, useRDS)
 	if err != nil {
 		return explorer.CreateDeposit{}, err
 	}
@@ -1672,6 +2031,8 @@ func getSettleDeposit(
 	bc blockchain.Blockchain,
 	ap actpool.ActPool,
 	settleDepositHash hash.Hash32B,
+	idx *indexservice.Server,
+	useRDS bool,
 ) (explorer.SettleDeposit, error) {
 	pending := false
 	var act action.Action
@@ -1687,7 +2048,7 @@ func getSettleDeposit(
 	}
 
 	// Fetch from block
-	blkHash, err := bc.GetBlockHashByActionHash(settleDepositHash)
+	blkHash, err := getBlockHashByOtherAction(bc, settleDepositHash, idx, useRDS)
 	if err != nil {
 		return explorer.SettleDeposit{}, err
 	}
@@ -1806,19 +2167,7 @@ func convertReceiptToExplorerReceipt(receipt *action.Receipt) (explorer.Receipt,
 	}
 	logs := []explorer.Log{}
 	for _, log := range receipt.Logs {
-		topics := []string{}
-		for _, topic := range log.Topics {
-			topics = append(topics, hex.EncodeToString(topic[:]))
-		}
-		logs = append(logs, explorer.Log{
-			Address:     log.Address,
-			Topics:      topics,
-			Data:        hex.EncodeToString(log.Data),
-			BlockNumber: int64(log.BlockNumber),
-			TxnHash:     hex.EncodeToString(log.TxnHash[:]),
-			BlockHash:   hex.EncodeToString(log.BlockHash[:]),
-			Index:       int64(log.Index),
-		})
+		logs = append(logs, convertLogToExplorerLog(log))
 	}
 
 	return explorer.Receipt{
@@ -1831,6 +2180,22 @@ func convertReceiptToExplorerReceipt(receipt *action.Receipt) (explorer.Receipt,
 	}, nil
 }
 
+func convertLogToExplorerLog(log *action.Log) explorer.Log {
+	topics := []string{}
+	for _, topic := range log.Topics {
+		topics = append(topics, hex.EncodeToString(topic[:]))
+	}
+	return explorer.Log{
+		Address:     log.Address,
+		Topics:      topics,
+		Data:        hex.EncodeToString(log.Data),
+		BlockNumber: int64(log.BlockNumber),
+		TxnHash:     hex.EncodeToString(log.TxnHash[:]),
+		BlockHash:   hex.EncodeToString(log.BlockHash[:]),
+		Index:       int64(log.Index),
+	}
+}
+
 func convertExplorerExecutionToActionPb(execution *explorer.Execution) (*pb.ActionPb, error) {
 	executorPubKey, err := keypair.StringToPubKeyBytes(execution.ExecutorPubKey)
 	if err != nil {
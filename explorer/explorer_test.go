@@ -14,6 +14,7 @@ import (
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/jsonpb"
@@ -29,23 +30,29 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/indexservice"
+	"github.com/iotexproject/iotex-core/iotxaddress"
 	"github.com/iotexproject/iotex-core/network/node"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	iproto "github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
 	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
 	"github.com/iotexproject/iotex-core/test/mock/mock_network"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
+	"github.com/iotexproject/iotex-core/trie"
 )
 
 const (
-	testTriePath = "trie.test"
-	testDBPath   = "db.test"
+	testTriePath  = "trie.test"
+	testDBPath    = "db.test"
+	testIndexPath = "index.test"
 )
 
 func addTestingBlocks(bc blockchain.Blockchain) error {
@@ -462,6 +469,42 @@ func TestService_StateByAddr(t *testing.T) {
 	require.Equal("456", state.Votee)
 }
 
+func TestService_GetAddressDetailsByHeight(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := state.Account{
+		Balance:      big.NewInt(46),
+		Nonce:        uint64(3),
+		IsCandidate:  true,
+		VotingWeight: big.NewInt(100),
+	}
+
+	mBc := mock_blockchain.NewMockBlockchain(ctrl)
+	mBc.EXPECT().StateByAddrAndHeight("123", uint64(5)).Times(2).Return(&s, nil)
+	mBc.EXPECT().StateByAddrAndHeight("123", uint64(6)).Times(1).Return(nil, blockchain.ErrInvalidTipHeight)
+
+	svc := Service{bc: mBc}
+	balance, err := svc.GetAddressBalanceByHeight("123", 5)
+	require.NoError(err)
+	require.Equal("46", balance)
+	details, err := svc.GetAddressDetailsByHeight("123", 5)
+	require.NoError(err)
+	require.Equal(explorer.AddressDetails{
+		Address:      "123",
+		TotalBalance: "46",
+		Nonce:        3,
+		PendingNonce: 4,
+		IsCandidate:  true,
+	}, details)
+	_, err = svc.GetAddressDetailsByHeight("123", 6)
+	require.Equal(blockchain.ErrInvalidTipHeight, errors.Cause(err))
+	_, err = svc.GetAddressBalanceByHeight("123", -1)
+	require.Error(err)
+}
+
 func TestService_GetConsensusMetrics(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -838,6 +881,368 @@ func TestExplorerGetReceiptByExecutionID(t *testing.T) {
 	receipt, err := svc.GetReceiptByExecutionID(eHashStr)
 	require.NoError(err)
 	require.Equal(eHashStr, receipt.Hash)
+
+	// the receipt is served from the index service as well, once it catches up with the chain
+	testutil.CleanupPath(t, testIndexPath)
+	defer testutil.CleanupPath(t, testIndexPath)
+	cfg.DB.RDS.Dialect = config.SQLiteDialect
+	cfg.DB.RDS.SQLitePath = testIndexPath
+	cfg.Indexer.NodeAddr = "node"
+	idx := indexservice.NewServer(cfg, bc)
+	require.NoError(idx.Start(ctx))
+	defer func() {
+		require.NoError(idx.Stop(ctx))
+	}()
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		height, err := idx.Indexer().GetIndexHeight()
+		return err == nil && height == bc.TipHeight(), nil
+	}))
+	svc.idx = idx
+	svc.cfg.UseRDS = true
+	receipt, err = svc.GetReceiptByExecutionID(eHashStr)
+	require.NoError(err)
+	require.Equal(eHashStr, receipt.Hash)
+}
+
+func TestExplorerGetActionProof(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Explorer.Enabled = true
+
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	require.NoError(addCreatorToFactory(sf))
+	// Disable block reward to make bookkeeping easier
+	blockchain.Gen.BlockReward = big.NewInt(0)
+
+	ctx := context.Background()
+	bc := blockchain.NewBlockchain(cfg, blockchain.PrecreatedStateFactoryOption(sf), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	svc := Service{bc: bc}
+
+	tsf, err := action.NewTransfer(
+		1,
+		big.NewInt(10),
+		ta.Addrinfo["producer"].RawAddress,
+		ta.Addrinfo["alfa"].RawAddress,
+		[]byte{},
+		testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPrice),
+	)
+	require.NoError(err)
+	require.NoError(action.Sign(tsf, ta.Addrinfo["producer"].PrivateKey))
+	execution, err := action.NewExecution(
+		ta.Addrinfo["producer"].RawAddress,
+		action.EmptyAddress,
+		2,
+		big.NewInt(0),
+		testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPrice),
+		[]byte{},
+	)
+	require.NoError(err)
+	require.NoError(action.Sign(execution, ta.Addrinfo["producer"].PrivateKey))
+	blk, err := bc.MintNewBlock([]action.Action{tsf, execution}, ta.Addrinfo["producer"], nil, nil, "")
+	require.NoError(err)
+	require.Nil(bc.CommitBlock(blk))
+
+	for _, act := range []action.Action{tsf, execution} {
+		h := act.Hash()
+		proof, err := svc.GetActionProof(hex.EncodeToString(h[:]))
+		require.NoError(err)
+		require.Equal(int64(blk.Height()), proof.BlockHeight)
+		txRoot := blk.TxRoot()
+		require.Equal(hex.EncodeToString(txRoot[:]), proof.TxRoot)
+
+		path := make([]hash.Hash32B, 0, len(proof.Path))
+		for _, p := range proof.Path {
+			b, err := hex.DecodeString(p)
+			require.NoError(err)
+			path = append(path, byteutil.BytesTo32B(b))
+		}
+		require.NoError(blockchain.VerifyActionProof(
+			map[string]hash.Hash32B{blockchain.TxRootName: txRoot},
+			h,
+			&blockchain.MerkleProof{Index: uint64(proof.Index), Size: uint64(proof.Size), Path: path},
+		))
+	}
+
+	// Only the execution has a receipt
+	h := execution.Hash()
+	proof, err := svc.GetActionProof(hex.EncodeToString(h[:]))
+	require.NoError(err)
+	require.NotEmpty(proof.ReceiptRoot)
+	require.Equal(int64(1), proof.ReceiptSize)
+	h = tsf.Hash()
+	proof, err = svc.GetActionProof(hex.EncodeToString(h[:]))
+	require.NoError(err)
+	require.Empty(proof.ReceiptRoot)
+
+	_, err = svc.GetActionProof(hex.EncodeToString(hash.ZeroHash32B[:]))
+	require.Error(err)
+}
+
+func TestExplorerGetAccountProof(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Explorer.Enabled = true
+
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.Nil(err)
+	require.Nil(sf.Start(context.Background()))
+	require.NoError(addCreatorToFactory(sf))
+	// Disable block reward to make bookkeeping easier
+	blockchain.Gen.BlockReward = big.NewInt(0)
+
+	ctx := context.Background()
+	bc := blockchain.NewBlockchain(cfg, blockchain.PrecreatedStateFactoryOption(sf), blockchain.InMemDaoOption())
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	svc := Service{bc: bc}
+
+	tsf, err := action.NewTransfer(
+		1,
+		big.NewInt(10),
+		ta.Addrinfo["producer"].RawAddress,
+		ta.Addrinfo["alfa"].RawAddress,
+		[]byte{},
+		testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPrice),
+	)
+	require.NoError(err)
+	require.NoError(action.Sign(tsf, ta.Addrinfo["producer"].PrivateKey))
+	blk, err := bc.MintNewBlock([]action.Action{tsf}, ta.Addrinfo["producer"], nil, nil, "")
+	require.NoError(err)
+	require.Nil(bc.CommitBlock(blk))
+
+	addr := ta.Addrinfo["alfa"].RawAddress
+	proof, err := svc.GetAccountProof(addr, int64(blk.Height()))
+	require.NoError(err)
+	require.Equal(int64(blk.Height()), proof.BlockHeight)
+	stateRoot := blk.StateRoot()
+	require.Equal(hex.EncodeToString(stateRoot[:]), proof.StateRoot)
+
+	nodes := make([][]byte, 0, len(proof.Proof))
+	for _, p := range proof.Proof {
+		b, err := hex.DecodeString(p)
+		require.NoError(err)
+		nodes = append(nodes, b)
+	}
+	iotxAddr, err := address.IotxAddressToAddress(addr)
+	require.NoError(err)
+	data, err := trie.VerifyProof(stateRoot, iotxAddr.Payload(), nodes)
+	require.NoError(err)
+	require.Equal(proof.State, hex.EncodeToString(data))
+	var account state.Account
+	require.NoError(state.Deserialize(&account, data))
+	expected, err := bc.StateByAddr(addr)
+	require.NoError(err)
+	require.Equal(expected.Balance, account.Balance)
+
+	// account which has never been touched has no proof
+	untouched, err := iotxaddress.NewAddress(iotxaddress.IsTestnet, []byte{0x00, 0x00, 0x00, 0x01})
+	require.NoError(err)
+	_, err = svc.GetAccountProof(untouched.RawAddress, int64(blk.Height()))
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+	// the account doesn't exist before the block
+	_, err = svc.GetAccountProof(addr, int64(blk.Height()-1))
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+	_, err = svc.GetAccountProof(addr, int64(blk.Height()+1))
+	require.Error(err)
+	_, err = svc.GetAccountProof(addr, -1)
+	require.Error(err)
+	_, err = svc.GetAccountProof("invalid", int64(blk.Height()))
+	require.Error(err)
+}
+
+func TestExplorerGetStorageProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := config.Default
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	// the contract is deployed on height 1
+	contract := ta.Addrinfo["alfa"].RawAddress
+	pkHash, err := iotxaddress.AddressToPKHash(contract)
+	require.NoError(err)
+	key := byteutil.BytesTo32B(hash.Hash256b([]byte("key")))
+	value := byteutil.BytesTo32B(hash.Hash256b([]byte("value")))
+	for height := uint64(0); height < 2; height++ {
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		if height == 1 {
+			_, err = ws.LoadOrCreateAccountState(contract, big.NewInt(0))
+			require.NoError(err)
+			require.NoError(ws.SetCode(pkHash, []byte("contract")))
+			require.NoError(ws.SetContractState(pkHash, key, value))
+		}
+		gasLimit := testutil.TestGasLimit
+		runCtx := state.WithRunActionsCtx(ctx, state.RunActionsCtx{
+			ProducerAddr:    ta.Addrinfo["producer"].RawAddress,
+			GasLimit:        &gasLimit,
+			EnableGasCharge: testutil.EnableGasCharge,
+		})
+		_, _, err = ws.RunActions(runCtx, height, nil)
+		require.NoError(err)
+		require.NoError(sf.Commit(ws))
+	}
+	root, err := sf.RootHashByHeight(1)
+	require.NoError(err)
+	blk := &blockchain.Block{}
+	require.NoError(blk.ConvertFromBlockPb(&iproto.BlockPb{
+		Header: &iproto.BlockHeaderPb{Height: 1, StateRoot: root[:]},
+	}))
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().GetFactory().Return(sf).AnyTimes()
+	bc.EXPECT().GetBlockByHeight(uint64(1)).Return(blk, nil).AnyTimes()
+	svc := Service{bc: bc}
+
+	decode := func(strs []string) [][]byte {
+		nodes := make([][]byte, 0, len(strs))
+		for _, str := range strs {
+			node, err := hex.DecodeString(str)
+			require.NoError(err)
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	proof, err := svc.GetStorageProof(contract, hex.EncodeToString(key[:]), 1)
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(root[:]), proof.StateRoot)
+	require.Equal(hex.EncodeToString(value[:]), proof.Value)
+	// the storage root is proven by the account state, which is proven by the state root
+	data, err := trie.VerifyProof(root, pkHash[:], decode(proof.AccountProof))
+	require.NoError(err)
+	var account state.Account
+	require.NoError(state.Deserialize(&account, data))
+	require.Equal(hex.EncodeToString(account.Root[:]), proof.StorageRoot)
+	v, err := trie.VerifyProof(account.Root, key[:], decode(proof.Proof))
+	require.NoError(err)
+	require.Equal(value[:], v)
+
+	// a slot never written is proven absent
+	unset := byteutil.BytesTo32B(hash.Hash256b([]byte("unset")))
+	proof, err = svc.GetStorageProof(contract, hex.EncodeToString(unset[:]), 1)
+	require.NoError(err)
+	require.Equal("", proof.Value)
+	_, err = trie.VerifyProof(account.Root, unset[:], decode(proof.Proof))
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	_, err = svc.GetStorageProof(contract, "invalid", 1)
+	require.Error(err)
+	_, err = svc.GetStorageProof(ta.Addrinfo["bravo"].RawAddress, hex.EncodeToString(key[:]), 1)
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+}
+
+func TestExplorerGetActionStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	svc := Service{bc: bc, ap: ap}
+
+	confirmed, pending, dropped, unknown := hash.Hash32B{1}, hash.Hash32B{2}, hash.Hash32B{3}, hash.Hash32B{4}
+	notFound := errors.New("not found")
+	bc.EXPECT().GetBlockHashByActionHash(confirmed).Return(hash.ZeroHash32B, nil).Times(1)
+	for _, h := range []hash.Hash32B{pending, dropped, unknown} {
+		bc.EXPECT().GetBlockHashByActionHash(h).Return(hash.ZeroHash32B, notFound).Times(1)
+		bc.EXPECT().GetBlockHashByTransferHash(h).Return(hash.ZeroHash32B, notFound).Times(1)
+		bc.EXPECT().GetBlockHashByVoteHash(h).Return(hash.ZeroHash32B, notFound).Times(1)
+		bc.EXPECT().GetBlockHashByExecutionHash(h).Return(hash.ZeroHash32B, notFound).Times(1)
+	}
+	ap.EXPECT().GetActionByHash(pending).Return(&action.Transfer{}, nil).Times(1)
+	ap.EXPECT().GetActionByHash(dropped).Return(nil, action.ErrHash).Times(1)
+	ap.EXPECT().GetActionByHash(unknown).Return(nil, action.ErrHash).Times(1)
+	ap.EXPECT().GetDropReason(dropped).Return(actpool.DropExpired, true).Times(1)
+	ap.EXPECT().GetDropReason(unknown).Return(actpool.DropReason(""), false).Times(1)
+
+	for _, c := range []struct {
+		hash   hash.Hash32B
+		status string
+		reason string
+	}{
+		{confirmed, "confirmed", ""},
+		{pending, "pending", ""},
+		{dropped, "dropped", "expired"},
+		{unknown, "unknown", ""},
+	} {
+		hashStr := hex.EncodeToString(c.hash[:])
+		status, err := svc.GetActionStatus(hashStr)
+		require.NoError(err)
+		require.Equal(explorer.ActionStatus{Hash: hashStr, Status: c.status, DropReason: c.reason}, status)
+	}
+	_, err := svc.GetActionStatus("invalid")
+	require.Error(err)
+}
+
+func TestExplorerGetLogs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	svc := Service{bc: bc}
+
+	topic := hash.Hash32B{1}
+	topicStr := hex.EncodeToString(topic[:])
+	logs := []*action.Log{
+		{Address: "contract", Topics: []hash.Hash32B{topic}, BlockNumber: 1},
+		{Address: "contract", Topics: []hash.Hash32B{topic}, BlockNumber: 2},
+		{Address: "contract", Topics: []hash.Hash32B{topic}, BlockNumber: 3},
+	}
+	filter := &blockchain.LogFilter{Addresses: []string{"contract"}, Topics: [][]hash.Hash32B{{}, {topic}}}
+	bc.EXPECT().GetLogs(filter, uint64(1), uint64(10), uint64(3)).Return(logs, nil).Times(1)
+
+	res, err := svc.GetLogs(explorer.GetLogsRequest{
+		FromHeight: 1,
+		ToHeight:   10,
+		Addresses:  []string{"contract"},
+		Topics:     []explorer.LogTopics{{Topics: []string{}}, {Topics: []string{topicStr}}},
+		Offset:     1,
+		Limit:      2,
+	})
+	require.NoError(err)
+	require.Equal(2, len(res))
+	require.Equal(int64(2), res[0].BlockNumber)
+	require.Equal(int64(3), res[1].BlockNumber)
+	require.Equal("contract", res[0].Address)
+	require.Equal([]string{topicStr}, res[0].Topics)
+
+	_, err = svc.GetLogs(explorer.GetLogsRequest{FromHeight: 2, ToHeight: 1, Limit: 1})
+	require.Error(err)
+	_, err = svc.GetLogs(explorer.GetLogsRequest{FromHeight: 1, ToHeight: 2})
+	require.Error(err)
+	_, err = svc.GetLogs(explorer.GetLogsRequest{FromHeight: 1, ToHeight: maxLogsHeightRange + 1, Limit: 1})
+	require.Error(err)
+	_, err = svc.GetLogs(explorer.GetLogsRequest{FromHeight: 1, ToHeight: 2, Limit: maxLogsLimit + 1})
+	require.Error(err)
+	_, err = svc.GetLogs(explorer.GetLogsRequest{FromHeight: 1, ToHeight: 2, Offset: maxLogsOffset + 1, Limit: 1})
+	require.Error(err)
+	_, err = svc.GetLogs(explorer.GetLogsRequest{
+		FromHeight: 1,
+		ToHeight:   2,
+		Topics:     []explorer.LogTopics{{Topics: []string{"01"}}},
+		Limit:      1,
+	})
+	require.Error(err)
 }
 
 func TestService_CreateDeposit(t *testing.T) {
@@ -300,6 +300,61 @@ type SettleDeposit struct {
 	IsPending    bool   `json:"isPending"`
 }
 
+type ActionProof struct {
+	ActionHash   string   `json:"actionHash"`
+	BlockHeight  int64    `json:"blockHeight"`
+	BlockHash    string   `json:"blockHash"`
+	TxRoot       string   `json:"txRoot"`
+	Index        int64    `json:"index"`
+	Size         int64    `json:"size"`
+	Path         []string `json:"path"`
+	ReceiptRoot  string   `json:"receiptRoot"`
+	ReceiptIndex int64    `json:"receiptIndex"`
+	ReceiptSize  int64    `json:"receiptSize"`
+	ReceiptPath  []string `json:"receiptPath"`
+}
+
+type AccountProof struct {
+	Address     string   `json:"address"`
+	BlockHeight int64    `json:"blockHeight"`
+	BlockHash   string   `json:"blockHash"`
+	StateRoot   string   `json:"stateRoot"`
+	State       string   `json:"state"`
+	Proof       []string `json:"proof"`
+}
+
+type StorageProof struct {
+	Address      string   `json:"address"`
+	Key          string   `json:"key"`
+	BlockHeight  int64    `json:"blockHeight"`
+	BlockHash    string   `json:"blockHash"`
+	StateRoot    string   `json:"stateRoot"`
+	State        string   `json:"state"`
+	AccountProof []string `json:"accountProof"`
+	StorageRoot  string   `json:"storageRoot"`
+	Value        string   `json:"value"`
+	Proof        []string `json:"proof"`
+}
+
+type ActionStatus struct {
+	Hash       string `json:"hash"`
+	Status     string `json:"status"`
+	DropReason string `json:"dropReason"`
+}
+
+type LogTopics struct {
+	Topics []string `json:"topics"`
+}
+
+type GetLogsRequest struct {
+	FromHeight int64       `json:"fromHeight"`
+	ToHeight   int64       `json:"toHeight"`
+	Addresses  []string    `json:"addresses"`
+	Topics     []LogTopics `json:"topics"`
+	Offset     int64       `json:"offset"`
+	Limit      int64       `json:"limit"`
+}
+
 type Explorer interface {
 	GetBlockchainHeight() (int64, error)
 	GetAddressBalance(address string) (string, error)
@@ -345,6 +400,13 @@ type Explorer interface {
 	EstimateGasForTransfer(request SendTransferRequest) (int64, error)
 	EstimateGasForVote() (int64, error)
 	EstimateGasForSmartContract(request Execution) (int64, error)
+	GetActionProof(hashStr string) (ActionProof, error)
+	GetAccountProof(address string, height int64) (AccountProof, error)
+	GetStorageProof(address string, key string, height int64) (StorageProof, error)
+	GetAddressBalanceByHeight(address string, height int64) (string, error)
+	GetAddressDetailsByHeight(address string, height int64) (AddressDetails, error)
+	GetActionStatus(hashStr string) (ActionStatus, error)
+	GetLogs(request GetLogsRequest) ([]Log, error)
 }
 
 func NewExplorerProxy(c barrister.Client) Explorer {
@@ -1148,6 +1210,132 @@ func (_p ExplorerProxy) EstimateGasForSmartContract(request Execution) (int64, e
 	return int64(0), _err
 }
 
+func (_p ExplorerProxy) GetActionProof(hashStr string) (ActionProof, error) {
+	_res, _err := _p.client.Call("Explorer.getActionProof", hashStr)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getActionProof").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(ActionProof{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(ActionProof)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getActionProof returned invalid type: %v", _t)
+			return ActionProof{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return ActionProof{}, _err
+}
+
+func (_p ExplorerProxy) GetAccountProof(address string, height int64) (AccountProof, error) {
+	_res, _err := _p.client.Call("Explorer.getAccountProof", address, height)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getAccountProof").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(AccountProof{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(AccountProof)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getAccountProof returned invalid type: %v", _t)
+			return AccountProof{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return AccountProof{}, _err
+}
+
+func (_p ExplorerProxy) GetStorageProof(address string, key string, height int64) (StorageProof, error) {
+	_res, _err := _p.client.Call("Explorer.getStorageProof", address, key, height)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getStorageProof").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(StorageProof{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(StorageProof)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getStorageProof returned invalid type: %v", _t)
+			return StorageProof{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return StorageProof{}, _err
+}
+
+func (_p ExplorerProxy) GetAddressBalanceByHeight(address string, height int64) (string, error) {
+	_res, _err := _p.client.Call("Explorer.getAddressBalanceByHeight", address, height)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getAddressBalanceByHeight").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(""), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(string)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getAddressBalanceByHeight returned invalid type: %v", _t)
+			return "", &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return "", _err
+}
+
+func (_p ExplorerProxy) GetAddressDetailsByHeight(address string, height int64) (AddressDetails, error) {
+	_res, _err := _p.client.Call("Explorer.getAddressDetailsByHeight", address, height)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getAddressDetailsByHeight").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(AddressDetails{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(AddressDetails)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getAddressDetailsByHeight returned invalid type: %v", _t)
+			return AddressDetails{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return AddressDetails{}, _err
+}
+
+func (_p ExplorerProxy) GetActionStatus(hashStr string) (ActionStatus, error) {
+	_res, _err := _p.client.Call("Explorer.getActionStatus", hashStr)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getActionStatus").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf(ActionStatus{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.(ActionStatus)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getActionStatus returned invalid type: %v", _t)
+			return ActionStatus{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return ActionStatus{}, _err
+}
+
+func (_p ExplorerProxy) GetLogs(request GetLogsRequest) ([]Log, error) {
+	_res, _err := _p.client.Call("Explorer.getLogs", request)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getLogs").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]Log{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]Log)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getLogs returned invalid type: %v", _t)
+			return []Log{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []Log{}, _err
+}
+
 func NewJSONServer(idl *barrister.Idl, forceASCII bool, explorer Explorer) barrister.Server {
 	return NewServer(idl, &barrister.JsonSerializer{forceASCII}, explorer)
 }
@@ -3004,160 +3192,503 @@ var IdlJsonRaw = `[
         "checksum": ""
     },
     {
-        "type": "interface",
-        "name": "Explorer",
+        "type": "struct",
+        "name": "ActionProof",
         "comment": "",
         "value": "",
         "extends": "",
-        "fields": null,
-        "values": null,
-        "functions": [
+        "fields": [
             {
-                "name": "getBlockchainHeight",
-                "comment": "get the blockchain tip height",
-                "params": [],
-                "returns": {
-                    "name": "",
-                    "type": "int",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
+                "name": "actionHash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             },
             {
-                "name": "getAddressBalance",
-                "comment": "get the balance of an address",
-                "params": [
-                    {
-                        "name": "address",
-                        "type": "string",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    }
-                ],
-                "returns": {
-                    "name": "",
-                    "type": "string",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
+                "name": "blockHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             },
             {
-                "name": "getAddressDetails",
-                "comment": "get the address detail of an iotex address",
-                "params": [
-                    {
-                        "name": "address",
-                        "type": "string",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    }
-                ],
-                "returns": {
-                    "name": "",
-                    "type": "AddressDetails",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
+                "name": "blockHash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             },
             {
-                "name": "getLastTransfersByRange",
-                "comment": "get list of transfers by start block height, transfer offset and limit",
-                "params": [
-                    {
-                        "name": "startBlockHeight",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "offset",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "limit",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "showCoinBase",
-                        "type": "bool",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    }
-                ],
-                "returns": {
-                    "name": "",
-                    "type": "Transfer",
-                    "optional": false,
-                    "is_array": true,
-                    "comment": ""
-                }
+                "name": "txRoot",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             },
             {
-                "name": "getTransferByID",
-                "comment": "get transfers from transaction id",
-                "params": [
-                    {
-                        "name": "transferID",
-                        "type": "string",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    }
-                ],
-                "returns": {
-                    "name": "",
-                    "type": "Transfer",
-                    "optional": false,
-                    "is_array": false,
-                    "comment": ""
-                }
+                "name": "index",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
             },
             {
-                "name": "getTransfersByAddress",
-                "comment": "get list of transfers belonging to an address",
-                "params": [
-                    {
-                        "name": "address",
-                        "type": "string",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "offset",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    },
-                    {
-                        "name": "limit",
-                        "type": "int",
-                        "optional": false,
-                        "is_array": false,
-                        "comment": ""
-                    }
-                ],
-                "returns": {
-                    "name": "",
-                    "type": "Transfer",
-                    "optional": false,
-                    "is_array": true,
-                    "comment": ""
-                }
+                "name": "size",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "path",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "receiptRoot",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "receiptIndex",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "receiptSize",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "receiptPath",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "AccountProof",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockHash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "stateRoot",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "state",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "proof",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "StorageProof",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "key",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "blockHash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "stateRoot",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "state",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "accountProof",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "storageRoot",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "value",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "proof",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "ActionStatus",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "hash",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "status",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "dropReason",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "LogTopics",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "topics",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "GetLogsRequest",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "fromHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "toHeight",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "addresses",
+                "type": "string",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "topics",
+                "type": "LogTopics",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            },
+            {
+                "name": "offset",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "limit",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "interface",
+        "name": "Explorer",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": null,
+        "values": null,
+        "functions": [
+            {
+                "name": "getBlockchainHeight",
+                "comment": "get the blockchain tip height",
+                "params": [],
+                "returns": {
+                    "name": "",
+                    "type": "int",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressBalance",
+                "comment": "get the balance of an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "string",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressDetails",
+                "comment": "get the address detail of an iotex address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "AddressDetails",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLastTransfersByRange",
+                "comment": "get list of transfers by start block height, transfer offset and limit",
+                "params": [
+                    {
+                        "name": "startBlockHeight",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "showCoinBase",
+                        "type": "bool",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransferByID",
+                "comment": "get transfers from transaction id",
+                "params": [
+                    {
+                        "name": "transferID",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getTransfersByAddress",
+                "comment": "get list of transfers belonging to an address",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "offset",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "limit",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Transfer",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             },
             {
                 "name": "getUnconfirmedTransfersByAddress",
@@ -4059,6 +4590,181 @@ var IdlJsonRaw = `[
                     "is_array": false,
                     "comment": ""
                 }
+            },
+            {
+                "name": "getActionProof",
+                "comment": "get the merkle proof of an action and its receipt in the block",
+                "params": [
+                    {
+                        "name": "hashStr",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "ActionProof",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAccountProof",
+                "comment": "get the trie proof of an account state against the state root of the block at a height",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "height",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "AccountProof",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getStorageProof",
+                "comment": "get the trie proof of a contract storage slot against the storage root of the contract at a block height",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "key",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "height",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "StorageProof",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressBalanceByHeight",
+                "comment": "get the balance of an address at a block height",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "height",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "string",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getAddressDetailsByHeight",
+                "comment": "get the address detail of an iotex address at a block height",
+                "params": [
+                    {
+                        "name": "address",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "height",
+                        "type": "int",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "AddressDetails",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getActionStatus",
+                "comment": "get whether an action is pending in actpool, dropped from actpool or confirmed, and why it's dropped",
+                "params": [
+                    {
+                        "name": "hashStr",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "ActionStatus",
+                    "optional": false,
+                    "is_array": false,
+                    "comment": ""
+                }
+            },
+            {
+                "name": "getLogs",
+                "comment": "get the contract logs matching the addresses and topics in the blocks between two heights",
+                "params": [
+                    {
+                        "name": "request",
+                        "type": "GetLogsRequest",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "Log",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
             }
         ],
         "barrister_version": "",
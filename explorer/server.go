@@ -23,6 +23,7 @@ import (
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/indexservice"
+	"github.com/iotexproject/iotex-core/lightclient"
 	"github.com/iotexproject/iotex-core/logger"
 	"github.com/iotexproject/iotex-core/network"
 )
@@ -34,6 +35,12 @@ type Server struct {
 	jrpcSvr barrister.Server
 	httpSvr http.Server
 	port    int
+	// stream pushes the chain events to the subscribers if the stream port is set
+	stream *StreamService
+	// api serves the public gRPC API if the api port is set
+	api *APIService
+	// eth serves the Ethereum compatible JSON-RPC API if the eth port is set
+	eth *EthService
 }
 
 // NewServer instantiates an explorer server
@@ -46,18 +53,34 @@ func NewServer(
 	p2p network.Overlay,
 	idx *indexservice.Server,
 ) *Server {
-	return &Server{
+	svc := &Service{
+		bc:  chain,
+		c:   consensus,
+		dp:  dispatcher,
+		ap:  actPool,
+		p2p: p2p,
 		cfg: cfg,
-		exp: &Service{
-			bc:  chain,
-			c:   consensus,
-			dp:  dispatcher,
-			ap:  actPool,
-			p2p: p2p,
-			cfg: cfg,
-			idx: idx,
-			gs:  GasStation{bc: chain, cfg: cfg},
-		},
+		idx: idx,
+		gs:  GasStation{bc: chain, cfg: cfg},
+	}
+	var stream *StreamService
+	if cfg.StreamPort > 0 {
+		stream = NewStreamService(cfg.StreamPort, chain, actPool)
+	}
+	var api *APIService
+	if cfg.APIPort > 0 {
+		api = NewAPIService(cfg.APIPort, cfg.APIGatewayPort, svc)
+	}
+	var eth *EthService
+	if cfg.EthPort > 0 {
+		eth = NewEthService(cfg.EthPort, svc)
+	}
+	return &Server{
+		cfg:    cfg,
+		stream: stream,
+		api:    api,
+		eth:    eth,
+		exp:    svc,
 	}
 }
 
@@ -70,8 +93,17 @@ func (s *Server) SetMainChainProtocol(p *mainchain.Protocol) {
 	svr.SetMainChainProtocol(p)
 }
 
+// SetLightClient sets the light client answering the queries of the latest states
+func (s *Server) SetLightClient(lc lightclient.LightClient) {
+	svr, ok := s.exp.(*Service)
+	if !ok {
+		return
+	}
+	svr.SetLightClient(lc)
+}
+
 // Start starts the explorer server
-func (s *Server) Start(_ context.Context) error {
+func (s *Server) Start(ctx context.Context) error {
 	portStr := strconv.Itoa(s.cfg.Port)
 	started := make(chan bool)
 	go func(started chan bool) {
@@ -98,11 +130,41 @@ func (s *Server) Start(_ context.Context) error {
 		}
 	}(started)
 	<-started
+	if s.stream != nil {
+		if err := s.stream.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting explorer streaming service")
+		}
+	}
+	if s.api != nil {
+		if err := s.api.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting API service")
+		}
+	}
+	if s.eth != nil {
+		if err := s.eth.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting Ethereum JSON-RPC service")
+		}
+	}
 	return nil
 }
 
 // Stop stops the explorer server
 func (s *Server) Stop(ctx context.Context) error {
+	if s.eth != nil {
+		if err := s.eth.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping Ethereum JSON-RPC service")
+		}
+	}
+	if s.api != nil {
+		if err := s.api.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping API service")
+		}
+	}
+	if s.stream != nil {
+		if err := s.stream.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping explorer streaming service")
+		}
+	}
 	if err := s.httpSvr.Shutdown(ctx); err != nil {
 		return errors.Wrap(err, "error when shutting down explorer http server")
 	}
@@ -117,6 +179,15 @@ func (s *Server) Port() int {
 // Explorer returns explorer interface.
 func (s *Server) Explorer() explorer.Explorer { return s.exp }
 
+// Stream returns the streaming service, or nil if it's disabled
+func (s *Server) Stream() *StreamService { return s.stream }
+
+// API returns the API service, or nil if it's disabled
+func (s *Server) API() *APIService { return s.api }
+
+// Eth returns the Ethereum compatible JSON-RPC service, or nil if it's disabled
+func (s *Server) Eth() *EthService { return s.eth }
+
 // logFilter example of Filter implementation
 type logFilter struct{}
 
@@ -0,0 +1,320 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	streampb "github.com/iotexproject/iotex-core/explorer/proto"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// subscriptionBufferSize is the number of events buffered for a subscriber. A subscriber falling further behind is
+// dropped, and should resume from the height following the last block it has received
+const subscriptionBufferSize = 256
+
+var (
+	_ streampb.StreamServer              = (*StreamService)(nil)
+	_ blockchain.BlockCreationSubscriber = (*StreamService)(nil)
+	_ actpool.PendingActionSubscriber    = (*StreamService)(nil)
+)
+
+// StreamService streams the new blocks, the actions accepted into the actpool and the contract logs to the
+// subscribers over gRPC. The block and log streams resume from a given height, so that a subscriber reconnecting after
+// a disconnect doesn't miss any block
+type StreamService struct {
+	port    int
+	bc      blockchain.Blockchain
+	ap      actpool.ActPool
+	grpcSvr *grpc.Server
+
+	mutex sync.RWMutex
+	subs  map[*subscription]struct{}
+}
+
+// subscription buffers the blocks or the actions to be sent to a subscriber
+type subscription struct {
+	blocks chan *blockchain.Block
+	acts   chan action.Action
+	// dropped is closed once the buffer overflows
+	dropped chan struct{}
+	once    sync.Once
+}
+
+func (sub *subscription) drop() { sub.once.Do(func() { close(sub.dropped) }) }
+
+// NewStreamService creates a streaming service listening on the port
+func NewStreamService(port int, bc blockchain.Blockchain, ap actpool.ActPool) *StreamService {
+	return &StreamService{
+		port: port,
+		bc:   bc,
+		ap:   ap,
+		subs: make(map[*subscription]struct{}),
+	}
+}
+
+// Start subscribes to the chain and the actpool, and starts serving the streaming requests
+func (s *StreamService) Start(_ context.Context) error {
+	if err := s.bc.AddSubscriber(s); err != nil {
+		return errors.Wrap(err, "error when subscribing to new blocks")
+	}
+	if err := s.ap.AddSubscriber(s); err != nil {
+		return errors.Wrap(err, "error when subscribing to pending actions")
+	}
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(s.port))
+	if err != nil {
+		return errors.Wrap(err, "error when creating network listener")
+	}
+	s.port = listener.Addr().(*net.TCPAddr).Port
+	s.grpcSvr = grpc.NewServer()
+	streampb.RegisterStreamServer(s.grpcSvr, s)
+	logger.Info().Msgf("Starting Explorer streaming server on %s", listener.Addr().String())
+	go func() {
+		if err := s.grpcSvr.Serve(listener); err != nil {
+			logger.Error().Err(err).Msg("error when serving streaming requests")
+		}
+	}()
+	return nil
+}
+
+// Stop stops serving, which closes all the streams, and unsubscribes from the chain and the actpool
+func (s *StreamService) Stop(_ context.Context) error {
+	if s.grpcSvr != nil {
+		s.grpcSvr.Stop()
+	}
+	if err := s.ap.RemoveSubscriber(s); err != nil {
+		return errors.Wrap(err, "error when unsubscribing from pending actions")
+	}
+	if err := s.bc.RemoveSubscriber(s); err != nil {
+		return errors.Wrap(err, "error when unsubscribing from new blocks")
+	}
+	return nil
+}
+
+// Port returns the actually binding port
+func (s *StreamService) Port() int { return s.port }
+
+// HandleBlock implements interface BlockCreationSubscriber
+func (s *StreamService) HandleBlock(blk *blockchain.Block) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for sub := range s.subs {
+		if sub.blocks == nil {
+			continue
+		}
+		select {
+		case sub.blocks <- blk:
+		default:
+			sub.drop()
+		}
+	}
+	return nil
+}
+
+// HandlePendingAction implements interface PendingActionSubscriber
+func (s *StreamService) HandlePendingAction(act action.Action) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for sub := range s.subs {
+		if sub.acts == nil {
+			continue
+		}
+		select {
+		case sub.acts <- act:
+		default:
+			sub.drop()
+		}
+	}
+	return nil
+}
+
+// SubscribeBlocks streams the headers of the blocks from the start height, followed by the new blocks
+func (s *StreamService) SubscribeBlocks(
+	req *streampb.BlocksRequest,
+	stream streampb.Stream_SubscribeBlocksServer,
+) error {
+	return s.streamBlocks(stream.Context(), req.StartHeight, func(blk *blockchain.Block) error {
+		blkHash := blk.HashBlock()
+		return stream.Send(&streampb.BlockEvent{Hash: blkHash[:], Header: blk.ConvertToBlockHeaderPb()})
+	})
+}
+
+// SubscribePendingActions streams the actions accepted into the actpool, which are sent or received by the given
+// addresses
+func (s *StreamService) SubscribePendingActions(
+	req *streampb.PendingActionsRequest,
+	stream streampb.Stream_SubscribePendingActionsServer,
+) error {
+	sub := &subscription{
+		acts:    make(chan action.Action, subscriptionBufferSize),
+		dropped: make(chan struct{}),
+	}
+	s.subscribe(sub)
+	defer s.unsubscribe(sub)
+	for {
+		select {
+		case act := <-sub.acts:
+			if len(req.Addresses) > 0 && !contains(req.Addresses, act.SrcAddr()) &&
+				!contains(req.Addresses, act.DstAddr()) {
+				continue
+			}
+			if err := stream.Send(act.Proto()); err != nil {
+				return err
+			}
+		case <-sub.dropped:
+			return status.Error(codes.ResourceExhausted, "subscriber falls behind")
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// SubscribeLogs streams the contract logs matching the filter from the start height, followed by the ones in the new
+// blocks
+func (s *StreamService) SubscribeLogs(req *streampb.LogsRequest, stream streampb.Stream_SubscribeLogsServer) error {
+	filter, err := newLogFilter(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return s.streamBlocks(stream.Context(), req.StartHeight, func(blk *blockchain.Block) error {
+		receipts, err := s.receipts(blk)
+		if err != nil {
+			return err
+		}
+		for _, receipt := range receipts {
+			for _, log := range receipt.Logs {
+				if !filter.Match(log) {
+					continue
+				}
+				if err := stream.Send(log.ConvertToLogPb()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// streamBlocks sends the blocks from the start height, or the new blocks if it's 0, in the order of height. A block
+// missed by the subscription, e.g., the one emitted before the subscription or out of order, is read from the chain.
+// On the switch to another branch, the blocks of the new branch are sent from the height following the last sent one,
+// so a subscriber finding a block not linked to the last one should resume from the fork height
+func (s *StreamService) streamBlocks(ctx context.Context, start uint64, send func(*blockchain.Block) error) error {
+	sub := &subscription{
+		blocks:  make(chan *blockchain.Block, subscriptionBufferSize),
+		dropped: make(chan struct{}),
+	}
+	s.subscribe(sub)
+	defer s.unsubscribe(sub)
+
+	next := start
+	if next == 0 {
+		next = s.bc.TipHeight() + 1
+	}
+	sendUntil := func(height uint64) error {
+		for ; next <= height; next++ {
+			blk, err := s.bc.GetBlockByHeight(next)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get block on height %d", next)
+			}
+			if err := send(blk); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := sendUntil(s.bc.TipHeight()); err != nil {
+		return err
+	}
+	for {
+		select {
+		case blk := <-sub.blocks:
+			if blk.Height() < next {
+				continue
+			}
+			if err := sendUntil(blk.Height() - 1); err != nil {
+				return err
+			}
+			if err := send(blk); err != nil {
+				return err
+			}
+			next = blk.Height() + 1
+		case <-sub.dropped:
+			return status.Errorf(codes.ResourceExhausted, "subscriber falls behind, resume from height %d", next)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// receipts returns the receipts of a block in the order of the actions, which are read from the chain if the block
+// doesn't carry them
+func (s *StreamService) receipts(blk *blockchain.Block) ([]*action.Receipt, error) {
+	if receipts := blk.Receipts(); len(receipts) > 0 {
+		return receipts, nil
+	}
+	_, _, executions := action.ClassifyActions(blk.Actions)
+	receipts := make([]*action.Receipt, 0, len(executions))
+	for _, execution := range executions {
+		receipt, err := s.bc.GetReceiptByExecutionHash(execution.Hash())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get receipt of execution %x", execution.Hash())
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+func (s *StreamService) subscribe(sub *subscription) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.subs[sub] = struct{}{}
+}
+
+func (s *StreamService) unsubscribe(sub *subscription) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.subs, sub)
+}
+
+// newLogFilter converts the log filter in the request
+func newLogFilter(req *streampb.LogsRequest) (*blockchain.LogFilter, error) {
+	filter := &blockchain.LogFilter{Addresses: req.Addresses}
+	for _, topicsPb := range req.Topics {
+		topics := make([]hash.Hash32B, 0, len(topicsPb.Topics))
+		for _, topic := range topicsPb.Topics {
+			if len(topic) != len(hash.ZeroHash32B) {
+				return nil, errors.Errorf("invalid topic %x", topic)
+			}
+			topics = append(topics, byteutil.BytesTo32B(topic))
+		}
+		filter.Topics = append(filter.Topics, topics)
+	}
+	return filter, nil
+}
+
+func contains(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,324 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	streampb "github.com/iotexproject/iotex-core/explorer/proto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestStreamService_SubscribeBlocks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blks := newTestBlocks(5)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().TipHeight().Return(uint64(2)).Times(1)
+	for h := uint64(1); h <= 3; h++ {
+		bc.EXPECT().GetBlockByHeight(h).Return(blks[h], nil).Times(1)
+	}
+	s := NewStreamService(0, bc, mock_actpool.NewMockActPool(ctrl))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testBlockStream{ctx: ctx, events: make(chan *streampb.BlockEvent, 10)}
+	done := make(chan error)
+	go func() {
+		done <- s.SubscribeBlocks(&streampb.BlocksRequest{StartHeight: 1}, stream)
+	}()
+	// the blocks from the start height to the tip are read from the chain
+	requireBlockEvents(t, stream.events, blks[1], blks[2])
+
+	// block 3 missed by the subscription is read from the chain
+	require.NoError(s.HandleBlock(blks[4]))
+	requireBlockEvents(t, stream.events, blks[3], blks[4])
+	// a block already sent is skipped
+	require.NoError(s.HandleBlock(blks[3]))
+	require.NoError(s.HandleBlock(blks[5]))
+	requireBlockEvents(t, stream.events, blks[5])
+
+	cancel()
+	require.NoError(<-done)
+	require.Equal(0, len(s.subs))
+}
+
+func TestStreamService_Dropped(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blks := newTestBlocks(subscriptionBufferSize + 2)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().TipHeight().Return(uint64(0)).Times(2)
+	s := NewStreamService(0, bc, mock_actpool.NewMockActPool(ctrl))
+
+	// the stream is blocked, so the buffered blocks overflow
+	stream := &testBlockStream{ctx: context.Background(), events: make(chan *streampb.BlockEvent)}
+	done := make(chan error)
+	go func() {
+		done <- s.SubscribeBlocks(&streampb.BlocksRequest{}, stream)
+	}()
+	waitSubscribed(t, s)
+	for _, blk := range blks[1:] {
+		require.NoError(s.HandleBlock(blk))
+	}
+	var last uint64
+	for {
+		select {
+		case event := <-stream.events:
+			require.Equal(last+1, event.Header.Height)
+			last = event.Header.Height
+		case err := <-done:
+			require.Equal(codes.ResourceExhausted, status.Code(err))
+			require.Contains(err.Error(), fmt.Sprintf("resume from height %d", last+1))
+			require.True(last < uint64(len(blks)-1))
+			return
+		}
+	}
+}
+
+func TestStreamService_SubscribePendingActions(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := NewStreamService(0, mock_blockchain.NewMockBlockchain(ctrl), mock_actpool.NewMockActPool(ctrl))
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testActionStream{ctx: ctx, acts: make(chan *pb.ActionPb, 10)}
+	done := make(chan error)
+	go func() {
+		done <- s.SubscribePendingActions(&streampb.PendingActionsRequest{
+			Addresses: []string{ta.Addrinfo["charlie"].RawAddress},
+		}, stream)
+	}()
+	waitSubscribed(t, s)
+
+	tsf1, err := action.NewTransfer(1, big.NewInt(10), ta.Addrinfo["producer"].RawAddress,
+		ta.Addrinfo["charlie"].RawAddress, []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	tsf2, err := action.NewTransfer(1, big.NewInt(10), ta.Addrinfo["producer"].RawAddress,
+		ta.Addrinfo["alfa"].RawAddress, []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	tsf3, err := action.NewTransfer(1, big.NewInt(10), ta.Addrinfo["charlie"].RawAddress,
+		ta.Addrinfo["alfa"].RawAddress, []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	require.NoError(s.HandlePendingAction(tsf1))
+	require.NoError(s.HandlePendingAction(tsf2))
+	require.NoError(s.HandlePendingAction(tsf3))
+	// the action not sent or received by the address is filtered out
+	for _, act := range []action.Action{tsf1, tsf3} {
+		select {
+		case actPb := <-stream.acts:
+			require.Equal(act.Proto(), actPb)
+		case <-time.After(time.Second):
+			require.FailNow("no pending action is streamed")
+		}
+	}
+
+	cancel()
+	require.NoError(<-done)
+	require.Equal(0, len(stream.acts))
+}
+
+func TestStreamService_SubscribeLogs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	contract := ta.Addrinfo["delta"].RawAddress
+	topicA := hash.Hash32B{1}
+	topicB := hash.Hash32B{2}
+	newExecution := func(nonce uint64) *action.Execution {
+		ex, err := action.NewExecution(ta.Addrinfo["producer"].RawAddress, contract, nonce, big.NewInt(0),
+			testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice), []byte{})
+		require.NoError(err)
+		return ex
+	}
+	ex1 := newExecution(1)
+	ex2 := newExecution(2)
+	blk1 := blockchain.NewBlock(config.Default.Chain.ID, 1, hash.ZeroHash32B, 1, ta.Addrinfo["producer"].PublicKey,
+		[]action.Action{ex1})
+	blk2 := blockchain.NewBlock(config.Default.Chain.ID, 2, blk1.HashBlock(), 2, ta.Addrinfo["producer"].PublicKey,
+		[]action.Action{ex2})
+	receipt1 := &action.Receipt{Hash: ex1.Hash(), Logs: []*action.Log{
+		{Address: contract, Topics: []hash.Hash32B{topicA}, BlockNumber: 1, Index: 0},
+		{Address: contract, Topics: []hash.Hash32B{topicB}, BlockNumber: 1, Index: 1},
+		{Address: ta.Addrinfo["echo"].RawAddress, Topics: []hash.Hash32B{topicA}, BlockNumber: 1, Index: 2},
+	}}
+	receipt2 := &action.Receipt{Hash: ex2.Hash(), Logs: []*action.Log{
+		{Address: contract, Topics: []hash.Hash32B{topicA, topicB}, BlockNumber: 2, Index: 0},
+		{Address: contract, BlockNumber: 2, Index: 1},
+	}}
+	// the live block carries the receipts
+	blk2.SetReceipts([]*action.Receipt{receipt2})
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().TipHeight().Return(uint64(1)).Times(1)
+	bc.EXPECT().GetBlockByHeight(uint64(1)).Return(blk1, nil).Times(1)
+	bc.EXPECT().GetReceiptByExecutionHash(ex1.Hash()).Return(receipt1, nil).Times(1)
+	s := NewStreamService(0, bc, mock_actpool.NewMockActPool(ctrl))
+
+	// invalid topic
+	err := s.SubscribeLogs(&streampb.LogsRequest{
+		Topics: []*streampb.Topics{{Topics: [][]byte{{1, 2, 3}}}},
+	}, &testLogStream{ctx: context.Background()})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testLogStream{ctx: ctx, logs: make(chan *pb.LogPb, 10)}
+	done := make(chan error)
+	go func() {
+		done <- s.SubscribeLogs(&streampb.LogsRequest{
+			StartHeight: 1,
+			Addresses:   []string{contract},
+			Topics:      []*streampb.Topics{{Topics: [][]byte{topicA[:]}}},
+		}, stream)
+	}()
+	requireLogs(t, stream.logs, receipt1.Logs[0])
+	require.NoError(s.HandleBlock(blk2))
+	requireLogs(t, stream.logs, receipt2.Logs[0])
+
+	cancel()
+	require.NoError(<-done)
+	require.Equal(0, len(stream.logs))
+}
+
+func TestLogFilter(t *testing.T) {
+	require := require.New(t)
+
+	topicA := hash.Hash32B{1}
+	topicB := hash.Hash32B{2}
+	topicC := hash.Hash32B{3}
+	log := &action.Log{Address: "contract", Topics: []hash.Hash32B{topicA, topicB}}
+	newFilter := func(addrs []string, topics ...[]hash.Hash32B) *blockchain.LogFilter {
+		req := &streampb.LogsRequest{Addresses: addrs}
+		for _, ts := range topics {
+			topicsPb := &streampb.Topics{}
+			for i := range ts {
+				topicsPb.Topics = append(topicsPb.Topics, ts[i][:])
+			}
+			req.Topics = append(req.Topics, topicsPb)
+		}
+		filter, err := newLogFilter(req)
+		require.NoError(err)
+		return filter
+	}
+	require.True(newFilter(nil).Match(log))
+	require.True(newFilter([]string{"another", "contract"}).Match(log))
+	require.False(newFilter([]string{"another"}).Match(log))
+	// any topic at a position matches
+	require.True(newFilter(nil, []hash.Hash32B{topicC, topicA}).Match(log))
+	require.False(newFilter(nil, []hash.Hash32B{topicB}).Match(log))
+	// empty topics at a position match any topic
+	require.True(newFilter(nil, nil, []hash.Hash32B{topicB}).Match(log))
+	require.False(newFilter(nil, nil, []hash.Hash32B{topicC}).Match(log))
+	// more positions than the topics of the log
+	require.False(newFilter(nil, nil, nil, nil).Match(log))
+}
+
+type testBlockStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *streampb.BlockEvent
+}
+
+func (s *testBlockStream) Context() context.Context { return s.ctx }
+
+func (s *testBlockStream) Send(event *streampb.BlockEvent) error {
+	s.events <- event
+	return nil
+}
+
+type testActionStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	acts chan *pb.ActionPb
+}
+
+func (s *testActionStream) Context() context.Context { return s.ctx }
+
+func (s *testActionStream) Send(act *pb.ActionPb) error {
+	s.acts <- act
+	return nil
+}
+
+type testLogStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	logs chan *pb.LogPb
+}
+
+func (s *testLogStream) Context() context.Context { return s.ctx }
+
+func (s *testLogStream) Send(log *pb.LogPb) error {
+	s.logs <- log
+	return nil
+}
+
+// newTestBlocks creates the chain of the blocks from height 0 to the given height
+func newTestBlocks(height uint64) []*blockchain.Block {
+	blks := make([]*blockchain.Block, 0, height+1)
+	prevHash := hash.ZeroHash32B
+	for h := uint64(0); h <= height; h++ {
+		blk := blockchain.NewBlock(config.Default.Chain.ID, h, prevHash, h, ta.Addrinfo["producer"].PublicKey, nil)
+		blks = append(blks, blk)
+		prevHash = blk.HashBlock()
+	}
+	return blks
+}
+
+func requireBlockEvents(t *testing.T, events chan *streampb.BlockEvent, blks ...*blockchain.Block) {
+	for _, blk := range blks {
+		select {
+		case event := <-events:
+			require.Equal(t, blk.HashBlock(), byteutil.BytesTo32B(event.Hash))
+			require.Equal(t, blk.Height(), event.Header.Height)
+		case <-time.After(time.Second):
+			require.FailNow(t, "no block is streamed", "expecting block %d", blk.Height())
+		}
+	}
+}
+
+func requireLogs(t *testing.T, logs chan *pb.LogPb, expected ...*action.Log) {
+	for _, log := range expected {
+		select {
+		case logPb := <-logs:
+			require.Equal(t, log.ConvertToLogPb(), logPb)
+		case <-time.After(time.Second):
+			require.FailNow(t, "no log is streamed")
+		}
+	}
+}
+
+func waitSubscribed(t *testing.T, s *StreamService) {
+	require.NoError(t, testutil.WaitUntil(10*time.Millisecond, time.Second, func() (bool, error) {
+		s.mutex.RLock()
+		defer s.mutex.RUnlock()
+		return len(s.subs) == 1, nil
+	}))
+}
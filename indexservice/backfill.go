@@ -0,0 +1,266 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package indexservice
+
+import (
+	"database/sql"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// The modes in which the blocks are indexed
+const (
+	backfillMode = "backfill"
+	liveMode     = "live"
+	reindexMode  = "reindex"
+)
+
+var (
+	indexHeightMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_indexer_height",
+			Help: "The height indexed by the index service and the tip height it catches up with",
+		},
+		[]string{"type"},
+	)
+	indexedBlocksMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_indexer_blocks",
+			Help: "Blocks indexed by the index service",
+		},
+		[]string{"mode"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(indexHeightMtc)
+	prometheus.MustRegister(indexedBlocksMtc)
+}
+
+// GetIndexHeight returns the height of the last indexed block, which is ErrNotExist if no block has been indexed
+func (idx *Indexer) GetIndexHeight() (uint64, error) {
+	var height uint64
+	err := idx.rds.GetDB().QueryRow(
+		idx.rds.Dialect().Rebind("SELECT height FROM index_height WHERE node_address=?"),
+		idx.hexEncodedNodeAddr,
+	).Scan(&height)
+	if err == sql.ErrNoRows {
+		return 0, ErrNotExist
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get index height")
+	}
+	return height, nil
+}
+
+// UpdateIndexHeight raises the height of the last indexed block in the transaction. A lower height, e.g., the one of a
+// reindexed block, leaves it unchanged
+func (idx *Indexer) UpdateIndexHeight(height uint64, tx *sql.Tx) error {
+	var current uint64
+	err := tx.QueryRow(
+		idx.rds.Dialect().Rebind("SELECT height FROM index_height WHERE node_address=?"),
+		idx.hexEncodedNodeAddr,
+	).Scan(&current)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(
+			idx.rds.Dialect().Rebind("INSERT INTO index_height (node_address, height) VALUES (?, ?)"),
+			idx.hexEncodedNodeAddr,
+			height,
+		)
+	case err == nil && height > current:
+		_, err = tx.Exec(
+			idx.rds.Dialect().Rebind("UPDATE index_height SET height=? WHERE node_address=?"),
+			height,
+			idx.hexEncodedNodeAddr,
+		)
+	}
+	return errors.Wrap(err, "failed to update index height")
+}
+
+// Backfill indexes the blocks from the one following the last indexed, or the genesis block, to the tip in batches,
+// each in a transaction, and then switches to live mode. It's resumable as the index height is updated along with each
+// batch. The mutex isn't held while indexing a batch, so that the blocks arriving meanwhile aren't blocked. It returns
+// early once stop is closed
+func (idx *Indexer) Backfill(batchSize uint64, stop <-chan struct{}) error {
+	if batchSize == 0 {
+		batchSize = 1
+	}
+	idx.mutex.Lock()
+	height, err := idx.GetIndexHeight()
+	switch {
+	case err == nil:
+		idx.next = height + 1
+	case errors.Cause(err) == ErrNotExist:
+		idx.next = 0
+	default:
+		idx.mutex.Unlock()
+		return err
+	}
+	idx.mutex.Unlock()
+	logger.Info().Uint64("height", idx.next).Msg("Index service starts backfill")
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+		idx.mutex.Lock()
+		target := idx.bc.TipHeight()
+		if idx.pending > target {
+			target = idx.pending
+		}
+		indexHeightMtc.WithLabelValues("tip").Set(float64(target))
+		if idx.next > target {
+			idx.live = true
+			idx.mutex.Unlock()
+			logger.Info().Uint64("height", target).Msg("Index service switches to live mode")
+			return nil
+		}
+		start := idx.next
+		idx.mutex.Unlock()
+
+		// no other one indexes the blocks following the index height before switching to live mode
+		end := start + batchSize - 1
+		if end > target {
+			end = target
+		}
+		if err := idx.indexRange(start, end); err != nil {
+			return err
+		}
+		idx.mutex.Lock()
+		idx.indexed(end, end-start+1, backfillMode)
+		idx.mutex.Unlock()
+	}
+}
+
+// Reindex rebuilds the index of the blocks from startHeight to endHeight in batches, replacing the existing one. Only
+// the blocks already indexed could be reindexed, so that the backfill and the live indexing never index the same
+// blocks. It returns early once stop is closed
+func (idx *Indexer) Reindex(startHeight, endHeight, batchSize uint64, stop <-chan struct{}) error {
+	if startHeight > endHeight {
+		return errors.Errorf("start height %d is higher than end height %d", startHeight, endHeight)
+	}
+	if tipHeight := idx.bc.TipHeight(); endHeight > tipHeight {
+		return errors.Errorf("end height %d is higher than tip height %d", endHeight, tipHeight)
+	}
+	idx.mutex.Lock()
+	next := idx.next
+	idx.mutex.Unlock()
+	if endHeight >= next {
+		return errors.Errorf("end height %d isn't indexed yet", endHeight)
+	}
+	if batchSize == 0 {
+		batchSize = 1
+	}
+	for start := startHeight; start <= endHeight; start += batchSize {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+		end := start + batchSize - 1
+		if end > endHeight || end < start {
+			end = endHeight
+		}
+		if err := idx.indexRange(start, end); err != nil {
+			return err
+		}
+		idx.mutex.Lock()
+		idx.indexed(end, end-start+1, reindexMode)
+		idx.mutex.Unlock()
+		logger.Info().Uint64("start", start).Uint64("end", end).Msg("Index service reindexed blocks")
+	}
+	return nil
+}
+
+// indexRange indexes the blocks from start to end read from the chain in a transaction. The caller should record the
+// progress with indexed
+func (idx *Indexer) indexRange(start, end uint64) error {
+	blks := make([]*blockchain.Block, 0, end-start+1)
+	for height := start; height <= end; height++ {
+		blk, err := idx.bc.GetBlockByHeight(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block on height %d", height)
+		}
+		blks = append(blks, blk)
+	}
+	return idx.rds.Transact(func(tx *sql.Tx) error {
+		for _, blk := range blks {
+			if err := idx.buildIndex(blk, tx); err != nil {
+				return errors.Wrapf(err, "failed to index block %d", blk.Height())
+			}
+		}
+		return nil
+	})
+}
+
+// indexed records the progress after the blocks up to height are indexed. The caller should hold the mutex
+func (idx *Indexer) indexed(height uint64, count uint64, mode string) {
+	if height >= idx.next {
+		idx.next = height + 1
+		indexHeightMtc.WithLabelValues("indexed").Set(float64(height))
+	}
+	indexedBlocksMtc.WithLabelValues(mode).Add(float64(count))
+}
+
+// deleteIndex deletes the existing index of the actions of a block in the transaction, so that reindexing a block
+// doesn't duplicate the rows
+func (idx *Indexer) deleteIndex(blk *blockchain.Block, tx *sql.Tx) error {
+	transfers, votes, executions := action.ClassifyActions(blk.Actions)
+	var transferHashes, voteHashes, executionHashes []hash.Hash32B
+	for _, transfer := range transfers {
+		transferHashes = append(transferHashes, transfer.Hash())
+	}
+	for _, vote := range votes {
+		voteHashes = append(voteHashes, vote.Hash())
+	}
+	for _, execution := range executions {
+		executionHashes = append(executionHashes, execution.Hash())
+	}
+	var actionHashes []hash.Hash32B
+	for _, act := range otherActions(blk) {
+		actionHashes = append(actionHashes, act.Hash())
+	}
+	for name, hashes := range map[string][]hash.Hash32B{
+		"transfer":  transferHashes,
+		"vote":      voteHashes,
+		"execution": executionHashes,
+		"action":    actionHashes,
+	} {
+		historyQuery := idx.rds.Dialect().Rebind(
+			"DELETE FROM " + name + "_history WHERE node_address=? AND " + name + "_hash=?")
+		toBlockQuery := idx.rds.Dialect().Rebind(
+			"DELETE FROM " + name + "_to_block WHERE node_address=? AND " + name + "_hash=?")
+		for _, h := range hashes {
+			if _, err := tx.Exec(historyQuery, idx.hexEncodedNodeAddr, h[:]); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(toBlockQuery, idx.hexEncodedNodeAddr, hex.EncodeToString(h[:])); err != nil {
+				return err
+			}
+		}
+	}
+	receiptQuery := idx.rds.Dialect().Rebind("DELETE FROM action_receipt WHERE node_address=? AND action_hash=?")
+	for _, act := range blk.Actions {
+		actHash := act.Hash()
+		if _, err := tx.Exec(receiptQuery, idx.hexEncodedNodeAddr, hex.EncodeToString(actHash[:])); err != nil {
+			return err
+		}
+	}
+	logQuery := idx.rds.Dialect().Rebind("DELETE FROM contract_log WHERE node_address=? AND block_height=?")
+	_, err := tx.Exec(logQuery, idx.hexEncodedNodeAddr, blk.Height())
+	return err
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package indexservice
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db/rds"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestIndexer_Backfill(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := "/tmp/test-indexer-backfill-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+	ctx := context.Background()
+	store := rds.NewSQLite(path)
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	require.NoError(rds.Migrate(store, migrations))
+
+	// each block has a transfer from the sender
+	var blks []*blockchain.Block
+	for height := uint64(0); height <= 6; height++ {
+		tsf, err := action.NewTransfer(height, big.NewInt(1), "sender", "recipient", nil, 0, big.NewInt(0))
+		require.NoError(err)
+		blks = append(blks, blockchain.NewBlock(
+			0, height, hash.ZeroHash32B, testutil.TimestampNow(), keypair.ZeroPublicKey, []action.Action{tsf}))
+	}
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	newIndexer := func() *Indexer {
+		return &Indexer{cfg: config.Default.Indexer, rds: store, hexEncodedNodeAddr: "node", bc: bc}
+	}
+	requireIndexed := func(idx *Indexer, height uint64) {
+		indexHeight, err := idx.GetIndexHeight()
+		require.NoError(err)
+		require.Equal(height, indexHeight)
+		transfers, err := idx.GetTransferHistory("sender")
+		require.NoError(err)
+		require.Equal(int(height+1), len(transfers))
+		blkHash, err := idx.GetBlockByTransfer(blks[height].Actions[0].Hash())
+		require.NoError(err)
+		require.Equal(blks[height].HashBlock(), blkHash)
+	}
+
+	idx := newIndexer()
+	_, err := idx.GetIndexHeight()
+	require.Equal(ErrNotExist, err)
+	// the blocks arrived during the backfill are left to it
+	require.NoError(idx.HandleBlock(blks[4]))
+	bc.EXPECT().TipHeight().Return(uint64(3)).AnyTimes()
+	for _, blk := range blks[:5] {
+		bc.EXPECT().GetBlockByHeight(blk.Height()).Return(blk, nil).Times(1)
+	}
+	require.NoError(idx.Backfill(2, nil))
+	require.True(idx.live)
+	requireIndexed(idx, 4)
+
+	// the block missed in live mode is read from the chain
+	bc.EXPECT().GetBlockByHeight(uint64(5)).Return(blks[5], nil).Times(1)
+	require.NoError(idx.HandleBlock(blks[6]))
+	requireIndexed(idx, 6)
+
+	// reindexing doesn't duplicate the index or lower the index height
+	for _, blk := range blks[2:4] {
+		bc.EXPECT().GetBlockByHeight(blk.Height()).Return(blk, nil).Times(1)
+	}
+	require.NoError(idx.Reindex(2, 3, 10, nil))
+	requireIndexed(idx, 6)
+	require.Error(idx.Reindex(3, 2, 10, nil))
+	require.Error(idx.Reindex(3, 4, 10, nil))
+
+	// the blocks aren't reindexed before the backfill
+	idx = newIndexer()
+	require.Error(idx.Reindex(2, 3, 10, nil))
+
+	// the backfill resumes from the index height
+	stop := make(chan struct{})
+	close(stop)
+	require.NoError(idx.Backfill(2, stop))
+	require.False(idx.live)
+	require.Equal(uint64(7), idx.next)
+}
+
+func TestIndexer_BackfillAfterMigration(t *testing.T) {
+	require := require.New(t)
+
+	path := "/tmp/test-indexer-backfill-migration-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+	ctx := context.Background()
+	store := rds.NewSQLite(path)
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	// the blocks were indexed before the action, receipt and contract log tables were created
+	require.NoError(rds.Migrate(store, migrations[:2]))
+	idx := &Indexer{cfg: config.Default.Indexer, rds: store, hexEncodedNodeAddr: "node"}
+	require.NoError(store.Transact(func(tx *sql.Tx) error {
+		return idx.UpdateIndexHeight(10, tx)
+	}))
+	height, err := idx.GetIndexHeight()
+	require.NoError(err)
+	require.Equal(uint64(10), height)
+
+	// the backfill fills the new tables from the genesis block
+	require.NoError(rds.Migrate(store, migrations))
+	_, err = idx.GetIndexHeight()
+	require.Equal(ErrNotExist, err)
+}
@@ -9,6 +9,7 @@ package indexservice
 import (
 	"database/sql"
 	"encoding/hex"
+	"sync"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -55,6 +56,27 @@ type (
 		ExecutionHash string
 		BlockHash     string
 	}
+	// ActionHistory defines the schema of "action history" table, which has the actions other than transfer, vote and
+	// execution
+	ActionHistory struct {
+		NodeAddress string
+		UserAddress string
+		ActionHash  string
+		Direction   string
+	}
+	// ActionToBlock defines the schema of "action hash to block hash" table
+	ActionToBlock struct {
+		NodeAddress string
+		ActionHash  string
+		BlockHash   string
+	}
+)
+
+const (
+	// actionFrom is the direction of an action in the history of its sender
+	actionFrom = "from"
+	// actionTo is the direction of an action in the history of its recipient
+	actionTo = "to"
 )
 
 // Indexer handle the index build for blocks
@@ -62,6 +84,15 @@ type Indexer struct {
 	cfg                config.Indexer
 	rds                rds.Store
 	hexEncodedNodeAddr string
+	bc                 blockchain.Blockchain
+
+	mutex sync.Mutex
+	// next is the height of the next block to index
+	next uint64
+	// live is true once the backfill has caught up with the tip, since when the new blocks are indexed on arrival
+	live bool
+	// pending is the highest height of the blocks arrived during the backfill
+	pending uint64
 }
 
 var (
@@ -71,49 +102,109 @@ var (
 	ErrAlreadyExist = errors.New("already exist in DB")
 )
 
-// HandleBlock is an implementation of interface BlockCreationSubscriber
+// HandleBlock is an implementation of interface BlockCreationSubscriber. The blocks arrived before the backfill
+// catches up with the tip are left to the backfill
 func (idx *Indexer) HandleBlock(blk *blockchain.Block) error {
-	return idx.BuildIndex(blk)
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if !idx.live {
+		if blk.Height() > idx.pending {
+			idx.pending = blk.Height()
+		}
+		return nil
+	}
+	// fill the gap of the blocks missed, e.g., the ones arrived while switching to live mode
+	if blk.Height() > idx.next {
+		if err := idx.indexRange(idx.next, blk.Height()-1); err != nil {
+			return err
+		}
+		idx.indexed(blk.Height()-1, blk.Height()-idx.next, liveMode)
+	}
+	if err := idx.BuildIndex(blk); err != nil {
+		return err
+	}
+	idx.indexed(blk.Height(), 1, liveMode)
+	return nil
 }
 
 // BuildIndex build the index for a block
 func (idx *Indexer) BuildIndex(blk *blockchain.Block) error {
-	idx.rds.Transact(func(tx *sql.Tx) error {
-		// log transfer to transfer history table
-		if err := idx.UpdateTransferHistory(blk, tx); err != nil {
-			return errors.Wrapf(err, "failed to update transfer to transfer history table")
-		}
-		// map transfer to block
-		if err := idx.UpdateTransferToBlock(blk, tx); err != nil {
-			return errors.Wrapf(err, "failed to update transfer to block")
-		}
+	return idx.rds.Transact(func(tx *sql.Tx) error {
+		return idx.buildIndex(blk, tx)
+	})
+}
 
-		// log vote to vote history table
-		if err := idx.UpdateVoteHistory(blk, tx); err != nil {
-			return errors.Wrapf(err, "failed to update vote to vote history table")
-		}
-		// map vote to block
-		if err := idx.UpdateVoteToBlock(blk, tx); err != nil {
-			return errors.Wrapf(err, "failed to update vote to block")
-		}
+// buildIndex builds the index for a block in the transaction, replacing the existing one of its actions, and raises
+// the index height
+func (idx *Indexer) buildIndex(blk *blockchain.Block, tx *sql.Tx) error {
+	if err := idx.deleteIndex(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to delete existing index of block %d", blk.Height())
+	}
+	if err := idx.updateIndex(blk, tx); err != nil {
+		return err
+	}
+	return idx.UpdateIndexHeight(blk.Height(), tx)
+}
 
-		// log execution to execution history table
-		if err := idx.UpdateExecutionHistory(blk, tx); err != nil {
-			return errors.Wrapf(err, "failed to update execution to execution history table")
-		}
-		// map execution to block
-		if err := idx.UpdateExecutionToBlock(blk, tx); err != nil {
-			return errors.Wrapf(err, "failed to update execution to block")
-		}
+// updateIndex stores the actions of a block into the history and to-block tables
+func (idx *Indexer) updateIndex(blk *blockchain.Block, tx *sql.Tx) error {
+	// log transfer to transfer history table
+	if err := idx.UpdateTransferHistory(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to update transfer to transfer history table")
+	}
+	// map transfer to block
+	if err := idx.UpdateTransferToBlock(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to update transfer to block")
+	}
+
+	// log vote to vote history table
+	if err := idx.UpdateVoteHistory(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to update vote to vote history table")
+	}
+	// map vote to block
+	if err := idx.UpdateVoteToBlock(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to update vote to block")
+	}
+
+	// log execution to execution history table
+	if err := idx.UpdateExecutionHistory(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to update execution to execution history table")
+	}
+	// map execution to block
+	if err := idx.UpdateExecutionToBlock(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to update execution to block")
+	}
+
+	// log the other actions to action history table
+	if err := idx.UpdateActionHistory(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to update action to action history table")
+	}
+	// map the other actions to block
+	if err := idx.UpdateActionToBlock(blk, tx); err != nil {
+		return errors.Wrapf(err, "failed to update action to block")
+	}
+
+	receipts, err := idx.receipts(blk)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get receipts of block %d", blk.Height())
+	}
+	// store receipts to action receipt table
+	if err := idx.UpdateReceipts(receipts, tx); err != nil {
+		return errors.Wrapf(err, "failed to update receipts to action receipt table")
+	}
+	// log contract logs to contract log table
+	if err := idx.UpdateContractLogs(blk, receipts, tx); err != nil {
+		return errors.Wrapf(err, "failed to update logs to contract log table")
+	}
 
-		return nil
-	})
 	return nil
 }
 
 // UpdateTransferHistory stores transfer information into transfer history table
 func (idx *Indexer) UpdateTransferHistory(blk *blockchain.Block, tx *sql.Tx) error {
-	insertQuery := "INSERT transfer_history SET node_address=?,user_address=?,transfer_hash=?"
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO transfer_history (node_address, user_address, transfer_hash) VALUES (?, ?, ?)")
 	transfers, _, _ := action.ClassifyActions(blk.Actions)
 	for _, transfer := range transfers {
 		transferHash := transfer.Hash()
@@ -135,7 +226,7 @@ func (idx *Indexer) UpdateTransferHistory(blk *blockchain.Block, tx *sql.Tx) err
 
 // GetTransferHistory get transfer history
 func (idx *Indexer) GetTransferHistory(userAddr string) ([]hash.Hash32B, error) {
-	getQuery := "SELECT * FROM transfer_history WHERE node_address=? AND user_address=?"
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM transfer_history WHERE node_address=? AND user_address=?")
 	db := idx.rds.GetDB()
 
 	stmt, err := db.Prepare(getQuery)
@@ -166,7 +257,8 @@ func (idx *Indexer) GetTransferHistory(userAddr string) ([]hash.Hash32B, error)
 // UpdateTransferToBlock map transfer hash to block hash
 func (idx *Indexer) UpdateTransferToBlock(blk *blockchain.Block, tx *sql.Tx) error {
 	blockHash := blk.HashBlock()
-	insertQuery := "INSERT transfer_to_block SET node_address=?,transfer_hash=?,block_hash=?"
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO transfer_to_block (node_address, transfer_hash, block_hash) VALUES (?, ?, ?)")
 	transfers, _, _ := action.ClassifyActions(blk.Actions)
 	for _, transfer := range transfers {
 		transferHash := transfer.Hash()
@@ -179,7 +271,7 @@ func (idx *Indexer) UpdateTransferToBlock(blk *blockchain.Block, tx *sql.Tx) err
 
 // GetBlockByTransfer return block hash by transfer hash
 func (idx *Indexer) GetBlockByTransfer(transferHash hash.Hash32B) (hash.Hash32B, error) {
-	getQuery := "SELECT * FROM transfer_to_block WHERE node_address=? AND transfer_hash=?"
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM transfer_to_block WHERE node_address=? AND transfer_hash=?")
 	db := idx.rds.GetDB()
 
 	stmt, err := db.Prepare(getQuery)
@@ -209,7 +301,8 @@ func (idx *Indexer) GetBlockByTransfer(transferHash hash.Hash32B) (hash.Hash32B,
 
 // UpdateVoteHistory stores vote information into vote history table
 func (idx *Indexer) UpdateVoteHistory(blk *blockchain.Block, tx *sql.Tx) error {
-	insertQuery := "INSERT vote_history SET node_address=?,user_address=?,vote_hash=?"
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO vote_history (node_address, user_address, vote_hash) VALUES (?, ?, ?)")
 	_, votes, _ := action.ClassifyActions(blk.Actions)
 	for _, vote := range votes {
 		voteHash := vote.Hash()
@@ -231,7 +324,7 @@ func (idx *Indexer) UpdateVoteHistory(blk *blockchain.Block, tx *sql.Tx) error {
 
 // GetVoteHistory get vote history
 func (idx *Indexer) GetVoteHistory(userAddr string) ([]hash.Hash32B, error) {
-	getQuery := "SELECT * FROM vote_history WHERE node_address=? AND user_address=?"
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM vote_history WHERE node_address=? AND user_address=?")
 	db := idx.rds.GetDB()
 
 	stmt, err := db.Prepare(getQuery)
@@ -262,7 +355,8 @@ func (idx *Indexer) GetVoteHistory(userAddr string) ([]hash.Hash32B, error) {
 // UpdateVoteToBlock map vote hash to block hash
 func (idx *Indexer) UpdateVoteToBlock(blk *blockchain.Block, tx *sql.Tx) error {
 	blockHash := blk.HashBlock()
-	insertQuery := "INSERT vote_to_block SET node_address=?,vote_hash=?,block_hash=?"
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO vote_to_block (node_address, vote_hash, block_hash) VALUES (?, ?, ?)")
 	_, votes, _ := action.ClassifyActions(blk.Actions)
 	for _, vote := range votes {
 		voteHash := vote.Hash()
@@ -275,7 +369,7 @@ func (idx *Indexer) UpdateVoteToBlock(blk *blockchain.Block, tx *sql.Tx) error {
 
 // GetBlockByVote return block hash by vote hash
 func (idx *Indexer) GetBlockByVote(voteHash hash.Hash32B) (hash.Hash32B, error) {
-	getQuery := "SELECT * FROM vote_to_block WHERE node_address=? AND vote_hash=?"
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM vote_to_block WHERE node_address=? AND vote_hash=?")
 	db := idx.rds.GetDB()
 
 	stmt, err := db.Prepare(getQuery)
@@ -305,7 +399,8 @@ func (idx *Indexer) GetBlockByVote(voteHash hash.Hash32B) (hash.Hash32B, error)
 
 // UpdateExecutionHistory stores execution information into execution history table
 func (idx *Indexer) UpdateExecutionHistory(blk *blockchain.Block, tx *sql.Tx) error {
-	insertQuery := "INSERT execution_history SET node_address=?,user_address=?,execution_hash=?"
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO execution_history (node_address, user_address, execution_hash) VALUES (?, ?, ?)")
 	_, _, executions := action.ClassifyActions(blk.Actions)
 	for _, execution := range executions {
 		executionHash := execution.Hash()
@@ -327,7 +422,7 @@ func (idx *Indexer) UpdateExecutionHistory(blk *blockchain.Block, tx *sql.Tx) er
 
 // GetExecutionHistory get execution history
 func (idx *Indexer) GetExecutionHistory(userAddr string) ([]hash.Hash32B, error) {
-	getQuery := "SELECT * FROM execution_history WHERE node_address=? AND user_address=?"
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM execution_history WHERE node_address=? AND user_address=?")
 	db := idx.rds.GetDB()
 
 	stmt, err := db.Prepare(getQuery)
@@ -358,7 +453,8 @@ func (idx *Indexer) GetExecutionHistory(userAddr string) ([]hash.Hash32B, error)
 // UpdateExecutionToBlock map execution hash to block hash
 func (idx *Indexer) UpdateExecutionToBlock(blk *blockchain.Block, tx *sql.Tx) error {
 	blockHash := blk.HashBlock()
-	insertQuery := "INSERT execution_to_block SET node_address=?,execution_hash=?,block_hash=?"
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO execution_to_block (node_address, execution_hash, block_hash) VALUES (?, ?, ?)")
 	_, _, executions := action.ClassifyActions(blk.Actions)
 	for _, execution := range executions {
 		executionHash := execution.Hash()
@@ -371,7 +467,7 @@ func (idx *Indexer) UpdateExecutionToBlock(blk *blockchain.Block, tx *sql.Tx) er
 
 // GetBlockByExecution return block hash by execution hash
 func (idx *Indexer) GetBlockByExecution(executionHash hash.Hash32B) (hash.Hash32B, error) {
-	getQuery := "SELECT * FROM execution_to_block WHERE node_address=? AND execution_hash=?"
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM execution_to_block WHERE node_address=? AND execution_hash=?")
 	db := idx.rds.GetDB()
 
 	stmt, err := db.Prepare(getQuery)
@@ -398,3 +494,138 @@ func (idx *Indexer) GetBlockByExecution(executionHash hash.Hash32B) (hash.Hash32
 	copy(hash[:], parsedRows[0].(*ExecutionToBlock).BlockHash)
 	return hash, nil
 }
+
+// UpdateActionHistory stores the actions other than transfer, vote and execution into action history table
+func (idx *Indexer) UpdateActionHistory(blk *blockchain.Block, tx *sql.Tx) error {
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO action_history (node_address, user_address, action_hash, direction) VALUES (?, ?, ?, ?)")
+	for _, act := range otherActions(blk) {
+		actHash := act.Hash()
+
+		// put new action for sender
+		if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, act.SrcAddr(), actHash[:], actionFrom); err != nil {
+			return err
+		}
+
+		// put new action for recipient, if there is one
+		if act.DstAddr() == "" {
+			continue
+		}
+		if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, act.DstAddr(), actHash[:], actionTo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetActionsFromAddress get the history of the actions other than transfer, vote and execution sent by the address
+func (idx *Indexer) GetActionsFromAddress(userAddr string) ([]hash.Hash32B, error) {
+	return idx.getActionHistory(userAddr, actionFrom)
+}
+
+// GetActionsToAddress get the history of the actions other than transfer, vote and execution sent to the address
+func (idx *Indexer) GetActionsToAddress(userAddr string) ([]hash.Hash32B, error) {
+	return idx.getActionHistory(userAddr, actionTo)
+}
+
+func (idx *Indexer) getActionHistory(userAddr string, direction string) ([]hash.Hash32B, error) {
+	getQuery := idx.rds.Dialect().Rebind(
+		"SELECT * FROM action_history WHERE node_address=? AND user_address=? AND direction=?")
+	db := idx.rds.GetDB()
+
+	stmt, err := db.Prepare(getQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to prepare get query")
+	}
+
+	rows, err := stmt.Query(idx.hexEncodedNodeAddr, userAddr, direction)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute get query")
+	}
+
+	var actionHistory ActionHistory
+	parsedRows, err := rds.ParseRows(rows, &actionHistory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse results")
+	}
+
+	var actionHashes []hash.Hash32B
+	for _, parsedRow := range parsedRows {
+		var hash hash.Hash32B
+		copy(hash[:], parsedRow.(*ActionHistory).ActionHash)
+		actionHashes = append(actionHashes, hash)
+	}
+	return actionHashes, nil
+}
+
+// UpdateActionToBlock map the hash of the actions other than transfer, vote and execution to block hash
+func (idx *Indexer) UpdateActionToBlock(blk *blockchain.Block, tx *sql.Tx) error {
+	blockHash := blk.HashBlock()
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO action_to_block (node_address, action_hash, block_hash) VALUES (?, ?, ?)")
+	for _, act := range otherActions(blk) {
+		actHash := act.Hash()
+		if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, hex.EncodeToString(actHash[:]), blockHash[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBlockByAction return block hash by the hash of an action other than transfer, vote and execution
+func (idx *Indexer) GetBlockByAction(actHash hash.Hash32B) (hash.Hash32B, error) {
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM action_to_block WHERE node_address=? AND action_hash=?")
+	db := idx.rds.GetDB()
+
+	stmt, err := db.Prepare(getQuery)
+	if err != nil {
+		return hash.ZeroHash32B, errors.Wrapf(err, "failed to prepare get query")
+	}
+
+	rows, err := stmt.Query(idx.hexEncodedNodeAddr, hex.EncodeToString(actHash[:]))
+	if err != nil {
+		return hash.ZeroHash32B, errors.Wrapf(err, "failed to execute get query")
+	}
+
+	var actionToBlock ActionToBlock
+	parsedRows, err := rds.ParseRows(rows, &actionToBlock)
+	if err != nil {
+		return hash.ZeroHash32B, errors.Wrapf(err, "failed to parse results")
+	}
+
+	if len(parsedRows) == 0 {
+		return hash.ZeroHash32B, ErrNotExist
+	}
+
+	var hash hash.Hash32B
+	copy(hash[:], parsedRows[0].(*ActionToBlock).BlockHash)
+	return hash, nil
+}
+
+// indexedAction is an action in the action history, which could be a secret proposal or a secret witness besides an
+// action.Action
+type indexedAction interface {
+	Hash() hash.Hash32B
+	SrcAddr() string
+	DstAddr() string
+}
+
+// otherActions returns the actions of a block other than transfer, vote and execution, including the secret
+// proposals and the secret witness
+func otherActions(blk *blockchain.Block) []indexedAction {
+	var acts []indexedAction
+	for _, sp := range blk.SecretProposals {
+		acts = append(acts, sp)
+	}
+	if blk.SecretWitness != nil {
+		acts = append(acts, blk.SecretWitness)
+	}
+	for _, act := range blk.Actions {
+		switch act.(type) {
+		case *action.Transfer, *action.Vote, *action.Execution:
+			continue
+		}
+		acts = append(acts, act)
+	}
+	return acts
+}
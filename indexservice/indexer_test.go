@@ -8,6 +8,7 @@ package indexservice
 
 import (
 	"context"
+	"math/big"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -18,6 +19,8 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db/rds"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/version"
 	"github.com/iotexproject/iotex-core/proto"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -29,8 +32,6 @@ var (
 
 func TestIndexService(t *testing.T) {
 	testRDSStorePutGet := func(rdsStore rds.Store, t *testing.T) {
-		t.Skip("Skipping when RDS credentail not provided.")
-
 		require := require.New(t)
 		ctx := context.Background()
 
@@ -40,6 +41,7 @@ func TestIndexService(t *testing.T) {
 			err = rdsStore.Stop(ctx)
 			require.Nil(err)
 		}()
+		require.NoError(rds.Migrate(rdsStore, migrations))
 
 		nodeAddr := "aaa"
 		userAddr1 := "bb"
@@ -61,23 +63,26 @@ func TestIndexService(t *testing.T) {
 				{Action: &iproto.ActionPb_Transfer{
 					Transfer: &iproto.TransferPb{Recipient: userAddr2},
 				},
-					Version: version.ProtocolVersion,
-					Sender:  userAddr1,
-					Nonce:   101,
+					Version:      version.ProtocolVersion,
+					Sender:       userAddr1,
+					SenderPubKey: keypair.ZeroPublicKey[:],
+					Nonce:        101,
 				},
 				{Action: &iproto.ActionPb_Vote{
 					Vote: &iproto.VotePb{VoteeAddress: userAddr2},
 				},
-					Version: version.ProtocolVersion,
-					Sender:  userAddr1,
-					Nonce:   103,
+					Version:      version.ProtocolVersion,
+					Sender:       userAddr1,
+					SenderPubKey: keypair.ZeroPublicKey[:],
+					Nonce:        103,
 				},
 				{Action: &iproto.ActionPb_Execution{
 					Execution: &iproto.ExecutionPb{Contract: userAddr2},
 				},
-					Version: version.ProtocolVersion,
-					Sender:  userAddr1,
-					Nonce:   104,
+					Version:      version.ProtocolVersion,
+					Sender:       userAddr1,
+					SenderPubKey: keypair.ZeroPublicKey[:],
+					Nonce:        104,
 				},
 			},
 		})
@@ -159,8 +164,118 @@ func TestIndexService(t *testing.T) {
 
 	path := "/tmp/test-indexer-" + strconv.Itoa(rand.Int())
 	t.Run("Indexer", func(t *testing.T) {
+		t.Skip("Skipping when RDS credentail not provided.")
+
 		testutil.CleanupPath(t, path)
 		defer testutil.CleanupPath(t, path)
 		testRDSStorePutGet(rds.NewAwsRDS(cfg), t)
 	})
+
+	t.Run("SQLite indexer", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testRDSStorePutGet(rds.NewSQLite(path), t)
+	})
+}
+
+func TestIndexer_ActionsReceiptsLogs(t *testing.T) {
+	require := require.New(t)
+
+	path := "/tmp/test-indexer-actions-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+	ctx := context.Background()
+	store := rds.NewSQLite(path)
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	require.NoError(rds.Migrate(store, migrations))
+	idx := Indexer{cfg: config.Default.Indexer, rds: store, hexEncodedNodeAddr: "node"}
+
+	cd := action.NewCreateDeposit(1, big.NewInt(1), "alice", "bob", 0, big.NewInt(0))
+	sd := action.NewSettleDeposit(2, big.NewInt(1), 0, "carol", "alice", 0, big.NewInt(0))
+	ex, err := action.NewExecution("alice", "contract", 3, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+	sp, err := action.NewSecretProposal(4, "alice", "dave", []uint32{1})
+	require.NoError(err)
+	topic1, topic2 := hash.Hash32B{1}, hash.Hash32B{2}
+	receipts := []*action.Receipt{
+		{Hash: cd.Hash(), Status: 1},
+		{Hash: ex.Hash(), Status: 1, Logs: []*action.Log{
+			{Address: "contract", Topics: []hash.Hash32B{topic1}, BlockNumber: 5},
+			{Address: "contract", Topics: []hash.Hash32B{topic2}, BlockNumber: 5},
+			{Address: "token", Topics: []hash.Hash32B{topic1}, BlockNumber: 5},
+		}},
+	}
+	blk := blockchain.NewBlock(
+		0, 5, hash.ZeroHash32B, testutil.TimestampNow(), keypair.ZeroPublicKey, []action.Action{cd, sd, ex})
+	blk.SecretProposals = []*action.SecretProposal{sp}
+	blk.SetReceipts(receipts)
+	// building the index again doesn't duplicate it
+	require.NoError(idx.BuildIndex(blk))
+	require.NoError(idx.BuildIndex(blk))
+
+	hashes, err := idx.GetActionsFromAddress("alice")
+	require.NoError(err)
+	require.Equal([]hash.Hash32B{sp.Hash(), cd.Hash()}, hashes)
+	hashes, err = idx.GetActionsToAddress("alice")
+	require.NoError(err)
+	require.Equal([]hash.Hash32B{sd.Hash()}, hashes)
+	hashes, err = idx.GetActionsToAddress("bob")
+	require.NoError(err)
+	require.Equal([]hash.Hash32B{cd.Hash()}, hashes)
+	// executions stay in their own history
+	hashes, err = idx.GetActionsToAddress("contract")
+	require.NoError(err)
+	require.Equal(0, len(hashes))
+	for _, act := range []indexedAction{cd, sd, sp} {
+		blkHash, err := idx.GetBlockByAction(act.Hash())
+		require.NoError(err)
+		require.Equal(blk.HashBlock(), blkHash)
+	}
+	_, err = idx.GetBlockByAction(ex.Hash())
+	require.Equal(ErrNotExist, err)
+
+	receipt, err := idx.GetReceiptByAction(ex.Hash())
+	require.NoError(err)
+	require.Equal(receipts[1].Hash, receipt.Hash)
+	require.Equal(3, len(receipt.Logs))
+	_, err = idx.GetReceiptByAction(sd.Hash())
+	require.Equal(ErrNotExist, err)
+
+	logs, err := idx.GetLogs(&blockchain.LogFilter{}, 0, 10, 0)
+	require.NoError(err)
+	require.Equal(3, len(logs))
+	logs, err = idx.GetLogs(&blockchain.LogFilter{Addresses: []string{"contract", "token"}}, 0, 10, 2)
+	require.NoError(err)
+	require.Equal(2, len(logs))
+	require.Equal([]hash.Hash32B{topic1}, logs[0].Topics)
+	require.Equal([]hash.Hash32B{topic2}, logs[1].Topics)
+	logs, err = idx.GetLogs(&blockchain.LogFilter{Topics: [][]hash.Hash32B{{topic1}}}, 0, 10, 0)
+	require.NoError(err)
+	require.Equal(2, len(logs))
+	require.Equal("contract", logs[0].Address)
+	require.Equal("token", logs[1].Address)
+	logs, err = idx.GetLogs(&blockchain.LogFilter{Addresses: []string{"token"}}, 6, 10, 0)
+	require.NoError(err)
+	require.Equal(0, len(logs))
+	logs, err = idx.GetLogs(&blockchain.LogFilter{Topics: [][]hash.Hash32B{{topic2}, {}}}, 0, 10, 0)
+	require.NoError(err)
+	require.Equal(0, len(logs))
+	logs, err = idx.GetLogs(&blockchain.LogFilter{Topics: [][]hash.Hash32B{{}}}, 0, 10, 1)
+	require.NoError(err)
+	require.Equal(1, len(logs))
+	require.Equal([]hash.Hash32B{topic1}, logs[0].Topics)
+
+	// the topics of the logs indexed before the contract log table has them are filled
+	_, err = store.GetDB().Exec("UPDATE contract_log SET num_topics=NULL, topic0=NULL")
+	require.NoError(err)
+	logs, err = idx.GetLogs(&blockchain.LogFilter{Topics: [][]hash.Hash32B{{topic1}}}, 0, 10, 0)
+	require.NoError(err)
+	require.Equal(0, len(logs))
+	require.NoError(idx.FillLogTopics())
+	logs, err = idx.GetLogs(&blockchain.LogFilter{Topics: [][]hash.Hash32B{{topic1}}}, 0, 10, 0)
+	require.NoError(err)
+	require.Equal(2, len(logs))
 }
@@ -0,0 +1,257 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package indexservice
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/rds"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+type (
+	// ActionReceipt defines the schema of "action hash to receipt" table
+	ActionReceipt struct {
+		NodeAddress string
+		ActionHash  string
+		Receipt     []byte
+	}
+	// ContractLog defines the schema of "contract log" table. The index of a log counts the logs in the receipts of
+	// the block, in the order of the actions
+	ContractLog struct {
+		NodeAddress string
+		BlockHeight uint64
+		LogIndex    uint64
+		Address     string
+		Log         []byte
+		NumTopics   sql.NullInt64
+		Topic0      sql.NullString
+		Topic1      sql.NullString
+		Topic2      sql.NullString
+		Topic3      sql.NullString
+	}
+)
+
+// maxLogTopics is the number of topics of a log in the contract log table, which is the most a log emitted by EVM has
+const maxLogTopics = 4
+
+// UpdateReceipts stores the receipts into action receipt table
+func (idx *Indexer) UpdateReceipts(receipts []*action.Receipt, tx *sql.Tx) error {
+	insertQuery := idx.rds.Dialect().Rebind(
+		"INSERT INTO action_receipt (node_address, action_hash, receipt) VALUES (?, ?, ?)")
+	for _, receipt := range receipts {
+		value, err := receipt.Serialize()
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize receipt %x", receipt.Hash)
+		}
+		if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, hex.EncodeToString(receipt.Hash[:]), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetReceiptByAction return the receipt by action hash
+func (idx *Indexer) GetReceiptByAction(actHash hash.Hash32B) (*action.Receipt, error) {
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM action_receipt WHERE node_address=? AND action_hash=?")
+	db := idx.rds.GetDB()
+
+	stmt, err := db.Prepare(getQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to prepare get query")
+	}
+
+	rows, err := stmt.Query(idx.hexEncodedNodeAddr, hex.EncodeToString(actHash[:]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute get query")
+	}
+
+	var actionReceipt ActionReceipt
+	parsedRows, err := rds.ParseRows(rows, &actionReceipt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse results")
+	}
+
+	if len(parsedRows) == 0 {
+		return nil, ErrNotExist
+	}
+
+	receipt := &action.Receipt{}
+	if err := receipt.Deserialize(parsedRows[0].(*ActionReceipt).Receipt); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize receipt %x", actHash)
+	}
+	return receipt, nil
+}
+
+// UpdateContractLogs stores the logs in the receipts of a block into contract log table
+func (idx *Indexer) UpdateContractLogs(blk *blockchain.Block, receipts []*action.Receipt, tx *sql.Tx) error {
+	insertQuery := idx.rds.Dialect().Rebind("INSERT INTO contract_log " +
+		"(node_address, block_height, log_index, address, log, num_topics, topic0, topic1, topic2, topic3) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	var logIndex uint64
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			value, err := log.Serialize()
+			if err != nil {
+				return errors.Wrapf(err, "failed to serialize log %d of receipt %x", logIndex, receipt.Hash)
+			}
+			args := []interface{}{idx.hexEncodedNodeAddr, blk.Height(), logIndex, log.Address, value}
+			args = append(args, logTopics(log)...)
+			if _, err := tx.Exec(insertQuery, args...); err != nil {
+				return err
+			}
+			logIndex++
+		}
+	}
+	return nil
+}
+
+// FillLogTopics fills the topics of the logs indexed before the contract log table has them
+func (idx *Indexer) FillLogTopics() error {
+	getQuery := idx.rds.Dialect().Rebind("SELECT * FROM contract_log WHERE node_address=? AND num_topics IS NULL")
+	rows, err := idx.rds.GetDB().Query(getQuery, idx.hexEncodedNodeAddr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to execute get query")
+	}
+	var contractLog ContractLog
+	parsedRows, err := rds.ParseRows(rows, &contractLog)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse results")
+	}
+	if len(parsedRows) == 0 {
+		return nil
+	}
+
+	updateQuery := idx.rds.Dialect().Rebind("UPDATE contract_log " +
+		"SET num_topics=?, topic0=?, topic1=?, topic2=?, topic3=? " +
+		"WHERE node_address=? AND block_height=? AND log_index=?")
+	return idx.rds.Transact(func(tx *sql.Tx) error {
+		for _, parsedRow := range parsedRows {
+			row := parsedRow.(*ContractLog)
+			log := &action.Log{}
+			if err := log.Deserialize(row.Log); err != nil {
+				return errors.Wrapf(err, "failed to deserialize log %d on height %d", row.LogIndex, row.BlockHeight)
+			}
+			args := logTopics(log)
+			args = append(args, idx.hexEncodedNodeAddr, row.BlockHeight, row.LogIndex)
+			if _, err := tx.Exec(updateQuery, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetLogs returns the logs matching the filter in the blocks from fromHeight to toHeight in order, at most limit of
+// them if limit is positive. The logs are looked up by the addresses and the topics of the filter
+func (idx *Indexer) GetLogs(filter *blockchain.LogFilter, fromHeight, toHeight, limit uint64) ([]*action.Log, error) {
+	getQuery := "SELECT * FROM contract_log WHERE node_address=? AND block_height>=? AND block_height<=?"
+	args := []interface{}{idx.hexEncodedNodeAddr, fromHeight, toHeight}
+	if len(filter.Addresses) > 0 {
+		getQuery += " AND address IN (?" + strings.Repeat(", ?", len(filter.Addresses)-1) + ")"
+		for _, addr := range filter.Addresses {
+			args = append(args, addr)
+		}
+	}
+	if len(filter.Topics) > 0 {
+		// a log matches only if it has a topic at each position of the filter
+		if len(filter.Topics) > maxLogTopics {
+			return nil, nil
+		}
+		getQuery += " AND num_topics>=?"
+		args = append(args, len(filter.Topics))
+	}
+	for i, topics := range filter.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		getQuery += " AND topic" + strconv.Itoa(i) + " IN (?" + strings.Repeat(", ?", len(topics)-1) + ")"
+		for _, topic := range topics {
+			args = append(args, hex.EncodeToString(topic[:]))
+		}
+	}
+	getQuery += " ORDER BY block_height, log_index"
+	if limit > 0 {
+		getQuery += " LIMIT ?"
+		args = append(args, limit)
+	}
+	db := idx.rds.GetDB()
+
+	stmt, err := db.Prepare(idx.rds.Dialect().Rebind(getQuery))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to prepare get query")
+	}
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute get query")
+	}
+
+	var contractLog ContractLog
+	parsedRows, err := rds.ParseRows(rows, &contractLog)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse results")
+	}
+
+	logs := make([]*action.Log, 0, len(parsedRows))
+	for _, parsedRow := range parsedRows {
+		row := parsedRow.(*ContractLog)
+		log := &action.Log{}
+		if err := log.Deserialize(row.Log); err != nil {
+			return nil, errors.Wrapf(err, "failed to deserialize log %d on height %d", row.LogIndex, row.BlockHeight)
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+// logTopics returns the values of the num_topics and topic columns of a log
+func logTopics(log *action.Log) []interface{} {
+	values := []interface{}{len(log.Topics)}
+	for i := 0; i < maxLogTopics; i++ {
+		if i < len(log.Topics) {
+			values = append(values, hex.EncodeToString(log.Topics[i][:]))
+		} else {
+			values = append(values, nil)
+		}
+	}
+	return values
+}
+
+// receipts returns the receipts of a block in the order of the actions, which are read from the chain if the block
+// doesn't carry them, e.g., the one read back for the backfill. Transfers and votes don't have receipts
+func (idx *Indexer) receipts(blk *blockchain.Block) ([]*action.Receipt, error) {
+	if receipts := blk.Receipts(); len(receipts) > 0 || idx.bc == nil {
+		return receipts, nil
+	}
+	var receipts []*action.Receipt
+	for _, act := range blk.Actions {
+		switch act.(type) {
+		case *action.Transfer, *action.Vote:
+			continue
+		}
+		receipt, err := idx.bc.GetReceiptByExecutionHash(act.Hash())
+		if err != nil {
+			// the receipt bucket doesn't exist until the first receipt is put
+			if cause := errors.Cause(err); cause == db.ErrNotExist || cause == bolt.ErrBucketNotFound {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get receipt of action %x", act.Hash())
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
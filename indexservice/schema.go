@@ -0,0 +1,110 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package indexservice
+
+import (
+	"strconv"
+
+	"github.com/iotexproject/iotex-core/db/rds"
+)
+
+// migrations creates and evolves the schema of the index tables, which the server runs on start. The columns of a
+// table are in the same order as the fields of its schema struct, as the rows are parsed in order
+var migrations = []rds.Migration{
+	{
+		Version:     1,
+		Description: "create transfer, vote and execution tables",
+		// the tables were created by hand before the migrations
+		Baseline: "transfer_history",
+		Statements: func(d rds.Dialect) []string {
+			var stmts []string
+			for _, name := range []string{"transfer", "vote", "execution"} {
+				stmts = append(stmts,
+					"CREATE TABLE "+name+"_history ("+
+						"node_address VARCHAR(128) NOT NULL, "+
+						"user_address VARCHAR(128) NOT NULL, "+
+						name+"_hash "+d.BinaryType(32)+" NOT NULL)",
+					"CREATE INDEX "+name+"_history_user ON "+name+"_history (node_address, user_address)",
+					"CREATE TABLE "+name+"_to_block ("+
+						"node_address VARCHAR(128) NOT NULL, "+
+						name+"_hash VARCHAR(64) NOT NULL, "+
+						"block_hash "+d.BinaryType(32)+" NOT NULL)",
+					"CREATE INDEX "+name+"_to_block_hash ON "+name+"_to_block (node_address, "+name+"_hash)",
+				)
+			}
+			return stmts
+		},
+	},
+	{
+		Version:     2,
+		Description: "create index height table",
+		Statements: func(d rds.Dialect) []string {
+			stmts := []string{
+				"CREATE TABLE index_height (node_address VARCHAR(128) PRIMARY KEY, height BIGINT NOT NULL)",
+			}
+			// the history is looked up by the action hashes to reindex a block
+			for _, name := range []string{"transfer", "vote", "execution"} {
+				stmts = append(stmts,
+					"CREATE INDEX "+name+"_history_hash ON "+name+"_history (node_address, "+name+"_hash)")
+			}
+			return stmts
+		},
+	},
+	{
+		Version:     3,
+		Description: "create action, receipt and contract log tables",
+		Statements: func(d rds.Dialect) []string {
+			return []string{
+				// the actions other than transfer, vote and execution, either from or to the user
+				"CREATE TABLE action_history (" +
+					"node_address VARCHAR(128) NOT NULL, " +
+					"user_address VARCHAR(128) NOT NULL, " +
+					"action_hash " + d.BinaryType(32) + " NOT NULL, " +
+					"direction VARCHAR(4) NOT NULL)",
+				"CREATE INDEX action_history_user ON action_history (node_address, user_address, direction)",
+				"CREATE INDEX action_history_hash ON action_history (node_address, action_hash)",
+				"CREATE TABLE action_to_block (" +
+					"node_address VARCHAR(128) NOT NULL, " +
+					"action_hash VARCHAR(64) NOT NULL, " +
+					"block_hash " + d.BinaryType(32) + " NOT NULL)",
+				"CREATE INDEX action_to_block_hash ON action_to_block (node_address, action_hash)",
+				"CREATE TABLE action_receipt (" +
+					"node_address VARCHAR(128) NOT NULL, " +
+					"action_hash VARCHAR(64) NOT NULL, " +
+					"receipt " + d.BlobType() + " NOT NULL)",
+				"CREATE INDEX action_receipt_hash ON action_receipt (node_address, action_hash)",
+				"CREATE TABLE contract_log (" +
+					"node_address VARCHAR(128) NOT NULL, " +
+					"block_height BIGINT NOT NULL, " +
+					"log_index BIGINT NOT NULL, " +
+					"address VARCHAR(128) NOT NULL, " +
+					"log " + d.BlobType() + " NOT NULL)",
+				"CREATE INDEX contract_log_height ON contract_log (node_address, block_height, log_index)",
+				"CREATE INDEX contract_log_address ON contract_log (node_address, address, block_height)",
+				// the new tables are empty for the blocks indexed before, so the backfill restarts from the genesis
+				// block, which replaces the existing index of the blocks
+				"DELETE FROM index_height",
+			}
+		},
+	},
+	{
+		Version:     4,
+		Description: "add topics to contract log table",
+		Statements: func(d rds.Dialect) []string {
+			// num_topics is null for the logs indexed before, whose topics are filled on start
+			stmts := []string{"ALTER TABLE contract_log ADD COLUMN num_topics BIGINT"}
+			for i := 0; i < maxLogTopics; i++ {
+				column := "topic" + strconv.Itoa(i)
+				stmts = append(stmts,
+					"ALTER TABLE contract_log ADD COLUMN "+column+" VARCHAR(64)",
+					"CREATE INDEX contract_log_"+column+" ON contract_log (node_address, "+column+", block_height)",
+				)
+			}
+			return stmts
+		},
+	},
+}
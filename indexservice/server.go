@@ -8,6 +8,8 @@ package indexservice
 
 import (
 	"encoding/hex"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
@@ -18,11 +20,17 @@ import (
 	"github.com/iotexproject/iotex-core/logger"
 )
 
+// backfillRetryInterval is the interval to retry a failed backfill, which resumes from the index height
+const backfillRetryInterval = 10 * time.Second
+
 // Server is the container of the index service
 type Server struct {
 	cfg config.Config
 	idx *Indexer
 	bc  blockchain.Blockchain
+
+	stop chan struct{}
+	wg   sync.WaitGroup
 }
 
 // NewServer instantiates an index service
@@ -34,6 +42,7 @@ func NewServer(
 		cfg:                cfg.Indexer,
 		rds:                nil,
 		hexEncodedNodeAddr: "",
+		bc:                 bc,
 	}
 	if err := bc.AddSubscriber(indexer); err != nil {
 		logger.Error().Err(err).Msg("error when subscribe to block")
@@ -59,16 +68,45 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 	s.idx.hexEncodedNodeAddr = addr
 
-	s.idx.rds = rds.NewAwsRDS(&s.cfg.DB.RDS)
+	store, err := rds.NewStore(&s.cfg.DB.RDS)
+	if err != nil {
+		return errors.Wrap(err, "error when create rds store")
+	}
+	s.idx.rds = store
 	if err := s.idx.rds.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when start rds store")
 	}
+	if err := rds.Migrate(s.idx.rds, migrations); err != nil {
+		return errors.Wrap(err, "error when migrate index schema")
+	}
+	if err := s.idx.FillLogTopics(); err != nil {
+		return errors.Wrap(err, "error when fill log topics")
+	}
+
+	// catch up with the tip in background, and then index the new blocks on arrival
+	s.stop = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if !s.backfill() {
+			return
+		}
+		if s.cfg.Indexer.ReindexEndHeight > 0 {
+			if err := s.Reindex(s.cfg.Indexer.ReindexStartHeight, s.cfg.Indexer.ReindexEndHeight); err != nil {
+				logger.Error().Err(err).Msg("error when reindex blocks")
+			}
+		}
+	}()
 
 	return nil
 }
 
 // Stop stops the explorer server
 func (s *Server) Stop(ctx context.Context) error {
+	if s.stop != nil {
+		close(s.stop)
+		s.wg.Wait()
+	}
 	if err := s.idx.rds.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when shutting down explorer http server")
 	}
@@ -80,5 +118,32 @@ func (s *Server) Stop(ctx context.Context) error {
 	return nil
 }
 
+// backfill runs the backfill until it switches to live mode, retrying on failure. It returns false once stopped
+func (s *Server) backfill() bool {
+	for {
+		err := s.idx.Backfill(s.cfg.Indexer.BackfillBatchSize, s.stop)
+		if err == nil {
+			select {
+			case <-s.stop:
+				return false
+			default:
+				return true
+			}
+		}
+		logger.Error().Err(err).Msg("error when backfill index, retrying")
+		select {
+		case <-s.stop:
+			return false
+		case <-time.After(backfillRetryInterval):
+		}
+	}
+}
+
+// Reindex forces to rebuild the index of the blocks from startHeight to endHeight, e.g., the ones indexed by a buggy
+// version
+func (s *Server) Reindex(startHeight, endHeight uint64) error {
+	return s.idx.Reindex(startHeight, endHeight, s.cfg.Indexer.BackfillBatchSize, s.stop)
+}
+
 // Indexer return indexer interface
 func (s *Server) Indexer() *Indexer { return s.idx }
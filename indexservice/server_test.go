@@ -6,10 +6,13 @@
 package indexservice
 
 import (
+	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,3 +37,22 @@ func TestServer(t *testing.T) {
 	err = svr.Stop(nil)
 	require.Nil(err)
 }
+
+func TestServer_SQLite(t *testing.T) {
+	require := require.New(t)
+
+	path := "/tmp/test-index-server-" + strconv.Itoa(rand.Int())
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+	cfg := config.Default
+	cfg.DB.RDS.Dialect = config.SQLiteDialect
+	cfg.DB.RDS.SQLitePath = path
+
+	bc := blockchain.NewBlockchain(cfg, blockchain.InMemDaoOption())
+	svr := NewServer(cfg, bc)
+	require.NoError(svr.Start(nil))
+	// the schema is migrated on start
+	_, err := svr.idx.rds.GetDB().Exec("SELECT * FROM transfer_history WHERE node_address=?", "aaa")
+	require.NoError(err)
+	require.NoError(svr.Stop(nil))
+}
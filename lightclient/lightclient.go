@@ -0,0 +1,316 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package lightclient
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/network"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/routine"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// headerNameSpace is the bucket name of the synced block headers, which are keyed by height
+	headerNameSpace = "lightHeaders"
+	// syncBatchSize is the max number of headers requested from a peer at a time
+	syncBatchSize = 32
+)
+
+// tipHeightKey is the key of the height of the latest verified header
+var tipHeightKey = []byte("tipHeight")
+
+// ErrInvalidHeader indicates a header isn't committed by the delegates of its epoch
+var ErrInvalidHeader = errors.New("invalid block header")
+
+// LightClient follows the chain with the block headers and the endorsements committing them, instead of the whole
+// blocks and the state trie. The headers are verified against the delegates of their epochs, whose candidate list is
+// proven by the state root of an earlier header. States are read by requesting their trie proofs from full nodes.
+// The rollDPoS parameters in the config must be the ones of the chain being followed
+type LightClient interface {
+	lifecycle.StartStopper
+	// TipHeight returns the height of the latest verified header
+	TipHeight() uint64
+	// HeaderByHeight returns the verified header on a height, which is a block without actions
+	HeaderByHeight(uint64) (*blockchain.Block, error)
+	// ProcessBlockHeader takes a header from a peer, which is verified and applied once the headers below it are
+	ProcessBlockHeader(*blockchain.Block) error
+	// ProcessStateProof takes the response to a state proof request
+	ProcessStateProof(*pb.StateProofResponse) error
+	// AccountState returns the account state at the tip height, which is verified by a trie proof from a peer
+	AccountState(string) (*state.Account, error)
+}
+
+// lightClient implements LightClient
+type lightClient struct {
+	mutex        sync.RWMutex
+	cfg          config.Config
+	chain        blockchain.Blockchain // holds the trusted genesis block only
+	p2p          network.Overlay
+	kvstore      db.KVStore
+	task         *routine.RecurringTask
+	tip          *blockchain.Block
+	targetHeight uint64
+	announced    *blockchain.Block // the highest header from peers, which raises targetHeight once verified
+	pending      map[uint64]*blockchain.Block
+	rrIdx        int
+	proofs       *proofRequests
+	// the caches below are only accessed by the sync routine
+	delegates map[uint64][]string
+	seeds     map[uint64][]byte
+}
+
+// NewLightClient creates a light client storing the headers in the kvstore. The genesis block of the chain is trusted
+// as the first header
+func NewLightClient(
+	cfg config.Config,
+	chain blockchain.Blockchain,
+	p2p network.Overlay,
+	kvstore db.KVStore,
+) (LightClient, error) {
+	if chain == nil || p2p == nil || kvstore == nil {
+		return nil, errors.New("cannot create light client: missing param")
+	}
+	lc := &lightClient{
+		cfg:       cfg,
+		chain:     chain,
+		p2p:       p2p,
+		kvstore:   kvstore,
+		pending:   make(map[uint64]*blockchain.Block),
+		proofs:    newProofRequests(),
+		delegates: make(map[uint64][]string),
+		seeds:     make(map[uint64][]byte),
+	}
+	lc.task = routine.NewRecurringTask(lc.Sync, cfg.BlockSync.Interval)
+	return lc, nil
+}
+
+// Start loads the tip header, and starts syncing headers. The blockchain holding the genesis block must have been
+// started
+func (lc *lightClient) Start(ctx context.Context) error {
+	if err := lc.kvstore.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start header db")
+	}
+	tipHeight, err := lc.kvstore.Get(headerNameSpace, tipHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		if lc.tip, err = lc.HeaderByHeight(byteutil.BytesToUint64(tipHeight)); err != nil {
+			return err
+		}
+	case db.ErrNotExist, bolt.ErrBucketNotFound:
+		genesis, err := lc.chain.GetBlockByHeight(0)
+		if err != nil {
+			return errors.Wrap(err, "failed to get genesis block")
+		}
+		if err := lc.putHeader(genesis); err != nil {
+			return err
+		}
+	default:
+		return errors.Wrap(err, "failed to get the tip height of headers")
+	}
+	return lc.task.Start(ctx)
+}
+
+// Stop stops syncing headers
+func (lc *lightClient) Stop(ctx context.Context) error {
+	if err := lc.task.Stop(ctx); err != nil {
+		return err
+	}
+	return lc.kvstore.Stop(ctx)
+}
+
+// TipHeight returns the height of the latest verified header
+func (lc *lightClient) TipHeight() uint64 {
+	lc.mutex.RLock()
+	defer lc.mutex.RUnlock()
+
+	return lc.tip.Height()
+}
+
+// HeaderByHeight returns the verified header on a height
+func (lc *lightClient) HeaderByHeight(height uint64) (*blockchain.Block, error) {
+	data, err := lc.kvstore.Get(headerNameSpace, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get header on height %d", height)
+	}
+	container := &pb.BlockHeaderContainer{}
+	if err := proto.Unmarshal(data, container); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal header on height %d", height)
+	}
+	blk := &blockchain.Block{}
+	if err := blk.ConvertFromBlockHeaderContainer(container); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert header on height %d", height)
+	}
+	return blk, nil
+}
+
+// ProcessBlockHeader buffers a header from a peer, which is either broadcast as the latest block or synced on
+// request. It's verified later by the sync routine, since verifying it may need state proofs from peers
+func (lc *lightClient) ProcessBlockHeader(blk *blockchain.Block) error {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	height := blk.Height()
+	if height <= lc.tip.Height() {
+		return nil
+	}
+	if height > lc.targetHeight && (lc.announced == nil || height > lc.announced.Height()) {
+		lc.announced = blk
+	}
+	if height > lc.tip.Height()+2*syncBatchSize {
+		// too far ahead to be applied soon, the header is synced later
+		return nil
+	}
+	if _, ok := lc.pending[height]; !ok {
+		lc.pending[height] = blk
+	}
+	return nil
+}
+
+// ProcessStateProof takes the response to a state proof request
+func (lc *lightClient) ProcessStateProof(res *pb.StateProofResponse) error {
+	return lc.proofs.deliver(res)
+}
+
+// Sync applies the buffered headers following the tip, and requests the missing headers up to the target height
+func (lc *lightClient) Sync() {
+	lc.applyPending()
+	lc.updateTarget()
+
+	lc.mutex.Lock()
+	start := lc.tip.Height() + 1
+	end := lc.targetHeight
+	lc.mutex.Unlock()
+	if end < start {
+		return
+	}
+	if end >= start+syncBatchSize {
+		end = start + syncBatchSize - 1
+	}
+	peer, err := lc.nextPeer()
+	if err != nil {
+		logger.Debug().Err(err).Msg("No peer to sync headers with.")
+		return
+	}
+	if err := lc.p2p.Tell(lc.chain.ChainID(), peer, &pb.BlockHeaderSync{Start: start, End: end}); err != nil {
+		logger.Warn().Err(err).Msg("Failed to sync block headers.")
+	}
+}
+
+// applyPending verifies and applies the buffered headers following the tip in order. An invalid header is dropped, so
+// that it's requested again from another peer
+func (lc *lightClient) applyPending() {
+	for {
+		lc.mutex.Lock()
+		tip := lc.tip
+		for height := range lc.pending {
+			if height <= tip.Height() {
+				delete(lc.pending, height)
+			}
+		}
+		blk, ok := lc.pending[tip.Height()+1]
+		lc.mutex.Unlock()
+		if !ok {
+			return
+		}
+		err := lc.verifyHeader(blk, tip)
+		if err == nil {
+			err = lc.putHeader(blk)
+		}
+		if err != nil {
+			logger.Warn().
+				Err(err).
+				Uint64("height", blk.Height()).
+				Msg("Failed to apply block header.")
+			lc.mutex.Lock()
+			delete(lc.pending, blk.Height())
+			lc.mutex.Unlock()
+			return
+		}
+	}
+}
+
+// updateTarget raises the target height to the height of the announced header once it's verified to be produced by a
+// delegate of its epoch. The delegates of an epoch are known only after the header they are chosen on is synced, so
+// until then the target is raised up to that header only
+func (lc *lightClient) updateTarget() {
+	lc.mutex.RLock()
+	announced := lc.announced
+	tipHeight := lc.tip.Height()
+	lc.mutex.RUnlock()
+	if announced == nil {
+		return
+	}
+	target := announced.Height()
+	epochNum := lc.epochOf(target)
+	if height := lc.delegatesHeight(epochNum); height > tipHeight {
+		target = height
+	} else {
+		err := lc.verifyProducer(announced, epochNum)
+		lc.mutex.Lock()
+		if lc.announced == announced {
+			lc.announced = nil
+		}
+		lc.mutex.Unlock()
+		if err != nil {
+			logger.Warn().
+				Err(err).
+				Uint64("height", target).
+				Msg("Failed to verify announced block header.")
+			return
+		}
+	}
+	lc.mutex.Lock()
+	if target > lc.targetHeight {
+		lc.targetHeight = target
+	}
+	lc.mutex.Unlock()
+}
+
+// putHeader stores a verified header as the new tip
+func (lc *lightClient) putHeader(blk *blockchain.Block) error {
+	data, err := proto.Marshal(blk.ConvertToBlockHeaderContainer())
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal header on height %d", blk.Height())
+	}
+	height := byteutil.Uint64ToBytes(blk.Height())
+	batch := db.NewBatch()
+	batch.Put(headerNameSpace, height, data, "failed to put header on height %d", blk.Height())
+	batch.Put(headerNameSpace, tipHeightKey, height, "failed to put tip height")
+	if err := lc.kvstore.Commit(batch); err != nil {
+		return errors.Wrapf(err, "failed to store header on height %d", blk.Height())
+	}
+	lc.mutex.Lock()
+	lc.tip = blk
+	lc.mutex.Unlock()
+	return nil
+}
+
+// nextPeer picks the peers in turn
+func (lc *lightClient) nextPeer() (net.Addr, error) {
+	peers := lc.p2p.GetPeers()
+	if len(peers) == 0 {
+		return nil, errors.New("no peer exists")
+	}
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+	lc.rrIdx = (lc.rrIdx + 1) % len(peers)
+	return peers[lc.rrIdx], nil
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package lightclient
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/network/node"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_network"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/trie"
+)
+
+func TestLightClient(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.Chain.NumCandidates = 4
+	cfg.Chain.CandidatesRootHeight = 0
+	cfg.Consensus.RollDPoS.NumDelegates = 4
+	cfg.Consensus.RollDPoS.NumSubEpochs = 1
+	cfg.BlockSync.Interval = time.Hour
+
+	// the states committed by the genesis block, which are proven by the peer
+	tr, err := trie.NewTrie(db.NewMemKVStore(), trie.AccountKVNameSpace, trie.EmptyRoot)
+	require.NoError(err)
+	require.NoError(tr.Start(ctx))
+	delegates := []*iotxaddress.Address{
+		ta.Addrinfo["producer"],
+		ta.Addrinfo["alfa"],
+		ta.Addrinfo["bravo"],
+		ta.Addrinfo["charlie"],
+	}
+	candidates := state.CandidateList{}
+	for i, delegate := range delegates {
+		candidates = append(candidates, &state.Candidate{
+			Address:   delegate.RawAddress,
+			Votes:     big.NewInt(int64(10 + i)),
+			PublicKey: delegate.PublicKey,
+		})
+	}
+	sort.Sort(candidates)
+	data, err := candidates.Serialize()
+	require.NoError(err)
+	require.NoError(tr.Upsert(factory.CandidatesKey[:], data))
+	pkHash, err := iotxaddress.AddressToPKHash(ta.Addrinfo["alfa"].RawAddress)
+	require.NoError(err)
+	data, err = state.Serialize(&state.Account{Balance: big.NewInt(100), VotingWeight: big.NewInt(0)})
+	require.NoError(err)
+	require.NoError(tr.Upsert(pkHash[:], data))
+	root := tr.RootHash()
+	genesis := newTestHeader(t, 0, hash.ZeroHash32B, root, ta.Addrinfo["producer"])
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().Return(cfg.Chain.ID).AnyTimes()
+	chain.EXPECT().GetBlockByHeight(uint64(0)).Return(genesis, nil).Times(1)
+	p2p := mock_network.NewMockOverlay(ctrl)
+	p2p.EXPECT().GetPeers().Return([]net.Addr{node.NewTCPNode("127.0.0.1:4689")}).AnyTimes()
+	var lc LightClient
+	var headerSyncs []*pb.BlockHeaderSync
+	withholdProof := false
+	p2p.EXPECT().Tell(cfg.Chain.ID, gomock.Any(), gomock.Any()).Do(func(_ uint32, _ net.Addr, msg proto.Message) {
+		switch req := msg.(type) {
+		case *pb.BlockHeaderSync:
+			headerSyncs = append(headerSyncs, req)
+		case *pb.StateProofRequest:
+			proof, _ := tr.Prove(req.Key)
+			if withholdProof {
+				proof = nil
+			}
+			go func() {
+				require.NoError(lc.ProcessStateProof(&pb.StateProofResponse{
+					Height: req.Height,
+					Key:    req.Key,
+					Proof:  proof,
+				}))
+			}()
+		}
+	}).Return(nil).AnyTimes()
+
+	lc, err = NewLightClient(cfg, chain, p2p, db.NewMemKVStore())
+	require.NoError(err)
+	require.NoError(lc.Start(ctx))
+	defer func() {
+		require.NoError(lc.Stop(ctx))
+	}()
+	require.Equal(uint64(0), lc.TipHeight())
+
+	blk1 := newTestHeader(t, 1, genesis.HashBlock(), root, ta.Addrinfo["alfa"])
+	blk1.Footer = newTestFooter(t, blk1, delegates[1:]...)
+	blk2 := newTestHeader(t, 2, blk1.HashBlock(), root, ta.Addrinfo["bravo"])
+	blk2.Footer = newTestFooter(t, blk2, delegates...)
+
+	// the broadcast block 2 is buffered until block 1 is synced
+	require.NoError(lc.ProcessBlockHeader(blk2))
+	lc.(*lightClient).Sync()
+	require.Equal(uint64(0), lc.TipHeight())
+	require.Equal(1, len(headerSyncs))
+	require.Equal(uint64(1), headerSyncs[0].Start)
+	require.Equal(uint64(2), headerSyncs[0].End)
+
+	// 2 of 4 delegates aren't enough to commit a block
+	invalid := newTestHeader(t, 1, genesis.HashBlock(), root, ta.Addrinfo["alfa"])
+	invalid.Footer = newTestFooter(t, invalid, delegates[:2]...)
+	require.NoError(lc.ProcessBlockHeader(invalid))
+	lc.(*lightClient).Sync()
+	require.Equal(uint64(0), lc.TipHeight())
+	// a header produced by a non-delegate is rejected
+	invalid = newTestHeader(t, 1, genesis.HashBlock(), root, ta.Addrinfo["echo"])
+	invalid.Footer = newTestFooter(t, invalid, delegates...)
+	require.NoError(lc.ProcessBlockHeader(invalid))
+	lc.(*lightClient).Sync()
+	require.Equal(uint64(0), lc.TipHeight())
+	// an aggregate signature not signed by the delegates doesn't commit the block
+	invalid = newTestHeader(t, 1, genesis.HashBlock(), root, ta.Addrinfo["alfa"])
+	setPb := newTestFooter(t, invalid, delegates...).Endorsements().ToProto()
+	setPb.AggregateSignature = []byte{1}
+	setPb.Signers = []byte{0xf}
+	aggregate := &endorsement.Set{}
+	require.NoError(aggregate.FromProto(setPb))
+	invalid.Footer = blockchain.NewBlockFooter(aggregate, invalid.Height())
+	require.NoError(lc.ProcessBlockHeader(invalid))
+	lc.(*lightClient).Sync()
+	require.Equal(uint64(0), lc.TipHeight())
+	// a header not on the tip is rejected
+	invalid = newTestHeader(t, 1, hash.ZeroHash32B, root, ta.Addrinfo["alfa"])
+	invalid.Footer = newTestFooter(t, invalid, delegates...)
+	require.NoError(lc.ProcessBlockHeader(invalid))
+	lc.(*lightClient).Sync()
+	require.Equal(uint64(0), lc.TipHeight())
+
+	require.NoError(lc.ProcessBlockHeader(blk1))
+	lc.(*lightClient).Sync()
+	require.Equal(uint64(2), lc.TipHeight())
+	header, err := lc.HeaderByHeight(2)
+	require.NoError(err)
+	require.Equal(blk2.HashBlock(), header.HashBlock())
+	require.NotNil(header.Footer)
+
+	// the states are verified against the state root of the tip header
+	account, err := lc.AccountState(ta.Addrinfo["alfa"].RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(100), account.Balance)
+	// the absence of an account is proven as well
+	account, err = lc.AccountState(ta.Addrinfo["delta"].RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(0), account.Balance)
+	// a response without a proof doesn't prove the absence
+	withholdProof = true
+	_, err = lc.AccountState(ta.Addrinfo["alfa"].RawAddress)
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+	withholdProof = false
+
+	// the target height of syncing isn't raised by a header produced by a non-delegate
+	headerSyncs = nil
+	require.NoError(lc.ProcessBlockHeader(newTestHeader(t, 3, blk2.HashBlock(), root, ta.Addrinfo["echo"])))
+	lc.(*lightClient).Sync()
+	require.Equal(0, len(headerSyncs))
+	// the delegates of a later epoch are unknown before the header they are chosen on is synced, so the target height
+	// is raised up to that header only
+	require.NoError(lc.ProcessBlockHeader(newTestHeader(t, 20, hash.ZeroHash32B, root, ta.Addrinfo["echo"])))
+	lc.(*lightClient).Sync()
+	require.Equal(1, len(headerSyncs))
+	require.Equal(uint64(3), headerSyncs[0].Start)
+	require.Equal(uint64(16), headerSyncs[0].End)
+}
+
+func TestVerifyEndorsements(t *testing.T) {
+	require := require.New(t)
+
+	delegates := []string{
+		ta.Addrinfo["producer"].RawAddress,
+		ta.Addrinfo["alfa"].RawAddress,
+		ta.Addrinfo["bravo"].RawAddress,
+		ta.Addrinfo["charlie"].RawAddress,
+	}
+	blk := newTestHeader(t, 1, hash.ZeroHash32B, hash.ZeroHash32B, ta.Addrinfo["producer"])
+	set := endorsement.NewSet(blk.HashBlock())
+	endorse := func(addr *iotxaddress.Address, height uint64, topic endorsement.ConsensusVoteTopic) {
+		vote := endorsement.NewConsensusVote(blk.HashBlock(), height, 0, topic)
+		require.NoError(set.AddEndorsement(endorsement.NewEndorsement(vote, addr)))
+	}
+	endorse(ta.Addrinfo["producer"], 1, endorsement.LOCK)
+	endorse(ta.Addrinfo["alfa"], 1, endorsement.COMMIT)
+	// proposal endorsements, endorsements on another height and endorsements of non-delegates aren't counted
+	endorse(ta.Addrinfo["bravo"], 1, endorsement.PROPOSAL)
+	endorse(ta.Addrinfo["charlie"], 2, endorsement.LOCK)
+	endorse(ta.Addrinfo["delta"], 1, endorsement.LOCK)
+	require.Equal(ErrInvalidHeader, errors.Cause(verifyEndorsements(blk, set, delegates, nil, endorsement.Quorum(len(delegates)))))
+	// the lock and commit endorsements of a delegate are counted once
+	endorse(ta.Addrinfo["producer"], 1, endorsement.COMMIT)
+	require.Equal(ErrInvalidHeader, errors.Cause(verifyEndorsements(blk, set, delegates, nil, endorsement.Quorum(len(delegates)))))
+
+	endorse(ta.Addrinfo["bravo"], 1, endorsement.LOCK)
+	require.NoError(verifyEndorsements(blk, set, delegates, nil, endorsement.Quorum(len(delegates))))
+}
+
+func newTestHeader(
+	t *testing.T,
+	height uint64,
+	prevHash hash.Hash32B,
+	stateRoot hash.Hash32B,
+	producer *iotxaddress.Address,
+) *blockchain.Block {
+	blk := &blockchain.Block{}
+	require.NoError(t, blk.ConvertFromBlockHeaderContainer(&pb.BlockHeaderContainer{
+		Header: &pb.BlockHeaderPb{
+			Version:       version.ProtocolVersion,
+			ChainID:       config.Default.Chain.ID,
+			Height:        height,
+			Timestamp:     height,
+			PrevBlockHash: prevHash[:],
+			StateRoot:     stateRoot[:],
+			Pubkey:        producer.PublicKey[:],
+		},
+	}))
+	require.NoError(t, blk.SignBlock(producer))
+	return blk
+}
+
+func newTestFooter(t *testing.T, blk *blockchain.Block, endorsers ...*iotxaddress.Address) *blockchain.BlockFooter {
+	set := endorsement.NewSet(blk.HashBlock())
+	for _, endorser := range endorsers {
+		vote := endorsement.NewConsensusVote(blk.HashBlock(), blk.Height(), 0, endorsement.LOCK)
+		require.NoError(t, set.AddEndorsement(endorsement.NewEndorsement(vote, endorser)))
+	}
+	return blockchain.NewBlockFooter(set, blk.Height())
+}
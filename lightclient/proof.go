@@ -0,0 +1,153 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package lightclient
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/iotxaddress"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	pb "github.com/iotexproject/iotex-core/proto"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/trie"
+)
+
+// proofTimeout is how long a state proof request waits for the response
+const proofTimeout = 10 * time.Second
+
+// ErrProofTimeout indicates no state proof is received in time
+var ErrProofTimeout = errors.New("state proof request timed out")
+
+type proofKey struct {
+	height uint64
+	key    hash.PKHash
+}
+
+// proofRequests tracks the state proof requests waiting for responses
+type proofRequests struct {
+	mutex   sync.Mutex
+	waiters map[proofKey][]chan [][]byte
+}
+
+func newProofRequests() *proofRequests {
+	return &proofRequests{waiters: make(map[proofKey][]chan [][]byte)}
+}
+
+func (r *proofRequests) wait(k proofKey) chan [][]byte {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ch := make(chan [][]byte, 1)
+	r.waiters[k] = append(r.waiters[k], ch)
+	return ch
+}
+
+func (r *proofRequests) cancel(k proofKey, ch chan [][]byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	waiters := r.waiters[k]
+	for i, w := range waiters {
+		if w == ch {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(waiters) == 0 {
+		delete(r.waiters, k)
+		return
+	}
+	r.waiters[k] = waiters
+}
+
+// deliver passes a response to the requests waiting for it. The proof is verified by the requests
+func (r *proofRequests) deliver(res *pb.StateProofResponse) error {
+	if len(res.Key) != hash.PKHashSize {
+		return errors.Errorf("invalid state key %x", res.Key)
+	}
+	k := proofKey{height: res.Height, key: byteutil.BytesTo20B(res.Key)}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	waiters, ok := r.waiters[k]
+	if !ok {
+		return errors.Errorf("unexpected proof of state %x on height %d", res.Key, res.Height)
+	}
+	delete(r.waiters, k)
+	for _, ch := range waiters {
+		ch <- res.Proof
+	}
+	return nil
+}
+
+// AccountState returns the account state at the tip height. An account is regarded as not existing only if the peer
+// proves its absence
+func (lc *lightClient) AccountState(addr string) (*state.Account, error) {
+	pkHash, err := iotxaddress.AddressToPKHash(addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting the pubkey hash")
+	}
+	lc.mutex.RLock()
+	tip := lc.tip
+	lc.mutex.RUnlock()
+
+	data, err := lc.proveState(tip, pkHash)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return &state.Account{
+			Balance:      big.NewInt(0),
+			VotingWeight: big.NewInt(0),
+		}, nil
+	}
+	var account state.Account
+	if err := state.Deserialize(&account, data); err != nil {
+		return nil, errors.Wrapf(err, "error when deserializing the state of %x", pkHash)
+	}
+	return &account, nil
+}
+
+// proveState requests the trie proof of a state on the height of a header from a peer, and returns the state verified
+// against the state root of the header. It returns nil if the proof verifies the absence of the state
+func (lc *lightClient) proveState(header *blockchain.Block, key hash.PKHash) ([]byte, error) {
+	peer, err := lc.nextPeer()
+	if err != nil {
+		return nil, err
+	}
+	k := proofKey{height: header.Height(), key: key}
+	ch := lc.proofs.wait(k)
+	if err := lc.p2p.Tell(lc.chain.ChainID(), peer, &pb.StateProofRequest{Height: k.height, Key: key[:]}); err != nil {
+		lc.proofs.cancel(k, ch)
+		return nil, errors.Wrapf(err, "failed to request the proof of state %x", key)
+	}
+	var proof [][]byte
+	select {
+	case proof = <-ch:
+	case <-time.After(proofTimeout):
+		lc.proofs.cancel(k, ch)
+		return nil, errors.Wrapf(ErrProofTimeout, "no proof of state %x on height %d", key, k.height)
+	}
+	if len(proof) == 0 {
+		return nil, errors.Wrapf(trie.ErrInvalidProof, "empty proof of state %x on height %d", key, k.height)
+	}
+	data, err := trie.VerifyProof(header.StateRoot(), key[:], proof)
+	switch errors.Cause(err) {
+	case nil:
+		return data, nil
+	case trie.ErrNotExist:
+		return nil, nil
+	default:
+		return nil, errors.Wrapf(err, "failed to verify the proof of state %x on height %d", key, k.height)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package lightclient
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/consensus/evidence"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+// verifyHeader verifies that a header follows the tip, and is produced and committed by the delegates of its epoch.
+// The aggregate endorsements are verified with the DKG public keys the delegates put into the verified headers of the
+// epoch, and their signers refer to the delegates the full nodes aggregate them against
+func (lc *lightClient) verifyHeader(blk *blockchain.Block, tip *blockchain.Block) error {
+	if blk.Height() != tip.Height()+1 {
+		return errors.Wrapf(ErrInvalidHeader, "height %d doesn't follow tip height %d", blk.Height(), tip.Height())
+	}
+	if blk.PrevHash() != tip.HashBlock() {
+		return errors.Wrapf(ErrInvalidHeader, "previous hash %x doesn't match tip hash %x", blk.PrevHash(), tip.HashBlock())
+	}
+	epochNum := lc.epochOf(blk.Height())
+	if err := lc.verifyProducer(blk, epochNum); err != nil {
+		return err
+	}
+	delegates, err := lc.rollingDelegates(epochNum)
+	if err != nil {
+		return err
+	}
+	if blk.Footer == nil || blk.Footer.Endorsements() == nil {
+		return errors.Wrapf(ErrInvalidHeader, "no endorsement on block %d", blk.Height())
+	}
+	set := blk.Footer.Endorsements()
+	if set.BlockHash() != blk.HashBlock() {
+		return errors.Wrapf(ErrInvalidHeader, "endorsements are on another block %x", set.BlockHash())
+	}
+	var dkgPubkeys map[string][]byte
+	if set.IsAggregate() {
+		if delegates, err = evidence.NewDelegatesFunc(lc.cfg, lc.candidatesByHeight)(blk.Height()); err != nil {
+			return err
+		}
+		if dkgPubkeys, err = lc.dkgPubkeys(blk, epochNum); err != nil {
+			return err
+		}
+	}
+	quorum := endorsement.Quorum(int(lc.cfg.Consensus.RollDPoS.NumDelegates))
+	return verifyEndorsements(blk, set, delegates, dkgPubkeys, quorum)
+}
+
+// dkgPubkeys collects the DKG public keys of the delegates from the headers of the epoch up to the header, which are
+// signed by their producers
+func (lc *lightClient) dkgPubkeys(blk *blockchain.Block, epochNum uint64) (map[string][]byte, error) {
+	numDlgs := uint64(lc.cfg.Consensus.RollDPoS.NumDelegates)
+	epochHeight := numDlgs*uint64(lc.numSubEpochs())*(epochNum-1) + 1
+	pubkeys := make(map[string][]byte)
+	for h := epochHeight; h < blk.Height(); h++ {
+		header, err := lc.HeaderByHeight(h)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get header on height %d", h)
+		}
+		if len(header.Header.DKGPubkey) > 0 {
+			pubkeys[header.ProducerAddress()] = header.Header.DKGPubkey
+		}
+	}
+	if len(blk.Header.DKGPubkey) > 0 {
+		pubkeys[blk.ProducerAddress()] = blk.Header.DKGPubkey
+	}
+	return pubkeys, nil
+}
+
+// verifyProducer verifies that a header is signed by its producer, which is a delegate of the epoch
+func (lc *lightClient) verifyProducer(blk *blockchain.Block, epochNum uint64) error {
+	if !blk.VerifySignature() {
+		return errors.Wrapf(ErrInvalidHeader, "invalid signature of producer %s", blk.ProducerAddress())
+	}
+	delegates, err := lc.rollingDelegates(epochNum)
+	if err != nil {
+		return err
+	}
+	if !contains(delegates, blk.ProducerAddress()) {
+		return errors.Wrapf(ErrInvalidHeader, "producer %s isn't a delegate of epoch %d", blk.ProducerAddress(), epochNum)
+	}
+	return nil
+}
+
+// epochOf returns the epoch ordinal number of a height, the same as rollDPoS does
+func (lc *lightClient) epochOf(height uint64) uint64 {
+	numDlgs := uint64(lc.cfg.Consensus.RollDPoS.NumDelegates)
+	numSubEpochs := uint64(lc.numSubEpochs())
+	return (height-1)/(numDlgs*numSubEpochs) + 1
+}
+
+// numSubEpochs returns max(configured number, 1), plus the DKG sub-epoch if enabled
+func (lc *lightClient) numSubEpochs() uint {
+	num := uint(1)
+	if lc.cfg.Consensus.RollDPoS.NumSubEpochs > 0 {
+		num = lc.cfg.Consensus.RollDPoS.NumSubEpochs
+	}
+	if lc.cfg.Consensus.RollDPoS.EnableDKG {
+		num++
+	}
+	return num
+}
+
+// rollingDelegates returns the delegates of an epoch. The candidates are proven by the state root of the header on
+// the height the delegates are chosen from, which has been verified before the epoch starts
+func (lc *lightClient) rollingDelegates(epochNum uint64) ([]string, error) {
+	if delegates, ok := lc.delegates[epochNum]; ok {
+		return delegates, nil
+	}
+	numDlgs := lc.cfg.Consensus.RollDPoS.NumDelegates
+	height := lc.delegatesHeight(epochNum)
+	candidates, err := lc.candidatesByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) < int(numDlgs) {
+		return nil, errors.Errorf("only %d candidates on height %d for %d delegates", len(candidates), height, numDlgs)
+	}
+	addrs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		addrs = append(addrs, candidate.Address)
+	}
+	crypto.SortCandidates(addrs, epochNum, lc.seed(epochNum))
+	delegates := addrs[:numDlgs]
+	lc.delegates[epochNum] = delegates
+	return delegates, nil
+}
+
+// candidatesByHeight returns the candidates on a height the same as the state factory does, which are proven by the
+// state root of the verified header on the height
+func (lc *lightClient) candidatesByHeight(height uint64) ([]*state.Candidate, error) {
+	if height < lc.cfg.Chain.CandidatesRootHeight {
+		return nil, errors.Errorf("candidates on height %d are not committed by the state root", height)
+	}
+	header, err := lc.HeaderByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	data, err := lc.proveState(header, factory.CandidatesKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when proving the candidates on height %d", height)
+	}
+	var candidates state.CandidateList
+	if data != nil {
+		if err := candidates.Deserialize(data); err != nil {
+			return nil, errors.Wrapf(err, "failed to deserialize the candidates on height %d", height)
+		}
+	}
+	if len(candidates) > int(lc.cfg.Chain.NumCandidates) {
+		candidates = candidates[:lc.cfg.Chain.NumCandidates]
+	}
+	return candidates, nil
+}
+
+// delegatesHeight returns the height whose candidates the delegates of an epoch are chosen from, the same as rollDPoS
+// does
+func (lc *lightClient) delegatesHeight(epochNum uint64) uint64 {
+	rolldpos := lc.cfg.Consensus.RollDPoS
+	return uint64(rolldpos.NumDelegates) * uint64(rolldpos.NumSubEpochs) * (epochNum - 1)
+}
+
+// seed returns the seed of an epoch the same as rollDPoS does. With DKG, it's the aggregate of the DKG signatures in
+// the headers of the previous epoch, or the hash of the previous seed if they can't be aggregated
+func (lc *lightClient) seed(epochNum uint64) []byte {
+	if !lc.cfg.Consensus.RollDPoS.EnableDKG || epochNum <= 1 {
+		return crypto.CryptoSeed
+	}
+	if seed, ok := lc.seeds[epochNum]; ok {
+		return seed
+	}
+	prevSeed := lc.seed(epochNum - 1)
+	numDlgs := uint64(lc.cfg.Consensus.RollDPoS.NumDelegates)
+	epochHeight := numDlgs*uint64(lc.numSubEpochs())*(epochNum-1) + 1
+	selectedID := make([][]uint8, 0)
+	selectedSig := make([][]byte, 0)
+	selectedPK := make([][]byte, 0)
+	start := numDlgs*uint64(lc.cfg.Consensus.RollDPoS.NumSubEpochs)*(epochNum-2) + 1
+	for h := start; h < epochHeight && len(selectedID) <= crypto.Degree; h++ {
+		blk, err := lc.HeaderByHeight(h)
+		if err != nil {
+			continue
+		}
+		if len(blk.Header.DKGID) > 0 && len(blk.Header.DKGPubkey) > 0 && len(blk.Header.DKGBlockSig) > 0 {
+			selectedID = append(selectedID, blk.Header.DKGID)
+			selectedSig = append(selectedSig, blk.Header.DKGBlockSig)
+			selectedPK = append(selectedPK, blk.Header.DKGPubkey)
+		}
+	}
+	seed := hash.Hash256b(prevSeed)
+	if len(selectedID) > crypto.Degree {
+		aggregateSig, err := crypto.BLS.SignAggregate(selectedID, selectedSig)
+		if err == nil {
+			err = crypto.BLS.VerifyAggregate(selectedID, selectedPK, prevSeed, aggregateSig)
+		}
+		if err == nil {
+			seed = aggregateSig
+		} else {
+			logger.Debug().Err(err).Uint64("epoch", epochNum).Msg("Failed to aggregate DKG signatures into seed.")
+		}
+	}
+	lc.seeds[epochNum] = seed
+	return seed
+}
+
+// verifyEndorsements checks that a quorum of the delegates have locked or committed the block, which is what rollDPoS
+// requires to commit a block
+func verifyEndorsements(
+	blk *blockchain.Block,
+	set *endorsement.Set,
+	delegates []string,
+	dkgPubkeys map[string][]byte,
+	quorum int,
+) error {
+	if err := set.VerifyCommit(blk.Height(), delegates, dkgPubkeys, quorum); err != nil {
+		return errors.Wrapf(ErrInvalidHeader, "block %d isn't committed: %v", blk.Height(), err)
+	}
+	return nil
+}
+
+func contains(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
@@ -33,7 +33,7 @@ func (m *TransferPb) Reset()         { *m = TransferPb{} }
 func (m *TransferPb) String() string { return proto.CompactTextString(m) }
 func (*TransferPb) ProtoMessage()    {}
 func (*TransferPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{0}
+	return fileDescriptor_action_0555573be280cdf2, []int{0}
 }
 func (m *TransferPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransferPb.Unmarshal(m, b)
@@ -93,7 +93,7 @@ func (m *VotePb) Reset()         { *m = VotePb{} }
 func (m *VotePb) String() string { return proto.CompactTextString(m) }
 func (*VotePb) ProtoMessage()    {}
 func (*VotePb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{1}
+	return fileDescriptor_action_0555573be280cdf2, []int{1}
 }
 func (m *VotePb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VotePb.Unmarshal(m, b)
@@ -140,7 +140,7 @@ func (m *ExecutionPb) Reset()         { *m = ExecutionPb{} }
 func (m *ExecutionPb) String() string { return proto.CompactTextString(m) }
 func (*ExecutionPb) ProtoMessage()    {}
 func (*ExecutionPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{2}
+	return fileDescriptor_action_0555573be280cdf2, []int{2}
 }
 func (m *ExecutionPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecutionPb.Unmarshal(m, b)
@@ -193,7 +193,7 @@ func (m *SecretProposalPb) Reset()         { *m = SecretProposalPb{} }
 func (m *SecretProposalPb) String() string { return proto.CompactTextString(m) }
 func (*SecretProposalPb) ProtoMessage()    {}
 func (*SecretProposalPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{3}
+	return fileDescriptor_action_0555573be280cdf2, []int{3}
 }
 func (m *SecretProposalPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SecretProposalPb.Unmarshal(m, b)
@@ -238,7 +238,7 @@ func (m *SecretWitnessPb) Reset()         { *m = SecretWitnessPb{} }
 func (m *SecretWitnessPb) String() string { return proto.CompactTextString(m) }
 func (*SecretWitnessPb) ProtoMessage()    {}
 func (*SecretWitnessPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{4}
+	return fileDescriptor_action_0555573be280cdf2, []int{4}
 }
 func (m *SecretWitnessPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SecretWitnessPb.Unmarshal(m, b)
@@ -281,7 +281,7 @@ func (m *StartSubChainPb) Reset()         { *m = StartSubChainPb{} }
 func (m *StartSubChainPb) String() string { return proto.CompactTextString(m) }
 func (*StartSubChainPb) ProtoMessage()    {}
 func (*StartSubChainPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{5}
+	return fileDescriptor_action_0555573be280cdf2, []int{5}
 }
 func (m *StartSubChainPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StartSubChainPb.Unmarshal(m, b)
@@ -349,7 +349,7 @@ func (m *StopSubChainPb) Reset()         { *m = StopSubChainPb{} }
 func (m *StopSubChainPb) String() string { return proto.CompactTextString(m) }
 func (*StopSubChainPb) ProtoMessage()    {}
 func (*StopSubChainPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{6}
+	return fileDescriptor_action_0555573be280cdf2, []int{6}
 }
 func (m *StopSubChainPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StopSubChainPb.Unmarshal(m, b)
@@ -403,7 +403,7 @@ func (m *PutBlockPb) Reset()         { *m = PutBlockPb{} }
 func (m *PutBlockPb) String() string { return proto.CompactTextString(m) }
 func (*PutBlockPb) ProtoMessage()    {}
 func (*PutBlockPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{7}
+	return fileDescriptor_action_0555573be280cdf2, []int{7}
 }
 func (m *PutBlockPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutBlockPb.Unmarshal(m, b)
@@ -456,7 +456,7 @@ func (m *CreateDepositPb) Reset()         { *m = CreateDepositPb{} }
 func (m *CreateDepositPb) String() string { return proto.CompactTextString(m) }
 func (*CreateDepositPb) ProtoMessage()    {}
 func (*CreateDepositPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{8}
+	return fileDescriptor_action_0555573be280cdf2, []int{8}
 }
 func (m *CreateDepositPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDepositPb.Unmarshal(m, b)
@@ -503,7 +503,7 @@ func (m *SettleDepositPb) Reset()         { *m = SettleDepositPb{} }
 func (m *SettleDepositPb) String() string { return proto.CompactTextString(m) }
 func (*SettleDepositPb) ProtoMessage()    {}
 func (*SettleDepositPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{9}
+	return fileDescriptor_action_0555573be280cdf2, []int{9}
 }
 func (m *SettleDepositPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SettleDepositPb.Unmarshal(m, b)
@@ -555,7 +555,7 @@ func (m *CreatePlumChainPb) Reset()         { *m = CreatePlumChainPb{} }
 func (m *CreatePlumChainPb) String() string { return proto.CompactTextString(m) }
 func (*CreatePlumChainPb) ProtoMessage()    {}
 func (*CreatePlumChainPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{10}
+	return fileDescriptor_action_0555573be280cdf2, []int{10}
 }
 func (m *CreatePlumChainPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreatePlumChainPb.Unmarshal(m, b)
@@ -586,7 +586,7 @@ func (m *TerminatePlumChainPb) Reset()         { *m = TerminatePlumChainPb{} }
 func (m *TerminatePlumChainPb) String() string { return proto.CompactTextString(m) }
 func (*TerminatePlumChainPb) ProtoMessage()    {}
 func (*TerminatePlumChainPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{11}
+	return fileDescriptor_action_0555573be280cdf2, []int{11}
 }
 func (m *TerminatePlumChainPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TerminatePlumChainPb.Unmarshal(m, b)
@@ -626,7 +626,7 @@ func (m *PlumPutBlockPb) Reset()         { *m = PlumPutBlockPb{} }
 func (m *PlumPutBlockPb) String() string { return proto.CompactTextString(m) }
 func (*PlumPutBlockPb) ProtoMessage()    {}
 func (*PlumPutBlockPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{12}
+	return fileDescriptor_action_0555573be280cdf2, []int{12}
 }
 func (m *PlumPutBlockPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumPutBlockPb.Unmarshal(m, b)
@@ -680,7 +680,7 @@ func (m *PlumCreateDepositPb) Reset()         { *m = PlumCreateDepositPb{} }
 func (m *PlumCreateDepositPb) String() string { return proto.CompactTextString(m) }
 func (*PlumCreateDepositPb) ProtoMessage()    {}
 func (*PlumCreateDepositPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{13}
+	return fileDescriptor_action_0555573be280cdf2, []int{13}
 }
 func (m *PlumCreateDepositPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumCreateDepositPb.Unmarshal(m, b)
@@ -729,6 +729,7 @@ type PlumStartExitPb struct {
 	ExitTransfer                []byte   `protobuf:"bytes,5,opt,name=exitTransfer,proto3" json:"exitTransfer,omitempty"`
 	ExitTransferBlockProof      []byte   `protobuf:"bytes,6,opt,name=exitTransferBlockProof,proto3" json:"exitTransferBlockProof,omitempty"`
 	ExitTransferBlockHeight     uint64   `protobuf:"varint,7,opt,name=exitTransferBlockHeight,proto3" json:"exitTransferBlockHeight,omitempty"`
+	CoinID                      uint64   `protobuf:"varint,8,opt,name=coinID,proto3" json:"coinID,omitempty"`
 	XXX_NoUnkeyedLiteral        struct{} `json:"-"`
 	XXX_unrecognized            []byte   `json:"-"`
 	XXX_sizecache               int32    `json:"-"`
@@ -738,7 +739,7 @@ func (m *PlumStartExitPb) Reset()         { *m = PlumStartExitPb{} }
 func (m *PlumStartExitPb) String() string { return proto.CompactTextString(m) }
 func (*PlumStartExitPb) ProtoMessage()    {}
 func (*PlumStartExitPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{14}
+	return fileDescriptor_action_0555573be280cdf2, []int{14}
 }
 func (m *PlumStartExitPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumStartExitPb.Unmarshal(m, b)
@@ -807,6 +808,13 @@ func (m *PlumStartExitPb) GetExitTransferBlockHeight() uint64 {
 	return 0
 }
 
+func (m *PlumStartExitPb) GetCoinID() uint64 {
+	if m != nil {
+		return m.CoinID
+	}
+	return 0
+}
+
 type PlumChallengeExit struct {
 	SubChainAddress              string   `protobuf:"bytes,1,opt,name=subChainAddress,proto3" json:"subChainAddress,omitempty"`
 	CoinID                       uint64   `protobuf:"varint,2,opt,name=coinID,proto3" json:"coinID,omitempty"`
@@ -822,7 +830,7 @@ func (m *PlumChallengeExit) Reset()         { *m = PlumChallengeExit{} }
 func (m *PlumChallengeExit) String() string { return proto.CompactTextString(m) }
 func (*PlumChallengeExit) ProtoMessage()    {}
 func (*PlumChallengeExit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{15}
+	return fileDescriptor_action_0555573be280cdf2, []int{15}
 }
 func (m *PlumChallengeExit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumChallengeExit.Unmarshal(m, b)
@@ -893,7 +901,7 @@ func (m *PlumResponseChallengeExit) Reset()         { *m = PlumResponseChallenge
 func (m *PlumResponseChallengeExit) String() string { return proto.CompactTextString(m) }
 func (*PlumResponseChallengeExit) ProtoMessage()    {}
 func (*PlumResponseChallengeExit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{16}
+	return fileDescriptor_action_0555573be280cdf2, []int{16}
 }
 func (m *PlumResponseChallengeExit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumResponseChallengeExit.Unmarshal(m, b)
@@ -967,7 +975,7 @@ func (m *PlumFinalizeExit) Reset()         { *m = PlumFinalizeExit{} }
 func (m *PlumFinalizeExit) String() string { return proto.CompactTextString(m) }
 func (*PlumFinalizeExit) ProtoMessage()    {}
 func (*PlumFinalizeExit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{17}
+	return fileDescriptor_action_0555573be280cdf2, []int{17}
 }
 func (m *PlumFinalizeExit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumFinalizeExit.Unmarshal(m, b)
@@ -1013,7 +1021,7 @@ func (m *PlumSettleDepositPb) Reset()         { *m = PlumSettleDepositPb{} }
 func (m *PlumSettleDepositPb) String() string { return proto.CompactTextString(m) }
 func (*PlumSettleDepositPb) ProtoMessage()    {}
 func (*PlumSettleDepositPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{18}
+	return fileDescriptor_action_0555573be280cdf2, []int{18}
 }
 func (m *PlumSettleDepositPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumSettleDepositPb.Unmarshal(m, b)
@@ -1054,7 +1062,7 @@ func (m *PlumTransferPb) Reset()         { *m = PlumTransferPb{} }
 func (m *PlumTransferPb) String() string { return proto.CompactTextString(m) }
 func (*PlumTransferPb) ProtoMessage()    {}
 func (*PlumTransferPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{19}
+	return fileDescriptor_action_0555573be280cdf2, []int{19}
 }
 func (m *PlumTransferPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumTransferPb.Unmarshal(m, b)
@@ -1102,6 +1110,63 @@ func (m *PlumTransferPb) GetRecipient() string {
 	return ""
 }
 
+// proof of a delegate signing conflicting consensus messages
+type EvidencePb struct {
+	// 0: double endorsement, 1: double proposal
+	Type uint32 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	// the serialized EndorsePb or ProposePb messages in conflict
+	First                []byte   `protobuf:"bytes,2,opt,name=first,proto3" json:"first,omitempty"`
+	Second               []byte   `protobuf:"bytes,3,opt,name=second,proto3" json:"second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvidencePb) Reset()         { *m = EvidencePb{} }
+func (m *EvidencePb) String() string { return proto.CompactTextString(m) }
+func (*EvidencePb) ProtoMessage()    {}
+func (*EvidencePb) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0555573be280cdf2, []int{20}
+}
+func (m *EvidencePb) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvidencePb.Unmarshal(m, b)
+}
+func (m *EvidencePb) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvidencePb.Marshal(b, m, deterministic)
+}
+func (dst *EvidencePb) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvidencePb.Merge(dst, src)
+}
+func (m *EvidencePb) XXX_Size() int {
+	return xxx_messageInfo_EvidencePb.Size(m)
+}
+func (m *EvidencePb) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvidencePb.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvidencePb proto.InternalMessageInfo
+
+func (m *EvidencePb) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *EvidencePb) GetFirst() []byte {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *EvidencePb) GetSecond() []byte {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
 type ActionPb struct {
 	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 	// TODO: we should remove sender address later
@@ -1132,6 +1197,7 @@ type ActionPb struct {
 	//	*ActionPb_PlumFinalizeExit
 	//	*ActionPb_PlumSettleDeposit
 	//	*ActionPb_PlumTransfer
+	//	*ActionPb_Evidence
 	Action               isActionPb_Action `protobuf_oneof:"action"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -1142,7 +1208,7 @@ func (m *ActionPb) Reset()         { *m = ActionPb{} }
 func (m *ActionPb) String() string { return proto.CompactTextString(m) }
 func (*ActionPb) ProtoMessage()    {}
 func (*ActionPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{20}
+	return fileDescriptor_action_0555573be280cdf2, []int{21}
 }
 func (m *ActionPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActionPb.Unmarshal(m, b)
@@ -1165,7 +1231,6 @@ var xxx_messageInfo_ActionPb proto.InternalMessageInfo
 type isActionPb_Action interface {
 	isActionPb_Action()
 }
-
 type ActionPb_Transfer struct {
 	Transfer *TransferPb `protobuf:"bytes,10,opt,name=transfer,proto3,oneof"`
 }
@@ -1226,6 +1291,9 @@ type ActionPb_PlumSettleDeposit struct {
 type ActionPb_PlumTransfer struct {
 	PlumTransfer *PlumTransferPb `protobuf:"bytes,29,opt,name=plumTransfer,proto3,oneof"`
 }
+type ActionPb_Evidence struct {
+	Evidence *EvidencePb `protobuf:"bytes,30,opt,name=evidence,proto3,oneof"`
+}
 
 func (*ActionPb_Transfer) isActionPb_Action()                  {}
 func (*ActionPb_Vote) isActionPb_Action()                      {}
@@ -1247,6 +1315,7 @@ func (*ActionPb_PlumResponseChallengeExit) isActionPb_Action() {}
 func (*ActionPb_PlumFinalizeExit) isActionPb_Action()          {}
 func (*ActionPb_PlumSettleDeposit) isActionPb_Action()         {}
 func (*ActionPb_PlumTransfer) isActionPb_Action()              {}
+func (*ActionPb_Evidence) isActionPb_Action()                  {}
 
 func (m *ActionPb) GetAction() isActionPb_Action {
 	if m != nil {
@@ -1444,6 +1513,13 @@ func (m *ActionPb) GetPlumTransfer() *PlumTransferPb {
 	return nil
 }
 
+func (m *ActionPb) GetEvidence() *EvidencePb {
+	if x, ok := m.GetAction().(*ActionPb_Evidence); ok {
+		return x.Evidence
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*ActionPb) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _ActionPb_OneofMarshaler, _ActionPb_OneofUnmarshaler, _ActionPb_OneofSizer, []interface{}{
@@ -1467,6 +1543,7 @@ func (*ActionPb) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) erro
 		(*ActionPb_PlumFinalizeExit)(nil),
 		(*ActionPb_PlumSettleDeposit)(nil),
 		(*ActionPb_PlumTransfer)(nil),
+		(*ActionPb_Evidence)(nil),
 	}
 }
 
@@ -1574,6 +1651,11 @@ func _ActionPb_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.PlumTransfer); err != nil {
 			return err
 		}
+	case *ActionPb_Evidence:
+		b.EncodeVarint(30<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Evidence); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("ActionPb.Action has unexpected type %T", x)
@@ -1744,6 +1826,14 @@ func _ActionPb_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffe
 		err := b.DecodeMessage(msg)
 		m.Action = &ActionPb_PlumTransfer{msg}
 		return true, err
+	case 30: // action.evidence
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(EvidencePb)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionPb_Evidence{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1853,6 +1943,11 @@ func _ActionPb_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *ActionPb_Evidence:
+		s := proto.Size(x.Evidence)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1876,7 +1971,7 @@ func (m *ReceiptPb) Reset()         { *m = ReceiptPb{} }
 func (m *ReceiptPb) String() string { return proto.CompactTextString(m) }
 func (*ReceiptPb) ProtoMessage()    {}
 func (*ReceiptPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{21}
+	return fileDescriptor_action_0555573be280cdf2, []int{22}
 }
 func (m *ReceiptPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReceiptPb.Unmarshal(m, b)
@@ -1955,7 +2050,7 @@ func (m *LogPb) Reset()         { *m = LogPb{} }
 func (m *LogPb) String() string { return proto.CompactTextString(m) }
 func (*LogPb) ProtoMessage()    {}
 func (*LogPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_dbf0d6811452017d, []int{22}
+	return fileDescriptor_action_0555573be280cdf2, []int{23}
 }
 func (m *LogPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogPb.Unmarshal(m, b)
@@ -2047,104 +2142,108 @@ func init() {
 	proto.RegisterType((*PlumFinalizeExit)(nil), "iproto.PlumFinalizeExit")
 	proto.RegisterType((*PlumSettleDepositPb)(nil), "iproto.PlumSettleDepositPb")
 	proto.RegisterType((*PlumTransferPb)(nil), "iproto.PlumTransferPb")
+	proto.RegisterType((*EvidencePb)(nil), "iproto.EvidencePb")
 	proto.RegisterType((*ActionPb)(nil), "iproto.ActionPb")
 	proto.RegisterType((*ReceiptPb)(nil), "iproto.ReceiptPb")
 	proto.RegisterType((*LogPb)(nil), "iproto.LogPb")
 }
 
-func init() { proto.RegisterFile("action.proto", fileDescriptor_action_dbf0d6811452017d) }
-
-var fileDescriptor_action_dbf0d6811452017d = []byte{
-	// 1454 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xf7, 0x3a, 0x8e, 0x93, 0x3c, 0x3b, 0xb1, 0x33, 0x29, 0xe9, 0x24, 0x4d, 0x2b, 0x77, 0xc5,
-	0xc1, 0x2a, 0x10, 0xa1, 0x56, 0x82, 0x0a, 0x21, 0x68, 0x93, 0xa6, 0x6c, 0x69, 0x55, 0xac, 0x49,
-	0x29, 0x27, 0x0e, 0xeb, 0xf5, 0xc4, 0x59, 0xd5, 0xde, 0x59, 0xed, 0xcc, 0xa6, 0x09, 0xe2, 0xc0,
-	0x95, 0x8f, 0xc4, 0x81, 0x23, 0x12, 0x77, 0x3e, 0x10, 0x68, 0xfe, 0xac, 0x3d, 0xb3, 0xeb, 0xa4,
-	0x2d, 0x54, 0xe2, 0xe4, 0x7d, 0x6f, 0xde, 0x7b, 0xf3, 0xfe, 0xed, 0x7b, 0xbf, 0x35, 0xb4, 0xc3,
-	0x48, 0xc4, 0x2c, 0xd9, 0x4f, 0x33, 0x26, 0x18, 0x6a, 0xc6, 0xea, 0xd7, 0xff, 0x19, 0xe0, 0x45,
-	0x16, 0x26, 0xfc, 0x84, 0x66, 0x83, 0x21, 0xda, 0x86, 0x66, 0x38, 0x65, 0x79, 0x22, 0xb0, 0xd7,
-	0xf3, 0xfa, 0x6d, 0x62, 0x28, 0xb4, 0x07, 0x6b, 0x19, 0x8d, 0xe2, 0x34, 0xa6, 0x89, 0xc0, 0xf5,
-	0x9e, 0xd7, 0x5f, 0x23, 0x73, 0x06, 0xc2, 0xb0, 0x92, 0x86, 0x17, 0x13, 0x16, 0x8e, 0xf0, 0x92,
-	0x52, 0x2b, 0x48, 0x74, 0x0b, 0x20, 0xe6, 0x87, 0x2c, 0x4e, 0x86, 0x21, 0xa7, 0xb8, 0xd1, 0xf3,
-	0xfa, 0xab, 0xc4, 0xe2, 0xf8, 0xdf, 0x42, 0xf3, 0x25, 0x13, 0x74, 0x30, 0x94, 0x37, 0x88, 0x78,
-	0x4a, 0xb9, 0x08, 0xa7, 0xa9, 0xba, 0xbc, 0x41, 0xe6, 0x0c, 0xe4, 0x43, 0xfb, 0x8c, 0x09, 0x4a,
-	0x1f, 0x8e, 0x46, 0x19, 0xe5, 0xdc, 0xb8, 0xe0, 0xf0, 0xfc, 0xef, 0xa1, 0x75, 0x74, 0x4e, 0xa3,
-	0x5c, 0x06, 0x79, 0x45, 0x28, 0xbb, 0xb0, 0x1a, 0xb1, 0x44, 0x64, 0x61, 0x54, 0x44, 0x32, 0xa3,
-	0x11, 0x82, 0xc6, 0x28, 0x14, 0xa1, 0x89, 0x42, 0x3d, 0xfb, 0x01, 0x74, 0x8f, 0x69, 0x94, 0x51,
-	0x31, 0xc8, 0x58, 0xca, 0x78, 0x38, 0xd1, 0xce, 0xce, 0xd3, 0xe1, 0x95, 0xd3, 0xb1, 0x0d, 0x4d,
-	0xae, 0x34, 0x70, 0xbd, 0xb7, 0xd4, 0x5f, 0x27, 0x86, 0xf2, 0x3f, 0x82, 0x8e, 0xb6, 0xf4, 0x43,
-	0x2c, 0x12, 0xca, 0xf9, 0x60, 0x28, 0x33, 0xf7, 0x5a, 0x13, 0xd8, 0xeb, 0x2d, 0xc9, 0xcc, 0x19,
-	0xd2, 0xff, 0xcb, 0x83, 0xce, 0xb1, 0x08, 0x33, 0x71, 0x9c, 0x0f, 0x0f, 0x4f, 0xc3, 0x38, 0xd1,
-	0xd2, 0x91, 0x7c, 0x7c, 0xf2, 0x48, 0x5d, 0xba, 0x4e, 0x0a, 0x12, 0xf5, 0xa1, 0xc3, 0x69, 0x94,
-	0x67, 0xb1, 0xb8, 0x78, 0x44, 0x53, 0xc6, 0x63, 0x1d, 0x5b, 0x9b, 0x94, 0xd9, 0xe8, 0x0e, 0x74,
-	0x59, 0x4a, 0xb3, 0x50, 0x66, 0xa9, 0x10, 0xd5, 0xe1, 0x56, 0xf8, 0xa8, 0x07, 0x2d, 0x2e, 0x5d,
-	0x08, 0x68, 0x3c, 0x3e, 0x15, 0xaa, 0x7c, 0x0d, 0x62, 0xb3, 0xd0, 0x3e, 0xa0, 0x34, 0xcc, 0x68,
-	0x62, 0xe8, 0xef, 0x4e, 0x4e, 0x38, 0x15, 0x78, 0x59, 0x09, 0x2e, 0x38, 0xf1, 0x05, 0x6c, 0x1c,
-	0x0b, 0x96, 0xbe, 0x55, 0x4c, 0xb7, 0x00, 0xb8, 0x60, 0xa9, 0xb9, 0xbc, 0xae, 0x6c, 0x5a, 0x1c,
-	0x15, 0xb3, 0xb1, 0x53, 0xb4, 0xc5, 0x92, 0x2a, 0x45, 0x99, 0xed, 0xff, 0xee, 0x01, 0x0c, 0x72,
-	0x71, 0x30, 0x61, 0xd1, 0xab, 0xc1, 0x70, 0x91, 0xa2, 0xb7, 0x50, 0x51, 0x56, 0xf2, 0xd4, 0xbe,
-	0xde, 0x50, 0xe8, 0x1e, 0x2c, 0x67, 0x8c, 0x09, 0x79, 0xe1, 0x52, 0xbf, 0x75, 0xf7, 0xe6, 0xbe,
-	0x7e, 0x99, 0xf6, 0xe7, 0x97, 0xec, 0x13, 0x79, 0x7e, 0x94, 0x88, 0xec, 0x82, 0x68, 0xd9, 0xdd,
-	0xfb, 0x00, 0x73, 0x26, 0xea, 0xc2, 0xd2, 0x2b, 0x7a, 0x61, 0x2e, 0x96, 0x8f, 0xe8, 0x1a, 0x2c,
-	0x9f, 0x85, 0x93, 0x9c, 0x9a, 0xca, 0x69, 0xe2, 0x8b, 0xfa, 0x7d, 0xcf, 0xff, 0x06, 0x3a, 0x87,
-	0x19, 0x0d, 0x05, 0x35, 0x85, 0xf9, 0xb7, 0x2f, 0xaa, 0xff, 0xa3, 0xec, 0x40, 0x21, 0x26, 0xff,
-	0xd5, 0x90, 0xf4, 0x35, 0x4e, 0x46, 0xf4, 0x5c, 0x65, 0xbc, 0x41, 0x34, 0xe1, 0x6f, 0xc1, 0xa6,
-	0xf6, 0x73, 0x30, 0xc9, 0xa7, 0xa6, 0xc0, 0xfe, 0x03, 0xb8, 0xf6, 0x82, 0x66, 0xd3, 0x38, 0x71,
-	0xf9, 0x6f, 0x5f, 0x05, 0xff, 0x0f, 0x0f, 0x36, 0xa4, 0xe6, 0x7b, 0x2d, 0xe1, 0xe7, 0x6e, 0x09,
-	0x6f, 0xcf, 0x4a, 0xe8, 0x5c, 0xf4, 0x5e, 0xcb, 0x98, 0xc3, 0x96, 0x4a, 0x40, 0xa9, 0x94, 0xef,
-	0x14, 0x8b, 0xa9, 0x55, 0xfd, 0xf2, 0x5a, 0x2d, 0x95, 0x8b, 0xfe, 0x77, 0x1d, 0x3a, 0xf2, 0x5e,
-	0x35, 0x4d, 0x8e, 0xce, 0xdf, 0xf1, 0xce, 0x3b, 0xd0, 0x4d, 0x33, 0x7a, 0x16, 0xb3, 0x9c, 0x17,
-	0x7b, 0xc2, 0xdc, 0x5e, 0xe1, 0xa3, 0xaf, 0x60, 0xb7, 0xcc, 0xd3, 0x79, 0xcc, 0x18, 0x3b, 0x31,
-	0x53, 0xe6, 0x0a, 0x09, 0xf4, 0x00, 0x6e, 0x2c, 0x3c, 0x75, 0xe6, 0xcf, 0x55, 0x22, 0x72, 0x4f,
-	0xd0, 0xf3, 0x58, 0xcc, 0x3c, 0x5d, 0x56, 0x77, 0x3a, 0x3c, 0xf4, 0x19, 0x6c, 0xdb, 0xb4, 0xe5,
-	0x61, 0x53, 0x49, 0x5f, 0x72, 0x8a, 0xee, 0xc3, 0xf5, 0xca, 0x89, 0xf1, 0x6c, 0x45, 0x79, 0x76,
-	0xd9, 0xb1, 0xff, 0x6b, 0x1d, 0x36, 0x4d, 0xeb, 0x4f, 0x26, 0x34, 0x19, 0x53, 0x59, 0x85, 0x77,
-	0xab, 0x7b, 0xc4, 0xd4, 0x88, 0x34, 0x3d, 0xac, 0x29, 0xf4, 0x31, 0x6c, 0x46, 0x85, 0xc9, 0x59,
-	0xc8, 0x3a, 0xcd, 0xd5, 0x03, 0x99, 0xdd, 0x0a, 0xd3, 0x0a, 0xbe, 0xa1, 0xf4, 0xae, 0x12, 0x41,
-	0x07, 0xb0, 0xb7, 0xf8, 0xd8, 0xa4, 0x41, 0xcf, 0xfd, 0x2b, 0x65, 0xfc, 0xdf, 0xea, 0xb0, 0x23,
-	0x73, 0x41, 0x28, 0x4f, 0x59, 0xc2, 0xe9, 0xff, 0x9b, 0x93, 0x3b, 0xd0, 0xcd, 0x8c, 0x23, 0x33,
-	0x61, 0x9d, 0x88, 0x0a, 0x5f, 0x76, 0x77, 0x99, 0x67, 0xa5, 0x4f, 0x77, 0xda, 0x15, 0x12, 0x6f,
-	0xea, 0xee, 0xe6, 0x1b, 0xbb, 0xdb, 0x7f, 0x01, 0x5d, 0x99, 0xba, 0xc7, 0x71, 0x12, 0x4e, 0xe2,
-	0x9f, 0xde, 0x53, 0xc6, 0xfc, 0x4f, 0xf4, 0x58, 0x5a, 0xb0, 0x18, 0x8c, 0xb8, 0xe7, 0x88, 0xff,
-	0x62, 0xa6, 0xb1, 0x8b, 0x1a, 0x17, 0x89, 0xca, 0xb7, 0x71, 0x44, 0x13, 0xa6, 0x66, 0x7f, 0xcc,
-	0x12, 0x33, 0x37, 0x1c, 0x9e, 0x1c, 0x97, 0xec, 0x75, 0x62, 0x6a, 0xb4, 0x46, 0x34, 0xe1, 0x4e,
-	0xb4, 0x46, 0x65, 0xa2, 0xb5, 0x60, 0xf5, 0x61, 0x64, 0x70, 0x1e, 0x86, 0x95, 0x33, 0x9a, 0x71,
-	0x69, 0xdf, 0x00, 0x08, 0x43, 0x6a, 0x1c, 0x96, 0x8c, 0xcc, 0xc0, 0x5a, 0x23, 0x86, 0x92, 0x6e,
-	0xe9, 0xa7, 0x41, 0x3e, 0x7c, 0x4a, 0x2f, 0x4c, 0x77, 0x38, 0x3c, 0xe9, 0x56, 0xc2, 0x92, 0x88,
-	0x9a, 0xa1, 0xa3, 0x09, 0x89, 0x1d, 0xc7, 0x21, 0x7f, 0x16, 0x4f, 0xe3, 0xa2, 0xd9, 0x67, 0xb4,
-	0x39, 0x1b, 0x64, 0x71, 0x44, 0xcd, 0x20, 0x99, 0xd1, 0x32, 0x1c, 0x1e, 0x8f, 0x93, 0x50, 0xe4,
-	0x19, 0x55, 0xc3, 0xa2, 0x4d, 0xe6, 0x0c, 0xf4, 0x29, 0xac, 0x8a, 0xa2, 0xf9, 0xa0, 0xe7, 0xf5,
-	0x5b, 0x77, 0x51, 0xb1, 0x8d, 0xe6, 0x49, 0x0e, 0x6a, 0x64, 0x26, 0x85, 0x3e, 0x84, 0x86, 0x84,
-	0xbe, 0xb8, 0xa5, 0xa4, 0x37, 0x0a, 0x69, 0x0d, 0xa5, 0x83, 0x1a, 0x51, 0xa7, 0xe8, 0x1e, 0xac,
-	0xd1, 0x02, 0x10, 0xe3, 0xb6, 0x12, 0xdd, 0x2a, 0x44, 0x2d, 0xa4, 0x1c, 0xd4, 0xc8, 0x5c, 0x0e,
-	0x1d, 0xc0, 0x06, 0x77, 0xe0, 0x2e, 0x5e, 0x57, 0x9a, 0xb8, 0xd0, 0x2c, 0x83, 0xe1, 0xa0, 0x46,
-	0x4a, 0x1a, 0xe8, 0x6b, 0x58, 0xe7, 0x36, 0xd0, 0xc5, 0x1b, 0xca, 0xc4, 0x75, 0xd7, 0xc4, 0x0c,
-	0x05, 0x07, 0x35, 0xe2, 0xca, 0x2b, 0x03, 0x36, 0xf6, 0xc5, 0x9d, 0x92, 0x01, 0x17, 0x18, 0x2b,
-	0x03, 0x36, 0x0b, 0x7d, 0x09, 0x6d, 0x6e, 0xe1, 0x4c, 0xdc, 0x55, 0xfa, 0xdb, 0x73, 0x7d, 0x1b,
-	0x83, 0x06, 0x35, 0xe2, 0x48, 0xcb, 0x82, 0xa4, 0x06, 0x02, 0xe0, 0x4d, 0xb7, 0x20, 0x73, 0x68,
-	0x20, 0x0b, 0x52, 0x48, 0x49, 0x87, 0x23, 0x7b, 0xad, 0x63, 0xe4, 0x3a, 0x5c, 0xda, 0xf9, 0xd2,
-	0x61, 0x47, 0x5e, 0xa7, 0xcc, 0x7a, 0x01, 0xf1, 0x56, 0x39, 0x65, 0xce, 0xdb, 0xa9, 0x53, 0x66,
-	0xb1, 0xd0, 0x11, 0x74, 0x22, 0x17, 0x7b, 0xe1, 0x6b, 0xca, 0xc4, 0x8e, 0xeb, 0x83, 0x05, 0xc1,
-	0x82, 0x1a, 0x29, 0xeb, 0xa0, 0xe7, 0x80, 0x44, 0x05, 0xad, 0xe1, 0x0f, 0x94, 0xa5, 0xbd, 0x59,
-	0x57, 0x2e, 0xc0, 0x73, 0x41, 0x8d, 0x2c, 0xd0, 0x94, 0x85, 0x48, 0x2d, 0x44, 0x85, 0xb7, 0xdd,
-	0x42, 0xb8, 0x68, 0x4b, 0x16, 0xc2, 0x96, 0x46, 0x4f, 0x61, 0x33, 0x2d, 0x23, 0x26, 0x7c, 0x5d,
-	0x99, 0xb8, 0x61, 0x9b, 0xa8, 0xa6, 0xb7, 0xaa, 0x27, 0x53, 0x9c, 0xda, 0x30, 0x08, 0x63, 0x37,
-	0xc5, 0x25, 0x8c, 0x24, 0x53, 0xec, 0xc8, 0xa3, 0x27, 0xc6, 0x1b, 0x7b, 0x63, 0xe1, 0x1d, 0x37,
-	0xc9, 0x95, 0x35, 0x3f, 0xf3, 0xc5, 0xd9, 0x73, 0x21, 0xec, 0xa4, 0x97, 0x2d, 0x41, 0xbc, 0xab,
-	0x4c, 0x3a, 0x88, 0x74, 0xa1, 0x60, 0x50, 0x23, 0x97, 0x5b, 0x41, 0x8f, 0xa1, 0x9b, 0x96, 0x96,
-	0x05, 0xbe, 0xe1, 0xbe, 0xca, 0xe5, 0x65, 0x12, 0xd4, 0x48, 0x45, 0xa7, 0xa8, 0x81, 0xd3, 0x80,
-	0x78, 0xaf, 0x5a, 0x83, 0x6a, 0x87, 0x56, 0xf5, 0x8a, 0x76, 0x98, 0xed, 0xda, 0x9b, 0xd5, 0x76,
-	0x70, 0x46, 0x9e, 0x23, 0x7d, 0xb0, 0x0a, 0x4d, 0xfd, 0x1f, 0x86, 0xff, 0xa7, 0x07, 0x6b, 0x84,
-	0x46, 0x34, 0x4e, 0xe5, 0xaa, 0xea, 0x41, 0x2b, 0xa3, 0x22, 0xcf, 0x92, 0x97, 0x0a, 0x78, 0xeb,
-	0x0f, 0x19, 0x9b, 0xa5, 0x56, 0x81, 0x08, 0x45, 0xce, 0x8b, 0xdd, 0xa7, 0x29, 0xf9, 0xc1, 0x7f,
-	0x1a, 0xf2, 0xd3, 0xe2, 0x83, 0x5f, 0x3e, 0x4b, 0x6b, 0xe3, 0x90, 0x1f, 0xb2, 0x84, 0xe7, 0x53,
-	0x3a, 0x2a, 0xbe, 0x7a, 0x2d, 0x96, 0xdc, 0xb9, 0xc5, 0x5f, 0x06, 0xc5, 0xce, 0x5d, 0xd6, 0x3b,
-	0xb7, 0xc4, 0x46, 0xb7, 0xa1, 0x31, 0x61, 0x63, 0x8e, 0x9b, 0xea, 0x23, 0x63, 0xbd, 0x88, 0xf3,
-	0x19, 0x1b, 0x0f, 0x86, 0x44, 0x1d, 0xc9, 0x8f, 0xd3, 0x65, 0x45, 0xcb, 0x4d, 0x16, 0x3a, 0x2b,
-	0xbc, 0x20, 0xa5, 0xfb, 0x82, 0xa5, 0x71, 0xc4, 0xd5, 0x3f, 0x0a, 0x6d, 0x62, 0xa8, 0x45, 0xff,
-	0x57, 0x48, 0xf7, 0x87, 0xf2, 0xe5, 0x79, 0x9e, 0x4f, 0x87, 0x06, 0xcd, 0x34, 0x88, 0xcd, 0x92,
-	0xf7, 0x88, 0xf3, 0x24, 0x90, 0x71, 0x6b, 0xd4, 0x52, 0x90, 0x72, 0x4f, 0x29, 0x41, 0x75, 0xa6,
-	0x97, 0xd8, 0x9c, 0x31, 0xff, 0xe8, 0x5b, 0x51, 0x7b, 0x56, 0x13, 0xc3, 0xa6, 0x0a, 0xe9, 0xde,
-	0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xdc, 0x91, 0xb8, 0x1c, 0x5f, 0x12, 0x00, 0x00,
+func init() { proto.RegisterFile("action.proto", fileDescriptor_action_0555573be280cdf2) }
+
+var fileDescriptor_action_0555573be280cdf2 = []byte{
+	// 1503 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc5, 0x58, 0x4b, 0x6f, 0x1c, 0x45,
+	0x10, 0x66, 0x1f, 0x76, 0xec, 0xf2, 0xfa, 0xd5, 0x0e, 0xce, 0xd8, 0x71, 0xa2, 0x64, 0xc4, 0xc1,
+	0xe2, 0x61, 0xa1, 0x44, 0x82, 0x08, 0x21, 0x48, 0xe2, 0x38, 0x38, 0x24, 0x0a, 0xab, 0x71, 0x08,
+	0x27, 0x0e, 0xb3, 0xb3, 0x6d, 0x7b, 0x94, 0xdd, 0x99, 0xd1, 0x4c, 0x8f, 0x63, 0x23, 0x0e, 0x5c,
+	0xf9, 0x05, 0xfc, 0x0e, 0x8e, 0x1c, 0x38, 0x22, 0x71, 0xe7, 0x0f, 0x51, 0xd5, 0x8f, 0x9d, 0xee,
+	0x99, 0xb5, 0x93, 0x90, 0x48, 0x9c, 0x76, 0xaa, 0xba, 0x5e, 0x5d, 0xd5, 0x5d, 0xf5, 0xf5, 0x42,
+	0x2f, 0x8c, 0x44, 0x9c, 0x26, 0x3b, 0x59, 0x9e, 0x8a, 0x94, 0xcd, 0xc6, 0xf2, 0xd7, 0xff, 0x19,
+	0xe0, 0x59, 0x1e, 0x26, 0xc5, 0x21, 0xcf, 0xfb, 0x03, 0xb6, 0x0e, 0xb3, 0xe1, 0x38, 0x2d, 0x13,
+	0xe1, 0xb5, 0x6e, 0xb4, 0xb6, 0x7b, 0x81, 0xa6, 0xd8, 0x16, 0xcc, 0xe7, 0x3c, 0x8a, 0xb3, 0x98,
+	0xe3, 0x52, 0x1b, 0x97, 0xe6, 0x83, 0x8a, 0xc1, 0x3c, 0xb8, 0x94, 0x85, 0x67, 0xa3, 0x34, 0x1c,
+	0x7a, 0x1d, 0xa9, 0x66, 0x48, 0x76, 0x1d, 0x20, 0x2e, 0x76, 0xd3, 0x38, 0x19, 0x84, 0x05, 0xf7,
+	0xba, 0xb8, 0x38, 0x17, 0x58, 0x1c, 0xff, 0x5b, 0x98, 0x7d, 0x9e, 0x0a, 0x8e, 0x9e, 0xd1, 0x83,
+	0x88, 0xc7, 0xbc, 0x10, 0xe1, 0x38, 0x93, 0xce, 0xbb, 0x41, 0xc5, 0x60, 0x3e, 0xf4, 0x4e, 0x50,
+	0x8e, 0xdf, 0x1b, 0x0e, 0x73, 0x5e, 0x14, 0x3a, 0x04, 0x87, 0xe7, 0x7f, 0x0f, 0x0b, 0x7b, 0xa7,
+	0x3c, 0x2a, 0x69, 0x93, 0x17, 0x6c, 0x65, 0x13, 0xe6, 0xa2, 0x34, 0x11, 0x39, 0x66, 0x43, 0x9b,
+	0x99, 0xd0, 0x8c, 0x41, 0x77, 0x18, 0x8a, 0x50, 0xef, 0x42, 0x7e, 0xfb, 0xfb, 0xb0, 0x72, 0xc0,
+	0xa3, 0x9c, 0x8b, 0x7e, 0x9e, 0x66, 0x69, 0x11, 0x8e, 0x54, 0xb0, 0x55, 0x3a, 0x5a, 0xf5, 0x74,
+	0xa0, 0xe7, 0x42, 0x6a, 0xa0, 0xfd, 0xce, 0xf6, 0x62, 0xa0, 0x29, 0xff, 0x23, 0x58, 0x56, 0x96,
+	0x7e, 0x88, 0x45, 0x82, 0x11, 0xa3, 0x21, 0xcc, 0xdc, 0x4b, 0x45, 0xa0, 0x99, 0x0e, 0x65, 0x4e,
+	0x93, 0xfe, 0x3f, 0x2d, 0x94, 0x16, 0x61, 0x2e, 0x0e, 0xca, 0xc1, 0xee, 0x71, 0x18, 0x27, 0x4a,
+	0x3a, 0xa2, 0xcf, 0x47, 0x0f, 0xa4, 0xd3, 0xc5, 0xc0, 0x90, 0x6c, 0x1b, 0x96, 0xd1, 0x49, 0x99,
+	0xc7, 0xe2, 0xec, 0x01, 0xc7, 0x28, 0x63, 0xb5, 0xb7, 0x5e, 0x50, 0x67, 0xb3, 0x0f, 0x61, 0x25,
+	0xcd, 0x78, 0x1e, 0x52, 0x96, 0x8c, 0xa8, 0xda, 0x6e, 0x83, 0xcf, 0x6e, 0xc0, 0x42, 0x41, 0x21,
+	0xec, 0xf3, 0xf8, 0xe8, 0x58, 0xc8, 0xf2, 0x75, 0x03, 0x9b, 0xc5, 0x76, 0x80, 0x65, 0x61, 0x8e,
+	0x9b, 0x56, 0xf4, 0x77, 0x87, 0x87, 0x05, 0x6e, 0x7b, 0x46, 0x0a, 0x4e, 0x59, 0xf1, 0x05, 0x2c,
+	0x1d, 0x88, 0x34, 0x7b, 0xad, 0x3d, 0xe1, 0xd9, 0x29, 0x50, 0x56, 0x3b, 0x6f, 0x4b, 0x9b, 0x16,
+	0x47, 0xee, 0x59, 0xdb, 0x31, 0xc7, 0xa2, 0x23, 0x4b, 0x51, 0x67, 0xfb, 0x7f, 0xb6, 0x00, 0xfa,
+	0xa5, 0xb8, 0x3f, 0x4a, 0xa3, 0x17, 0xe8, 0x72, 0x8a, 0x62, 0x6b, 0xaa, 0x22, 0x55, 0xf2, 0xd8,
+	0x76, 0xaf, 0x29, 0x76, 0x1b, 0x66, 0xf2, 0x34, 0x15, 0xe4, 0xb0, 0xb3, 0xbd, 0x70, 0xeb, 0xda,
+	0x8e, 0xba, 0x4c, 0x3b, 0x95, 0x93, 0x9d, 0x80, 0xd6, 0xf7, 0xf0, 0x5c, 0x9d, 0x05, 0x4a, 0x76,
+	0xf3, 0x0e, 0x40, 0xc5, 0x64, 0x2b, 0xd0, 0x79, 0xc1, 0xcf, 0xb4, 0x63, 0xfa, 0x64, 0x97, 0x61,
+	0xe6, 0x24, 0x1c, 0x95, 0x5c, 0x57, 0x4e, 0x11, 0x5f, 0xb4, 0xef, 0xb4, 0xfc, 0x6f, 0x60, 0x79,
+	0x37, 0xe7, 0xa1, 0xe0, 0xba, 0x30, 0xff, 0xf5, 0xa2, 0xfa, 0x3f, 0xd2, 0x09, 0x14, 0x62, 0xf4,
+	0xb6, 0x86, 0x28, 0xd6, 0x38, 0x19, 0xf2, 0x53, 0x99, 0xf1, 0x6e, 0xa0, 0x08, 0x7f, 0x0d, 0x56,
+	0x55, 0x9c, 0xfd, 0x51, 0x39, 0xd6, 0x05, 0xf6, 0xef, 0xc2, 0xe5, 0x67, 0x3c, 0x1f, 0xc7, 0x89,
+	0xcb, 0x7f, 0xfd, 0x2a, 0xf8, 0x7f, 0xb5, 0x60, 0x89, 0x34, 0xdf, 0x69, 0x09, 0x3f, 0x77, 0x4b,
+	0x78, 0x73, 0x52, 0x42, 0xc7, 0xd1, 0x3b, 0x2d, 0x63, 0x09, 0x6b, 0x32, 0x01, 0xb5, 0x52, 0xbe,
+	0xd1, 0x5e, 0x74, 0xad, 0xda, 0xe7, 0xd7, 0xaa, 0x53, 0x2f, 0xfa, 0x6f, 0x1d, 0x58, 0x26, 0xbf,
+	0xb2, 0x9b, 0xec, 0x9d, 0xbe, 0xa1, 0x4f, 0xec, 0x17, 0x59, 0xce, 0x4f, 0xe2, 0xb4, 0x2c, 0xcc,
+	0x9c, 0xd0, 0xde, 0x1b, 0x7c, 0xf6, 0x15, 0x6c, 0xd6, 0x79, 0x2a, 0x8f, 0x98, 0xb9, 0x43, 0xdd,
+	0x65, 0x2e, 0x90, 0x60, 0x77, 0xe1, 0xea, 0xd4, 0x55, 0xa7, 0xff, 0x5c, 0x24, 0x42, 0x73, 0x82,
+	0xe3, 0x0e, 0x27, 0x91, 0xce, 0x48, 0x9f, 0x0e, 0x8f, 0x7d, 0x06, 0xeb, 0x36, 0x6d, 0x45, 0x38,
+	0x2b, 0xa5, 0xcf, 0x59, 0x65, 0x77, 0xe0, 0x4a, 0x63, 0x45, 0x47, 0x76, 0x49, 0x46, 0x76, 0xde,
+	0x32, 0xd5, 0x2d, 0x4a, 0x65, 0x8b, 0x9b, 0x53, 0x67, 0x50, 0x51, 0xfe, 0xaf, 0x6d, 0x58, 0xd5,
+	0x57, 0x62, 0x34, 0xe2, 0xc9, 0x11, 0xa7, 0xea, 0xbc, 0xd9, 0x79, 0xd0, 0x76, 0xdb, 0xb6, 0x5d,
+	0xf6, 0x31, 0xac, 0x46, 0xc6, 0xe4, 0x24, 0x15, 0x2a, 0xfd, 0xcd, 0x05, 0xca, 0x7a, 0x83, 0x69,
+	0x25, 0xa5, 0x2b, 0xf5, 0x2e, 0x12, 0x61, 0xf7, 0x61, 0x6b, 0xfa, 0xb2, 0x4e, 0x8f, 0x9a, 0x07,
+	0x17, 0xca, 0xf8, 0x7f, 0xb4, 0x61, 0x83, 0x72, 0x11, 0xf0, 0x22, 0x4b, 0x93, 0x82, 0xff, 0xbf,
+	0x39, 0xc1, 0x53, 0x9f, 0xeb, 0x40, 0x26, 0xc2, 0x2a, 0x11, 0x0d, 0x3e, 0x9d, 0xfa, 0x3a, 0xcf,
+	0x4a, 0x9f, 0x3a, 0x81, 0x17, 0x48, 0xbc, 0xea, 0xd4, 0xcf, 0xbe, 0xf2, 0xd4, 0xfb, 0xcf, 0x60,
+	0x85, 0x52, 0xf7, 0x10, 0x7b, 0xec, 0x28, 0xfe, 0xe9, 0x1d, 0x65, 0xcc, 0xff, 0x44, 0xb5, 0xab,
+	0x29, 0x03, 0x43, 0x8b, 0xb7, 0x1c, 0xf1, 0x5f, 0x74, 0x97, 0x76, 0xd1, 0xe4, 0x34, 0x51, 0xba,
+	0xa5, 0x43, 0x9e, 0xa4, 0x72, 0x26, 0x20, 0xdc, 0xd0, 0xfd, 0xc4, 0xe1, 0x51, 0x1b, 0x4d, 0x5f,
+	0x26, 0xba, 0x46, 0xf3, 0x81, 0x22, 0xdc, 0x4e, 0xd7, 0xad, 0x77, 0xba, 0xa7, 0x00, 0x7b, 0x27,
+	0x31, 0x9a, 0x89, 0x08, 0x51, 0x22, 0x98, 0x13, 0x67, 0x19, 0xd7, 0xb0, 0x42, 0x7e, 0x93, 0xd5,
+	0xc3, 0x38, 0x2f, 0x4c, 0x03, 0x55, 0x84, 0x06, 0x6c, 0x69, 0x62, 0xe0, 0xab, 0xa6, 0xfc, 0xdf,
+	0x7b, 0x30, 0x77, 0x2f, 0xd2, 0x78, 0x12, 0x81, 0xca, 0x09, 0xcf, 0x0b, 0x8a, 0x57, 0x03, 0x15,
+	0x4d, 0x2a, 0x75, 0x1c, 0x80, 0xb9, 0x9e, 0x93, 0x9a, 0xa2, 0x6d, 0xaa, 0xaf, 0x7e, 0x39, 0x78,
+	0x8c, 0x43, 0x42, 0x19, 0x77, 0x78, 0x14, 0x50, 0x92, 0x62, 0xbc, 0xba, 0xb9, 0x29, 0x82, 0x30,
+	0xea, 0x51, 0x58, 0x3c, 0x89, 0xc7, 0xb1, 0xb9, 0x3c, 0x13, 0x5a, 0xaf, 0xf5, 0xf3, 0x18, 0x95,
+	0x54, 0xc3, 0x9a, 0xd0, 0x94, 0x9e, 0x22, 0x3e, 0xc2, 0x0c, 0x96, 0x39, 0x97, 0x4d, 0xa9, 0x17,
+	0x54, 0x0c, 0xf6, 0x29, 0xcc, 0x09, 0x73, 0x98, 0x01, 0x17, 0x17, 0x6e, 0x31, 0x33, 0xf5, 0xaa,
+	0xa2, 0xed, 0xbf, 0x17, 0x4c, 0xa4, 0xd8, 0x07, 0xd0, 0x25, 0x88, 0xed, 0x2d, 0x48, 0xe9, 0x25,
+	0x23, 0xad, 0x20, 0x3b, 0x4a, 0xca, 0x55, 0x44, 0x43, 0xf3, 0xdc, 0x00, 0x6f, 0xaf, 0x27, 0x45,
+	0xd7, 0x8c, 0xa8, 0x85, 0xc8, 0x51, 0xbe, 0x92, 0xc3, 0x9e, 0xb1, 0x54, 0x38, 0xb0, 0xda, 0x5b,
+	0x94, 0x9a, 0x9e, 0xd1, 0xac, 0x83, 0x6e, 0x54, 0xaf, 0x69, 0xb0, 0xaf, 0x61, 0xb1, 0xb0, 0x01,
+	0xb5, 0xb7, 0x24, 0x4d, 0x5c, 0x71, 0x4d, 0x4c, 0xd0, 0x36, 0x5a, 0x70, 0xe5, 0xa5, 0x01, 0x1b,
+	0x63, 0x7b, 0xcb, 0x35, 0x03, 0x2e, 0x00, 0x97, 0x06, 0x6c, 0x16, 0xfb, 0x12, 0x4b, 0x6c, 0xe1,
+	0x59, 0x6f, 0x45, 0xea, 0xaf, 0x57, 0xfa, 0x36, 0xd6, 0x45, 0x75, 0x47, 0x9a, 0x0a, 0x92, 0x69,
+	0xa8, 0xe1, 0xad, 0xba, 0x05, 0xa9, 0x20, 0x08, 0x15, 0xc4, 0x48, 0x51, 0xc0, 0x91, 0x0d, 0x1f,
+	0x3c, 0xe6, 0x06, 0x5c, 0xc3, 0x16, 0x14, 0xb0, 0x23, 0xaf, 0x52, 0x66, 0x5d, 0x68, 0x6f, 0xad,
+	0x9e, 0x32, 0xe7, 0xb6, 0xab, 0x94, 0x59, 0x2c, 0xb6, 0x07, 0xcb, 0x91, 0x8b, 0xf1, 0xbc, 0xcb,
+	0xd2, 0xc4, 0x86, 0x1b, 0x83, 0x05, 0xf5, 0xd0, 0x48, 0x5d, 0x87, 0x3d, 0x05, 0x26, 0x1a, 0xa8,
+	0xd0, 0x7b, 0x5f, 0x5a, 0xda, 0x9a, 0x9c, 0xca, 0x29, 0xb8, 0x11, 0x8d, 0x4d, 0xd1, 0xa4, 0x42,
+	0x64, 0x16, 0x72, 0xf3, 0xd6, 0xdd, 0x42, 0xb8, 0xa8, 0x8e, 0x0a, 0x61, 0x4b, 0xb3, 0xc7, 0xb0,
+	0x9a, 0xd5, 0x91, 0x99, 0x77, 0x45, 0x9a, 0xb8, 0x6a, 0x9b, 0x68, 0xa6, 0xb7, 0xa9, 0x47, 0x29,
+	0xce, 0x6c, 0xb8, 0xe5, 0x79, 0x6e, 0x8a, 0x6b, 0x58, 0x8c, 0x52, 0xec, 0xc8, 0xb3, 0x47, 0x3a,
+	0x1a, 0x7b, 0x02, 0x7a, 0x1b, 0x6e, 0x92, 0x1b, 0xb0, 0x61, 0x12, 0x8b, 0x33, 0x37, 0x43, 0xd8,
+	0xc8, 0xce, 0x1b, 0xaa, 0xde, 0xa6, 0x34, 0xe9, 0x20, 0xdf, 0xa9, 0x82, 0x68, 0xfa, 0x7c, 0x2b,
+	0xec, 0x21, 0x02, 0xc4, 0xda, 0xf0, 0xf1, 0xae, 0xba, 0x57, 0xb9, 0x3e, 0x9c, 0xd0, 0x60, 0x43,
+	0xc7, 0xd4, 0xc0, 0x39, 0x80, 0xde, 0x56, 0xb3, 0x06, 0xcd, 0x13, 0xda, 0xd4, 0x33, 0xc7, 0x61,
+	0x32, 0xbb, 0xaf, 0x35, 0x8f, 0x83, 0xd3, 0xf2, 0x1c, 0x69, 0xba, 0x97, 0x5c, 0xcf, 0x11, 0xef,
+	0xba, 0x7b, 0x2f, 0xab, 0xf9, 0x42, 0xf7, 0xd2, 0x48, 0xdd, 0x9f, 0x43, 0x64, 0x2e, 0x07, 0x85,
+	0xff, 0x77, 0x0b, 0xe6, 0x03, 0x1e, 0xf1, 0x38, 0xa3, 0x61, 0x89, 0x2f, 0x68, 0x6c, 0x37, 0x65,
+	0x9e, 0x3c, 0x97, 0x4f, 0x02, 0xf5, 0xc4, 0xb2, 0x59, 0x72, 0x78, 0x08, 0xec, 0xcf, 0x85, 0x99,
+	0xbe, 0x8a, 0xa2, 0xe9, 0x75, 0x1c, 0x16, 0xc7, 0xe6, 0xaf, 0x08, 0xfa, 0x26, 0x6b, 0xd8, 0xea,
+	0x77, 0xb1, 0x06, 0xe5, 0x98, 0x0f, 0xcd, 0x7b, 0xdc, 0x62, 0xd1, 0xd4, 0x37, 0x7f, 0x66, 0x98,
+	0xa9, 0x3f, 0xa3, 0xa6, 0x7e, 0x8d, 0xcd, 0x6e, 0x42, 0x77, 0x94, 0x1e, 0x15, 0x38, 0x42, 0xe8,
+	0xf9, 0xb3, 0x68, 0xf6, 0xf7, 0x24, 0x3d, 0xea, 0x0f, 0x02, 0xb9, 0x44, 0xcf, 0xe6, 0x19, 0x49,
+	0xd3, 0xec, 0x0b, 0x1d, 0x10, 0x61, 0x48, 0x0a, 0x1f, 0x3b, 0x5a, 0x1c, 0x15, 0xf2, 0xbf, 0x0e,
+	0x1c, 0x9d, 0x8a, 0x9a, 0xf6, 0x4f, 0x0a, 0x85, 0x3f, 0xa0, 0xeb, 0xf6, 0xb4, 0x1c, 0x0f, 0x34,
+	0x9e, 0xc2, 0xf0, 0x2d, 0x16, 0xf9, 0x11, 0xa7, 0xc9, 0x3e, 0xed, 0x5b, 0xe1, 0x26, 0x43, 0xd2,
+	0x64, 0x93, 0x82, 0x72, 0x4d, 0x8d, 0xbd, 0x8a, 0x51, 0x3d, 0x47, 0x2f, 0xc9, 0xc9, 0xac, 0x88,
+	0xc1, 0xac, 0xdc, 0xd2, 0xed, 0x7f, 0x01, 0x31, 0xb9, 0xdc, 0xf7, 0xf9, 0x12, 0x00, 0x00,
 }
@@ -41,7 +41,7 @@ func (x EndorsePb_ConsensusVoteTopic) String() string {
 	return proto.EnumName(EndorsePb_ConsensusVoteTopic_name, int32(x))
 }
 func (EndorsePb_ConsensusVoteTopic) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{6, 0}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{11, 0}
 }
 
 // header of a block
@@ -60,6 +60,7 @@ type BlockHeaderPb struct {
 	DkgID                []byte   `protobuf:"bytes,12,opt,name=dkgID,proto3" json:"dkgID,omitempty"`
 	DkgPubkey            []byte   `protobuf:"bytes,13,opt,name=dkgPubkey,proto3" json:"dkgPubkey,omitempty"`
 	DkgSignature         []byte   `protobuf:"bytes,14,opt,name=dkgSignature,proto3" json:"dkgSignature,omitempty"`
+	LogsBloom            []byte   `protobuf:"bytes,15,opt,name=logsBloom,proto3" json:"logsBloom,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -69,7 +70,7 @@ func (m *BlockHeaderPb) Reset()         { *m = BlockHeaderPb{} }
 func (m *BlockHeaderPb) String() string { return proto.CompactTextString(m) }
 func (*BlockHeaderPb) ProtoMessage()    {}
 func (*BlockHeaderPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{0}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{0}
 }
 func (m *BlockHeaderPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeaderPb.Unmarshal(m, b)
@@ -187,11 +188,19 @@ func (m *BlockHeaderPb) GetDkgSignature() []byte {
 	return nil
 }
 
+func (m *BlockHeaderPb) GetLogsBloom() []byte {
+	if m != nil {
+		return m.LogsBloom
+	}
+	return nil
+}
+
 // block consists of header followed by transactions
 // hash of current block can be computed from header hence not stored
 type BlockPb struct {
 	Header               *BlockHeaderPb `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Actions              []*ActionPb    `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
+	Footer               *BlockFooterPb `protobuf:"bytes,3,opt,name=footer,proto3" json:"footer,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -201,7 +210,7 @@ func (m *BlockPb) Reset()         { *m = BlockPb{} }
 func (m *BlockPb) String() string { return proto.CompactTextString(m) }
 func (*BlockPb) ProtoMessage()    {}
 func (*BlockPb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{1}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{1}
 }
 func (m *BlockPb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockPb.Unmarshal(m, b)
@@ -235,6 +244,60 @@ func (m *BlockPb) GetActions() []*ActionPb {
 	return nil
 }
 
+func (m *BlockPb) GetFooter() *BlockFooterPb {
+	if m != nil {
+		return m.Footer
+	}
+	return nil
+}
+
+// footer of a block, which carries the commit proof and isn't covered by the block hash
+type BlockFooterPb struct {
+	Endorsements         *EndorsementSet `protobuf:"bytes,1,opt,name=endorsements,proto3" json:"endorsements,omitempty"`
+	CommitTimestamp      uint64          `protobuf:"varint,2,opt,name=commitTimestamp,proto3" json:"commitTimestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *BlockFooterPb) Reset()         { *m = BlockFooterPb{} }
+func (m *BlockFooterPb) String() string { return proto.CompactTextString(m) }
+func (*BlockFooterPb) ProtoMessage()    {}
+func (*BlockFooterPb) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{2}
+}
+func (m *BlockFooterPb) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockFooterPb.Unmarshal(m, b)
+}
+func (m *BlockFooterPb) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockFooterPb.Marshal(b, m, deterministic)
+}
+func (dst *BlockFooterPb) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockFooterPb.Merge(dst, src)
+}
+func (m *BlockFooterPb) XXX_Size() int {
+	return xxx_messageInfo_BlockFooterPb.Size(m)
+}
+func (m *BlockFooterPb) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockFooterPb.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockFooterPb proto.InternalMessageInfo
+
+func (m *BlockFooterPb) GetEndorsements() *EndorsementSet {
+	if m != nil {
+		return m.Endorsements
+	}
+	return nil
+}
+
+func (m *BlockFooterPb) GetCommitTimestamp() uint64 {
+	if m != nil {
+		return m.CommitTimestamp
+	}
+	return 0
+}
+
 // index of block raw data file
 type BlockIndex struct {
 	Start                uint64   `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
@@ -249,7 +312,7 @@ func (m *BlockIndex) Reset()         { *m = BlockIndex{} }
 func (m *BlockIndex) String() string { return proto.CompactTextString(m) }
 func (*BlockIndex) ProtoMessage()    {}
 func (*BlockIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{2}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{3}
 }
 func (m *BlockIndex) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockIndex.Unmarshal(m, b)
@@ -302,7 +365,7 @@ func (m *BlockSync) Reset()         { *m = BlockSync{} }
 func (m *BlockSync) String() string { return proto.CompactTextString(m) }
 func (*BlockSync) ProtoMessage()    {}
 func (*BlockSync) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{3}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{4}
 }
 func (m *BlockSync) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockSync.Unmarshal(m, b)
@@ -349,7 +412,7 @@ func (m *BlockContainer) Reset()         { *m = BlockContainer{} }
 func (m *BlockContainer) String() string { return proto.CompactTextString(m) }
 func (*BlockContainer) ProtoMessage()    {}
 func (*BlockContainer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{4}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{5}
 }
 func (m *BlockContainer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockContainer.Unmarshal(m, b)
@@ -376,6 +439,204 @@ func (m *BlockContainer) GetBlock() *BlockPb {
 	return nil
 }
 
+// header sync message
+// used by light clients to request the headers and footers of blocks in the range
+type BlockHeaderSync struct {
+	Start                uint64   `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  uint64   `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockHeaderSync) Reset()         { *m = BlockHeaderSync{} }
+func (m *BlockHeaderSync) String() string { return proto.CompactTextString(m) }
+func (*BlockHeaderSync) ProtoMessage()    {}
+func (*BlockHeaderSync) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{6}
+}
+func (m *BlockHeaderSync) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeaderSync.Unmarshal(m, b)
+}
+func (m *BlockHeaderSync) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeaderSync.Marshal(b, m, deterministic)
+}
+func (dst *BlockHeaderSync) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeaderSync.Merge(dst, src)
+}
+func (m *BlockHeaderSync) XXX_Size() int {
+	return xxx_messageInfo_BlockHeaderSync.Size(m)
+}
+func (m *BlockHeaderSync) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeaderSync.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeaderSync proto.InternalMessageInfo
+
+func (m *BlockHeaderSync) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *BlockHeaderSync) GetEnd() uint64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+// block header container
+// used to send the header and footer of a block in header sync
+type BlockHeaderContainer struct {
+	Header               *BlockHeaderPb `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Footer               *BlockFooterPb `protobuf:"bytes,2,opt,name=footer,proto3" json:"footer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BlockHeaderContainer) Reset()         { *m = BlockHeaderContainer{} }
+func (m *BlockHeaderContainer) String() string { return proto.CompactTextString(m) }
+func (*BlockHeaderContainer) ProtoMessage()    {}
+func (*BlockHeaderContainer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{7}
+}
+func (m *BlockHeaderContainer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeaderContainer.Unmarshal(m, b)
+}
+func (m *BlockHeaderContainer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeaderContainer.Marshal(b, m, deterministic)
+}
+func (dst *BlockHeaderContainer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeaderContainer.Merge(dst, src)
+}
+func (m *BlockHeaderContainer) XXX_Size() int {
+	return xxx_messageInfo_BlockHeaderContainer.Size(m)
+}
+func (m *BlockHeaderContainer) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeaderContainer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeaderContainer proto.InternalMessageInfo
+
+func (m *BlockHeaderContainer) GetHeader() *BlockHeaderPb {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *BlockHeaderContainer) GetFooter() *BlockFooterPb {
+	if m != nil {
+		return m.Footer
+	}
+	return nil
+}
+
+// request of the trie proof of a state at a height
+type StateProofRequest struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateProofRequest) Reset()         { *m = StateProofRequest{} }
+func (m *StateProofRequest) String() string { return proto.CompactTextString(m) }
+func (*StateProofRequest) ProtoMessage()    {}
+func (*StateProofRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{8}
+}
+func (m *StateProofRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProofRequest.Unmarshal(m, b)
+}
+func (m *StateProofRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProofRequest.Marshal(b, m, deterministic)
+}
+func (dst *StateProofRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProofRequest.Merge(dst, src)
+}
+func (m *StateProofRequest) XXX_Size() int {
+	return xxx_messageInfo_StateProofRequest.Size(m)
+}
+func (m *StateProofRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProofRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProofRequest proto.InternalMessageInfo
+
+func (m *StateProofRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateProofRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// trie proof of a state at a height, which is the response to StateProofRequest
+type StateProofResponse struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Proof                [][]byte `protobuf:"bytes,3,rep,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateProofResponse) Reset()         { *m = StateProofResponse{} }
+func (m *StateProofResponse) String() string { return proto.CompactTextString(m) }
+func (*StateProofResponse) ProtoMessage()    {}
+func (*StateProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{9}
+}
+func (m *StateProofResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProofResponse.Unmarshal(m, b)
+}
+func (m *StateProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProofResponse.Marshal(b, m, deterministic)
+}
+func (dst *StateProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProofResponse.Merge(dst, src)
+}
+func (m *StateProofResponse) XXX_Size() int {
+	return xxx_messageInfo_StateProofResponse.Size(m)
+}
+func (m *StateProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProofResponse proto.InternalMessageInfo
+
+func (m *StateProofResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateProofResponse) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StateProofResponse) GetProof() [][]byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 // corresponding to pre-prepare pharse in view change protocol
 type ProposePb struct {
 	Proposer             string          `protobuf:"bytes,1,opt,name=proposer,proto3" json:"proposer,omitempty"`
@@ -391,7 +652,7 @@ func (m *ProposePb) Reset()         { *m = ProposePb{} }
 func (m *ProposePb) String() string { return proto.CompactTextString(m) }
 func (*ProposePb) ProtoMessage()    {}
 func (*ProposePb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{5}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{10}
 }
 func (m *ProposePb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposePb.Unmarshal(m, b)
@@ -449,6 +710,7 @@ type EndorsePb struct {
 	EndorserPubKey       []byte                       `protobuf:"bytes,6,opt,name=endorserPubKey,proto3" json:"endorserPubKey,omitempty"`
 	Decision             bool                         `protobuf:"varint,7,opt,name=decision,proto3" json:"decision,omitempty"`
 	Signature            []byte                       `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	DkgSignature         []byte                       `protobuf:"bytes,9,opt,name=dkgSignature,proto3" json:"dkgSignature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -458,7 +720,7 @@ func (m *EndorsePb) Reset()         { *m = EndorsePb{} }
 func (m *EndorsePb) String() string { return proto.CompactTextString(m) }
 func (*EndorsePb) ProtoMessage()    {}
 func (*EndorsePb) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{6}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{11}
 }
 func (m *EndorsePb) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EndorsePb.Unmarshal(m, b)
@@ -534,20 +796,30 @@ func (m *EndorsePb) GetSignature() []byte {
 	return nil
 }
 
+func (m *EndorsePb) GetDkgSignature() []byte {
+	if m != nil {
+		return m.DkgSignature
+	}
+	return nil
+}
+
 type EndorsementSet struct {
-	BlockHash            []byte       `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
-	Round                uint32       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
-	Endorsements         []*EndorsePb `protobuf:"bytes,3,rep,name=endorsements,proto3" json:"endorsements,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	BlockHash    []byte       `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Round        uint32       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Endorsements []*EndorsePb `protobuf:"bytes,3,rep,name=endorsements,proto3" json:"endorsements,omitempty"`
+	// the endorsements folded into an aggregate DKG signature, and the bitmap of the signers among the delegates
+	AggregateSignature   []byte   `protobuf:"bytes,4,opt,name=aggregateSignature,proto3" json:"aggregateSignature,omitempty"`
+	Signers              []byte   `protobuf:"bytes,5,opt,name=signers,proto3" json:"signers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *EndorsementSet) Reset()         { *m = EndorsementSet{} }
 func (m *EndorsementSet) String() string { return proto.CompactTextString(m) }
 func (*EndorsementSet) ProtoMessage()    {}
 func (*EndorsementSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{7}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{12}
 }
 func (m *EndorsementSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EndorsementSet.Unmarshal(m, b)
@@ -588,6 +860,20 @@ func (m *EndorsementSet) GetEndorsements() []*EndorsePb {
 	return nil
 }
 
+func (m *EndorsementSet) GetAggregateSignature() []byte {
+	if m != nil {
+		return m.AggregateSignature
+	}
+	return nil
+}
+
+func (m *EndorsementSet) GetSigners() []byte {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
 // Candidates and list of candidates
 type Candidate struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
@@ -604,7 +890,7 @@ func (m *Candidate) Reset()         { *m = Candidate{} }
 func (m *Candidate) String() string { return proto.CompactTextString(m) }
 func (*Candidate) ProtoMessage()    {}
 func (*Candidate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{8}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{13}
 }
 func (m *Candidate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Candidate.Unmarshal(m, b)
@@ -670,7 +956,7 @@ func (m *CandidateList) Reset()         { *m = CandidateList{} }
 func (m *CandidateList) String() string { return proto.CompactTextString(m) }
 func (*CandidateList) ProtoMessage()    {}
 func (*CandidateList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{9}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{14}
 }
 func (m *CandidateList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CandidateList.Unmarshal(m, b)
@@ -711,7 +997,7 @@ func (m *TestPayload) Reset()         { *m = TestPayload{} }
 func (m *TestPayload) String() string { return proto.CompactTextString(m) }
 func (*TestPayload) ProtoMessage()    {}
 func (*TestPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_6c5378db0cb704e5, []int{10}
+	return fileDescriptor_blockchain_d7576016d6e08274, []int{15}
 }
 func (m *TestPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestPayload.Unmarshal(m, b)
@@ -741,9 +1027,14 @@ func (m *TestPayload) GetMsgBody() []byte {
 func init() {
 	proto.RegisterType((*BlockHeaderPb)(nil), "iproto.BlockHeaderPb")
 	proto.RegisterType((*BlockPb)(nil), "iproto.BlockPb")
+	proto.RegisterType((*BlockFooterPb)(nil), "iproto.BlockFooterPb")
 	proto.RegisterType((*BlockIndex)(nil), "iproto.BlockIndex")
 	proto.RegisterType((*BlockSync)(nil), "iproto.BlockSync")
 	proto.RegisterType((*BlockContainer)(nil), "iproto.BlockContainer")
+	proto.RegisterType((*BlockHeaderSync)(nil), "iproto.BlockHeaderSync")
+	proto.RegisterType((*BlockHeaderContainer)(nil), "iproto.BlockHeaderContainer")
+	proto.RegisterType((*StateProofRequest)(nil), "iproto.StateProofRequest")
+	proto.RegisterType((*StateProofResponse)(nil), "iproto.StateProofResponse")
 	proto.RegisterType((*ProposePb)(nil), "iproto.ProposePb")
 	proto.RegisterType((*EndorsePb)(nil), "iproto.EndorsePb")
 	proto.RegisterType((*EndorsementSet)(nil), "iproto.EndorsementSet")
@@ -753,57 +1044,68 @@ func init() {
 	proto.RegisterEnum("iproto.EndorsePb_ConsensusVoteTopic", EndorsePb_ConsensusVoteTopic_name, EndorsePb_ConsensusVoteTopic_value)
 }
 
-func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_blockchain_6c5378db0cb704e5) }
-
-var fileDescriptor_blockchain_6c5378db0cb704e5 = []byte{
-	// 783 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0x4d, 0x8f, 0xdb, 0x36,
-	0x10, 0xad, 0x2c, 0x7f, 0x69, 0xfc, 0x51, 0x97, 0x68, 0x03, 0x35, 0xc8, 0xc1, 0x10, 0xd2, 0xc0,
-	0x08, 0xd0, 0x05, 0xea, 0xb4, 0x68, 0xd1, 0x5b, 0xd6, 0x29, 0x90, 0x45, 0x36, 0xb0, 0x40, 0x6f,
-	0x7b, 0x2d, 0x28, 0x71, 0xd6, 0x16, 0xbc, 0x16, 0x05, 0x92, 0x36, 0x62, 0xf4, 0x9f, 0xf4, 0xd6,
-	0x53, 0x7f, 0x4c, 0xff, 0x54, 0x41, 0x52, 0x92, 0x2d, 0xef, 0x22, 0x27, 0xfb, 0xbd, 0x79, 0x7a,
-	0x33, 0x9c, 0xe1, 0x10, 0x26, 0xc9, 0x83, 0x48, 0xb7, 0xe9, 0x86, 0x65, 0xf9, 0x55, 0x21, 0x85,
-	0x16, 0xa4, 0x9b, 0xd9, 0xdf, 0xe7, 0x43, 0x96, 0xea, 0x4c, 0x94, 0x6c, 0xf4, 0x8f, 0x0f, 0xa3,
-	0x6b, 0x23, 0x7d, 0x8f, 0x8c, 0xa3, 0x8c, 0x13, 0x12, 0x42, 0xef, 0x80, 0x52, 0x65, 0x22, 0x0f,
-	0xbd, 0xa9, 0x37, 0x1b, 0xd1, 0x0a, 0x9a, 0x88, 0x35, 0xbc, 0x79, 0x17, 0xb6, 0x5c, 0xa4, 0x84,
-	0xe4, 0x19, 0x74, 0x37, 0x98, 0xad, 0x37, 0x3a, 0xf4, 0xa7, 0xde, 0xac, 0x4d, 0x4b, 0x44, 0x5e,
-	0x40, 0xa0, 0xb3, 0x1d, 0x2a, 0xcd, 0x76, 0x45, 0xd8, 0xb6, 0xa1, 0x13, 0x41, 0x5e, 0xc2, 0xa8,
-	0x90, 0x78, 0x70, 0xe9, 0x99, 0xda, 0x84, 0x9d, 0xa9, 0x37, 0x1b, 0xd2, 0x26, 0x69, 0xbc, 0xf5,
-	0x27, 0x2a, 0x84, 0x0e, 0xbb, 0x36, 0x5c, 0x22, 0xe3, 0xad, 0x34, 0xd3, 0x68, 0x43, 0x3d, 0x1b,
-	0x3a, 0x11, 0x64, 0x0a, 0x03, 0x89, 0x29, 0x66, 0x85, 0xb6, 0xf1, 0xbe, 0x8d, 0x9f, 0x53, 0xe4,
-	0x39, 0xf4, 0x25, 0x2a, 0x94, 0x07, 0xe4, 0x61, 0x60, 0xc3, 0x35, 0xb6, 0xde, 0xd9, 0x3a, 0x67,
-	0x7a, 0x2f, 0x31, 0x84, 0xd2, 0xbb, 0x22, 0x4c, 0x45, 0xc5, 0x3e, 0xd9, 0xe2, 0x31, 0x1c, 0xb8,
-	0x8a, 0x1c, 0x22, 0x5f, 0x43, 0x87, 0x6f, 0xd7, 0x37, 0xef, 0xc2, 0xa1, 0xa5, 0x1d, 0x30, 0x5e,
-	0x7c, 0xbb, 0x8e, 0xdd, 0x07, 0x23, 0xe7, 0x55, 0x13, 0x24, 0x82, 0x21, 0xdf, 0xae, 0x57, 0x75,
-	0xb2, 0xb1, 0x15, 0x34, 0xb8, 0x88, 0x43, 0xcf, 0xb6, 0x23, 0x4e, 0xc8, 0xf7, 0xa6, 0xd1, 0x66,
-	0x50, 0x76, 0x36, 0x83, 0xf9, 0x37, 0x57, 0x6e, 0xaa, 0x57, 0x8d, 0x19, 0xd2, 0x52, 0x44, 0x5e,
-	0x43, 0xcf, 0x4d, 0x5b, 0x85, 0xad, 0xa9, 0x3f, 0x1b, 0xcc, 0x27, 0x95, 0xfe, 0xad, 0xa5, 0xe3,
-	0x84, 0x56, 0x82, 0xe8, 0x16, 0xc0, 0x9a, 0xdc, 0xe4, 0x1c, 0x3f, 0x99, 0xb3, 0x28, 0xcd, 0xa4,
-	0xb6, 0x79, 0xda, 0xd4, 0x01, 0x32, 0x01, 0x1f, 0x73, 0x6e, 0xa7, 0xdf, 0xa6, 0xe6, 0xaf, 0xe9,
-	0x85, 0xb8, 0xbf, 0x57, 0x68, 0x26, 0xef, 0xcf, 0x46, 0xb4, 0x44, 0xd1, 0x1b, 0x08, 0xac, 0xdb,
-	0xea, 0x98, 0xa7, 0x27, 0xb3, 0xd6, 0x13, 0x66, 0x7e, 0x6d, 0x16, 0xfd, 0x0c, 0x63, 0xfb, 0xd1,
-	0x42, 0xe4, 0x9a, 0x65, 0x39, 0x4a, 0xf2, 0x1d, 0x74, 0xec, 0x45, 0x2e, 0x8f, 0xfb, 0x65, 0xe3,
-	0xb8, 0x71, 0x42, 0x5d, 0x34, 0xfa, 0xdb, 0x83, 0x20, 0x96, 0xa2, 0x10, 0x0a, 0xe3, 0xc4, 0x4c,
-	0xb6, 0x70, 0xc0, 0xb5, 0x29, 0xa0, 0x35, 0x3e, 0x19, 0xb6, 0x3e, 0x67, 0x68, 0x2a, 0x96, 0x62,
-	0x5f, 0x56, 0x37, 0xa2, 0x0e, 0x90, 0x1f, 0x21, 0xb0, 0x32, 0x29, 0xc4, 0xbd, 0xbd, 0xce, 0x83,
-	0xf9, 0xb3, 0xca, 0xe0, 0xb7, 0x9c, 0x0b, 0xa9, 0x70, 0x87, 0xb9, 0x5e, 0xa1, 0xa6, 0x27, 0x61,
-	0xf4, 0x5f, 0x0b, 0x82, 0x32, 0x1a, 0x27, 0x67, 0xab, 0xe2, 0x35, 0x56, 0xa5, 0xce, 0xd8, 0x3a,
-	0xcf, 0xf8, 0x02, 0x82, 0xa4, 0x5e, 0x0f, 0xdf, 0x5d, 0x9e, 0x9a, 0x20, 0xbf, 0x42, 0x47, 0x8b,
-	0x22, 0x4b, 0x6d, 0x2d, 0xe3, 0xf9, 0xcb, 0x8b, 0x5a, 0xe2, 0xe4, 0x6a, 0x21, 0x72, 0x85, 0xb9,
-	0xda, 0xab, 0x3f, 0x84, 0xc6, 0x3b, 0xa3, 0xa5, 0xee, 0x13, 0xd3, 0x24, 0x74, 0x32, 0x69, 0xf7,
-	0x2e, 0xa0, 0x35, 0x26, 0xaf, 0x60, 0x5c, 0xfd, 0x8f, 0xf7, 0xc9, 0x07, 0x3c, 0x96, 0xab, 0x77,
-	0xc1, 0x1a, 0x0f, 0x8e, 0x69, 0x66, 0xdf, 0x0a, 0xb3, 0x81, 0x7d, 0x5a, 0xe3, 0xe6, 0x0a, 0xf5,
-	0x2f, 0x56, 0x28, 0xfa, 0x05, 0xc8, 0xe3, 0xd2, 0xc8, 0x10, 0xfa, 0x31, 0x5d, 0xc6, 0xcb, 0xd5,
-	0xdb, 0xdb, 0xc9, 0x17, 0xa4, 0x0f, 0xed, 0xdb, 0xe5, 0xe2, 0xc3, 0xc4, 0x23, 0x00, 0xdd, 0xc5,
-	0xf2, 0xe3, 0xc7, 0x9b, 0xbb, 0x49, 0x2b, 0xfa, 0x0b, 0xc6, 0xcd, 0x56, 0x37, 0x7b, 0xe4, 0x5d,
-	0xf6, 0xe8, 0xe9, 0xbe, 0xfe, 0x04, 0x43, 0x3c, 0xb9, 0x28, 0x7b, 0x79, 0x07, 0xf3, 0xaf, 0x1e,
-	0x35, 0x90, 0x36, 0x64, 0xd1, 0xbf, 0x1e, 0x04, 0x0b, 0x96, 0xf3, 0x8c, 0x33, 0x8d, 0xe6, 0x3d,
-	0x64, 0x9c, 0x4b, 0x54, 0xaa, 0xbc, 0x66, 0x15, 0x34, 0x49, 0x0f, 0x42, 0xa3, 0xb2, 0x49, 0x87,
-	0xd4, 0x81, 0xf2, 0xdd, 0x30, 0xed, 0xf4, 0xeb, 0x77, 0xc3, 0xb4, 0xf1, 0x15, 0x8c, 0x53, 0x89,
-	0xcc, 0xac, 0xe1, 0x7b, 0x77, 0x35, 0xdc, 0x53, 0x79, 0xc1, 0x92, 0xd7, 0x30, 0x79, 0x60, 0x4a,
-	0xff, 0x5e, 0x98, 0xec, 0xa5, 0xb2, 0x63, 0x95, 0x8f, 0xf8, 0xe8, 0x1a, 0x46, 0x75, 0xa1, 0xb7,
-	0x99, 0xd2, 0xe4, 0x07, 0x80, 0xb4, 0x22, 0x4c, 0xbd, 0x8d, 0xf3, 0xd6, 0x52, 0x7a, 0x26, 0x8a,
-	0x66, 0x30, 0xb8, 0x43, 0xa5, 0x63, 0x76, 0x7c, 0x10, 0x8c, 0x93, 0x6f, 0xa1, 0xbf, 0x53, 0xeb,
-	0x3f, 0x13, 0xc1, 0x8f, 0x65, 0x9b, 0x7b, 0x3b, 0xb5, 0xbe, 0x16, 0xfc, 0x98, 0x74, 0xad, 0xcd,
-	0x9b, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x22, 0xa8, 0x6b, 0xa6, 0x76, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_blockchain_d7576016d6e08274) }
+
+var fileDescriptor_blockchain_d7576016d6e08274 = []byte{
+	// 952 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0xfe, 0x49, 0xca, 0x92, 0x38, 0x3a, 0x58, 0x59, 0xe4, 0x0f, 0xd8, 0x20, 0x17, 0x02, 0x91,
+	0x06, 0x42, 0x80, 0x0a, 0xa8, 0xd3, 0xa2, 0x6d, 0x80, 0x5e, 0xc4, 0x4a, 0x8b, 0x18, 0x71, 0x60,
+	0x62, 0xa5, 0xf6, 0xb6, 0xe0, 0x61, 0x4c, 0x13, 0x92, 0xb8, 0xec, 0xee, 0xca, 0x88, 0x9e, 0xa3,
+	0x77, 0x7d, 0x81, 0x3e, 0x46, 0xaf, 0xfa, 0x3c, 0x7d, 0x85, 0x62, 0x0f, 0xa2, 0x44, 0xd9, 0x35,
+	0xd2, 0x2b, 0xe9, 0x9b, 0x99, 0xfd, 0x66, 0x38, 0xdf, 0xec, 0x2c, 0x8c, 0x92, 0x15, 0x4b, 0x97,
+	0xe9, 0x4d, 0x5c, 0x94, 0xd3, 0x8a, 0x33, 0xc9, 0x48, 0xbb, 0xd0, 0xbf, 0x4f, 0xfb, 0x71, 0x2a,
+	0x0b, 0x66, 0xad, 0xe1, 0x9f, 0x1e, 0x0c, 0xce, 0x55, 0xe8, 0x3b, 0x8c, 0x33, 0xe4, 0x51, 0x42,
+	0x02, 0xe8, 0xdc, 0x22, 0x17, 0x05, 0x2b, 0x03, 0x67, 0xec, 0x4c, 0x06, 0x74, 0x07, 0x95, 0x47,
+	0x13, 0x5e, 0xbc, 0x0d, 0x5c, 0xe3, 0xb1, 0x90, 0x3c, 0x81, 0xf6, 0x0d, 0x16, 0xf9, 0x8d, 0x0c,
+	0xbc, 0xb1, 0x33, 0x69, 0x51, 0x8b, 0xc8, 0x33, 0xf0, 0x65, 0xb1, 0x46, 0x21, 0xe3, 0x75, 0x15,
+	0xb4, 0xb4, 0x6b, 0x6f, 0x20, 0xcf, 0x61, 0x50, 0x71, 0xbc, 0x35, 0xe9, 0x63, 0x71, 0x13, 0x9c,
+	0x8c, 0x9d, 0x49, 0x9f, 0x36, 0x8d, 0x8a, 0x5b, 0x7e, 0xa4, 0x8c, 0xc9, 0xa0, 0xad, 0xdd, 0x16,
+	0x29, 0x6e, 0x21, 0x63, 0x89, 0xda, 0xd5, 0xd1, 0xae, 0xbd, 0x81, 0x8c, 0xa1, 0xc7, 0x31, 0xc5,
+	0xa2, 0x92, 0xda, 0xdf, 0xd5, 0xfe, 0x43, 0x13, 0x79, 0x0a, 0x5d, 0x8e, 0x02, 0xf9, 0x2d, 0x66,
+	0x81, 0xaf, 0xdd, 0x35, 0xd6, 0xdc, 0x45, 0x5e, 0xc6, 0x72, 0xc3, 0x31, 0x00, 0xcb, 0xbd, 0x33,
+	0xa8, 0x8a, 0xaa, 0x4d, 0xb2, 0xc4, 0x6d, 0xd0, 0x33, 0x15, 0x19, 0x44, 0x1e, 0xc3, 0x49, 0xb6,
+	0xcc, 0x2f, 0xde, 0x06, 0x7d, 0x6d, 0x36, 0x40, 0x71, 0x65, 0xcb, 0x3c, 0x32, 0x07, 0x06, 0x86,
+	0xab, 0x36, 0x90, 0x10, 0xfa, 0xd9, 0x32, 0x9f, 0xd7, 0xc9, 0x86, 0x3a, 0xa0, 0x61, 0x53, 0x0c,
+	0x2b, 0x96, 0x8b, 0xf3, 0x15, 0x63, 0xeb, 0xe0, 0xd4, 0x30, 0xd4, 0x86, 0xf0, 0x37, 0x07, 0x3a,
+	0xba, 0x5b, 0x51, 0x42, 0xbe, 0x50, 0x3a, 0x28, 0x1d, 0xb5, 0x74, 0xbd, 0xb3, 0xff, 0x4f, 0x8d,
+	0xe8, 0xd3, 0x86, 0xc4, 0xd4, 0x06, 0x91, 0x97, 0xd0, 0x31, 0xc3, 0x20, 0x02, 0x77, 0xec, 0x4d,
+	0x7a, 0x67, 0xa3, 0x5d, 0xfc, 0x1b, 0x6d, 0x8e, 0x12, 0xba, 0x0b, 0x50, 0xd4, 0xd7, 0x8c, 0x49,
+	0xe4, 0x81, 0x77, 0x0f, 0xf5, 0x8f, 0xda, 0xa5, 0xa8, 0x4d, 0x50, 0xb8, 0x81, 0x41, 0xc3, 0x41,
+	0x5e, 0x43, 0x1f, 0xcb, 0x8c, 0x71, 0x81, 0x6b, 0x2c, 0xa5, 0xb0, 0x05, 0x3e, 0xd9, 0xb1, 0xfc,
+	0xb0, 0xf7, 0xcd, 0x51, 0xd2, 0x46, 0x2c, 0x99, 0xc0, 0x69, 0xca, 0xd6, 0xeb, 0x42, 0x2e, 0xea,
+	0x61, 0x72, 0xf5, 0x30, 0x1d, 0x9b, 0xc3, 0x4b, 0x00, 0x9d, 0xf6, 0xa2, 0xcc, 0xf0, 0xa3, 0x12,
+	0x44, 0xc8, 0x98, 0x4b, 0x9d, 0xac, 0x45, 0x0d, 0x20, 0x23, 0xf0, 0xb0, 0xcc, 0x2c, 0x83, 0xfa,
+	0xab, 0x04, 0x65, 0xd7, 0xd7, 0x02, 0xd5, 0xf8, 0x7a, 0x93, 0x01, 0xb5, 0x28, 0x7c, 0x05, 0xbe,
+	0x66, 0x9b, 0x6f, 0xcb, 0x74, 0x4f, 0xe6, 0xde, 0x43, 0xe6, 0xd5, 0x64, 0xe1, 0x37, 0x30, 0xd4,
+	0x87, 0x66, 0xac, 0x94, 0x71, 0x51, 0x22, 0x27, 0x9f, 0xc3, 0x89, 0xbe, 0x8d, 0xf6, 0x9b, 0x4f,
+	0x1b, 0x9d, 0x8b, 0x12, 0x6a, 0xbc, 0xe1, 0x77, 0x70, 0x7a, 0x20, 0x53, 0x33, 0xe7, 0xc3, 0x1f,
+	0x10, 0x4a, 0x78, 0x7c, 0x70, 0x74, 0x9f, 0xf9, 0x3f, 0xce, 0xc3, 0x5e, 0x63, 0xf7, 0x53, 0x34,
+	0xfe, 0x1e, 0x1e, 0xcd, 0xd5, 0x85, 0x8b, 0x38, 0x63, 0xd7, 0x14, 0x7f, 0xdd, 0xa0, 0x90, 0x07,
+	0xab, 0xc0, 0x69, 0xac, 0x82, 0x11, 0x78, 0xea, 0x02, 0xb8, 0x7a, 0x7c, 0xd5, 0xdf, 0x70, 0x01,
+	0xe4, 0xf0, 0xb8, 0xa8, 0x58, 0x29, 0xf0, 0xd3, 0xcf, 0xab, 0xe6, 0x54, 0xea, 0xa8, 0x16, 0xad,
+	0x4f, 0x0d, 0x08, 0x7f, 0x77, 0xc0, 0x8f, 0x38, 0xab, 0x98, 0xc0, 0x28, 0x51, 0x97, 0xbc, 0x32,
+	0xc0, 0xb4, 0xc0, 0xa7, 0x35, 0xde, 0xcb, 0xe2, 0x3e, 0x24, 0x8b, 0x4a, 0xc3, 0xd9, 0xc6, 0x6a,
+	0x3c, 0xa0, 0x06, 0x90, 0xaf, 0xd4, 0x9d, 0x4c, 0x97, 0xba, 0xf6, 0xa0, 0xf5, 0xe0, 0x2c, 0xef,
+	0x03, 0xc3, 0xbf, 0x5d, 0xf0, 0xad, 0x37, 0x4a, 0xfe, 0xf5, 0x53, 0xeb, 0x8c, 0xee, 0x61, 0xc6,
+	0x67, 0xe0, 0x27, 0xf5, 0xa6, 0xf4, 0xcc, 0x16, 0xa8, 0x0d, 0xe4, 0x35, 0x9c, 0x48, 0x56, 0x15,
+	0xa9, 0xae, 0x65, 0x78, 0xf6, 0xfc, 0xa8, 0x96, 0x28, 0x99, 0xce, 0x54, 0x7b, 0x4b, 0xb1, 0x11,
+	0x3f, 0x33, 0x89, 0x0b, 0x15, 0x4b, 0xcd, 0x11, 0xd5, 0x24, 0x7b, 0xdd, 0xb8, 0x5e, 0xc1, 0x3e,
+	0xad, 0x31, 0x79, 0x01, 0xc3, 0xdd, 0xff, 0x68, 0x93, 0xbc, 0xc7, 0xad, 0xdd, 0xc2, 0x47, 0x56,
+	0xc5, 0x91, 0x61, 0x5a, 0xe8, 0x67, 0x43, 0x2d, 0xe3, 0x2e, 0xad, 0x71, 0x73, 0x9b, 0x76, 0x8f,
+	0xb7, 0xe9, 0xf1, 0x06, 0xf4, 0xef, 0x6e, 0xc0, 0xf0, 0x5b, 0x20, 0x77, 0xcb, 0x27, 0x7d, 0xe8,
+	0x46, 0xf4, 0x2a, 0xba, 0x9a, 0xbf, 0xb9, 0x1c, 0xfd, 0x8f, 0x74, 0xa1, 0x75, 0x79, 0x35, 0x7b,
+	0x3f, 0x72, 0x08, 0x40, 0x7b, 0x76, 0xf5, 0xe1, 0xc3, 0xc5, 0x62, 0xe4, 0x86, 0x7f, 0x39, 0x30,
+	0x6c, 0xea, 0xd1, 0x6c, 0xa4, 0x73, 0xdc, 0xc8, 0xfb, 0x9b, 0xff, 0xf5, 0xd1, 0xf6, 0xf2, 0xf4,
+	0xba, 0x7c, 0x74, 0xa7, 0xcb, 0x47, 0x8b, 0x6b, 0x0a, 0x24, 0xce, 0x73, 0x8e, 0x79, 0x2c, 0x71,
+	0xff, 0x85, 0x2d, 0x9d, 0xf3, 0x1e, 0x8f, 0x7a, 0x61, 0x55, 0x63, 0x90, 0x0b, 0xfb, 0x16, 0xee,
+	0x60, 0xf8, 0x87, 0x03, 0xfe, 0x2c, 0x2e, 0xb3, 0x22, 0x8b, 0xa5, 0x8e, 0x8b, 0xb3, 0x8c, 0xa3,
+	0x10, 0x76, 0xaa, 0x77, 0x50, 0x95, 0x7f, 0xcb, 0x24, 0x0a, 0x7b, 0x51, 0x0c, 0xb0, 0x2f, 0x96,
+	0x52, 0xcf, 0xab, 0x5f, 0x2c, 0xa5, 0xda, 0x0b, 0x18, 0xa6, 0x1c, 0x63, 0xb5, 0xe1, 0xdf, 0x99,
+	0x49, 0x34, 0x8f, 0xf4, 0x91, 0x95, 0xbc, 0x84, 0xd1, 0x2a, 0x16, 0xf2, 0xa7, 0x4a, 0x65, 0xb7,
+	0x91, 0x27, 0x3a, 0xf2, 0x8e, 0x3d, 0x3c, 0x87, 0x41, 0x5d, 0xe8, 0x65, 0x21, 0x24, 0xf9, 0x12,
+	0x20, 0xdd, 0x19, 0x54, 0xbd, 0x8d, 0xce, 0xd5, 0xa1, 0xf4, 0x20, 0x28, 0x9c, 0x40, 0x6f, 0x81,
+	0x42, 0x46, 0xf1, 0x76, 0xc5, 0xe2, 0x8c, 0x7c, 0x06, 0xdd, 0xb5, 0xc8, 0x7f, 0x49, 0x58, 0xb6,
+	0xb5, 0x82, 0x75, 0xd6, 0x22, 0x3f, 0x67, 0xd9, 0x36, 0x69, 0x6b, 0x9a, 0x57, 0xff, 0x0c, 0x00,
+	0xc1, 0x24, 0x65, 0xfb, 0xf0, 0x08, 0x00, 0x00,
 }
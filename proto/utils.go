@@ -34,6 +34,14 @@ const (
 	MsgProposeProtoMsgType uint32 = 6
 	// MsgEndorseProtoMsgType is for consensus endorse
 	MsgEndorseProtoMsgType uint32 = 7
+	// MsgBlockHeaderSyncReqType is for requests of light clients to sync block headers
+	MsgBlockHeaderSyncReqType uint32 = 8
+	// MsgBlockHeaderSyncDataType is the response to messages of type MsgBlockHeaderSyncReqType
+	MsgBlockHeaderSyncDataType uint32 = 9
+	// MsgStateProofReqType is for requests of light clients to prove a state
+	MsgStateProofReqType uint32 = 10
+	// MsgStateProofDataType is the response to messages of type MsgStateProofReqType
+	MsgStateProofDataType uint32 = 11
 	// TestPayloadType is a test payload message type
 	TestPayloadType uint32 = 10001
 )
@@ -55,6 +63,14 @@ func GetTypeFromProtoMsg(msg proto.Message) (uint32, error) {
 		return MsgProposeProtoMsgType, nil
 	case *EndorsePb:
 		return MsgEndorseProtoMsgType, nil
+	case *BlockHeaderSync:
+		return MsgBlockHeaderSyncReqType, nil
+	case *BlockHeaderContainer:
+		return MsgBlockHeaderSyncDataType, nil
+	case *StateProofRequest:
+		return MsgStateProofReqType, nil
+	case *StateProofResponse:
+		return MsgStateProofDataType, nil
 	default:
 		return UnknownProtoMsgType, errors.New("UnknownProtoMsgType proto message type")
 	}
@@ -74,6 +90,14 @@ func TypifyProtoMsg(tp uint32, msg []byte) (proto.Message, error) {
 		m = &BlockSync{}
 	case MsgBlockSyncDataType:
 		m = &BlockContainer{}
+	case MsgBlockHeaderSyncReqType:
+		m = &BlockHeaderSync{}
+	case MsgBlockHeaderSyncDataType:
+		m = &BlockHeaderContainer{}
+	case MsgStateProofReqType:
+		m = &StateProofRequest{}
+	case MsgStateProofDataType:
+		m = &StateProofResponse{}
 	case MsgActionType:
 		m = &ActionPb{}
 	case TestPayloadType:
@@ -21,6 +21,7 @@ type (
 	Contract interface {
 		GetState(hash.Hash32B) ([]byte, error)
 		SetState(hash.Hash32B, []byte) error
+		DelState(hash.Hash32B) error
 		GetCode() ([]byte, error)
 		SetCode(hash.Hash32B, []byte)
 		SelfState() *state.Account
@@ -52,6 +53,16 @@ func (c *contract) SetState(key hash.Hash32B, value []byte) error {
 	return c.trie.Upsert(key[:], value)
 }
 
+// DelState deletes the value from contract storage
+func (c *contract) DelState(key hash.Hash32B) error {
+	// trie's delete would remove the nodes along the path even if the key does not exist
+	if _, err := c.trie.Get(key[:]); err != nil {
+		return err
+	}
+	c.dirtyState = true
+	return c.trie.Delete(key[:])
+}
+
 // GetCode gets the contract's byte-code
 func (c *contract) GetCode() ([]byte, error) {
 	if c.code != nil {
@@ -60,8 +71,14 @@ func (c *contract) GetCode() ([]byte, error) {
 	return c.trie.TrieDB().Get(trie.CodeKVNameSpace, c.Account.CodeHash[:])
 }
 
-// SetCode sets the contract's byte-code
+// SetCode sets the contract's byte-code, an empty code clears the existing one
 func (c *contract) SetCode(hash hash.Hash32B, code []byte) {
+	if len(code) == 0 {
+		c.Account.CodeHash = nil
+		c.code = nil
+		c.dirtyCode = false
+		return
+	}
 	c.Account.CodeHash = hash[:]
 	c.code = code
 	c.dirtyCode = true
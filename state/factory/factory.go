@@ -23,6 +23,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/pkg/routine"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/trie"
@@ -35,6 +36,11 @@ const (
 	AccountTrieRootKey = "accountTrieRoot"
 )
 
+// CandidatesKey is the key of the sorted candidate list in the account trie. The list is committed by the state root
+// of each height from Chain.CandidatesRootHeight on, so that light clients could verify the candidates with a state
+// proof
+var CandidatesKey = byteutil.BytesTo20B(hash.Hash160b([]byte("CandidatesByHeight")))
+
 type (
 	// Factory defines an interface for managing states
 	Factory interface {
@@ -44,13 +50,27 @@ type (
 		Nonce(string) (uint64, error) // Note that Nonce starts with 1.
 		AccountState(string) (*state.Account, error)
 		RootHash() hash.Hash32B
+		// Accounts at a given height
+		BalanceByHeight(string, uint64) (*big.Int, error)
+		NonceByHeight(string, uint64) (uint64, error)
+		AccountStateByHeight(string, uint64) (*state.Account, error)
+		RootHashByHeight(uint64) (hash.Hash32B, error)
 		Height() (uint64, error)
 		NewWorkingSet() (WorkingSet, error)
 		Commit(WorkingSet) error
+		// Rollback rolls the states back to a lower height, whose root hash is still recorded
+		Rollback(uint64) error
 		// Candidate pool
 		CandidatesByHeight(uint64) ([]*state.Candidate, error)
 
 		State(hash.PKHash, interface{}) error
+		// StateProof returns the trie proof of a confirmed state against the current root hash
+		StateProof(hash.PKHash) ([][]byte, error)
+		// StateProofByHeight returns the trie proof of a state against the root hash at the given height
+		StateProofByHeight(hash.PKHash, uint64) ([][]byte, error)
+		// StorageProofByHeight returns the trie proof of a contract storage slot against the storage root of the
+		// contract at the given height
+		StorageProofByHeight(hash.PKHash, hash.Hash32B, uint64) ([][]byte, error)
 		AddActionHandlers(...protocol.ActionHandler)
 	}
 
@@ -65,6 +85,9 @@ type (
 		dao                db.KVStore               // the underlying DB for account/contract storage
 		actionHandlers     []protocol.ActionHandler // the handlers to handle actions
 		timerFactory       *prometheustimer.TimerFactory
+		retainedHeights    uint64 // number of the latest heights whose states are kept when pruning the trie
+		// the height from which the candidate list is committed into the account trie
+		candidatesRootHeight uint64
 	}
 )
 
@@ -99,7 +122,7 @@ func DefaultTrieOption() Option {
 		if len(dbPath) == 0 {
 			return errors.New("Invalid empty trie db path")
 		}
-		trieDB := db.NewBoltDB(dbPath, cfg.DB)
+		trieDB := db.NewOnDiskDB(dbPath, cfg.DB)
 		if err = trieDB.Start(context.Background()); err != nil {
 			return errors.Wrap(err, "failed to start trie db")
 		}
@@ -137,8 +160,9 @@ func InMemTrieOption() Option {
 // NewFactory creates a new state factory
 func NewFactory(cfg config.Config, opts ...Option) (Factory, error) {
 	sf := &factory{
-		currentChainHeight: 0,
-		numCandidates:      cfg.Chain.NumCandidates,
+		currentChainHeight:   0,
+		numCandidates:        cfg.Chain.NumCandidates,
+		candidatesRootHeight: cfg.Chain.CandidatesRootHeight,
 	}
 
 	for _, opt := range opts {
@@ -160,6 +184,10 @@ func NewFactory(cfg config.Config, opts ...Option) (Factory, error) {
 		logger.Error().Err(err).Msg("Failed to generate prometheus timer factory")
 	}
 	sf.timerFactory = timerFactory
+	if cfg.Chain.TriePruning.Enabled && sf.dao != nil {
+		sf.retainedHeights = cfg.Chain.TriePruning.RetainedHeights
+		sf.lifecycle.Add(routine.NewRecurringTask(sf.pruneTrie, cfg.Chain.TriePruning.Interval))
+	}
 
 	return sf, nil
 }
@@ -193,7 +221,7 @@ func (sf *factory) AddActionHandlers(actionHandlers ...protocol.ActionHandler) {
 func (sf *factory) Balance(addr string) (*big.Int, error) {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
-	account, err := sf.accountState(addr)
+	account, err := sf.accountState(sf.accountTrie, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +232,7 @@ func (sf *factory) Balance(addr string) (*big.Int, error) {
 func (sf *factory) Nonce(addr string) (uint64, error) {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
-	account, err := sf.accountState(addr)
+	account, err := sf.accountState(sf.accountTrie, addr)
 	if err != nil {
 		return 0, err
 	}
@@ -216,7 +244,7 @@ func (sf *factory) AccountState(addr string) (*state.Account, error) {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
 
-	return sf.accountState(addr)
+	return sf.accountState(sf.accountTrie, addr)
 }
 
 // RootHash returns the hash of the root node of the state trie
@@ -226,6 +254,44 @@ func (sf *factory) RootHash() hash.Hash32B {
 	return sf.rootHash
 }
 
+// BalanceByHeight returns the balance at the given height
+func (sf *factory) BalanceByHeight(addr string, height uint64) (*big.Int, error) {
+	account, err := sf.AccountStateByHeight(addr, height)
+	if err != nil {
+		return nil, err
+	}
+	return account.Balance, nil
+}
+
+// NonceByHeight returns the Nonce at the given height
+func (sf *factory) NonceByHeight(addr string, height uint64) (uint64, error) {
+	account, err := sf.AccountStateByHeight(addr, height)
+	if err != nil {
+		return 0, err
+	}
+	return account.Nonce, nil
+}
+
+// AccountStateByHeight returns the confirmed account state at the given height
+func (sf *factory) AccountStateByHeight(addr string, height uint64) (*state.Account, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+
+	tr, err := sf.accountTrieByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return sf.accountState(tr, addr)
+}
+
+// RootHashByHeight returns the hash of the root node of the state trie at the given height
+func (sf *factory) RootHashByHeight(height uint64) (hash.Hash32B, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+
+	return sf.rootHashByHeight(height)
+}
+
 // Height returns factory's height
 func (sf *factory) Height() (uint64, error) {
 	sf.mutex.RLock()
@@ -240,7 +306,7 @@ func (sf *factory) Height() (uint64, error) {
 func (sf *factory) NewWorkingSet() (WorkingSet, error) {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
-	return NewWorkingSet(sf.currentChainHeight, sf.dao, sf.rootHash, sf.actionHandlers)
+	return NewWorkingSet(sf.currentChainHeight, sf.dao, sf.rootHash, sf.actionHandlers, sf.candidatesRootHeight)
 }
 
 // Commit persists all changes in RunActions() into the DB
@@ -267,6 +333,42 @@ func (sf *factory) Commit(ws WorkingSet) error {
 	return nil
 }
 
+// Rollback rolls the states back to the given height, which is used to switch to another branch of the chain. The
+// trie nodes of the height must not have been pruned
+func (sf *factory) Rollback(height uint64) error {
+	sf.mutex.Lock()
+	defer sf.mutex.Unlock()
+	if height > sf.currentChainHeight {
+		return errors.Errorf("cannot roll back to height %d higher than %d", height, sf.currentChainHeight)
+	}
+	root, err := sf.rootHashByHeight(height)
+	if err != nil {
+		return err
+	}
+	batch := db.NewBatch()
+	batch.Put(trie.AccountKVNameSpace, []byte(AccountTrieRootKey), root[:], "failed to store accountTrie's root hash")
+	batch.Put(
+		trie.AccountKVNameSpace,
+		[]byte(CurrentHeightKey),
+		byteutil.Uint64ToBytes(height),
+		"failed to store accountTrie's current Height",
+	)
+	for h := height + 1; h <= sf.currentChainHeight; h++ {
+		key := byteutil.Uint64ToBytes(h)
+		batch.Delete(trie.AccountTrieRootKVNameSpace, key, "failed to delete root hash on height %d", h)
+		batch.Delete(trie.CandidateKVNameSpace, key, "failed to delete Candidates on height %d", h)
+	}
+	if err := sf.dao.Commit(batch); err != nil {
+		return errors.Wrapf(err, "failed to roll back to height %d", height)
+	}
+	if err := sf.accountTrie.SetRoot(root); err != nil {
+		return errors.Wrapf(err, "failed to load accountTrie on height %d", height)
+	}
+	sf.currentChainHeight = height
+	sf.rootHash = root
+	return nil
+}
+
 //======================================
 // Candidate functions
 //======================================
@@ -294,7 +396,63 @@ func (sf *factory) State(addr hash.PKHash, state interface{}) error {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
 
-	return sf.state(addr, state)
+	return sf.state(sf.accountTrie, addr, state)
+}
+
+// StateProof returns the serialized trie nodes on the path from the current root to a confirmed state, which could be
+// verified by trie.VerifyProof() against the state root of the tip block. For a state not existing, the path ends where
+// it diverges from the state's key, which proves its absence
+func (sf *factory) StateProof(addr hash.PKHash) ([][]byte, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+
+	return sf.stateProof(sf.accountTrie, addr)
+}
+
+// StateProofByHeight returns the trie proof of a state at the given height, which could be verified against the
+// state root of the block on the height
+func (sf *factory) StateProofByHeight(addr hash.PKHash, height uint64) ([][]byte, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+
+	tr, err := sf.accountTrieByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return sf.stateProof(tr, addr)
+}
+
+// StorageProofByHeight returns the trie proof of a storage slot of a contract at the given height, which could be
+// verified against the storage root in the account state of the contract on the height
+func (sf *factory) StorageProofByHeight(addr hash.PKHash, key hash.Hash32B, height uint64) ([][]byte, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+
+	tr, err := sf.accountTrieByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	var account state.Account
+	if err := sf.state(tr, addr, &account); err != nil {
+		return nil, err
+	}
+	root := account.Root
+	if root == hash.ZeroHash32B {
+		root = trie.EmptyRoot
+	}
+	// the view has its own batch, which is never committed
+	storage, err := trie.NewTrieSharedBatch(sf.dao, db.NewCachedBatch(), trie.ContractKVNameSpace, root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate the storage trie of %x on height %d", addr, height)
+	}
+	if err := storage.SetRoot(root); err != nil {
+		return nil, errors.Wrapf(err, "failed to load the storage trie of %x on height %d", addr, height)
+	}
+	proof, err := storage.Prove(key[:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when proving the storage %x of %x", key, addr)
+	}
+	return proof, nil
 }
 
 //======================================
@@ -305,7 +463,8 @@ func (sf *factory) getRoot(nameSpace string, key string) (hash.Hash32B, error) {
 	switch root, err := sf.dao.Get(nameSpace, []byte(key)); errors.Cause(err) {
 	case nil:
 		trieRoot = byteutil.BytesTo32B(root)
-	case bolt.ErrBucketNotFound:
+	case bolt.ErrBucketNotFound, db.ErrNotExist:
+		// bolt reports a missing bucket while LevelDB, which has no buckets, reports a missing key
 		trieRoot = trie.EmptyRoot
 	default:
 		return hash.ZeroHash32B, err
@@ -313,8 +472,33 @@ func (sf *factory) getRoot(nameSpace string, key string) (hash.Hash32B, error) {
 	return trieRoot, nil
 }
 
-func (sf *factory) state(addr hash.PKHash, s interface{}) error {
-	data, err := sf.accountTrie.Get(addr[:])
+func (sf *factory) rootHashByHeight(height uint64) (hash.Hash32B, error) {
+	root, err := sf.dao.Get(trie.AccountTrieRootKVNameSpace, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		return hash.ZeroHash32B, errors.Wrapf(err, "failed to get accountTrie's root hash on height %d", height)
+	}
+	return byteutil.BytesTo32B(root), nil
+}
+
+// accountTrieByHeight returns a read-only view of the account trie at the given height
+func (sf *factory) accountTrieByHeight(height uint64) (trie.Trie, error) {
+	root, err := sf.rootHashByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	// the view has its own batch, which is never committed
+	tr, err := trie.NewTrieSharedBatch(sf.dao, db.NewCachedBatch(), trie.AccountKVNameSpace, root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate accountTrie on height %d", height)
+	}
+	if err := tr.SetRoot(root); err != nil {
+		return nil, errors.Wrapf(err, "failed to load accountTrie on height %d", height)
+	}
+	return tr, nil
+}
+
+func (sf *factory) state(tr trie.Trie, addr hash.PKHash, s interface{}) error {
+	data, err := tr.Get(addr[:])
 	if err != nil {
 		if errors.Cause(err) == trie.ErrNotExist {
 			return errors.Wrapf(state.ErrStateNotExist, "state of %x doesn't exist", addr)
@@ -327,13 +511,21 @@ func (sf *factory) state(addr hash.PKHash, s interface{}) error {
 	return nil
 }
 
-func (sf *factory) accountState(addr string) (*state.Account, error) {
+func (sf *factory) stateProof(tr trie.Trie, addr hash.PKHash) ([][]byte, error) {
+	proof, err := tr.Prove(addr[:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when proving the state of %x", addr)
+	}
+	return proof, nil
+}
+
+func (sf *factory) accountState(tr trie.Trie, addr string) (*state.Account, error) {
 	pkHash, err := iotxaddress.AddressToPKHash(addr)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when getting the pubkey hash")
 	}
 	var account state.Account
-	if err := sf.state(pkHash, &account); err != nil {
+	if err := sf.state(tr, pkHash, &account); err != nil {
 		if errors.Cause(err) == state.ErrStateNotExist {
 			return &state.Account{
 				Balance:      big.NewInt(0),
@@ -16,6 +16,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -111,6 +112,340 @@ func TestCreateState(t *testing.T) {
 	require.Equal(big.NewInt(5), ss.Balance)
 }
 
+func TestStateProof(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	addr, err := iotxaddress.NewAddress(true, []byte{0xa4, 0x00, 0x00, 0x00})
+	require.NoError(err)
+	pkHash, err := iotxaddress.AddressToPKHash(addr.RawAddress)
+	require.NoError(err)
+	// the proof of a state not existing proves its absence
+	proof, err := sf.StateProof(pkHash)
+	require.NoError(err)
+	_, err = trie.VerifyProof(sf.RootHash(), pkHash[:], proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = ws.LoadOrCreateAccountState(addr.RawAddress, big.NewInt(5))
+	require.NoError(err)
+	gasLimit := testutil.TestGasLimit
+	ctx := state.WithRunActionsCtx(context.Background(),
+		state.RunActionsCtx{
+			ProducerAddr:    testaddress.Addrinfo["producer"].RawAddress,
+			GasLimit:        &gasLimit,
+			EnableGasCharge: testutil.EnableGasCharge,
+		})
+	_, _, err = ws.RunActions(ctx, 0, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+
+	proof, err = sf.StateProof(pkHash)
+	require.NoError(err)
+	data, err := trie.VerifyProof(sf.RootHash(), pkHash[:], proof)
+	require.NoError(err)
+	var account state.Account
+	require.NoError(state.Deserialize(&account, data))
+	require.Equal(big.NewInt(5), account.Balance)
+	_, err = trie.VerifyProof(trie.EmptyRoot, pkHash[:], proof)
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+}
+
+func TestStateByHeight(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	addr, err := iotxaddress.NewAddress(true, []byte{0xa4, 0x00, 0x00, 0x00})
+	require.NoError(err)
+	gasLimit := testutil.TestGasLimit
+	ctx := state.WithRunActionsCtx(context.Background(),
+		state.RunActionsCtx{
+			ProducerAddr:    testaddress.Addrinfo["producer"].RawAddress,
+			GasLimit:        &gasLimit,
+			EnableGasCharge: testutil.EnableGasCharge,
+		})
+
+	var roots []hash.Hash32B
+	for height := uint64(0); height < 3; height++ {
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		s, err := ws.LoadOrCreateAccountState(addr.RawAddress, big.NewInt(5))
+		require.NoError(err)
+		if height > 0 {
+			require.NoError(s.AddBalance(big.NewInt(10)))
+			s.Nonce = height
+		}
+		_, _, err = ws.RunActions(ctx, height, nil)
+		require.NoError(err)
+		require.NoError(sf.Commit(ws))
+		roots = append(roots, sf.RootHash())
+	}
+
+	balance, err := sf.Balance(addr.RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(25), balance)
+	for height := uint64(0); height < 3; height++ {
+		root, err := sf.RootHashByHeight(height)
+		require.NoError(err)
+		require.Equal(roots[height], root)
+		balance, err := sf.BalanceByHeight(addr.RawAddress, height)
+		require.NoError(err)
+		require.Equal(big.NewInt(int64(5+10*height)), balance)
+		nonce, err := sf.NonceByHeight(addr.RawAddress, height)
+		require.NoError(err)
+		require.Equal(height, nonce)
+	}
+	// account doesn't exist at a past height
+	other, err := iotxaddress.NewAddress(true, []byte{0xa4, 0x00, 0x00, 0x00})
+	require.NoError(err)
+	account, err := sf.AccountStateByHeight(other.RawAddress, 1)
+	require.NoError(err)
+	require.Equal(big.NewInt(0), account.Balance)
+	// height in the future
+	_, err = sf.AccountStateByHeight(addr.RawAddress, 3)
+	require.Error(err)
+}
+
+func TestCandidatesProof(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Chain.CandidatesRootHeight = 1
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	gasLimit := testutil.TestGasLimit
+	ctx := state.WithRunActionsCtx(context.Background(),
+		state.RunActionsCtx{
+			ProducerAddr:    testaddress.Addrinfo["producer"].RawAddress,
+			GasLimit:        &gasLimit,
+			EnableGasCharge: testutil.EnableGasCharge,
+		})
+
+	alfa := testaddress.Addrinfo["alfa"]
+	pkHash, err := iotxaddress.AddressToPKHash(alfa.RawAddress)
+	require.NoError(err)
+	putCandidate := func(ws WorkingSet) {
+		ws.(*workingSet).cachedCandidates[pkHash] = &state.Candidate{
+			Address:   alfa.RawAddress,
+			Votes:     big.NewInt(10),
+			PublicKey: alfa.PublicKey,
+		}
+	}
+
+	// the candidates aren't committed by the state root below the configured height
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	putCandidate(ws)
+	_, _, err = ws.RunActions(ctx, 0, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	proof, err := sf.StateProofByHeight(CandidatesKey, 0)
+	require.NoError(err)
+	root, err := sf.RootHashByHeight(0)
+	require.NoError(err)
+	_, err = trie.VerifyProof(root, CandidatesKey[:], proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	putCandidate(ws)
+	_, _, err = ws.RunActions(ctx, 1, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+
+	proof, err = sf.StateProofByHeight(CandidatesKey, 1)
+	require.NoError(err)
+	root, err = sf.RootHashByHeight(1)
+	require.NoError(err)
+	data, err := trie.VerifyProof(root, CandidatesKey[:], proof)
+	require.NoError(err)
+	var candidates state.CandidateList
+	require.NoError(candidates.Deserialize(data))
+	require.Equal(1, len(candidates))
+	require.Equal(alfa.RawAddress, candidates[0].Address)
+	require.Equal(big.NewInt(10), candidates[0].Votes)
+	// the proof doesn't hold against the root of another height
+	root, err = sf.RootHashByHeight(0)
+	require.NoError(err)
+	_, err = trie.VerifyProof(root, CandidatesKey[:], proof)
+	require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+}
+
+func TestRollback(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	addr, err := iotxaddress.NewAddress(true, []byte{0xa4, 0x00, 0x00, 0x00})
+	require.NoError(err)
+	gasLimit := testutil.TestGasLimit
+	ctx := state.WithRunActionsCtx(context.Background(),
+		state.RunActionsCtx{
+			ProducerAddr:    testaddress.Addrinfo["producer"].RawAddress,
+			GasLimit:        &gasLimit,
+			EnableGasCharge: testutil.EnableGasCharge,
+		})
+	commit := func(height uint64, amount int64) {
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		s, err := ws.LoadOrCreateAccountState(addr.RawAddress, big.NewInt(0))
+		require.NoError(err)
+		require.NoError(s.AddBalance(big.NewInt(amount)))
+		_, _, err = ws.RunActions(ctx, height, nil)
+		require.NoError(err)
+		require.NoError(sf.Commit(ws))
+	}
+
+	var roots []hash.Hash32B
+	for height := uint64(0); height < 4; height++ {
+		commit(height, 10)
+		roots = append(roots, sf.RootHash())
+	}
+	require.Error(sf.Rollback(4))
+
+	require.NoError(sf.Rollback(1))
+	height, err := sf.Height()
+	require.NoError(err)
+	require.Equal(uint64(1), height)
+	require.Equal(roots[1], sf.RootHash())
+	balance, err := sf.Balance(addr.RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(20), balance)
+	// the states above the height are gone
+	_, err = sf.RootHashByHeight(2)
+	require.Error(err)
+
+	// the working sets are built on the rolled back states
+	commit(2, 100)
+	balance, err = sf.Balance(addr.RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(120), balance)
+	root, err := sf.RootHashByHeight(2)
+	require.NoError(err)
+	require.NotEqual(roots[2], root)
+}
+
+func TestPruneTrie(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Chain.TriePruning.Enabled = true
+	cfg.Chain.TriePruning.RetainedHeights = 2
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	addr, err := iotxaddress.NewAddress(true, []byte{0xa4, 0x00, 0x00, 0x00})
+	require.NoError(err)
+	gasLimit := testutil.TestGasLimit
+	ctx := state.WithRunActionsCtx(context.Background(),
+		state.RunActionsCtx{
+			ProducerAddr:    testaddress.Addrinfo["producer"].RawAddress,
+			GasLimit:        &gasLimit,
+			EnableGasCharge: testutil.EnableGasCharge,
+		})
+	contractHash, err := iotxaddress.GetPubkeyHash(testaddress.Addrinfo["alfa"].RawAddress)
+	require.NoError(err)
+	contract := byteutil.BytesTo20B(contractHash)
+	storageKey := byteutil.BytesTo32B(hash.Hash160b([]byte("key")))
+	var storageRoots []hash.Hash32B
+	for height := uint64(0); height < 5; height++ {
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		s, err := ws.LoadOrCreateAccountState(addr.RawAddress, big.NewInt(5))
+		require.NoError(err)
+		if height > 0 {
+			require.NoError(s.AddBalance(big.NewInt(10)))
+		} else {
+			_, err = ws.LoadOrCreateAccountState(testaddress.Addrinfo["alfa"].RawAddress, big.NewInt(0))
+			require.NoError(err)
+			require.NoError(ws.SetCode(contract, []byte("contract")))
+		}
+		// the contract storage is updated on every height
+		value := byteutil.BytesTo32B(hash.Hash256b(byteutil.Uint64ToBytes(height)))
+		require.NoError(ws.SetContractState(contract, storageKey, value))
+		_, _, err = ws.RunActions(ctx, height, nil)
+		require.NoError(err)
+		require.NoError(sf.Commit(ws))
+		account, err := sf.AccountStateByHeight(testaddress.Addrinfo["alfa"].RawAddress, height)
+		require.NoError(err)
+		storageRoots = append(storageRoots, account.Root)
+	}
+
+	require.NoError(sf.(*factory).prune())
+	// only the storage tries of the retained heights are kept
+	dao := sf.(*factory).dao
+	for height := uint64(0); height < 3; height++ {
+		_, err := dao.Get(trie.ContractKVNameSpace, storageRoots[height][:])
+		require.Error(err)
+	}
+	for height := uint64(3); height < 5; height++ {
+		_, err := dao.Get(trie.ContractKVNameSpace, storageRoots[height][:])
+		require.NoError(err)
+	}
+	for height := uint64(0); height < 3; height++ {
+		_, err := sf.RootHashByHeight(height)
+		require.Error(err)
+		_, err = sf.BalanceByHeight(addr.RawAddress, height)
+		require.Error(err)
+	}
+	for height := uint64(3); height < 5; height++ {
+		balance, err := sf.BalanceByHeight(addr.RawAddress, height)
+		require.NoError(err)
+		require.Equal(big.NewInt(int64(5+10*height)), balance)
+	}
+	// the contract storage of the retained heights is proven against the storage root of the height
+	for height := uint64(0); height < 3; height++ {
+		_, err := sf.StorageProofByHeight(contract, storageKey, height)
+		require.Error(err)
+	}
+	for height := uint64(3); height < 5; height++ {
+		proof, err := sf.StorageProofByHeight(contract, storageKey, height)
+		require.NoError(err)
+		value, err := trie.VerifyProof(storageRoots[height], storageKey[:], proof)
+		require.NoError(err)
+		require.Equal(hash.Hash256b(byteutil.Uint64ToBytes(height)), value)
+	}
+	unsetKey := byteutil.BytesTo32B(hash.Hash160b([]byte("unset")))
+	proof, err := sf.StorageProofByHeight(contract, unsetKey, 4)
+	require.NoError(err)
+	_, err = trie.VerifyProof(storageRoots[4], unsetKey[:], proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	balance, err := sf.Balance(addr.RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(45), balance)
+
+	// the state could still be updated after pruning
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	s, err := ws.LoadOrCreateAccountState(addr.RawAddress, big.NewInt(5))
+	require.NoError(err)
+	require.NoError(s.AddBalance(big.NewInt(10)))
+	_, _, err = ws.RunActions(ctx, 5, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	balance, err = sf.BalanceByHeight(addr.RawAddress, 4)
+	require.NoError(err)
+	require.Equal(big.NewInt(45), balance)
+	balance, err = sf.Balance(addr.RawAddress)
+	require.NoError(err)
+	require.Equal(big.NewInt(55), balance)
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	value, err := ws.GetContractState(contract, storageKey)
+	require.NoError(err)
+	require.Equal(byteutil.BytesTo32B(hash.Hash256b(byteutil.Uint64ToBytes(4))), value)
+}
+
 func TestBalance(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -891,6 +1226,25 @@ func TestLoadStoreHeight(t *testing.T) {
 	require.Equal(uint64(10), height)
 }
 
+func TestNewFactoryOnLevelDB(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.DB.Engine = config.LevelDBEngine
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	// a new LevelDB has no record of the root hash, which is taken as an empty trie
+	statefactory, err := NewFactory(cfg, DefaultTrieOption())
+	require.NoError(err)
+	require.NoError(statefactory.Start(context.Background()))
+	defer func() {
+		require.NoError(statefactory.Stop(context.Background()))
+	}()
+	require.Equal(trie.EmptyRoot, statefactory.RootHash())
+}
+
 func TestLoadStoreHeightInMem(t *testing.T) {
 	require := require.New(t)
 
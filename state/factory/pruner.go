@@ -0,0 +1,167 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/logger"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/trie"
+)
+
+var (
+	pruneMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_state_trie_pruning",
+			Help: "State trie pruning counter.",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(pruneMtc)
+}
+
+// pruneChunkSize is the number of the nodes deleted at a time, which blocks the commits
+const pruneChunkSize = 1000
+
+// prune deletes the nodes of the account trie and the contract storage tries which are not reachable from the roots of
+// the retained heights. The expensive marking and the iteration of the nodes run without holding the lock, so that
+// the blocks could still be committed in the meantime. Only marking the roots committed in between and deleting the
+// nodes block the commits
+func (sf *factory) prune() error {
+	if sf.retainedHeights == 0 {
+		return errors.New("trie pruning is not enabled")
+	}
+	defer sf.timerFactory.NewTimer("PruneTrie").End()
+	tipHeight, err := sf.Height()
+	if err != nil {
+		return err
+	}
+	if tipHeight < sf.retainedHeights {
+		return nil
+	}
+	lowest := tipHeight - sf.retainedHeights + 1
+
+	accountPruner := trie.NewPruner(sf.dao, trie.AccountKVNameSpace)
+	contractPruner := trie.NewPruner(sf.dao, trie.ContractKVNameSpace)
+	for height := lowest; height <= tipHeight; height++ {
+		if err := sf.markHeight(accountPruner, contractPruner, height); err != nil {
+			return err
+		}
+	}
+	// markCommitted is called with the lock held, so that the height and the current root don't change until the
+	// nodes are deleted
+	markedHeight := tipHeight
+	markCommitted := func() error {
+		value, err := sf.dao.Get(trie.AccountKVNameSpace, []byte(CurrentHeightKey))
+		if err != nil {
+			return errors.Wrap(err, "failed to get factory's height from underlying DB")
+		}
+		newTipHeight := byteutil.BytesToUint64(value)
+		for ; markedHeight < newTipHeight; markedHeight++ {
+			if err := sf.markHeight(accountPruner, contractPruner, markedHeight+1); err != nil {
+				return err
+			}
+		}
+		// the working sets are always created on the current root
+		return sf.markRoot(accountPruner, contractPruner, sf.rootHash)
+	}
+	deleted, err := accountPruner.Sweep(pruneChunkSize, &sf.mutex, markCommitted)
+	pruneMtc.WithLabelValues("deletedNode").Add(float64(deleted))
+	if err != nil {
+		return err
+	}
+	deletedContract, err := contractPruner.Sweep(pruneChunkSize, &sf.mutex, markCommitted)
+	pruneMtc.WithLabelValues("deletedNode").Add(float64(deletedContract))
+	if err != nil {
+		return err
+	}
+	if err := sf.deleteRootHashes(lowest); err != nil {
+		return err
+	}
+	logger.Info().
+		Uint64("lowestHeight", lowest).
+		Int("marked", accountPruner.NumMarked()).
+		Int("deleted", deleted).
+		Int("markedContract", contractPruner.NumMarked()).
+		Int("deletedContract", deletedContract).
+		Msg("pruned state trie")
+	return nil
+}
+
+func (sf *factory) pruneTrie() {
+	pruneMtc.WithLabelValues("round").Inc()
+	if err := sf.prune(); err != nil {
+		pruneMtc.WithLabelValues("failure").Inc()
+		logger.Error().Err(err).Msg("failed to prune state trie")
+	}
+}
+
+// markHeight marks the nodes of the account trie and the contract storage tries at the given height
+func (sf *factory) markHeight(accountPruner, contractPruner *trie.Pruner, height uint64) error {
+	root, err := sf.dao.Get(trie.AccountTrieRootKVNameSpace, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		// the root hash is not recorded for the heights committed by an older version, whose nodes have been deleted
+		logger.Warn().Err(err).Uint64("height", height).Msg("root hash is missing when pruning state trie")
+		return nil
+	}
+	if err := sf.markRoot(accountPruner, contractPruner, byteutil.BytesTo32B(root)); err != nil {
+		return errors.Wrapf(err, "failed to mark state trie on height %d", height)
+	}
+	return nil
+}
+
+// markRoot marks the nodes of the account trie of the root, and the storage tries of the contracts in it
+func (sf *factory) markRoot(accountPruner, contractPruner *trie.Pruner, root hash.Hash32B) error {
+	return accountPruner.MarkWithValues(root, func(value []byte) error {
+		// the account trie also stores the states other than accounts, which don't have a storage trie, or may happen
+		// to be deserialized into an account with a root which is not a node
+		var account state.Account
+		if err := account.Deserialize(value); err != nil || account.Root == hash.ZeroHash32B {
+			return nil
+		}
+		switch _, err := sf.dao.Get(trie.ContractKVNameSpace, account.Root[:]); errors.Cause(err) {
+		case nil:
+			return contractPruner.Mark(account.Root)
+		case bolt.ErrBucketNotFound, db.ErrNotExist:
+			return nil
+		default:
+			// the sweep is aborted, since a storage trie which fails to be read is still in use
+			return errors.Wrapf(err, "failed to read the storage trie root %x", account.Root)
+		}
+	})
+}
+
+// deleteRootHashes deletes the root hashes of the heights lower than lowest, whose states are no longer available
+func (sf *factory) deleteRootHashes(lowest uint64) error {
+	iter, err := sf.dao.Iterate(trie.AccountTrieRootKVNameSpace, db.Range{})
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate the root hashes")
+	}
+	batch := db.NewBatch()
+	for iter.Next() {
+		height := byteutil.BytesToUint64(iter.Key())
+		if height < lowest {
+			batch.Delete(trie.AccountTrieRootKVNameSpace, iter.Key(), "failed to delete root hash on height %d", height)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "failed to iterate the root hashes")
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
+	return sf.dao.Commit(batch)
+}
@@ -41,6 +41,7 @@ type (
 		SetCode(hash.PKHash, []byte) error
 		GetContractState(hash.PKHash, hash.Hash32B) (hash.Hash32B, error)
 		SetContractState(hash.PKHash, hash.Hash32B, hash.Hash32B) error
+		DelContractState(hash.PKHash, hash.Hash32B) error
 		// Accounts
 		RootHash() hash.Hash32B
 		Version() uint64
@@ -48,6 +49,7 @@ type (
 		// General state
 		State(hash.PKHash, interface{}) error
 		PutState(hash.PKHash, interface{}) error
+		DelState(hash.PKHash) error
 		CachedState(hash.PKHash, state.State) (state.State, error)
 		UpdateCachedStates(hash.PKHash, *state.Account)
 	}
@@ -63,6 +65,8 @@ type (
 		cb               db.CachedBatch              // cached batch for pending writes
 		dao              db.KVStore                  // the underlying DB for account/contract storage
 		actionHandlers   []protocol.ActionHandler
+		// the height from which the candidate list is committed into the account trie
+		candidatesRootHeight uint64
 	}
 )
 
@@ -72,15 +76,17 @@ func NewWorkingSet(
 	kv db.KVStore,
 	root hash.Hash32B,
 	actionHandlers []protocol.ActionHandler,
+	candidatesRootHeight uint64,
 ) (WorkingSet, error) {
 	ws := &workingSet{
-		ver:              version,
-		cachedCandidates: make(map[hash.PKHash]*state.Candidate),
-		cachedStates:     make(map[hash.PKHash]state.State),
-		cachedContract:   make(map[hash.PKHash]Contract),
-		cb:               db.NewCachedBatch(),
-		dao:              kv,
-		actionHandlers:   actionHandlers,
+		ver:                  version,
+		cachedCandidates:     make(map[hash.PKHash]*state.Candidate),
+		cachedStates:         make(map[hash.PKHash]state.State),
+		cachedContract:       make(map[hash.PKHash]Contract),
+		cb:                   db.NewCachedBatch(),
+		dao:                  kv,
+		actionHandlers:       actionHandlers,
+		candidatesRootHeight: candidatesRootHeight,
 	}
 	tr, err := trie.NewTrieSharedBatch(ws.dao, ws.cb, trie.AccountKVNameSpace, root)
 	if err != nil {
@@ -103,6 +109,9 @@ func (ws *workingSet) LoadOrCreateAccountState(addr string, init *big.Int) (*sta
 	if err != nil {
 		return nil, err
 	}
+	if contract, ok := ws.cachedContract[addrHash]; ok {
+		return contract.SelfState(), nil
+	}
 	s, err := ws.CachedState(addrHash, &state.Account{})
 	switch {
 	case errors.Cause(err) == state.ErrStateNotExist:
@@ -287,9 +296,6 @@ func (ws *workingSet) RunActions(
 		}
 	}
 
-	// Persist accountTrie's root hash
-	rootHash := ws.accountTrie.RootHash()
-	ws.cb.Put(trie.AccountKVNameSpace, []byte(AccountTrieRootKey), rootHash[:], "failed to store accountTrie's root hash")
 	// Persist new list of Candidates
 	candidates, err := state.MapToCandidates(ws.cachedCandidates)
 	if err != nil {
@@ -300,8 +306,24 @@ func (ws *workingSet) RunActions(
 	if err != nil {
 		return hash.ZeroHash32B, nil, errors.Wrap(err, "failed to serialize Candidates")
 	}
+	if blockHeight >= ws.candidatesRootHeight {
+		if err := ws.commitCandidates(candidatesBytes); err != nil {
+			return hash.ZeroHash32B, nil, err
+		}
+	}
+	// Persist accountTrie's root hash
+	rootHash := ws.accountTrie.RootHash()
+	ws.cb.Put(trie.AccountKVNameSpace, []byte(AccountTrieRootKey), rootHash[:], "failed to store accountTrie's root hash")
 	h := byteutil.Uint64ToBytes(blockHeight)
 	ws.cb.Put(trie.CandidateKVNameSpace, h, candidatesBytes, "failed to store Candidates on Height %d", blockHeight)
+	// Persist accountTrie's root hash of this height, so that the historical states could be read
+	ws.cb.Put(
+		trie.AccountTrieRootKVNameSpace,
+		h,
+		rootHash[:],
+		"failed to store accountTrie's root hash on Height %d",
+		blockHeight,
+	)
 	// Persist current chain Height
 	ws.cb.Put(trie.AccountKVNameSpace, []byte(CurrentHeightKey), h, "failed to store accountTrie's current Height")
 
@@ -398,6 +420,18 @@ func (ws *workingSet) SetContractState(addr hash.PKHash, key, value hash.Hash32B
 	return contract.SetState(key, value[:])
 }
 
+// DelContractState deletes contract's storage value
+func (ws *workingSet) DelContractState(addr hash.PKHash, key hash.Hash32B) error {
+	if contract, ok := ws.cachedContract[addr]; ok {
+		return contract.DelState(key)
+	}
+	contract, err := ws.getContract(addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to DelContractState for contract %x", addr)
+	}
+	return contract.DelState(key)
+}
+
 //======================================
 // private account/contract functions
 //======================================
@@ -451,6 +485,20 @@ func (ws *workingSet) PutState(pkHash hash.PKHash, s interface{}) error {
 	return ws.accountTrie.Upsert(pkHash[:], ss)
 }
 
+// DelState removes a state from local cache and DB
+func (ws *workingSet) DelState(pkHash hash.PKHash) error {
+	delete(ws.cachedStates, pkHash)
+	delete(ws.cachedContract, pkHash)
+	// the state may only live in local cache, in which case there is nothing to delete in the trie
+	if _, err := ws.accountTrie.Get(pkHash[:]); err != nil {
+		if errors.Cause(err) == trie.ErrNotExist {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get account of %x", pkHash)
+	}
+	return ws.accountTrie.Delete(pkHash[:])
+}
+
 func (ws *workingSet) getContract(addr hash.PKHash) (Contract, error) {
 	s, err := ws.CachedState(addr, &state.Account{})
 	if err != nil {
@@ -494,6 +542,28 @@ func (ws *workingSet) updateCandidate(pkHash hash.PKHash, totalWeight *big.Int,
 	candidate.LastUpdateHeight = blockHeight
 }
 
+// commitCandidates puts the candidate list of the height into the account trie, so that the state root commits to it
+// and the list could be proven to light clients. As it changes the state roots, it's only done from the configured
+// height on
+func (ws *workingSet) commitCandidates(candidatesBytes []byte) error {
+	if len(ws.cachedCandidates) > 0 {
+		if err := ws.accountTrie.Upsert(CandidatesKey[:], candidatesBytes); err != nil {
+			return errors.Wrap(err, "failed to put Candidates into trie")
+		}
+		return nil
+	}
+	switch _, err := ws.accountTrie.Get(CandidatesKey[:]); errors.Cause(err) {
+	case nil:
+		if err := ws.accountTrie.Delete(CandidatesKey[:]); err != nil {
+			return errors.Wrap(err, "failed to delete Candidates from trie")
+		}
+	case trie.ErrNotExist:
+	default:
+		return errors.Wrap(err, "failed to get Candidates from trie")
+	}
+	return nil
+}
+
 func (ws *workingSet) getCandidates(height uint64) (state.CandidateList, error) {
 	candidatesBytes, err := ws.dao.Get(trie.CandidateKVNameSpace, byteutil.Uint64ToBytes(height))
 	if err != nil {
@@ -77,6 +77,19 @@ func (mr *MockBlockchainMockRecorder) Balance(addr interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockBlockchain)(nil).Balance), addr)
 }
 
+// BalanceByHeight mocks base method
+func (m *MockBlockchain) BalanceByHeight(addr string, height uint64) (*big.Int, error) {
+	ret := m.ctrl.Call(m, "BalanceByHeight", addr, height)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceByHeight indicates an expected call of BalanceByHeight
+func (mr *MockBlockchainMockRecorder) BalanceByHeight(addr, height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceByHeight", reflect.TypeOf((*MockBlockchain)(nil).BalanceByHeight), addr, height)
+}
+
 // Nonce mocks base method
 func (m *MockBlockchain) Nonce(addr string) (uint64, error) {
 	ret := m.ctrl.Call(m, "Nonce", addr)
@@ -389,6 +402,19 @@ func (mr *MockBlockchainMockRecorder) GetReceiptByExecutionHash(h interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptByExecutionHash", reflect.TypeOf((*MockBlockchain)(nil).GetReceiptByExecutionHash), h)
 }
 
+// GetLogs mocks base method
+func (m *MockBlockchain) GetLogs(filter *blockchain.LogFilter, fromHeight, toHeight, limit uint64) ([]*action.Log, error) {
+	ret := m.ctrl.Call(m, "GetLogs", filter, fromHeight, toHeight, limit)
+	ret0, _ := ret[0].([]*action.Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogs indicates an expected call of GetLogs
+func (mr *MockBlockchainMockRecorder) GetLogs(filter, fromHeight, toHeight, limit interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockBlockchain)(nil).GetLogs), filter, fromHeight, toHeight, limit)
+}
+
 // GetActionsFromAddress mocks base method
 func (m *MockBlockchain) GetActionsFromAddress(address string) ([]hash.Hash32B, error) {
 	ret := m.ctrl.Call(m, "GetActionsFromAddress", address)
@@ -514,6 +540,19 @@ func (mr *MockBlockchainMockRecorder) StateByAddr(address interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateByAddr", reflect.TypeOf((*MockBlockchain)(nil).StateByAddr), address)
 }
 
+// StateByAddrAndHeight mocks base method
+func (m *MockBlockchain) StateByAddrAndHeight(address string, height uint64) (*state.Account, error) {
+	ret := m.ctrl.Call(m, "StateByAddrAndHeight", address, height)
+	ret0, _ := ret[0].(*state.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateByAddrAndHeight indicates an expected call of StateByAddrAndHeight
+func (mr *MockBlockchainMockRecorder) StateByAddrAndHeight(address, height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateByAddrAndHeight", reflect.TypeOf((*MockBlockchain)(nil).StateByAddrAndHeight), address, height)
+}
+
 // MintNewBlock mocks base method
 func (m *MockBlockchain) MintNewBlock(actions []action.Action, producer *iotxaddress.Address, dkgAddress *iotxaddress.DKGAddress, seed []byte, data string) (*blockchain.Block, error) {
 	ret := m.ctrl.Call(m, "MintNewBlock", actions, producer, dkgAddress, seed, data)
@@ -96,6 +96,30 @@ func (mr *MockBlockSyncMockRecorder) ProcessSyncRequest(sender, sync interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSyncRequest", reflect.TypeOf((*MockBlockSync)(nil).ProcessSyncRequest), sender, sync)
 }
 
+// ProcessHeaderSyncRequest mocks base method
+func (m *MockBlockSync) ProcessHeaderSyncRequest(sender string, sync *proto.BlockHeaderSync) error {
+	ret := m.ctrl.Call(m, "ProcessHeaderSyncRequest", sender, sync)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessHeaderSyncRequest indicates an expected call of ProcessHeaderSyncRequest
+func (mr *MockBlockSyncMockRecorder) ProcessHeaderSyncRequest(sender, sync interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessHeaderSyncRequest", reflect.TypeOf((*MockBlockSync)(nil).ProcessHeaderSyncRequest), sender, sync)
+}
+
+// ProcessStateProofRequest mocks base method
+func (m *MockBlockSync) ProcessStateProofRequest(sender string, req *proto.StateProofRequest) error {
+	ret := m.ctrl.Call(m, "ProcessStateProofRequest", sender, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessStateProofRequest indicates an expected call of ProcessStateProofRequest
+func (mr *MockBlockSyncMockRecorder) ProcessStateProofRequest(sender, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessStateProofRequest", reflect.TypeOf((*MockBlockSync)(nil).ProcessStateProofRequest), sender, req)
+}
+
 // ProcessBlock mocks base method
 func (m *MockBlockSync) ProcessBlock(blk *blockchain.Block) error {
 	ret := m.ctrl.Call(m, "ProcessBlock", blk)
@@ -604,3 +604,94 @@ func (m *MockExplorer) EstimateGasForSmartContract(request explorer.Execution) (
 func (mr *MockExplorerMockRecorder) EstimateGasForSmartContract(request interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGasForSmartContract", reflect.TypeOf((*MockExplorer)(nil).EstimateGasForSmartContract), request)
 }
+
+// GetActionProof mocks base method
+func (m *MockExplorer) GetActionProof(hashStr string) (explorer.ActionProof, error) {
+	ret := m.ctrl.Call(m, "GetActionProof", hashStr)
+	ret0, _ := ret[0].(explorer.ActionProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActionProof indicates an expected call of GetActionProof
+func (mr *MockExplorerMockRecorder) GetActionProof(hashStr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionProof", reflect.TypeOf((*MockExplorer)(nil).GetActionProof), hashStr)
+}
+
+// GetAccountProof mocks base method
+func (m *MockExplorer) GetAccountProof(address string, height int64) (explorer.AccountProof, error) {
+	ret := m.ctrl.Call(m, "GetAccountProof", address, height)
+	ret0, _ := ret[0].(explorer.AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountProof indicates an expected call of GetAccountProof
+func (mr *MockExplorerMockRecorder) GetAccountProof(address, height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountProof", reflect.TypeOf((*MockExplorer)(nil).GetAccountProof), address, height)
+}
+
+// GetStorageProof mocks base method
+func (m *MockExplorer) GetStorageProof(address, key string, height int64) (explorer.StorageProof, error) {
+	ret := m.ctrl.Call(m, "GetStorageProof", address, key, height)
+	ret0, _ := ret[0].(explorer.StorageProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageProof indicates an expected call of GetStorageProof
+func (mr *MockExplorerMockRecorder) GetStorageProof(address, key, height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageProof", reflect.TypeOf((*MockExplorer)(nil).GetStorageProof), address, key, height)
+}
+
+// GetAddressBalanceByHeight mocks base method
+func (m *MockExplorer) GetAddressBalanceByHeight(address string, height int64) (string, error) {
+	ret := m.ctrl.Call(m, "GetAddressBalanceByHeight", address, height)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressBalanceByHeight indicates an expected call of GetAddressBalanceByHeight
+func (mr *MockExplorerMockRecorder) GetAddressBalanceByHeight(address, height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressBalanceByHeight", reflect.TypeOf((*MockExplorer)(nil).GetAddressBalanceByHeight), address, height)
+}
+
+// GetAddressDetailsByHeight mocks base method
+func (m *MockExplorer) GetAddressDetailsByHeight(address string, height int64) (explorer.AddressDetails, error) {
+	ret := m.ctrl.Call(m, "GetAddressDetailsByHeight", address, height)
+	ret0, _ := ret[0].(explorer.AddressDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressDetailsByHeight indicates an expected call of GetAddressDetailsByHeight
+func (mr *MockExplorerMockRecorder) GetAddressDetailsByHeight(address, height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressDetailsByHeight", reflect.TypeOf((*MockExplorer)(nil).GetAddressDetailsByHeight), address, height)
+}
+
+// GetActionStatus mocks base method
+func (m *MockExplorer) GetActionStatus(hashStr string) (explorer.ActionStatus, error) {
+	ret := m.ctrl.Call(m, "GetActionStatus", hashStr)
+	ret0, _ := ret[0].(explorer.ActionStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActionStatus indicates an expected call of GetActionStatus
+func (mr *MockExplorerMockRecorder) GetActionStatus(hashStr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionStatus", reflect.TypeOf((*MockExplorer)(nil).GetActionStatus), hashStr)
+}
+
+// GetLogs mocks base method
+func (m *MockExplorer) GetLogs(request explorer.GetLogsRequest) ([]explorer.Log, error) {
+	ret := m.ctrl.Call(m, "GetLogs", request)
+	ret0, _ := ret[0].([]explorer.Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogs indicates an expected call of GetLogs
+func (mr *MockExplorerMockRecorder) GetLogs(request interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockExplorer)(nil).GetLogs), request)
+}
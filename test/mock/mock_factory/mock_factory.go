@@ -113,6 +113,58 @@ func (mr *MockFactoryMockRecorder) RootHash() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RootHash", reflect.TypeOf((*MockFactory)(nil).RootHash))
 }
 
+// BalanceByHeight mocks base method
+func (m *MockFactory) BalanceByHeight(arg0 string, arg1 uint64) (*big.Int, error) {
+	ret := m.ctrl.Call(m, "BalanceByHeight", arg0, arg1)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceByHeight indicates an expected call of BalanceByHeight
+func (mr *MockFactoryMockRecorder) BalanceByHeight(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceByHeight", reflect.TypeOf((*MockFactory)(nil).BalanceByHeight), arg0, arg1)
+}
+
+// NonceByHeight mocks base method
+func (m *MockFactory) NonceByHeight(arg0 string, arg1 uint64) (uint64, error) {
+	ret := m.ctrl.Call(m, "NonceByHeight", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NonceByHeight indicates an expected call of NonceByHeight
+func (mr *MockFactoryMockRecorder) NonceByHeight(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NonceByHeight", reflect.TypeOf((*MockFactory)(nil).NonceByHeight), arg0, arg1)
+}
+
+// AccountStateByHeight mocks base method
+func (m *MockFactory) AccountStateByHeight(arg0 string, arg1 uint64) (*state.Account, error) {
+	ret := m.ctrl.Call(m, "AccountStateByHeight", arg0, arg1)
+	ret0, _ := ret[0].(*state.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountStateByHeight indicates an expected call of AccountStateByHeight
+func (mr *MockFactoryMockRecorder) AccountStateByHeight(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountStateByHeight", reflect.TypeOf((*MockFactory)(nil).AccountStateByHeight), arg0, arg1)
+}
+
+// RootHashByHeight mocks base method
+func (m *MockFactory) RootHashByHeight(arg0 uint64) (hash.Hash32B, error) {
+	ret := m.ctrl.Call(m, "RootHashByHeight", arg0)
+	ret0, _ := ret[0].(hash.Hash32B)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RootHashByHeight indicates an expected call of RootHashByHeight
+func (mr *MockFactoryMockRecorder) RootHashByHeight(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RootHashByHeight", reflect.TypeOf((*MockFactory)(nil).RootHashByHeight), arg0)
+}
+
 // Height mocks base method
 func (m *MockFactory) Height() (uint64, error) {
 	ret := m.ctrl.Call(m, "Height")
@@ -151,6 +203,18 @@ func (mr *MockFactoryMockRecorder) Commit(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockFactory)(nil).Commit), arg0)
 }
 
+// Rollback mocks base method
+func (m *MockFactory) Rollback(arg0 uint64) error {
+	ret := m.ctrl.Call(m, "Rollback", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback
+func (mr *MockFactoryMockRecorder) Rollback(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockFactory)(nil).Rollback), arg0)
+}
+
 // CandidatesByHeight mocks base method
 func (m *MockFactory) CandidatesByHeight(arg0 uint64) ([]*state.Candidate, error) {
 	ret := m.ctrl.Call(m, "CandidatesByHeight", arg0)
@@ -176,6 +240,45 @@ func (mr *MockFactoryMockRecorder) State(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockFactory)(nil).State), arg0, arg1)
 }
 
+// StateProof mocks base method
+func (m *MockFactory) StateProof(arg0 hash.PKHash) ([][]byte, error) {
+	ret := m.ctrl.Call(m, "StateProof", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateProof indicates an expected call of StateProof
+func (mr *MockFactoryMockRecorder) StateProof(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateProof", reflect.TypeOf((*MockFactory)(nil).StateProof), arg0)
+}
+
+// StateProofByHeight mocks base method
+func (m *MockFactory) StateProofByHeight(arg0 hash.PKHash, arg1 uint64) ([][]byte, error) {
+	ret := m.ctrl.Call(m, "StateProofByHeight", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateProofByHeight indicates an expected call of StateProofByHeight
+func (mr *MockFactoryMockRecorder) StateProofByHeight(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateProofByHeight", reflect.TypeOf((*MockFactory)(nil).StateProofByHeight), arg0, arg1)
+}
+
+// StorageProofByHeight mocks base method
+func (m *MockFactory) StorageProofByHeight(arg0 hash.PKHash, arg1 hash.Hash32B, arg2 uint64) ([][]byte, error) {
+	ret := m.ctrl.Call(m, "StorageProofByHeight", arg0, arg1, arg2)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageProofByHeight indicates an expected call of StorageProofByHeight
+func (mr *MockFactoryMockRecorder) StorageProofByHeight(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageProofByHeight", reflect.TypeOf((*MockFactory)(nil).StorageProofByHeight), arg0, arg1, arg2)
+}
+
 // AddActionHandlers mocks base method
 func (m *MockFactory) AddActionHandlers(arg0 ...protocol.ActionHandler) {
 	varargs := []interface{}{}
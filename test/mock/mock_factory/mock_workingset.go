@@ -165,6 +165,18 @@ func (mr *MockWorkingSetMockRecorder) SetContractState(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContractState", reflect.TypeOf((*MockWorkingSet)(nil).SetContractState), arg0, arg1, arg2)
 }
 
+// DelContractState mocks base method
+func (m *MockWorkingSet) DelContractState(arg0 hash.PKHash, arg1 hash.Hash32B) error {
+	ret := m.ctrl.Call(m, "DelContractState", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelContractState indicates an expected call of DelContractState
+func (mr *MockWorkingSetMockRecorder) DelContractState(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelContractState", reflect.TypeOf((*MockWorkingSet)(nil).DelContractState), arg0, arg1)
+}
+
 // RootHash mocks base method
 func (m *MockWorkingSet) RootHash() hash.Hash32B {
 	ret := m.ctrl.Call(m, "RootHash")
@@ -225,6 +237,18 @@ func (mr *MockWorkingSetMockRecorder) PutState(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutState", reflect.TypeOf((*MockWorkingSet)(nil).PutState), arg0, arg1)
 }
 
+// DelState mocks base method
+func (m *MockWorkingSet) DelState(arg0 hash.PKHash) error {
+	ret := m.ctrl.Call(m, "DelState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelState indicates an expected call of DelState
+func (mr *MockWorkingSetMockRecorder) DelState(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelState", reflect.TypeOf((*MockWorkingSet)(nil).DelState), arg0)
+}
+
 // CachedState mocks base method
 func (m *MockWorkingSet) CachedState(arg0 hash.PKHash, arg1 state.State) (state.State, error) {
 	ret := m.ctrl.Call(m, "CachedState", arg0, arg1)
@@ -13,9 +13,9 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 )
 
-// CleanupPath detects the existence of test DB file and removes it if found
+// CleanupPath detects the existence of test DB file (or directory, e.g., LevelDB) and removes it if found
 func CleanupPath(t *testing.T, path string) {
-	if fileutil.FileExists(path) && os.Remove(path) != nil {
+	if fileutil.FileExists(path) && os.RemoveAll(path) != nil {
 		t.Error("Fail to remove testDB file")
 	}
 }
@@ -0,0 +1,63 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is a tool to migrate an existing BoltDB file (chain.db or trie.db) to another DB engine
+// To use, run "make build" and " ./bin/dbmigrate -from=./chain.db -to=./chain.leveldb"
+// then set db.engine in the config and point chain.chainDBPath (or chain.trieDBPath) to the new path
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/logger"
+)
+
+func main() {
+	// path of the BoltDB file to migrate from
+	var from string
+	// path of the DB to migrate to
+	var to string
+	// engine of the DB to migrate to. Default is "leveldb"
+	var engine string
+	// number of records written in one batch. Default is 10000
+	var batchSize int
+
+	flag.StringVar(&from, "from", "./chain.db", "path of the BoltDB file to migrate from")
+	flag.StringVar(&to, "to", "./chain.leveldb", "path of the DB to migrate to")
+	flag.StringVar(&engine, "engine", config.LevelDBEngine, "engine of the DB to migrate to")
+	flag.IntVar(&batchSize, "batch-size", 10000, "number of records written in one batch")
+	flag.Parse()
+
+	if engine == config.BoltDBEngine {
+		logger.Fatal().Str("engine", engine).Msg("Cannot migrate to the same DB engine")
+	}
+	cfg := config.Default.DB
+	cfg.Engine = engine
+	if err := config.ValidateDB(config.Config{DB: cfg}); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid DB config")
+	}
+
+	ctx := context.Background()
+	kvStore := db.NewOnDiskDB(to, cfg)
+	if err := kvStore.Start(ctx); err != nil {
+		logger.Fatal().Err(err).Str("path", to).Msg("Failed to open the DB to migrate to")
+	}
+	defer func() {
+		if err := kvStore.Stop(ctx); err != nil {
+			logger.Error().Err(err).Str("path", to).Msg("Failed to close the DB")
+		}
+	}()
+
+	if err := db.MigrateBoltDB(from, kvStore, batchSize); err != nil {
+		logger.Error().Err(err).Str("from", from).Str("to", to).Msg("Failed to migrate DB")
+		return
+	}
+	logger.Info().Str("from", from).Str("to", to).Str("engine", engine).Msg("Finished migrating DB")
+}
@@ -59,9 +59,10 @@ func main() {
 	for i := 0; i < numNodes; i++ {
 		chainDBPath := fmt.Sprintf("./chain%d.db", i+1)
 		trieDBPath := fmt.Sprintf("./trie%d.db", i+1)
+		walPath := fmt.Sprintf("./wal%d.db", i+1)
 		networkPort := 4689 + i
 		explorerPort := 14004 + i
-		config := newConfig(genesisConfigPath, chainDBPath, trieDBPath, chainAddrs[i].PublicKey,
+		config := newConfig(genesisConfigPath, chainDBPath, trieDBPath, walPath, chainAddrs[i].PublicKey,
 			chainAddrs[i].PrivateKey, networkPort, explorerPort)
 		configs[i] = config
 	}
@@ -183,7 +184,8 @@ func main() {
 func newConfig(
 	genesisConfigPath,
 	chainDBPath,
-	trieDBPath string,
+	trieDBPath,
+	walPath string,
 	producerPubKey keypair.PublicKey,
 	producerPriKey keypair.PrivateKey,
 	networkPort,
@@ -220,6 +222,7 @@ func newConfig(
 	cfg.Consensus.RollDPoS.NumSubEpochs = 2
 	cfg.Consensus.RollDPoS.EventChanSize = 100000
 	cfg.Consensus.RollDPoS.NumDelegates = numNodes
+	cfg.Consensus.RollDPoS.WALPath = walPath
 	cfg.Consensus.RollDPoS.TimeBasedRotation = true
 
 	cfg.ActPool.MaxNumActsToPick = 2000
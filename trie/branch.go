@@ -110,7 +110,7 @@ func (b *branch) collapse(
 		}
 		if _, ok := child.(*leaf); ok {
 			// delete from DB
-			delPatricia(child, bucket, cb)
+			delPatricia(child, dao, bucket, cb)
 			// merge with child
 			if err := child.merge(path); err != nil {
 				return nil, err
@@ -169,7 +169,7 @@ func (l *leaf) collapse(
 	}
 	if _, ok := child.(*leaf); ok {
 		// delete from DB
-		delPatricia(child, bucket, cb)
+		delPatricia(child, dao, bucket, cb)
 		if err := child.merge(l.Path); err != nil {
 			return nil, err
 		}
@@ -7,6 +7,8 @@
 package trie
 
 import (
+	"bytes"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
@@ -95,7 +97,7 @@ func getHelper(
 func upsertHelper(
 	node patricia, key, value []byte, prefix int, dao db.KVStore, bucket string, cb db.CachedBatch) ([]byte, error) {
 	// delete node from DB
-	delPatricia(node, bucket, cb)
+	delPatricia(node, dao, bucket, cb)
 	// parse the key and get child node
 	child, match, err := node.child(key[prefix:], dao, bucket, cb)
 	if err != nil {
@@ -128,7 +130,7 @@ func upsertHelper(
 func deleteHelper(
 	node patricia, key []byte, prefix int, dao db.KVStore, bucket string, cb db.CachedBatch) ([]byte, error) {
 	// delete node from DB
-	delPatricia(node, bucket, cb)
+	delPatricia(node, dao, bucket, cb)
 	// parse the key and get child node
 	child, match, err := node.child(key[prefix:], dao, bucket, cb)
 	if err != nil {
@@ -164,6 +166,11 @@ func getPatricia(key []byte, dao db.KVStore, bucket string, cb db.CachedBatch) (
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get key %x", key[:8])
 	}
+	return deserializePatricia(node)
+}
+
+// deserializePatricia decodes the patricia node from bytes
+func deserializePatricia(node []byte) (patricia, error) {
 	pbNode := iproto.NodePb{}
 	if err := proto.Unmarshal(node, &pbNode); err != nil {
 		return nil, err
@@ -195,7 +202,8 @@ func putPatricia(ptr patricia, bucket string, cb db.CachedBatch) ([]byte, error)
 }
 
 // putPatriciaNew stores a new patricia node into DB
-// it is expected the node does not exist yet, will return error if already exist
+// it is expected the node is not pending in the batch yet, will return error if already exist
+// the node may have been committed as part of a previous state, in which case it is overwritten with the same value
 func putPatriciaNew(ptr patricia, bucket string, cb db.CachedBatch) ([]byte, error) {
 	value, err := ptr.serialize()
 	if err != nil {
@@ -203,12 +211,28 @@ func putPatriciaNew(ptr patricia, bucket string, cb db.CachedBatch) ([]byte, err
 	}
 	key := ptr.hash()
 	logger.Debug().Hex("key", key[:8]).Msg("putnew")
-	return key[:], cb.PutIfNotExists(bucket, key[:], value, "failed to put non-existing key = %x", key)
+	if v, err := cb.Get(bucket, key[:]); err == nil {
+		// a committed node is kept pending when it is re-put by an upsert, which is fine as long as it is the same
+		if bytes.Equal(v, value) {
+			return key[:], nil
+		}
+		return key[:], errors.Wrapf(db.ErrAlreadyExist, "failed to put non-existing key = %x", key)
+	}
+	cb.Put(bucket, key[:], value, "failed to put key = %x", key)
+	return key[:], nil
 }
 
 // delPatricia deletes the patricia node from DB
-func delPatricia(ptr patricia, bucket string, cb db.CachedBatch) {
+// only the node pending in the batch is deleted, the committed node is kept so that the states of previous roots
+// remain readable
+func delPatricia(ptr patricia, dao db.KVStore, bucket string, cb db.CachedBatch) {
 	key := ptr.hash()
+	if _, err := cb.Get(bucket, key[:]); err != nil {
+		return
+	}
+	if _, err := dao.Get(bucket, key[:]); err == nil {
+		return
+	}
 	logger.Debug().Hex("key", key[:8]).Msg("del")
 	cb.Delete(bucket, key[:], "failed to delete key = %x", key)
 }
@@ -0,0 +1,109 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// ErrInvalidProof indicates the proof doesn't match the root hash or the key
+var ErrInvalidProof = errors.New("invalid trie proof")
+
+// proveHelper collects the serialized nodes on the path from node to the leaf of key. If key is not in the trie, the
+// path ends at the node where it diverges from key, which proves the absence of key
+func proveHelper(
+	node patricia, key []byte, prefix int, dao db.KVStore, bucket string, cb db.CachedBatch) ([][]byte, error) {
+	stream, err := node.serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode patricia node")
+	}
+	// a branch without the path or a leaf diverging from the key means the key is not in the trie, while the other
+	// errors, e.g., failing to read a child node, are returned as is
+	if b, ok := node.(*branch); ok && b.Path[key[prefix]] == nil {
+		return [][]byte{stream}, nil
+	}
+	// parse the key and get child node
+	child, match, err := node.child(key[prefix:], dao, bucket, cb)
+	if err != nil {
+		if errors.Cause(err) == ErrPathDiverge {
+			return [][]byte{stream}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get child of key = %x", key)
+	}
+	if child == nil {
+		// this is the last node on path, which either stores the key or a diverging one
+		if _, err := node.blob(key); err != nil && errors.Cause(err) != ErrNotExist {
+			return nil, err
+		}
+		return [][]byte{stream}, nil
+	}
+	proof, err := proveHelper(child, key, prefix+match, dao, bucket, cb)
+	if err != nil {
+		return nil, err
+	}
+	return append([][]byte{stream}, proof...), nil
+}
+
+// VerifyProof verifies the proof of key against the root hash of a trie, and returns the value of key. The proof is
+// the list of serialized nodes on the path from the root to the leaf, as returned by Trie.Prove(). If the path ends
+// at a verified node diverging from key, the proof is a proof of absence, and ErrNotExist is returned. It doesn't need
+// access to the trie, so that light clients and other chains could check a state with the state root only
+func VerifyProof(root hash.Hash32B, key []byte, proof [][]byte) ([]byte, error) {
+	expected := root[:]
+	prefix := 0
+	for i, stream := range proof {
+		node, err := deserializePatricia(stream)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidProof, "failed to decode node %d: %v", i, err)
+		}
+		if h := node.hash(); !bytes.Equal(h[:], expected) {
+			return nil, errors.Wrapf(ErrInvalidProof, "hash of node %d = %x, expecting %x", i, h, expected)
+		}
+		last := i == len(proof)-1
+		switch n := node.(type) {
+		case *branch:
+			if prefix >= len(key) {
+				return nil, errors.Wrapf(ErrInvalidProof, "branch at node %d is beyond key = %x", i, key)
+			}
+			expected = n.Path[key[prefix]]
+			if expected == nil {
+				if !last {
+					return nil, errors.Wrapf(ErrInvalidProof, "branch at node %d diverges before the end", i)
+				}
+				return nil, errors.Wrapf(ErrNotExist, "branch does not have path = %d", key[prefix])
+			}
+			prefix++
+		case *leaf:
+			if n.Ext == EXTLEAF {
+				// ext stores the squashed path and the hash of next node
+				if !bytes.HasPrefix(key[prefix:], n.Path) {
+					if !last {
+						return nil, errors.Wrapf(ErrInvalidProof, "ext at node %d diverges before the end", i)
+					}
+					return nil, errors.Wrapf(ErrNotExist, "ext diverges from key = %x", key)
+				}
+				expected = n.Value
+				prefix += len(n.Path)
+				continue
+			}
+			// leaf is the final node on the path, storing the full path and the value
+			if !last {
+				return nil, errors.Wrapf(ErrInvalidProof, "leaf at node %d is not the last node", i)
+			}
+			if !bytes.Equal(n.Path, key) {
+				return nil, errors.Wrapf(ErrNotExist, "leaf path = %x, key = %x", n.Path, key)
+			}
+			return n.Value, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrInvalidProof, "proof does not end with a leaf of key = %x", key)
+}
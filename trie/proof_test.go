@@ -0,0 +1,102 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+func TestProof(t *testing.T) {
+	require := require.New(t)
+
+	tr, err := NewTrie(db.NewMemKVStore(), "test", EmptyRoot)
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+	defer func() {
+		require.NoError(tr.Stop(context.Background()))
+	}()
+
+	keys := [][]byte{ham, car, cat, dog, egg, fox, cow, ant}
+	for i, k := range keys {
+		require.NoError(tr.Upsert(k, testV[i]))
+	}
+	root := tr.RootHash()
+	for i, k := range keys {
+		proof, err := tr.Prove(k)
+		require.NoError(err)
+		v, err := VerifyProof(root, k, proof)
+		require.NoError(err)
+		require.Equal(testV[i], v)
+	}
+
+	// the proof of a non-existing key ends where the path diverges, which proves its absence
+	proof, err := tr.Prove(rat)
+	require.NoError(err)
+	_, err = VerifyProof(root, rat, proof)
+	require.Equal(ErrNotExist, errors.Cause(err))
+	_, err = VerifyProof(hash.ZeroHash32B, rat, proof)
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	// a truncated path doesn't prove the absence
+	require.True(len(proof) > 1)
+	_, err = VerifyProof(root, rat, proof[:len(proof)-1])
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	_, err = VerifyProof(root, rat, nil)
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	// failing to read a node isn't taken as a non-existing key
+	_, err = proveHelper(tr.(*trie).root, cat, 0, db.NewMemKVStore(), "test", db.NewCachedBatch())
+	require.Error(err)
+	require.NotEqual(ErrNotExist, errors.Cause(err))
+
+	proof, err = tr.Prove(cat)
+	require.NoError(err)
+	require.True(len(proof) > 1)
+	// the proof of a key doesn't prove another key
+	_, err = VerifyProof(root, car, proof)
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	_, err = VerifyProof(root, rat, proof)
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	// the proof doesn't match another root
+	_, err = VerifyProof(hash.ZeroHash32B, cat, proof)
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	// truncated proof
+	_, err = VerifyProof(root, cat, proof[:len(proof)-1])
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	// tampered leaf
+	l := leaf{len(cat), cat, testV[1]}
+	stream, err := l.serialize()
+	require.NoError(err)
+	proof[len(proof)-1] = stream
+	_, err = VerifyProof(root, cat, proof)
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+
+	// a proof is bound to the root it's generated against
+	require.NoError(tr.Upsert(rat, testV[2]))
+	require.NotEqual(root, tr.RootHash())
+	proof, err = tr.Prove(rat)
+	require.NoError(err)
+	v, err := VerifyProof(tr.RootHash(), rat, proof)
+	require.NoError(err)
+	require.Equal(testV[2], v)
+	_, err = VerifyProof(root, rat, proof)
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+
+	// an empty trie proves the absence of any key
+	empty, err := NewTrie(db.NewMemKVStore(), "test", EmptyRoot)
+	require.NoError(err)
+	require.NoError(empty.Start(context.Background()))
+	proof, err = empty.Prove(cat)
+	require.NoError(err)
+	_, err = VerifyProof(EmptyRoot, cat, proof)
+	require.Equal(ErrNotExist, errors.Cause(err))
+}
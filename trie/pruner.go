@@ -0,0 +1,150 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// Pruner mark-and-sweeps the nodes of the tries stored in a bucket. A round of pruning goes as
+// 1. Mark() is called on every root to keep, which marks the nodes reachable from the root
+// 2. Sweep() iterates the bucket and deletes the nodes which are not marked
+// The writes to the trie don't need to stop during pruning. Sweep() deletes the nodes in chunks under the lock of the
+// writer, and calls back to mark the roots committed in the meantime before deleting each chunk
+type Pruner struct {
+	dao    db.KVStore
+	bucket string
+	marked map[hash.Hash32B]struct{}
+}
+
+// NewPruner creates a pruner of the tries in bucket
+func NewPruner(dao db.KVStore, bucket string) *Pruner {
+	return &Pruner{
+		dao:    dao,
+		bucket: bucket,
+		marked: make(map[hash.Hash32B]struct{}),
+	}
+}
+
+// Mark marks the nodes reachable from root, the subtrees which have been marked are skipped
+func (p *Pruner) Mark(root hash.Hash32B) error {
+	return p.mark(root, nil)
+}
+
+// MarkWithValues marks the nodes reachable from root as Mark does, and calls onValue on the value of every leaf newly
+// marked, e.g., to mark the storage tries of the contracts in the account trie
+func (p *Pruner) MarkWithValues(root hash.Hash32B, onValue func([]byte) error) error {
+	return p.mark(root, onValue)
+}
+
+func (p *Pruner) mark(root hash.Hash32B, onValue func([]byte) error) error {
+	if _, ok := p.marked[root]; ok {
+		return nil
+	}
+	node, err := getPatricia(root[:], p.dao, p.bucket, db.NewCachedBatch())
+	if err != nil {
+		return errors.Wrapf(err, "failed to mark node %x", root)
+	}
+	p.marked[root] = struct{}{}
+	switch n := node.(type) {
+	case *branch:
+		for i := 0; i < RADIX; i++ {
+			if n.Path[i] == nil {
+				continue
+			}
+			if err := p.mark(byteutil.BytesTo32B(n.Path[i]), onValue); err != nil {
+				return err
+			}
+		}
+	case *leaf:
+		// ext stores the hash of next node, while leaf stores the value
+		if n.Ext == EXTLEAF {
+			return p.mark(byteutil.BytesTo32B(n.Value), onValue)
+		}
+		if onValue != nil {
+			return onValue(n.Value)
+		}
+	}
+	return nil
+}
+
+// Sweep iterates the bucket and deletes the nodes which are not marked, and returns the number of deleted nodes. The
+// nodes are deleted in chunks of chunkSize while holding lock, which is the lock of the trie writer. beforeDelete is
+// called with the lock held before deleting each chunk, to mark the roots committed since the last call, so that the
+// nodes written during pruning are never deleted
+func (p *Pruner) Sweep(chunkSize int, lock sync.Locker, beforeDelete func() error) (int, error) {
+	iter, err := p.dao.Iterate(p.bucket, db.Range{})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to iterate bucket %s", p.bucket)
+	}
+	deleted := 0
+	chunk := make([]hash.Hash32B, 0, chunkSize)
+	for iter.Next() {
+		// the bucket may also store other records, e.g., the current height, which are not nodes
+		if len(iter.Key()) != hash.HashSize {
+			continue
+		}
+		key := byteutil.BytesTo32B(iter.Key())
+		if _, ok := p.marked[key]; ok {
+			continue
+		}
+		if chunk = append(chunk, key); len(chunk) < chunkSize {
+			continue
+		}
+		n, err := p.delete(chunk, lock, beforeDelete)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+		chunk = chunk[:0]
+	}
+	if err := iter.Error(); err != nil {
+		return deleted, errors.Wrapf(err, "failed to iterate bucket %s", p.bucket)
+	}
+	n, err := p.delete(chunk, lock, beforeDelete)
+	return deleted + n, err
+}
+
+// NumMarked returns the number of the marked nodes
+func (p *Pruner) NumMarked() int {
+	return len(p.marked)
+}
+
+// delete deletes the nodes in chunk which are still not marked after beforeDelete
+func (p *Pruner) delete(chunk []hash.Hash32B, lock sync.Locker, beforeDelete func() error) (int, error) {
+	if len(chunk) == 0 {
+		return 0, nil
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := beforeDelete(); err != nil {
+		return 0, err
+	}
+	batch := db.NewBatch()
+	for _, key := range chunk {
+		if _, ok := p.marked[key]; ok {
+			continue
+		}
+		k := key
+		batch.Delete(p.bucket, k[:], "failed to delete node %x", k)
+	}
+	deleted := batch.Size()
+	if deleted == 0 {
+		return 0, nil
+	}
+	if err := p.dao.Commit(batch); err != nil {
+		return 0, errors.Wrapf(err, "failed to delete %d nodes", deleted)
+	}
+	return deleted, nil
+}
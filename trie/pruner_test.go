@@ -0,0 +1,106 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+func TestPruner(t *testing.T) {
+	require := require.New(t)
+
+	dao := db.NewMemKVStore()
+	tr, err := NewTrie(dao, "test", EmptyRoot)
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+	defer func() {
+		require.NoError(tr.Stop(context.Background()))
+	}()
+	// a record which is not a node
+	require.NoError(dao.Put("test", []byte("height"), []byte{1}))
+
+	var roots []hash.Hash32B
+	keys := [][]byte{ham, car, cat, dog, egg, fox}
+	for i, k := range keys {
+		require.NoError(tr.Upsert(k, testV[i]))
+		require.NoError(tr.Commit())
+		roots = append(roots, tr.RootHash())
+	}
+	require.NoError(tr.Upsert(cat, testV[7]))
+	require.NoError(tr.Commit())
+	roots = append(roots, tr.RootHash())
+
+	// keep the last 2 roots, the last one is only marked right before deleting, as if it's committed during pruning
+	pruner := NewPruner(dao, "test")
+	require.NoError(pruner.Mark(roots[len(roots)-2]))
+	var mutex sync.Mutex
+	calls := 0
+	deleted, err := pruner.Sweep(2, &mutex, func() error {
+		calls++
+		return pruner.Mark(roots[len(roots)-1])
+	})
+	require.NoError(err)
+	require.True(deleted > 0)
+	// the nodes are deleted in chunks of 2
+	require.True(calls > 1)
+
+	// nothing to delete in another round
+	pruner = NewPruner(dao, "test")
+	for _, root := range roots[len(roots)-2:] {
+		require.NoError(pruner.Mark(root))
+	}
+	deleted, err = pruner.Sweep(2, &mutex, func() error { return nil })
+	require.NoError(err)
+	require.Equal(0, deleted)
+
+	tr1, err := NewTrieSharedBatch(dao, db.NewCachedBatch(), "test", EmptyRoot)
+	require.NoError(err)
+	require.NoError(tr1.SetRoot(roots[len(roots)-2]))
+	v, err := tr1.Get(cat)
+	require.NoError(err)
+	require.Equal(testV[2], v)
+	require.NoError(tr1.SetRoot(roots[len(roots)-1]))
+	for i, k := range keys {
+		v, err := tr1.Get(k)
+		require.NoError(err)
+		if bytes.Equal(cat, k) {
+			require.Equal(testV[7], v)
+			continue
+		}
+		require.Equal(testV[i], v)
+	}
+	// the older roots are gone
+	require.Error(tr1.SetRoot(roots[0]))
+	v, err = dao.Get("test", []byte("height"))
+	require.NoError(err)
+	require.Equal([]byte{1}, v)
+
+	// the values of the leaves are visited when marking
+	pruner = NewPruner(dao, "test")
+	var values [][]byte
+	require.NoError(pruner.MarkWithValues(roots[len(roots)-1], func(value []byte) error {
+		values = append(values, value)
+		return nil
+	}))
+	require.Len(values, len(keys))
+	require.Contains(values, testV[7])
+
+	// the trie still works after pruning
+	require.NoError(tr.Upsert(rat, testV[3]))
+	require.NoError(tr.Commit())
+	v, err = tr.Get(rat)
+	require.NoError(err)
+	require.Equal(testV[3], v)
+}
@@ -30,6 +30,9 @@ var (
 	// CandidateKVNameSpace is the bucket name for candidate data storage
 	CandidateKVNameSpace = "Candidate"
 
+	// AccountTrieRootKVNameSpace is the bucket name for the account trie root hash of each height
+	AccountTrieRootKVNameSpace = "AccountTrieRoot"
+
 	// ErrInvalidTrie indicates something wrong causing invalid operation
 	ErrInvalidTrie = errors.New("invalid trie operation")
 
@@ -45,13 +48,14 @@ type (
 	// Trie is the interface of Merkle Patricia Trie
 	Trie interface {
 		lifecycle.StartStopper
-		TrieDB() db.KVStore          // return the underlying DB instance
-		Upsert([]byte, []byte) error // insert a new entry
-		Get([]byte) ([]byte, error)  // retrieve an existing entry
-		Delete([]byte) error         // delete an entry
-		Commit() error               // commit the state changes in a batch
-		RootHash() hash.Hash32B      // returns trie's root hash
-		SetRoot(hash.Hash32B) error  // set a new root to trie
+		TrieDB() db.KVStore             // return the underlying DB instance
+		Upsert([]byte, []byte) error    // insert a new entry
+		Get([]byte) ([]byte, error)     // retrieve an existing entry
+		Delete([]byte) error            // delete an entry
+		Prove([]byte) ([][]byte, error) // return the serialized nodes on the path to an entry, or where it diverges
+		Commit() error                  // commit the state changes in a batch
+		RootHash() hash.Hash32B         // returns trie's root hash
+		SetRoot(hash.Hash32B) error     // set a new root to trie
 	}
 
 	// trie implements the Trie interface
@@ -162,6 +166,15 @@ func (t *trie) Delete(key []byte) error {
 	return err
 }
 
+// Prove returns the serialized nodes on the path from the root to the leaf of an entry. For a missing entry, the path
+// ends at the node diverging from the key, which proves its absence
+func (t *trie) Prove(key []byte) ([][]byte, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return proveHelper(t.root, key, 0, t.dao, t.bucket, t.cb)
+}
+
 // Commit local cached <k, v> in a batch
 func (t *trie) Commit() error {
 	t.mutex.Lock()
@@ -183,6 +196,12 @@ func (t *trie) SetRoot(rootHash hash.Hash32B) error {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
+	if rootHash == EmptyRoot {
+		// the empty root node isn't necessarily stored, e.g., for an account without storage
+		t.root = &branch{}
+		t.rootHash = rootHash
+		return nil
+	}
 	root, err := getPatricia(rootHash[:], t.dao, t.bucket, t.cb)
 	if err != nil {
 		return errors.Wrapf(err, "failed to set root %x", rootHash[:])
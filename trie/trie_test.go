@@ -124,6 +124,52 @@ func Test2Roots(t *testing.T) {
 	require.Nil(tr2.Stop(context.Background()))
 }
 
+func TestHistory(t *testing.T) {
+	require := require.New(t)
+
+	tr, err := NewTrie(db.NewMemKVStore(), "test", EmptyRoot)
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+	require.NoError(tr.Upsert(cat, testV[2]))
+	require.NoError(tr.Upsert(car, testV[1]))
+	require.NoError(tr.Commit())
+	root := tr.RootHash()
+
+	// update, delete and insert after commit
+	require.NoError(tr.Upsert(cat, testV[5]))
+	require.NoError(tr.Delete(car))
+	require.NoError(tr.Upsert(dog, testV[3]))
+	require.NoError(tr.Commit())
+	root1 := tr.RootHash()
+	// change the value back, the node of the previous state is written again
+	require.NoError(tr.Upsert(cat, testV[2]))
+	require.NoError(tr.Commit())
+
+	// the committed nodes of the previous roots are kept
+	tr1, err := NewTrieSharedBatch(tr.TrieDB(), db.NewCachedBatch(), "test", EmptyRoot)
+	require.NoError(err)
+	require.NoError(tr1.SetRoot(root))
+	v, err := tr1.Get(cat)
+	require.NoError(err)
+	require.Equal(testV[2], v)
+	v, err = tr1.Get(car)
+	require.NoError(err)
+	require.Equal(testV[1], v)
+	_, err = tr1.Get(dog)
+	require.Equal(ErrNotExist, errors.Cause(err))
+
+	require.NoError(tr1.SetRoot(root1))
+	v, err = tr1.Get(cat)
+	require.NoError(err)
+	require.Equal(testV[5], v)
+	_, err = tr1.Get(car)
+	require.Equal(ErrNotExist, errors.Cause(err))
+	v, err = tr1.Get(dog)
+	require.NoError(err)
+	require.Equal(testV[3], v)
+	require.NoError(tr.Stop(context.Background()))
+}
+
 func TestInsert(t *testing.T) {
 	require := require.New(t)
 
@@ -461,7 +507,7 @@ func TestCollision(t *testing.T) {
 	tr, err := NewTrie(db.NewBoltDB(testTriePath, cfg), "test", EmptyRoot)
 	require.Nil(err)
 	require.Nil(tr.Start(context.Background()))
-	defer require.Nil(tr.Stop(context.Background()))
+	defer func() { require.Nil(tr.Stop(context.Background())) }()
 
 	// this creates 2 leaf (with same value) on branch '0' and '1'
 	require.NoError(tr.Upsert(br1, testV[0]))